@@ -3,23 +3,66 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
+	"net/url"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/audit"
+	"github.com/johnzastrow/actalog-benchmark/internal/cleanup"
 	"github.com/johnzastrow/actalog-benchmark/internal/client"
+	"github.com/johnzastrow/actalog-benchmark/internal/exporter"
 	"github.com/johnzastrow/actalog-benchmark/internal/metrics"
 	"github.com/johnzastrow/actalog-benchmark/internal/reporter"
+	"github.com/johnzastrow/actalog-benchmark/internal/telemetry"
+	"github.com/johnzastrow/actalog-benchmark/internal/wsserver"
 )
 
 var version = "0.6.0"
 
+// defaultAutoCompareLimit is the default --auto-compare-limit
+const defaultAutoCompareLimit = 10
+
+// defaultMaxResponseSize is the default --max-response-size (10 MB)
+const defaultMaxResponseSize = 10 << 20
+
+// otelFlushTimeout bounds how long exiting waits to send the last --otel-endpoint spans
+const otelFlushTimeout = 5 * time.Second
+
+// defaultTraceHeader is the default --trace-header, the W3C Trace Context header
+const defaultTraceHeader = "traceparent"
+
+// defaultSSEEvents is the default --sse-events
+const defaultSSEEvents = 5
+
+// Defaults for --step-interval and --step-start
+const (
+	defaultStepInterval = 5 * time.Second
+	defaultStepStart    = 1
+)
+
+// exitInterrupted is the exit status after SIGINT or SIGTERM, following the
+// shell convention of 128 plus the signal number of SIGINT
+const exitInterrupted = 130
+
+// healthPollInterval is how often --wait-healthy re-checks the health endpoint
+const healthPollInterval = 5 * time.Second
+
+// leakDetectWindows is how many equal windows --leak-detect splits the load test into
+const leakDetectWindows = 10
+
 var appHelpTemplate = `NAME:
    {{.Name}} - {{.Usage}}
 
@@ -28,6 +71,7 @@ VERSION:
 
 USAGE:
    {{.HelpName}} [options]
+   {{.HelpName}} config generate [--format yaml|toml]
 
 DESCRIPTION:
    A comprehensive benchmarking tool for ActaLog instances. Tests connectivity,
@@ -115,7 +159,8 @@ EXAMPLES:
 
       $ actalog-bench --compare ./reports/
 
-      Scans the directory for all benchmark_*.json files, sorts them by
+      Scans the directory for all benchmark_*.json files (or
+      <prefix>_*.json with --output-prefix), sorts them by
       timestamp, and generates a comparison markdown report showing:
       - Side-by-side metrics comparison
       - Delta calculations with trend indicators
@@ -176,6 +221,15 @@ EXAMPLES:
 EXIT CODES:
    0    All checks passed
    1    One or more checks failed or error occurred
+   2    --assert-overall was set and the overall status differed from it
+   130  Interrupted by Ctrl+C (SIGINT) or SIGTERM; partial results are still reported
+
+   --assert-overall pass|degraded|fail|any replaces the usual exit status with
+   a check of the overall status, for pipelines that expect a degraded or
+   failing target (e.g. chaos tests). A run that cannot start, such as one
+   with an invalid flag, still exits 1.
+
+      $ actalog-bench --url https://chaos.example.com --full --assert-overall degraded
 
 REPORT FORMATS:
    Console     Real-time colored output with box-drawing characters
@@ -188,23 +242,78 @@ For more information: https://github.com/johnzastrow/actalog-benchmark
 func main() {
 	cli.AppHelpTemplate = appHelpTemplate
 
-	app := &cli.App{
+	if err := newApp().Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// newApp returns the command-line application with every flag and subcommand
+func newApp() *cli.App {
+	return &cli.App{
 		Name:    "actalog-bench",
 		Usage:   "Benchmark tool for ActaLog instances",
 		Version: version,
+		Commands: []*cli.Command{
+			{
+				Name:  "config",
+				Usage: "Configuration file helpers",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "generate",
+						Usage: "Print a template config file with every option at its default",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "format",
+								Value: "yaml",
+								Usage: "Template format: yaml or toml",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							template, err := internal.GenerateConfigTemplate(c.String("format"))
+							if err != nil {
+								return err
+							}
+							fmt.Print(template)
+							return nil
+						},
+					},
+				},
+			},
+		},
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "config",
+				Usage: "Load option defaults from a YAML (.yaml, .yml) or TOML (.toml) file; flags override file values",
+			},
 			&cli.StringFlag{
 				Name:    "url",
 				Aliases: []string{"u"},
 				Usage:   "Target ActaLog instance URL (required for benchmarking, not for --compare)",
 			},
+			&cli.StringFlag{
+				Name:  "url-list",
+				Usage: "Benchmark each URL in this file (one per line) in turn and compare them side by side, e.g. blue/green deployments",
+			},
 			&cli.StringFlag{
 				Name:  "user",
 				Usage: "Username for authenticated tests",
 			},
 			&cli.StringFlag{
 				Name:  "pass",
-				Usage: "Password for authenticated tests",
+				Usage: "Password for authenticated tests (visible in ps and shell history; prefer --pass-file or --pass-cmd)",
+			},
+			&cli.StringFlag{
+				Name:  "pass-file",
+				Usage: "Read the password from the first line of this file",
+			},
+			&cli.StringFlag{
+				Name:  "pass-cmd",
+				Usage: "Run this shell command and use its output as the password (e.g. a secrets manager CLI)",
+			},
+			&cli.StringFlag{
+				Name:  "token-file",
+				Usage: "Authenticate with the pre-computed JWT in this file instead of logging in",
 			},
 			&cli.BoolFlag{
 				Name:    "full",
@@ -220,11 +329,107 @@ func main() {
 				Aliases: []string{"j"},
 				Usage:   "Export results to JSON file",
 			},
+			&cli.BoolFlag{
+				Name:  "json-append",
+				Usage: "Append results to the JSON array in the --json file instead of overwriting it",
+			},
 			&cli.StringFlag{
 				Name:    "markdown",
 				Aliases: []string{"m"},
 				Usage:   "Export results to Markdown file (directory path, filename auto-generated with timestamp)",
 			},
+			&cli.StringFlag{
+				Name:  "csv",
+				Usage: "Export results to a CSV file with one row per metric (directory or .csv file path)",
+			},
+			&cli.StringFlag{
+				Name:  "html",
+				Usage: "Export results to a self-contained HTML report with charts (directory or .html file path)",
+			},
+			&cli.StringFlag{
+				Name:  "junit",
+				Usage: "Export results as JUnit XML for CI test reports, with threshold breaches as failures (directory or .xml file path)",
+			},
+			&cli.BoolFlag{
+				Name:  "ws-load-test",
+				Usage: "Measure WebSocket ping round-trip latency with --concurrent connections for --duration",
+			},
+			&cli.StringFlag{
+				Name:  "ws-path",
+				Value: "/ws",
+				Usage: "WebSocket endpoint path for --ws-load-test (the server must echo each message)",
+			},
+			&cli.StringFlag{
+				Name:  "sse-path",
+				Usage: "Measure time to first event and event intervals on this server-sent events path (e.g. /api/events)",
+			},
+			&cli.IntFlag{
+				Name:  "sse-events",
+				Value: defaultSSEEvents,
+				Usage: "Events to read from --sse-path; all must arrive within --timeout",
+			},
+			&cli.StringFlag{
+				Name:  "ws-server",
+				Usage: "Serve live load test metrics to WebSocket clients on this address (e.g. :8081), updated every second",
+			},
+			&cli.IntFlag{
+				Name:  "prometheus-port",
+				Usage: "Serve each run's metrics on /metrics at this port for Prometheus to scrape, until interrupted after the last run",
+			},
+			&cli.BoolFlag{
+				Name:  "concurrency-profile",
+				Usage: "Run the load test at each of --concurrency-steps and recommend the best concurrency",
+			},
+			&cli.StringFlag{
+				Name:  "concurrency-steps",
+				Value: metrics.DefaultConcurrencySteps,
+				Usage: "Comma-separated concurrency levels for --concurrency-profile",
+			},
+			&cli.DurationFlag{
+				Name:  "step-duration",
+				Value: 10 * time.Second,
+				Usage: "Load test duration for each --concurrency-profile step",
+			},
+			&cli.IntFlag{
+				Name:  "repeat",
+				Value: 1,
+				Usage: "Run the benchmark suite this many times and report the averaged result",
+			},
+			&cli.BoolFlag{
+				Name:  "go-bench",
+				Usage: "Print results in go test -bench format (for benchstat) instead of the console report",
+			},
+			&cli.BoolFlag{
+				Name:  "ci",
+				Usage: "Also print a single KEY=value status line for CI scripts (the only output with --silent)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Comma-separated output formats written to --output-dir (json, markdown, csv, html, junit)",
+			},
+			&cli.StringFlag{
+				Name:  "output-dir",
+				Value: ".",
+				Usage: "Directory for reports selected with --format",
+			},
+			&cli.IntFlag{
+				Name:  "max-output-files",
+				Usage: "Keep only this many <prefix>_*.json reports in the output directory, deleting the oldest (0 keeps all)",
+			},
+			&cli.BoolFlag{
+				Name:  "auto-compare",
+				Usage: "After writing the JSON report to a directory, write a comparison report of the latest results there",
+			},
+			&cli.IntFlag{
+				Name:  "auto-compare-limit",
+				Value: defaultAutoCompareLimit,
+				Usage: "Most recent JSON reports covered by --auto-compare",
+			},
+			&cli.StringFlag{
+				Name:  "output-prefix",
+				Value: reporter.DefaultOutputPrefix,
+				Usage: "Start of generated report filenames, e.g. staging for staging_<timestamp>.json; --compare looks for <prefix>_*.json first",
+			},
 			&cli.IntFlag{
 				Name:    "concurrent",
 				Aliases: []string{"c"},
@@ -243,14 +448,80 @@ func main() {
 				Value:   30 * time.Second,
 				Usage:   "Request timeout",
 			},
+			&cli.Int64Flag{
+				Name:  "max-response-size",
+				Value: defaultMaxResponseSize,
+				Usage: "Read at most this many response body bytes per request (0 for no limit)",
+			},
+			&cli.IntFlag{
+				Name:  "max-idle-conns-per-host",
+				Value: client.DefaultMaxIdleConnsPerHost,
+				Usage: "Idle connections kept for reuse; with fewer than --concurrent, load test workers dial new connections",
+			},
+			&cli.DurationFlag{
+				Name:  "simulate-latency",
+				Usage: "Hold every HTTP request for this long, plus up to 10% jitter, before sending it, to see how results degrade on a slow network",
+			},
+			&cli.Float64Flag{
+				Name:  "simulate-loss-rate",
+				Usage: "Fail this fraction of HTTP requests (0 to 1) with a simulated connection error instead of sending them",
+			},
 			&cli.BoolFlag{
 				Name:  "verbose",
 				Usage: "Verbose output",
 			},
+			&cli.BoolFlag{
+				Name:  "silent",
+				Usage: "Suppress all output; exit status 1 unless the benchmark passes (report files are still written)",
+			},
+			&cli.BoolFlag{
+				Name:  "anonymize",
+				Usage: "Redact the target URL, hostname, IP addresses, username, and endpoint paths from every report, for sharing",
+			},
+			&cli.StringFlag{
+				Name:  "assert-overall",
+				Usage: "Exit 0 only when the overall status is this (pass, degraded, fail, or any), otherwise exit 2",
+			},
 			&cli.StringFlag{
 				Name:  "compare",
 				Usage: "Compare mode: generate comparison report from JSON files in directory",
 			},
+			&cli.StringFlag{
+				Name:  "compare-url",
+				Usage: "Compare mode: download the JSON results listed in a manifest (one URL per line) or directory listing URL",
+			},
+			&cli.StringFlag{
+				Name:  "compare-url-token",
+				Usage: "Bearer token for --compare-url, sent only to the manifest's host",
+			},
+			&cli.DurationFlag{
+				Name:  "compare-since",
+				Usage: "Compare mode: only compare files with a result from within this long ago (e.g. 168h for a week)",
+			},
+			&cli.StringFlag{
+				Name:  "diff",
+				Usage: "Diff mode: print the metrics that changed between two comma-separated JSON results (before,after)",
+			},
+			&cli.StringFlag{
+				Name:  "merge",
+				Usage: "Merge mode: combine comma-separated JSON results from multiple agents into one report",
+			},
+			&cli.BoolFlag{
+				Name:  "schema-version-check",
+				Usage: "Warn when compared JSON files were written with a different result schema version",
+			},
+			&cli.BoolFlag{
+				Name:  "compare-regressions-only",
+				Usage: "Compare mode: only show metrics that regressed between the first and last run",
+			},
+			&cli.BoolFlag{
+				Name:  "aggregate",
+				Usage: "Compare mode: append mean and standard deviation statistics across all runs",
+			},
+			&cli.StringFlag{
+				Name:  "threshold-file",
+				Usage: "Load alert thresholds from a JSON or YAML file; --threshold-* flags override file values",
+			},
 			&cli.Float64Flag{
 				Name:  "threshold-p95",
 				Value: 500,
@@ -271,19 +542,256 @@ func main() {
 				Value: 10,
 				Usage: "Alert threshold for minimum RPS",
 			},
+			&cli.Float64Flag{
+				Name:  "threshold-db-max",
+				Value: 50,
+				Usage: "Alert threshold for any server-side database operation (ms, 0 disables)",
+			},
+			&cli.Float64Flag{
+				Name:  "threshold-serial-max",
+				Value: 1,
+				Usage: "Alert threshold for any server-side serialization operation (ms, 0 disables)",
+			},
 			&cli.IntFlag{
 				Name:  "benchmark-records",
 				Value: 1000,
 				Usage: "Number of records for server-side benchmark API (default: 1000, max: 500000)",
 			},
+			&cli.BoolFlag{
+				Name:  "benchmark-concurrent",
+				Usage: "Include concurrent operations in the server-side benchmark (concurrent=true on /api/benchmark)",
+			},
+			&cli.DurationFlag{
+				Name:  "benchmark-timeout",
+				Usage: "Request timeout for the server-side benchmark API (default: same as --timeout)",
+			},
+			&cli.StringFlag{
+				Name:  "benchmark-records-sweep",
+				Usage: "Run the server-side benchmark at each record count from min to max: min,max,step (e.g. 1000,10000,3000)",
+			},
+			&cli.StringFlag{
+				Name:  "endpoint-strategy",
+				Value: metrics.StrategyRoundRobin,
+				Usage: "Load test endpoint rotation strategy: round-robin, random, or weighted",
+			},
+			&cli.StringFlag{
+				Name:  "endpoints-file",
+				Usage: "YAML or JSON file of endpoints, or one \"path [weight]\" or JSON object per line",
+			},
+			&cli.StringFlag{
+				Name:  "load-endpoints",
+				Usage: "Load test endpoints as comma-separated path[:weight] entries, e.g. /api/workouts:3,/health:1",
+			},
+			&cli.StringFlag{
+				Name:  "stress-endpoint",
+				Usage: "Run the load test against only this path instead of /health",
+			},
+			&cli.BoolFlag{
+				Name:  "leak-detect",
+				Usage: "Split the load test into 10 windows and flag a steady RPS decline as a possible memory leak",
+			},
+			&cli.BoolFlag{
+				Name:  "abort-on-threshold",
+				Usage: "Stop the load test early once p95 latency exceeds --threshold-p95, or the error rate exceeds --threshold-error-rate for two consecutive seconds",
+			},
+			&cli.StringFlag{
+				Name:  "sla-targets",
+				Usage: "Comma-separated load test latency targets (e.g. 200ms,500ms,1s); counts the requests slower than each",
+			},
+			&cli.StringFlag{
+				Name:  "percentiles",
+				Usage: "Comma-separated load test latency percentiles to record and report (e.g. 50,90,99,99.9)",
+				Value: metrics.DefaultPercentiles,
+			},
+			&cli.IntFlag{
+				Name:  "histogram-buckets",
+				Usage: "Equal-width buckets in the load test latency histogram, shown in the console with --verbose",
+				Value: metrics.DefaultHistogramBuckets,
+			},
+			&cli.BoolFlag{
+				Name:  "step-load",
+				Usage: "Ramp the load test up from --step-start workers, adding one every --step-interval until --concurrent are running",
+			},
+			&cli.DurationFlag{
+				Name:  "step-interval",
+				Value: defaultStepInterval,
+				Usage: "Time between added workers with --step-load; each interval is reported as a step",
+			},
+			&cli.IntFlag{
+				Name:  "step-start",
+				Value: defaultStepStart,
+				Usage: "Workers running at the start of a --step-load test",
+			},
+			&cli.DurationFlag{
+				Name:  "warmup",
+				Usage: "Send load test requests for this long (e.g. 10s) before the measured run, and discard them",
+			},
+			&cli.Float64Flag{
+				Name:  "max-rps",
+				Usage: "Cap the load test at this many requests per second across all workers (0 for no cap)",
+			},
+			&cli.DurationFlag{
+				Name:  "think-time",
+				Usage: "Pause each load test worker this long (e.g. 500ms) after a response before its next request, like a user reading the page",
+			},
+			&cli.Float64Flag{
+				Name:  "think-time-jitter",
+				Usage: "Lengthen each --think-time pause by a random 0 to this percentage",
+			},
+			&cli.Float64Flag{
+				Name:  "target-rps",
+				Usage: "Run an open-loop load test: requests arrive at this average rate as a Poisson process and queue for the --concurrent workers",
+			},
+			&cli.StringFlag{
+				Name:  "request-id-header",
+				Usage: "Send a unique request ID in this header (e.g. X-Request-ID) for server log correlation",
+			},
+			&cli.StringFlag{
+				Name:  "trace-header",
+				Value: defaultTraceHeader,
+				Usage: "Send a W3C traceparent value with the run's trace ID in this header; empty to disable",
+			},
+			&cli.StringFlag{
+				Name:  "otel-endpoint",
+				Usage: "Send OpenTelemetry traces of the run's phases and requests to this OTLP HTTP receiver (e.g. http://localhost:4318)",
+			},
+			&cli.StringFlag{
+				Name:  "user-agent",
+				Usage: "User-Agent header for every request (default \"actalog-bench/<version>\")",
+			},
+			&cli.BoolFlag{
+				Name:  "include-user-agent-version",
+				Value: true,
+				Usage: "Append \"actalog-bench/<version>\" to a custom --user-agent; disable to send it unchanged",
+			},
+			&cli.StringFlag{
+				Name:  "audit-log",
+				Usage: "Append every HTTP request (URL, headers with credentials redacted, status, timing) to this file as JSON Lines",
+			},
+			&cli.BoolFlag{
+				Name:  "prefer-ipv4",
+				Usage: "Connect over IPv4 for all benchmark phases",
+			},
+			&cli.BoolFlag{
+				Name:  "prefer-ipv6",
+				Usage: "Connect over IPv6 for all benchmark phases",
+			},
+			&cli.BoolFlag{
+				Name:  "cold-start",
+				Usage: "Also time each GET endpoint over a fresh connection (no keep-alive)",
+			},
+			&cli.StringFlag{
+				Name:  "endpoint-order",
+				Value: metrics.EndpointOrderDefault,
+				Usage: "Order endpoints are benchmarked in: default, alphabetical, random, or slowest-first (needs --baseline-json); results keep the default order",
+			},
+			&cli.Int64Flag{
+				Name:  "seed",
+				Usage: "Shuffle seed for --endpoint-order random, to repeat a run's order (0 picks one; the seed used is recorded)",
+			},
+			&cli.StringFlag{
+				Name:  "baseline-json",
+				Usage: "Earlier JSON result whose endpoint response times --endpoint-order slowest-first sorts by",
+			},
+			&cli.IntFlag{
+				Name:  "endpoint-samples",
+				Value: 1,
+				Usage: "Request each GET endpoint this many times and record mean/min/max (plus p50/p95/p99 from 20 samples)",
+			},
+			&cli.BoolFlag{
+				Name:  "http-only",
+				Usage: "Skip TLS timing for a plain http:// target and record it as not applicable (-1)",
+			},
+			&cli.BoolFlag{
+				Name:  "probe-keepalive",
+				Usage: "Measure how often sequential requests reuse a kept-alive connection",
+			},
+			&cli.BoolFlag{
+				Name:  "traceroute",
+				Usage: "Count network hops to the server (raw ICMP, or the system traceroute command)",
+			},
+			&cli.StringFlag{
+				Name:  "dns-resolver",
+				Usage: "Resolve the target with this DNS server (e.g. 8.8.8.8:53) instead of the system resolver",
+			},
+			&cli.StringFlag{
+				Name:  "doh-url",
+				Usage: "Resolve the target for the connectivity probe with this DNS-over-HTTPS endpoint (e.g. https://dns.cloudflare.com/dns-query)",
+			},
+			&cli.BoolFlag{
+				Name:  "dns-cache",
+				Usage: "Resolve the target once and reuse the addresses for new connections for the load test duration, counting the load test's cache hits",
+			},
+			&cli.StringFlag{
+				Name:  "bind-addr",
+				Usage: "Make every benchmark connection from this local IP address, e.g. to compare network interfaces",
+			},
+			&cli.StringFlag{
+				Name:  "unix-socket",
+				Usage: "Connect to the server through this Unix domain socket instead of TCP (the URL host is still sent as the Host header)",
+			},
+			&cli.BoolFlag{
+				Name:  "allow-https-downgrade",
+				Usage: "Follow redirects from HTTPS to plain HTTP instead of failing the request",
+			},
+			&cli.BoolFlag{
+				Name:  "probe-mtu",
+				Usage: "Estimate the path MTU to the server from the TCP MSS (or a UDP probe)",
+			},
+			&cli.BoolFlag{
+				Name:  "probe-http3",
+				Usage: "Also time a request over HTTP/3 (QUIC) to an https target; a server without HTTP/3 is reported as unsupported",
+			},
+			&cli.BoolFlag{
+				Name:  "wait-healthy",
+				Usage: "Poll the health endpoint until it reports healthy before benchmarking",
+			},
+			&cli.DurationFlag{
+				Name:  "wait-timeout",
+				Value: 2 * time.Minute,
+				Usage: "Maximum time to wait for the target to become healthy (with --wait-healthy)",
+			},
+			&cli.BoolFlag{
+				Name:  "k8s-readiness-probe",
+				Usage: "Wait for /health like --wait-healthy, print READY or NOT_READY, and exit without benchmarking",
+			},
+			&cli.BoolFlag{
+				Name:  "grpc-health",
+				Usage: "Also call the gRPC health service (grpc.health.v1.Health/Check) at --grpc-addr",
+			},
+			&cli.StringFlag{
+				Name:  "grpc-addr",
+				Usage: "host:port of the gRPC health service for --grpc-health (plaintext)",
+			},
+			&cli.StringFlag{
+				Name:  "pushgateway-url",
+				Usage: "Push metrics to a Prometheus Pushgateway at this URL",
+			},
+			&cli.StringFlag{
+				Name:  "pushgateway-job",
+				Value: "actalog_bench",
+				Usage: "Job label for metrics pushed to the Pushgateway",
+			},
+			&cli.StringFlag{
+				Name:  "elasticsearch-url",
+				Usage: "Index the result as a document in Elasticsearch at this URL",
+			},
+			&cli.StringFlag{
+				Name:  "elasticsearch-index",
+				Value: "actalog-bench",
+				Usage: "Elasticsearch index for results",
+			},
+			&cli.StringFlag{
+				Name:  "elasticsearch-username",
+				Usage: "Username for Elasticsearch basic auth",
+			},
+			&cli.StringFlag{
+				Name:  "elasticsearch-password",
+				Usage: "Password for Elasticsearch basic auth",
+			},
 		},
 		Action: run,
 	}
-
-	if err := app.Run(os.Args); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
 }
 
 // buildCommandLine constructs a copy-pasteable command from the arguments
@@ -292,16 +800,34 @@ func buildCommandLine(c *cli.Context) string {
 	var parts []string
 	parts = append(parts, "actalog-bench")
 
+	// Compare mode ignores the benchmark flags, so only its own are shown
+	if c.String("compare") != "" || c.String("compare-url") != "" {
+		return strings.Join(appendCompareFlags(c, parts), " \\\n  ")
+	}
+
 	// Add flags in a readable order
 	if url := c.String("url"); url != "" {
 		parts = append(parts, fmt.Sprintf("--url %s", url))
 	}
+	if urlList := c.String("url-list"); urlList != "" {
+		parts = append(parts, fmt.Sprintf("--url-list %s", urlList))
+	}
 	if user := c.String("user"); user != "" {
 		parts = append(parts, fmt.Sprintf("--user %s", user))
 	}
 	if c.String("pass") != "" {
 		parts = append(parts, "--pass <PASSWORD>")
 	}
+	if path := c.String("pass-file"); path != "" {
+		parts = append(parts, fmt.Sprintf("--pass-file %s", path))
+	}
+	if c.String("pass-cmd") != "" {
+		// The command may embed the secret itself, e.g. echo or a vault token
+		parts = append(parts, "--pass-cmd <COMMAND>")
+	}
+	if path := c.String("token-file"); path != "" {
+		parts = append(parts, fmt.Sprintf("--token-file %s", path))
+	}
 	if c.Bool("full") {
 		parts = append(parts, "--full")
 	}
@@ -317,59 +843,926 @@ func buildCommandLine(c *cli.Context) string {
 	if timeout := c.Duration("timeout"); timeout != 30*time.Second {
 		parts = append(parts, fmt.Sprintf("--timeout %s", timeout))
 	}
+	if maxSize := c.Int64("max-response-size"); maxSize != defaultMaxResponseSize {
+		parts = append(parts, fmt.Sprintf("--max-response-size %d", maxSize))
+	}
+	if maxIdle := c.Int("max-idle-conns-per-host"); maxIdle != client.DefaultMaxIdleConnsPerHost {
+		parts = append(parts, fmt.Sprintf("--max-idle-conns-per-host %d", maxIdle))
+	}
+	if latency := c.Duration("simulate-latency"); latency > 0 {
+		parts = append(parts, fmt.Sprintf("--simulate-latency %s", latency))
+	}
+	if lossRate := c.Float64("simulate-loss-rate"); lossRate > 0 {
+		parts = append(parts, fmt.Sprintf("--simulate-loss-rate %g", lossRate))
+	}
 	if jsonOut := c.String("json"); jsonOut != "" {
 		parts = append(parts, fmt.Sprintf("--json %s", jsonOut))
 	}
+	if c.Bool("json-append") {
+		parts = append(parts, "--json-append")
+	}
 	if mdOut := c.String("markdown"); mdOut != "" {
 		parts = append(parts, fmt.Sprintf("--markdown %s", mdOut))
 	}
+	if csvOut := c.String("csv"); csvOut != "" {
+		parts = append(parts, fmt.Sprintf("--csv %s", csvOut))
+	}
+	if htmlOut := c.String("html"); htmlOut != "" {
+		parts = append(parts, fmt.Sprintf("--html %s", htmlOut))
+	}
+	if junitOut := c.String("junit"); junitOut != "" {
+		parts = append(parts, fmt.Sprintf("--junit %s", junitOut))
+	}
+	if c.Bool("ws-load-test") {
+		parts = append(parts, "--ws-load-test")
+	}
+	if wsPath := c.String("ws-path"); wsPath != "/ws" {
+		parts = append(parts, fmt.Sprintf("--ws-path %s", wsPath))
+	}
+	if ssePath := c.String("sse-path"); ssePath != "" {
+		parts = append(parts, fmt.Sprintf("--sse-path %s", ssePath))
+	}
+	if sseEvents := c.Int("sse-events"); sseEvents != defaultSSEEvents {
+		parts = append(parts, fmt.Sprintf("--sse-events %d", sseEvents))
+	}
+	if wsServer := c.String("ws-server"); wsServer != "" {
+		parts = append(parts, fmt.Sprintf("--ws-server %s", wsServer))
+	}
+	if port := c.Int("prometheus-port"); port != 0 {
+		parts = append(parts, fmt.Sprintf("--prometheus-port %d", port))
+	}
+	if c.Bool("concurrency-profile") {
+		parts = append(parts, "--concurrency-profile")
+	}
+	if steps := c.String("concurrency-steps"); steps != metrics.DefaultConcurrencySteps {
+		parts = append(parts, fmt.Sprintf("--concurrency-steps %s", steps))
+	}
+	if stepDuration := c.Duration("step-duration"); stepDuration != 10*time.Second {
+		parts = append(parts, fmt.Sprintf("--step-duration %s", stepDuration))
+	}
+	if repeat := c.Int("repeat"); repeat > 1 {
+		parts = append(parts, fmt.Sprintf("--repeat %d", repeat))
+	}
+	if c.Bool("go-bench") {
+		parts = append(parts, "--go-bench")
+	}
+	if c.Bool("ci") {
+		parts = append(parts, "--ci")
+	}
+	if format := c.String("format"); format != "" {
+		parts = append(parts, fmt.Sprintf("--format %s", format))
+	}
+	if outputDir := c.String("output-dir"); outputDir != "." {
+		parts = append(parts, fmt.Sprintf("--output-dir %s", outputDir))
+	}
+	if maxFiles := c.Int("max-output-files"); maxFiles != 0 {
+		parts = append(parts, fmt.Sprintf("--max-output-files %d", maxFiles))
+	}
+	if c.Bool("auto-compare") {
+		parts = append(parts, "--auto-compare")
+	}
+	if limit := c.Int("auto-compare-limit"); limit != defaultAutoCompareLimit {
+		parts = append(parts, fmt.Sprintf("--auto-compare-limit %d", limit))
+	}
+	if prefix := c.String("output-prefix"); prefix != reporter.DefaultOutputPrefix {
+		parts = append(parts, fmt.Sprintf("--output-prefix %s", prefix))
+	}
 	if c.Bool("verbose") {
 		parts = append(parts, "--verbose")
 	}
+	if c.Bool("silent") {
+		parts = append(parts, "--silent")
+	}
+	if c.Bool("anonymize") {
+		parts = append(parts, "--anonymize")
+	}
+	if expected := c.String("assert-overall"); expected != "" {
+		parts = append(parts, fmt.Sprintf("--assert-overall %s", expected))
+	}
 	if benchRecords := c.Int("benchmark-records"); benchRecords != 1000 {
 		parts = append(parts, fmt.Sprintf("--benchmark-records %d", benchRecords))
 	}
+	if c.Bool("benchmark-concurrent") {
+		parts = append(parts, "--benchmark-concurrent")
+	}
+	if benchTimeout := c.Duration("benchmark-timeout"); benchTimeout != 0 {
+		parts = append(parts, fmt.Sprintf("--benchmark-timeout %s", benchTimeout))
+	}
+	if sweep := c.String("benchmark-records-sweep"); sweep != "" {
+		parts = append(parts, fmt.Sprintf("--benchmark-records-sweep %s", sweep))
+	}
+	parts = appendThresholdFlags(c, parts)
+	if strategy := c.String("endpoint-strategy"); strategy != metrics.StrategyRoundRobin {
+		parts = append(parts, fmt.Sprintf("--endpoint-strategy %s", strategy))
+	}
+	if endpointsFile := c.String("endpoints-file"); endpointsFile != "" {
+		parts = append(parts, fmt.Sprintf("--endpoints-file %s", endpointsFile))
+	}
+	if loadEndpoints := c.String("load-endpoints"); loadEndpoints != "" {
+		parts = append(parts, fmt.Sprintf("--load-endpoints %s", loadEndpoints))
+	}
+	if path := c.String("stress-endpoint"); path != "" {
+		parts = append(parts, fmt.Sprintf("--stress-endpoint %s", path))
+	}
+	if c.Bool("leak-detect") {
+		parts = append(parts, "--leak-detect")
+	}
+	if c.Bool("abort-on-threshold") {
+		parts = append(parts, "--abort-on-threshold")
+	}
+	if targets := c.String("sla-targets"); targets != "" {
+		parts = append(parts, fmt.Sprintf("--sla-targets %s", targets))
+	}
+	if percentiles := c.String("percentiles"); percentiles != metrics.DefaultPercentiles {
+		parts = append(parts, fmt.Sprintf("--percentiles %s", percentiles))
+	}
+	if buckets := c.Int("histogram-buckets"); buckets != metrics.DefaultHistogramBuckets {
+		parts = append(parts, fmt.Sprintf("--histogram-buckets %d", buckets))
+	}
+	if c.Bool("step-load") {
+		parts = append(parts, "--step-load")
+	}
+	if interval := c.Duration("step-interval"); interval != defaultStepInterval {
+		parts = append(parts, fmt.Sprintf("--step-interval %s", interval))
+	}
+	if stepStart := c.Int("step-start"); stepStart != defaultStepStart {
+		parts = append(parts, fmt.Sprintf("--step-start %d", stepStart))
+	}
+	if warmup := c.Duration("warmup"); warmup > 0 {
+		parts = append(parts, fmt.Sprintf("--warmup %s", warmup))
+	}
+	if maxRPS := c.Float64("max-rps"); maxRPS > 0 {
+		parts = append(parts, fmt.Sprintf("--max-rps %g", maxRPS))
+	}
+	if thinkTime := c.Duration("think-time"); thinkTime > 0 {
+		parts = append(parts, fmt.Sprintf("--think-time %s", thinkTime))
+	}
+	if jitter := c.Float64("think-time-jitter"); jitter > 0 {
+		parts = append(parts, fmt.Sprintf("--think-time-jitter %g", jitter))
+	}
+	if targetRPS := c.Float64("target-rps"); targetRPS > 0 {
+		parts = append(parts, fmt.Sprintf("--target-rps %g", targetRPS))
+	}
+	if header := c.String("request-id-header"); header != "" {
+		parts = append(parts, fmt.Sprintf("--request-id-header %s", header))
+	}
+	if header := c.String("trace-header"); header != defaultTraceHeader {
+		parts = append(parts, fmt.Sprintf("--trace-header %q", header))
+	}
+	if endpoint := c.String("otel-endpoint"); endpoint != "" {
+		parts = append(parts, fmt.Sprintf("--otel-endpoint %s", endpoint))
+	}
+	if ua := c.String("user-agent"); ua != "" {
+		parts = append(parts, fmt.Sprintf("--user-agent %q", ua))
+	}
+	if !c.Bool("include-user-agent-version") {
+		parts = append(parts, "--include-user-agent-version=false")
+	}
+	if path := c.String("audit-log"); path != "" {
+		parts = append(parts, fmt.Sprintf("--audit-log %s", path))
+	}
+	if c.Bool("probe-keepalive") {
+		parts = append(parts, "--probe-keepalive")
+	}
+	if c.Bool("traceroute") {
+		parts = append(parts, "--traceroute")
+	}
+	if c.Bool("probe-mtu") {
+		parts = append(parts, "--probe-mtu")
+	}
+	if c.Bool("probe-http3") {
+		parts = append(parts, "--probe-http3")
+	}
+	if resolver := c.String("dns-resolver"); resolver != "" {
+		parts = append(parts, fmt.Sprintf("--dns-resolver %s", resolver))
+	}
+	if dohURL := c.String("doh-url"); dohURL != "" {
+		parts = append(parts, fmt.Sprintf("--doh-url %s", dohURL))
+	}
+	if c.Bool("dns-cache") {
+		parts = append(parts, "--dns-cache")
+	}
+	if bindAddr := c.String("bind-addr"); bindAddr != "" {
+		parts = append(parts, fmt.Sprintf("--bind-addr %s", bindAddr))
+	}
+	if socket := c.String("unix-socket"); socket != "" {
+		parts = append(parts, fmt.Sprintf("--unix-socket %s", socket))
+	}
+	if c.Bool("allow-https-downgrade") {
+		parts = append(parts, "--allow-https-downgrade")
+	}
+	if c.Bool("http-only") {
+		parts = append(parts, "--http-only")
+	}
+	if c.Bool("cold-start") {
+		parts = append(parts, "--cold-start")
+	}
+	if order := c.String("endpoint-order"); order != metrics.EndpointOrderDefault {
+		parts = append(parts, fmt.Sprintf("--endpoint-order %s", order))
+	}
+	if seed := c.Int64("seed"); seed != 0 {
+		parts = append(parts, fmt.Sprintf("--seed %d", seed))
+	}
+	if baseline := c.String("baseline-json"); baseline != "" {
+		parts = append(parts, fmt.Sprintf("--baseline-json %s", baseline))
+	}
+	if samples := c.Int("endpoint-samples"); samples != 1 {
+		parts = append(parts, fmt.Sprintf("--endpoint-samples %d", samples))
+	}
+	if c.Bool("prefer-ipv4") {
+		parts = append(parts, "--prefer-ipv4")
+	}
+	if c.Bool("prefer-ipv6") {
+		parts = append(parts, "--prefer-ipv6")
+	}
+	if c.Bool("wait-healthy") {
+		parts = append(parts, "--wait-healthy")
+	}
+	if waitTimeout := c.Duration("wait-timeout"); waitTimeout != 2*time.Minute {
+		parts = append(parts, fmt.Sprintf("--wait-timeout %s", waitTimeout))
+	}
+	if c.Bool("grpc-health") {
+		parts = append(parts, "--grpc-health")
+	}
+	if addr := c.String("grpc-addr"); addr != "" {
+		parts = append(parts, fmt.Sprintf("--grpc-addr %s", addr))
+	}
+	if c.Bool("k8s-readiness-probe") {
+		parts = append(parts, "--k8s-readiness-probe")
+	}
+	if pushURL := c.String("pushgateway-url"); pushURL != "" {
+		parts = append(parts, fmt.Sprintf("--pushgateway-url %s", pushURL))
+	}
+	if pushJob := c.String("pushgateway-job"); pushJob != "actalog_bench" {
+		parts = append(parts, fmt.Sprintf("--pushgateway-job %s", pushJob))
+	}
+	if esURL := c.String("elasticsearch-url"); esURL != "" {
+		parts = append(parts, fmt.Sprintf("--elasticsearch-url %s", esURL))
+	}
+	if esIndex := c.String("elasticsearch-index"); esIndex != "actalog-bench" {
+		parts = append(parts, fmt.Sprintf("--elasticsearch-index %s", esIndex))
+	}
+	if esUser := c.String("elasticsearch-username"); esUser != "" {
+		parts = append(parts, fmt.Sprintf("--elasticsearch-username %s", esUser))
+	}
+	if c.String("elasticsearch-password") != "" {
+		parts = append(parts, "--elasticsearch-password <PASSWORD>")
+	}
 
 	return strings.Join(parts, " \\\n  ")
 }
 
-func run(c *cli.Context) error {
+// appendCompareFlags appends the flags that affect compare mode
+func appendCompareFlags(c *cli.Context, parts []string) []string {
+	if dir := c.String("compare"); dir != "" {
+		parts = append(parts, fmt.Sprintf("--compare %s", dir))
+	}
+	if manifestURL := c.String("compare-url"); manifestURL != "" {
+		parts = append(parts, fmt.Sprintf("--compare-url %s", manifestURL))
+	}
+	if c.String("compare-url-token") != "" {
+		parts = append(parts, "--compare-url-token <TOKEN>")
+	}
+	if since := c.Duration("compare-since"); since != 0 {
+		parts = append(parts, fmt.Sprintf("--compare-since %s", since))
+	}
+	if c.Bool("compare-regressions-only") {
+		parts = append(parts, "--compare-regressions-only")
+	}
+	if c.Bool("aggregate") {
+		parts = append(parts, "--aggregate")
+	}
+	if c.Bool("schema-version-check") {
+		parts = append(parts, "--schema-version-check")
+	}
+	if mdOut := c.String("markdown"); mdOut != "" {
+		parts = append(parts, fmt.Sprintf("--markdown %s", mdOut))
+	}
+	if prefix := c.String("output-prefix"); prefix != reporter.DefaultOutputPrefix {
+		parts = append(parts, fmt.Sprintf("--output-prefix %s", prefix))
+	}
+	return appendThresholdFlags(c, parts)
+}
+
+// appendThresholdFlags appends the alert threshold flags that differ from
+// their defaults
+func appendThresholdFlags(c *cli.Context, parts []string) []string {
+	if path := c.String("threshold-file"); path != "" {
+		parts = append(parts, fmt.Sprintf("--threshold-file %s", path))
+	}
+	for _, threshold := range []struct {
+		name string
+		def  float64
+	}{
+		{"threshold-p95", 500},
+		{"threshold-p99", 1000},
+		{"threshold-error-rate", 1.0},
+		{"threshold-rps-min", 10},
+		{"threshold-db-max", 50},
+		{"threshold-serial-max", 1},
+	} {
+		if value := c.Float64(threshold.name); value != threshold.def {
+			parts = append(parts, fmt.Sprintf("--%s %g", threshold.name, value))
+		}
+	}
+	return parts
+}
+
+func run(c *cli.Context) (err error) {
+	if path := c.String("config"); path != "" {
+		if err := applyConfigFile(c, path); err != nil {
+			return err
+		}
+	}
+
+	// In silent mode errors only surface through the exit status, and an
+	// error that already has one keeps it
+	if c.Bool("silent") {
+		defer func() {
+			var exitErr cli.ExitCoder
+			if err != nil && !errors.As(err, &exitErr) {
+				err = cli.Exit("", 1)
+			}
+		}()
+	}
+
+	if err := validateAssertOverall(c.String("assert-overall")); err != nil {
+		return err
+	}
+
 	// Handle compare mode separately
-	if compareDir := c.String("compare"); compareDir != "" {
-		return runCompare(c, compareDir)
+	compareDir, compareURL := c.String("compare"), c.String("compare-url")
+	if compareDir != "" && compareURL != "" {
+		return fmt.Errorf("--compare and --compare-url are mutually exclusive")
+	}
+	if compareDir != "" || compareURL != "" {
+		return runCompare(c, compareDir, compareURL)
+	}
+
+	if c.Bool("aggregate") {
+		return fmt.Errorf("--aggregate requires --compare or --compare-url")
+	}
+
+	// Handle diff mode separately
+	if diff := c.String("diff"); diff != "" {
+		return runDiff(c, diff)
+	}
+
+	// Handle merge mode separately
+	if merge := c.String("merge"); merge != "" {
+		return runMerge(c, merge)
 	}
 
 	// URL is required for benchmarking mode
-	if c.String("url") == "" {
+	var urls []string
+	if path := c.String("url-list"); path != "" {
+		if c.String("url") != "" {
+			return fmt.Errorf("--url and --url-list are mutually exclusive")
+		}
+		list, err := readURLList(path)
+		if err != nil {
+			return err
+		}
+		// These modes assume a single target
+		for _, conflict := range []struct {
+			flag string
+			set  bool
+		}{
+			{"--repeat", c.Int("repeat") > 1},
+			{"--wait-healthy", c.Bool("wait-healthy")},
+			{"--k8s-readiness-probe", c.Bool("k8s-readiness-probe")},
+		} {
+			if conflict.set {
+				return fmt.Errorf("--url-list cannot be used with %s", conflict.flag)
+			}
+		}
+		urls = list
+	} else if c.String("url") == "" {
 		return fmt.Errorf("--url is required for benchmarking (use --compare for comparison mode)")
 	}
 
-	ctx := context.Background()
+	// Ctrl+C or SIGTERM cancels ctx, ending the run at the current phase with
+	// the measurements taken so far still reported. Once it has, the default
+	// handling is restored, so a second Ctrl+C quits at once.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	password, err := resolvePassword(c.String("pass"), c.String("pass-file"), c.String("pass-cmd"))
+	if err != nil {
+		return err
+	}
+	var token string
+	if path := c.String("token-file"); path != "" {
+		if password != "" {
+			return fmt.Errorf("--token-file cannot be used with a password")
+		}
+		if token, err = readToken(path); err != nil {
+			return err
+		}
+	}
 
 	config := &internal.Config{
 		URL:              c.String("url"),
 		User:             c.String("user"),
-		Pass:             c.String("pass"),
+		Pass:             password,
+		Token:            token,
 		Full:             c.Bool("full"),
 		Frontend:         c.Bool("frontend"),
 		JSONOutput:       c.String("json"),
+		JSONAppend:       c.Bool("json-append"),
+		MaxOutputFiles:   c.Int("max-output-files"),
+		AutoCompare:      c.Bool("auto-compare"),
+		AutoCompareLimit: c.Int("auto-compare-limit"),
+		OutputPrefix:     c.String("output-prefix"),
 		MarkdownOutput:   c.String("markdown"),
+		CSVOutput:        c.String("csv"),
+		HTMLOutput:       c.String("html"),
+		JUnitOutput:      c.String("junit"),
 		Concurrent:       c.Int("concurrent"),
 		Duration:         c.Duration("duration"),
 		Timeout:          c.Duration("timeout"),
+		MaxResponseSize:  c.Int64("max-response-size"),
 		Verbose:          c.Bool("verbose"),
 		CommandLine:      buildCommandLine(c),
 		BenchmarkRecords: c.Int("benchmark-records"),
+		EndpointStrategy: c.String("endpoint-strategy"),
+		PushgatewayURL:   c.String("pushgateway-url"),
+		PushgatewayJob:   c.String("pushgateway-job"),
+		Silent:           c.Bool("silent"),
+		AssertOverall:    c.String("assert-overall"),
+		Anonymize:        c.Bool("anonymize"),
+		RequestIDHeader:  c.String("request-id-header"),
+		TraceHeader:      c.String("trace-header"),
+		OTelEndpoint:     c.String("otel-endpoint"),
+		UserAgent:        userAgent(c.String("user-agent"), c.Bool("include-user-agent-version")),
+		AuditLog:         c.String("audit-log"),
+		ProbeKeepAlive:   c.Bool("probe-keepalive"),
+		GoBench:          c.Bool("go-bench"),
+		CI:               c.Bool("ci"),
+
+		ConcurrencyProfile: c.Bool("concurrency-profile"),
+		StepDuration:       c.Duration("step-duration"),
+
+		WSLoadTest: c.Bool("ws-load-test"),
+		WSPath:     c.String("ws-path"),
+
+		SSEPath:   c.String("sse-path"),
+		SSEEvents: c.Int("sse-events"),
+		WSServer:  c.String("ws-server"),
+
+		PrometheusPort: c.Int("prometheus-port"),
+
+		Traceroute: c.Bool("traceroute"),
+		ProbeMTU:   c.Bool("probe-mtu"),
+		ProbeHTTP3: c.Bool("probe-http3"),
+		HTTPOnly:   c.Bool("http-only"),
+		ColdStart:  c.Bool("cold-start"),
+
+		GRPCHealth: c.Bool("grpc-health"),
+		GRPCAddr:   c.String("grpc-addr"),
+
+		DNSResolver: c.String("dns-resolver"),
+		DoHURL:      c.String("doh-url"),
+		DNSCache:    c.Bool("dns-cache"),
+		BindAddr:    c.String("bind-addr"),
+		UnixSocket:  c.String("unix-socket"),
+
+		AllowHTTPSDowngrade: c.Bool("allow-https-downgrade"),
+
+		EndpointSamples: c.Int("endpoint-samples"),
+
+		EndpointOrder: c.String("endpoint-order"),
+		Seed:          c.Int64("seed"),
+
+		StressEndpoint: c.String("stress-endpoint"),
+		LeakDetect:     c.Bool("leak-detect"),
+
+		AbortOnThreshold: c.Bool("abort-on-threshold"),
+
+		HistogramBuckets: c.Int("histogram-buckets"),
+		MaxRPS:           c.Float64("max-rps"),
+		WarmupDuration:   c.Duration("warmup"),
+
+		StepLoad:     c.Bool("step-load"),
+		StepInterval: c.Duration("step-interval"),
+		StepStart:    c.Int("step-start"),
+
+		ThinkTime:          c.Duration("think-time"),
+		ThinkTimeJitterPct: c.Float64("think-time-jitter"),
+
+		TargetRPS: c.Float64("target-rps"),
+
+		MaxIdleConnsPerHost: c.Int("max-idle-conns-per-host"),
+
+		SimulateLatency:  c.Duration("simulate-latency"),
+		SimulateLossRate: c.Float64("simulate-loss-rate"),
+
+		Repeat: c.Int("repeat"),
+
+		BenchmarkConcurrent: c.Bool("benchmark-concurrent"),
+		BenchmarkTimeout:    c.Duration("benchmark-timeout"),
+
+		ElasticsearchURL:      c.String("elasticsearch-url"),
+		ElasticsearchIndex:    c.String("elasticsearch-index"),
+		ElasticsearchUsername: c.String("elasticsearch-username"),
+		ElasticsearchPassword: c.String("elasticsearch-password"),
+	}
+	if len(urls) > 0 {
+		// Options are checked against the first URL; runURLList visits each in turn
+		config.URL = urls[0]
+	}
+
+	if config.JSONAppend && !strings.HasSuffix(strings.ToLower(config.JSONOutput), ".json") {
+		return fmt.Errorf("--json-append requires --json with a .json file path")
+	}
+
+	if config.GRPCHealth {
+		if _, _, err := net.SplitHostPort(config.GRPCAddr); err != nil {
+			return fmt.Errorf("--grpc-health requires --grpc-addr host:port, got %q", config.GRPCAddr)
+		}
+	}
+
+	if config.HTTPOnly && strings.HasPrefix(strings.ToLower(config.URL), "https://") {
+		return fmt.Errorf("--http-only cannot be used with an https:// URL")
+	}
+	if config.ProbeHTTP3 && !strings.HasPrefix(strings.ToLower(config.URL), "https://") {
+		return fmt.Errorf("--probe-http3 requires an https:// URL")
+	}
+
+	if config.MaxResponseSize < 0 {
+		return fmt.Errorf("--max-response-size must not be negative, got %d", config.MaxResponseSize)
+	}
+	if config.MaxIdleConnsPerHost < 1 {
+		return fmt.Errorf("--max-idle-conns-per-host must be at least 1, got %d", config.MaxIdleConnsPerHost)
+	}
+	if config.HistogramBuckets < 1 {
+		return fmt.Errorf("--histogram-buckets must be at least 1, got %d", config.HistogramBuckets)
+	}
+	if config.StepLoad {
+		if config.StepInterval <= 0 {
+			return fmt.Errorf("--step-interval must be positive, got %s", config.StepInterval)
+		}
+		if config.StepStart < 1 || config.StepStart > config.Concurrent {
+			return fmt.Errorf("--step-start must be from 1 to --concurrent (%d), got %d", config.Concurrent, config.StepStart)
+		}
+		ramp := time.Duration(config.Concurrent-config.StepStart) * config.StepInterval
+		if config.Duration < ramp && !config.Silent {
+			peak := config.StepStart + int(config.Duration/config.StepInterval)
+			fmt.Fprintf(os.Stderr, "Warning: --duration %s is shorter than the %s ramp to --concurrent %d; the load test will peak at %d workers\n",
+				config.Duration, ramp, config.Concurrent, peak)
+		}
+	}
+	if config.WarmupDuration < 0 {
+		return fmt.Errorf("--warmup must not be negative, got %s", config.WarmupDuration)
+	}
+	if config.MaxRPS < 0 {
+		return fmt.Errorf("--max-rps must not be negative, got %g", config.MaxRPS)
+	}
+	if config.TargetRPS < 0 {
+		return fmt.Errorf("--target-rps must not be negative, got %g", config.TargetRPS)
+	}
+	if config.TargetRPS > 0 && (config.MaxRPS > 0 || config.StepLoad) {
+		return fmt.Errorf("--target-rps sets its own request rate and cannot be combined with --max-rps or --step-load")
+	}
+	if config.ThinkTime < 0 {
+		return fmt.Errorf("--think-time must not be negative, got %s", config.ThinkTime)
+	}
+	if config.ThinkTimeJitterPct < 0 || config.ThinkTimeJitterPct > 100 {
+		return fmt.Errorf("--think-time-jitter must be from 0 to 100, got %g", config.ThinkTimeJitterPct)
+	}
+	if config.ThinkTimeJitterPct > 0 && config.ThinkTime == 0 {
+		return fmt.Errorf("--think-time-jitter requires --think-time")
+	}
+	if config.ThinkTime > 0 && config.TargetRPS > 0 {
+		return fmt.Errorf("--think-time cannot be combined with --target-rps, whose arrivals do not wait for responses")
+	}
+	if config.PrometheusPort < 0 || config.PrometheusPort > 65535 {
+		return fmt.Errorf("--prometheus-port must be from 1 to 65535, got %d", config.PrometheusPort)
+	}
+	if config.SimulateLatency < 0 {
+		return fmt.Errorf("--simulate-latency must not be negative, got %s", config.SimulateLatency)
+	}
+	if config.SimulateLossRate < 0 || config.SimulateLossRate >= 1 {
+		return fmt.Errorf("--simulate-loss-rate must be at least 0 and below 1, got %g", config.SimulateLossRate)
+	}
+
+	if config.EndpointSamples < 1 {
+		return fmt.Errorf("--endpoint-samples must be at least 1, got %d", config.EndpointSamples)
+	}
+	if config.EndpointSamples > 1 && config.EndpointSamples < metrics.MinPercentileSamples && !config.Silent {
+		fmt.Fprintf(os.Stderr, "Warning: --endpoint-samples %d is below %d; recording mean/min/max without percentiles\n",
+			config.EndpointSamples, metrics.MinPercentileSamples)
+	}
+
+	if config.MaxOutputFiles < 0 {
+		return fmt.Errorf("--max-output-files must not be negative, got %d", config.MaxOutputFiles)
+	}
+	if err := validateOutputPrefix(config.OutputPrefix); err != nil {
+		return err
+	}
+
+	if config.SSEPath != "" && config.SSEEvents < 1 {
+		return fmt.Errorf("--sse-events must be at least 1, got %d", config.SSEEvents)
+	}
+
+	if config.Repeat < 1 {
+		return fmt.Errorf("--repeat must be at least 1, got %d", config.Repeat)
+	}
+
+	// Silent wins over verbose
+	if config.Silent {
+		config.Verbose = false
+	}
+
+	if err := applyFormats(config, c.String("format"), c.String("output-dir")); err != nil {
+		return err
+	}
+	if err := validateAutoCompare(config); err != nil {
+		return err
+	}
+
+	if config.ConcurrencyProfile {
+		steps, err := metrics.ParseConcurrencySteps(c.String("concurrency-steps"))
+		if err != nil {
+			return fmt.Errorf("--concurrency-steps: %w", err)
+		}
+		config.ConcurrencySteps = steps
+	}
+
+	if targets := c.String("sla-targets"); targets != "" {
+		slaTargets, err := metrics.ParseSLATargets(targets)
+		if err != nil {
+			return fmt.Errorf("--sla-targets: %w", err)
+		}
+		config.SLATargets = slaTargets
+	}
+
+	percentiles, err := metrics.ParsePercentiles(c.String("percentiles"))
+	if err != nil {
+		return fmt.Errorf("--percentiles: %w", err)
+	}
+	config.Percentiles = percentiles
+
+	if sweep := c.String("benchmark-records-sweep"); sweep != "" {
+		counts, err := metrics.ParseRecordsSweep(sweep)
+		if err != nil {
+			return fmt.Errorf("--benchmark-records-sweep: %w", err)
+		}
+		config.BenchmarkRecordsSweep = counts
+	}
+
+	switch {
+	case config.BenchmarkTimeout < 0:
+		return fmt.Errorf("--benchmark-timeout must not be negative, got %s", config.BenchmarkTimeout)
+	case config.BenchmarkTimeout == 0:
+		config.BenchmarkTimeout = config.Timeout
+	}
+
+	switch {
+	case c.Bool("prefer-ipv4") && c.Bool("prefer-ipv6"):
+		return fmt.Errorf("--prefer-ipv4 and --prefer-ipv6 are mutually exclusive")
+	case c.Bool("prefer-ipv4"):
+		config.IPFamily = metrics.IPFamilyIPv4
+	case c.Bool("prefer-ipv6"):
+		config.IPFamily = metrics.IPFamilyIPv6
+	}
+
+	if config.BindAddr != "" {
+		local := net.ParseIP(config.BindAddr)
+		switch {
+		case local == nil:
+			return fmt.Errorf("--bind-addr must be an IP address, got %q", config.BindAddr)
+		case local.To4() != nil && config.IPFamily == metrics.IPFamilyIPv6:
+			return fmt.Errorf("--bind-addr %s is IPv4 and cannot be used with --prefer-ipv6", config.BindAddr)
+		case local.To4() == nil && config.IPFamily == metrics.IPFamilyIPv4:
+			return fmt.Errorf("--bind-addr %s is IPv6 and cannot be used with --prefer-ipv4", config.BindAddr)
+		}
+	}
+
+	if config.UnixSocket != "" {
+		// These options only affect TCP connections, which a socket replaces
+		for _, conflict := range []struct {
+			flag string
+			set  bool
+		}{
+			{"--bind-addr", config.BindAddr != ""},
+			{"--dns-resolver", config.DNSResolver != ""},
+			{"--doh-url", config.DoHURL != ""},
+			{"--dns-cache", config.DNSCache},
+		} {
+			if conflict.set {
+				return fmt.Errorf("--unix-socket cannot be used with %s", conflict.flag)
+			}
+		}
+	}
+
+	if config.DoHURL != "" {
+		if config.DNSResolver != "" {
+			return fmt.Errorf("--doh-url cannot be used with --dns-resolver")
+		}
+		if u, err := url.Parse(config.DoHURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("--doh-url must be an https:// URL, got %q", config.DoHURL)
+		}
+	}
+
+	if endpointsFile := c.String("endpoints-file"); endpointsFile != "" {
+		endpoints, err := metrics.LoadWeightedEndpoints(endpointsFile)
+		if err != nil {
+			return fmt.Errorf("load endpoints file: %w", err)
+		}
+		config.LoadEndpoints = endpoints
+	}
+	if spec := c.String("load-endpoints"); spec != "" {
+		if len(config.LoadEndpoints) > 0 {
+			return fmt.Errorf("--load-endpoints cannot be used with --endpoints-file")
+		}
+		endpoints, err := metrics.ParseLoadEndpoints(spec)
+		if err != nil {
+			return fmt.Errorf("--load-endpoints: %w", err)
+		}
+		config.LoadTestEndpoints = endpoints
+		// The weights are the point of the list, so they apply unless another strategy is asked for
+		if !c.IsSet("endpoint-strategy") {
+			config.EndpointStrategy = metrics.StrategyWeighted
+		}
+	}
+
+	if err := metrics.ValidateEndpointOrder(config.EndpointOrder); err != nil {
+		return fmt.Errorf("--endpoint-order: %w", err)
+	}
+	switch baselinePath := c.String("baseline-json"); {
+	case config.EndpointOrder == metrics.EndpointOrderSlowestFirst && baselinePath == "":
+		return fmt.Errorf("--endpoint-order %s requires --baseline-json", metrics.EndpointOrderSlowestFirst)
+	case baselinePath != "":
+		// An appended results file holds several runs; the newest is the baseline
+		baselines, err := reporter.NewComparison("").LoadResults([]string{baselinePath})
+		if err != nil {
+			return fmt.Errorf("load baseline: %w", err)
+		}
+		if len(baselines) == 0 {
+			return fmt.Errorf("load baseline: %s holds no results", baselinePath)
+		}
+		config.Baseline = baselines[len(baselines)-1]
+	}
+	if config.EndpointOrder == metrics.EndpointOrderRandom && config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+
+	// Validate the strategy up front rather than after the other phases have run
+	// Only GET entries join the load test rotation; POST entries are benchmarked once
+	var selector metrics.EndpointSelector
+	switch {
+	case len(config.LoadTestEndpoints) > 0:
+		var err error
+		selector, err = metrics.NewEndpointSelector(config.EndpointStrategy, config.LoadTestEndpoints)
+		if err != nil {
+			return err
+		}
+	case len(config.LoadEndpoints) > 0:
+		if loadEndpoints := metrics.FilterGET(config.LoadEndpoints); len(loadEndpoints) > 0 {
+			var err error
+			selector, err = metrics.NewEndpointSelector(config.EndpointStrategy, loadEndpoints)
+			if err != nil {
+				return err
+			}
+		}
+	case config.EndpointStrategy != metrics.StrategyRoundRobin:
+		return fmt.Errorf("--endpoint-strategy %s requires --endpoints-file or --load-endpoints", config.EndpointStrategy)
 	}
 
-	result := &internal.BenchmarkResult{
-		Timestamp: time.Now().UTC(),
-		Target:    config.URL,
-		Overall:   "pass",
+	// Load thresholds up front so a bad --threshold-file fails before the run
+	thresholds, err := alertThresholds(c)
+	if err != nil {
+		return err
 	}
 
 	// Create HTTP client
-	httpClient := client.New(config.URL, config.Timeout)
+	clientOpts := []client.Option{
+		client.WithNetwork(metrics.DialNetwork(config.IPFamily)),
+		client.WithMaxResponseSize(config.MaxResponseSize),
+		client.WithMaxIdleConnsPerHost(config.MaxIdleConnsPerHost),
+		client.WithDNSResolver(config.DNSResolver),
+		client.WithUserAgent(config.UserAgent),
+		client.WithBindAddr(config.BindAddr),
+		client.WithUnixSocket(config.UnixSocket),
+		client.WithAllowHTTPSDowngrade(config.AllowHTTPSDowngrade),
+		client.WithToken(config.Token),
+		client.WithSimulatedNetwork(config.SimulateLatency, config.SimulateLossRate),
+	}
+	if config.DNSCache {
+		// Shared by every run, so later runs start with the target resolved
+		dnsCache := metrics.NewDNSCache(metrics.HostLookup(config.DNSResolver, config.DoHURL, config.Timeout), config.Duration)
+		config.DNSCacheHits = dnsCache.Hits
+		clientOpts = append(clientOpts, client.WithHostLookup(dnsCache.LookupHost))
+	}
+	if config.RequestIDHeader != "" {
+		clientOpts = append(clientOpts, client.WithRequestIDHeader(config.RequestIDHeader))
+	}
+	if config.TraceHeader != "" {
+		config.TraceID = client.NewTraceID(time.Now())
+		clientOpts = append(clientOpts, client.WithTraceHeader(config.TraceHeader, config.TraceID))
+	}
+	// Set up below with --prometheus-port. Waiting for scrapes is deferred
+	// first so it runs last, after traces are flushed and the audit log closed.
+	var promServer *reporter.PrometheusServer
+	var published bool
+	defer func() { awaitScrapes(ctx, promServer, published, config.Silent) }()
+
+	if config.OTelEndpoint != "" {
+		if config.TraceID == "" {
+			config.TraceID = client.NewTraceID(time.Now())
+		}
+		shutdown, err := telemetry.Setup(config.OTelEndpoint, config.TraceID, version)
+		if err != nil {
+			return fmt.Errorf("--otel-endpoint: %w", err)
+		}
+		defer flushTraces(shutdown, config)
+	}
+	if config.AuditLog != "" {
+		f, err := os.OpenFile(config.AuditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("open audit log: %w", err)
+		}
+		defer f.Close()
+		auditLogger := audit.NewAuditLogger(f)
+		defer func() {
+			if err := auditLogger.Err(); err != nil && !config.Silent {
+				fmt.Fprintf(os.Stderr, "Warning: audit log %s is incomplete: %v\n", config.AuditLog, err)
+			}
+		}()
+		clientOpts = append(clientOpts, client.WithAuditLogger(auditLogger))
+	}
+	httpClient := client.New(config.URL, config.Timeout, clientOpts...)
+
+	// Readiness probe mode replaces the benchmark entirely
+	if c.Bool("k8s-readiness-probe") {
+		return readinessProbe(ctx, httpClient, c.Duration("wait-timeout"), os.Stdout, os.Stderr)
+	}
+
+	// Wait for the target to become healthy (e.g. right after a deployment)
+	var waited time.Duration
+	if c.Bool("wait-healthy") {
+		waitTimeout := c.Duration("wait-timeout")
+		if !config.Silent {
+			fmt.Printf("Waiting up to %s for %s to become healthy...\n", waitTimeout, config.URL)
+		}
+		var health *internal.HealthResult
+		health, waited = metrics.WaitForHealthy(ctx, httpClient, waitTimeout, healthPollInterval,
+			func(attempt int, h *internal.HealthResult) {
+				if !config.Silent {
+					fmt.Printf("  Attempt %d: status %s, retrying in %s\n", attempt, h.Status, healthPollInterval)
+				}
+			})
+		if !config.Silent {
+			if health.Status == "healthy" {
+				fmt.Printf("Target healthy after %.1fs\n", waited.Seconds())
+			} else {
+				fmt.Fprintf(os.Stderr, "Warning: target not healthy after %s, continuing anyway\n", waitTimeout)
+			}
+		}
+	}
+
+	// Stream load test progress to dashboards (if --ws-server)
+	if config.WSServer != "" {
+		metricsServer := wsserver.NewMetricsServer()
+		if err := metricsServer.Start(config.WSServer); err != nil {
+			return fmt.Errorf("start metrics server: %w", err)
+		}
+		defer metricsServer.Close()
+		if !config.Silent {
+			fmt.Printf("Streaming load test metrics on ws://%s\n", metricsServer.Addr())
+		}
+		config.LoadTestProgress = func(lt *internal.LoadTestResult) {
+			metricsServer.Broadcast(lt)
+		}
+	}
+
+	// Expose each run's metrics to Prometheus (if --prometheus-port)
+	if config.PrometheusPort != 0 {
+		server := reporter.NewPrometheusServer()
+		if err := server.Start(fmt.Sprintf(":%d", config.PrometheusPort)); err != nil {
+			return fmt.Errorf("start Prometheus metrics server: %w", err)
+		}
+		promServer = server
+		if !config.Silent {
+			fmt.Printf("Serving Prometheus metrics on http://%s/metrics\n", promServer.Addr())
+		}
+		config.RunComplete = func(result *internal.BenchmarkResult) {
+			if config.Anonymize {
+				result = anonymizedCopy(result, config)
+			}
+			promServer.Update(result)
+			published = true
+		}
+	}
+
+	if len(urls) > 0 {
+		return runURLList(ctx, c, config, urls, clientOpts, selector, thresholds)
+	}
 
 	// Authentication (if credentials provided)
 	if config.User != "" && config.Pass != "" {
@@ -377,41 +1770,388 @@ func run(c *cli.Context) error {
 			fmt.Println("Authenticating...")
 		}
 		if err := httpClient.Login(ctx, config.User, config.Pass); err != nil {
+			result := newResult(config)
+			result.WaitedForHealthySec = waited.Seconds()
 			result.Error = fmt.Sprintf("authentication failed: %v", err)
 			result.Overall = "fail"
-			outputResults(result, config)
-			return nil
+			outputResults(result, config, thresholds)
+			return exitStatus(result, config)
 		}
 	}
 
+	if config.Repeat > 1 {
+		return runRepeated(ctx, config, httpClient, selector, thresholds, waited)
+	}
+
+	result := runSuite(ctx, config, httpClient, selector, thresholds)
+	result.WaitedForHealthySec = waited.Seconds()
+	result.ThresholdBreaches = thresholds.Breaches(result, reporter.RunLabel(0, result))
+	interrupted := markShutdown(ctx, result)
+	runComplete(config, result)
+	outputResults(result, config, thresholds)
+	pruneOutputFiles(config)
+	if interrupted {
+		return interruptedExit(config)
+	}
+	return exitStatus(result, config)
+}
+
+// awaitScrapes keeps the --prometheus-port server up until interrupted, so
+// Prometheus can scrape the last run, then closes it. Without a published
+// run there is nothing to scrape and the server is closed at once.
+func awaitScrapes(ctx context.Context, server *reporter.PrometheusServer, published, silent bool) {
+	if server == nil {
+		return
+	}
+	if published && ctx.Err() == nil {
+		if !silent {
+			fmt.Fprintln(os.Stderr, "Benchmark complete; serving its metrics until interrupted (Ctrl+C)")
+		}
+		<-ctx.Done()
+	}
+	server.Close()
+}
+
+// runComplete passes a finished run's result to config.RunComplete, if set,
+// once its threshold breaches and shutdown state are recorded
+func runComplete(config *internal.Config, result *internal.BenchmarkResult) {
+	if config.RunComplete != nil {
+		config.RunComplete(result)
+	}
+}
+
+// markShutdown records on result that the run was cut short when ctx was
+// cancelled by SIGINT or SIGTERM, and reports whether it was
+func markShutdown(ctx context.Context, result *internal.BenchmarkResult) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	result.GracefulShutdown = true
+	result.ShutdownReason = "interrupted by SIGINT or SIGTERM; only the phases measured before it are reported"
+	return true
+}
+
+// interruptedExit returns the exit status for a run stopped by a signal once
+// its partial results have been reported
+func interruptedExit(config *internal.Config) error {
+	if config.Silent {
+		return cli.Exit("", exitInterrupted)
+	}
+	return cli.Exit("Interrupted: partial results were reported", exitInterrupted)
+}
+
+// readURLList reads the --url-list file: one URL per line, with blank lines
+// and lines starting with # ignored. At least two URLs are required.
+func readURLList(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read --url-list: %w", err)
+	}
+
+	var urls []string
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if u, err := url.Parse(line); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("--url-list %s line %d: %q is not an http:// or https:// URL", path, i+1, line)
+		}
+		urls = append(urls, line)
+	}
+	if len(urls) < 2 {
+		return nil, fmt.Errorf("--url-list %s must list at least 2 URLs, got %d", path, len(urls))
+	}
+	return urls, nil
+}
+
+// passCmdTimeout bounds how long --pass-cmd may run
+const passCmdTimeout = 10 * time.Second
+
+// resolvePassword returns the password from --pass (flag), the first line of
+// --pass-file (file), or the output of --pass-cmd (cmd), at most one of which
+// may be set. It returns "" when none is.
+func resolvePassword(flag, file, cmd string) (string, error) {
+	set := 0
+	for _, v := range []string{flag, file, cmd} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return "", fmt.Errorf("only one of --pass, --pass-file, and --pass-cmd may be set")
+	}
+
+	switch {
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("read --pass-file: %w", err)
+		}
+		line, _, _ := strings.Cut(string(data), "\n")
+		password := strings.TrimSuffix(line, "\r")
+		if password == "" {
+			return "", fmt.Errorf("--pass-file %s is empty", file)
+		}
+		return password, nil
+
+	case cmd != "":
+		ctx, cancel := context.WithTimeout(context.Background(), passCmdTimeout)
+		defer cancel()
+
+		shell, shellFlag := "sh", "-c"
+		if runtime.GOOS == "windows" {
+			shell, shellFlag = "cmd", "/C"
+		}
+		command := exec.CommandContext(ctx, shell, shellFlag, cmd)
+		command.Stderr = os.Stderr // Let the command prompt or report errors itself
+		out, err := command.Output()
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("--pass-cmd did not finish within %s", passCmdTimeout)
+		}
+		if err != nil {
+			// The command line is not echoed, as it may contain the secret
+			return "", fmt.Errorf("run --pass-cmd: %w", err)
+		}
+		password := strings.TrimRight(string(out), "\r\n")
+		if password == "" {
+			return "", fmt.Errorf("--pass-cmd printed no password")
+		}
+		return password, nil
+	}
+	return flag, nil
+}
+
+// readToken returns the JWT in path, without surrounding whitespace
+func readToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read --token-file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("--token-file %s is empty", path)
+	}
+	return token, nil
+}
+
+// readinessProbe waits up to timeout for the target to report healthy, logging
+// each attempt to stderr and writing READY or NOT_READY to stdout for containers
+// that gate on it. It fails with exit status 1 unless the target became healthy.
+func readinessProbe(ctx context.Context, httpClient *client.Client, timeout time.Duration, stdout, stderr io.Writer) error {
+	health, waited := metrics.WaitForHealthy(ctx, httpClient, timeout, healthPollInterval,
+		func(attempt int, h *internal.HealthResult) {
+			if h.Error != "" {
+				fmt.Fprintf(stderr, "Readiness attempt %d: status %s (%s), retrying in %s\n", attempt, h.Status, h.Error, healthPollInterval)
+			} else {
+				fmt.Fprintf(stderr, "Readiness attempt %d: status %s, retrying in %s\n", attempt, h.Status, healthPollInterval)
+			}
+		})
+
+	if health.Status != "healthy" {
+		fmt.Fprintf(stderr, "Target not ready after %.1fs\n", waited.Seconds())
+		fmt.Fprintln(stdout, "NOT_READY")
+		return cli.Exit("", 1)
+	}
+
+	fmt.Fprintf(stderr, "Target ready after %.1fs\n", waited.Seconds())
+	fmt.Fprintln(stdout, "READY")
+	return nil
+}
+
+// newResult creates an empty passing result for the configured target
+func newResult(config *internal.Config) *internal.BenchmarkResult {
+	return &internal.BenchmarkResult{
+		Timestamp:     time.Now().UTC(),
+		Target:        config.URL,
+		Overall:       "pass",
+		SchemaVersion: internal.SchemaVersion,
+		UserAgent:     config.UserAgent,
+		TraceID:       config.TraceID,
+
+		SimulatedLatencyMs: float64(config.SimulateLatency.Microseconds()) / 1000.0,
+		SimulatedLossRate:  config.SimulateLossRate,
+	}
+}
+
+// anonymizedCopy returns an anonymized copy of result, leaving result itself
+// for the reports, which anonymize it on their own
+func anonymizedCopy(result *internal.BenchmarkResult, config *internal.Config) *internal.BenchmarkResult {
+	var copied internal.BenchmarkResult
+	data, err := json.Marshal(result)
+	if err == nil {
+		err = json.Unmarshal(data, &copied)
+	}
+	if err != nil {
+		// Expose nothing rather than the unanonymized result
+		copied = internal.BenchmarkResult{Target: result.Target}
+	}
+	reporter.Anonymize(&copied, config)
+	return &copied
+}
+
+// flushTraces sends the --otel-endpoint spans still queued, giving up after
+// otelFlushTimeout so an unreachable collector cannot hold up the exit
+func flushTraces(shutdown func(context.Context) error, config *internal.Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), otelFlushTimeout)
+	defer cancel()
+	if err := shutdown(ctx); err != nil && !config.Silent {
+		fmt.Fprintf(os.Stderr, "Warning: failed to send traces to %s: %v\n", config.OTelEndpoint, err)
+	}
+}
+
+// userAgent builds the User-Agent header. The tool's own product token,
+// actalog-bench/<version>, is the default and is appended to a custom value
+// unless includeVersion is false.
+func userAgent(custom string, includeVersion bool) string {
+	product := "actalog-bench/" + version
+	switch {
+	case custom == "" && includeVersion:
+		return product
+	case custom == "":
+		return "actalog-bench"
+	case includeVersion:
+		return custom + " " + product
+	default:
+		return custom
+	}
+}
+
+// runSuite runs every enabled benchmark phase once against an already
+// authenticated client. thresholds supply the --abort-on-threshold limits.
+// When ctx is cancelled the phase in progress stops and later ones are skipped.
+func runSuite(ctx context.Context, config *internal.Config, httpClient *client.Client, selector metrics.EndpointSelector, thresholds *reporter.ThresholdConfig) *internal.BenchmarkResult {
+	result := newResult(config)
+	result.PhaseDurations = make(map[string]float64)
+	recordPhase := func(phase string, start time.Time) {
+		result.PhaseDurations[phase] = float64(time.Since(start).Microseconds()) / 1000.0
+	}
+
+	// Trace the run, with a span per phase, when --otel-endpoint is set
+	ctx, runSpan := telemetry.StartSpan(telemetry.WithTarget(ctx, config.URL), telemetry.SpanBenchmark)
+	defer func() { telemetry.EndBenchmark(runSpan, result) }()
+
 	// Phase 1: Connectivity
 	if config.Verbose {
 		fmt.Println("Testing connectivity...")
 	}
-	result.Connectivity = metrics.MeasureConnectivity(ctx, config.URL, config.Timeout)
+	phaseStart := time.Now()
+	phaseCtx, span := telemetry.StartSpan(ctx, telemetry.SpanConnectivity)
+	result.Connectivity = metrics.MeasureConnectivityWithOptions(phaseCtx, config.URL, config.Timeout, metrics.ConnectivityOptions{
+		Family:      config.IPFamily,
+		HTTPOnly:    config.HTTPOnly,
+		DNSResolver: config.DNSResolver,
+		DoHURL:      config.DoHURL,
+		BindAddr:    config.BindAddr,
+		UnixSocket:  config.UnixSocket,
+		HTTP3:       config.ProbeHTTP3,
+	})
 	if !result.Connectivity.Connected {
 		result.Overall = "fail"
 	}
+	if config.ProbeKeepAlive && result.Connectivity.Connected {
+		if config.Verbose {
+			fmt.Println("Probing connection keep-alive...")
+		}
+		reuse, err := metrics.MeasureKeepAlive(ctx, httpClient)
+		if err != nil {
+			if !config.Silent {
+				fmt.Fprintf(os.Stderr, "Warning: keep-alive probe failed: %v\n", err)
+			}
+		} else {
+			result.Connectivity.KeepAliveReuseFraction = &reuse
+		}
+	}
+	if config.Traceroute && result.Connectivity.Connected {
+		if config.Verbose {
+			fmt.Println("Tracing route to server...")
+		}
+		hops, rtts, err := metrics.MeasureRoute(config.URL, config.Timeout)
+		if err != nil && !config.Silent {
+			fmt.Fprintf(os.Stderr, "Warning: traceroute incomplete: %v\n", err)
+		}
+		result.Connectivity.HopCount = hops
+		result.Connectivity.TraceRouteMs = rtts
+	}
+	if config.ProbeMTU && result.Connectivity.Connected {
+		if config.Verbose {
+			fmt.Println("Probing path MTU...")
+		}
+		mtu, err := metrics.MeasurePathMTU(config.URL, config.Timeout)
+		if err != nil {
+			if !config.Silent {
+				fmt.Fprintf(os.Stderr, "Warning: path MTU probe failed: %v\n", err)
+			}
+		} else {
+			result.Connectivity.PathMTU = mtu
+		}
+	}
+	telemetry.EndConnectivity(span, result.Connectivity)
+	recordPhase(internal.PhaseConnectivity, phaseStart)
+
+	if ctx.Err() != nil {
+		return result
+	}
 
 	// Phase 2: Health check
 	if config.Verbose {
 		fmt.Println("Checking health endpoint...")
 	}
-	result.Health = metrics.CheckHealth(ctx, httpClient)
+	phaseStart = time.Now()
+	phaseCtx, span = telemetry.StartSpan(ctx, telemetry.SpanHealth)
+	result.Health = metrics.CheckHealth(phaseCtx, httpClient)
 	if result.Health.Status != "healthy" {
 		result.Overall = "fail"
 	}
+	if config.GRPCHealth {
+		if config.Verbose {
+			fmt.Printf("Checking gRPC health at %s...\n", config.GRPCAddr)
+		}
+		result.GRPCHealth = metrics.CheckGRPCHealth(ctx, config.GRPCAddr, config.Timeout)
+		if result.GRPCHealth.Status != "healthy" {
+			result.Overall = "fail"
+		}
+	}
+	telemetry.EndHealth(span, result.Health)
+	recordPhase(internal.PhaseHealth, phaseStart)
 
 	// Get version info
 	result.Version = getVersion(ctx, httpClient)
 
-	// Phase 3: Endpoint benchmarks
-	if config.Full || httpClient.IsAuthenticated() {
+	if ctx.Err() != nil {
+		return result
+	}
+
+	// Phase 3: Endpoint benchmarks (built-in list plus any endpoints file entries)
+	if config.Full || httpClient.IsAuthenticated() || len(config.LoadEndpoints) > 0 {
 		if config.Verbose {
 			fmt.Println("Benchmarking endpoints...")
 		}
-		endpoints := metrics.GetEndpointsForAuth(httpClient.IsAuthenticated())
-		result.Endpoints = metrics.BenchmarkEndpoints(ctx, httpClient, endpoints)
+		phaseStart = time.Now()
+		if config.Full || httpClient.IsAuthenticated() {
+			endpoints := metrics.GetEndpointsForAuth(httpClient.IsAuthenticated())
+			result.Endpoints = metrics.BenchmarkEndpointsInOrder(ctx, httpClient, endpoints, config.EndpointOrder, config.Seed, config.Baseline)
+			if config.EndpointOrder != metrics.EndpointOrderDefault {
+				result.EndpointOrder = config.EndpointOrder
+			}
+			if config.EndpointOrder == metrics.EndpointOrderRandom {
+				result.EndpointOrderSeed = config.Seed
+			}
+		}
+		result.Endpoints = append(result.Endpoints, metrics.BenchmarkCustomEndpoints(ctx, httpClient, config.LoadEndpoints)...)
+		if config.EndpointSamples > 1 {
+			if config.Verbose {
+				fmt.Printf("Sampling each endpoint %d times...\n", config.EndpointSamples)
+			}
+			metrics.SampleEndpoints(ctx, httpClient, result.Endpoints, config.LoadEndpoints, config.EndpointSamples)
+		}
+		if config.ColdStart {
+			if config.Verbose {
+				fmt.Println("Measuring cold-start response times...")
+			}
+			metrics.MeasureColdStarts(ctx, httpClient, result.Endpoints)
+		}
+		recordPhase(internal.PhaseEndpoints, phaseStart)
 
 		// Check for any failed endpoints
 		for _, ep := range result.Endpoints {
@@ -422,12 +2162,24 @@ func run(c *cli.Context) error {
 		}
 	}
 
+	if ctx.Err() != nil {
+		return result
+	}
+
 	// Phase 3.5: Frontend benchmarks (if --frontend or --full)
 	if config.Frontend || config.Full {
 		if config.Verbose {
 			fmt.Println("Benchmarking frontend assets...")
 		}
-		result.Frontend = metrics.BenchmarkFrontend(ctx, httpClient)
+		phaseStart = time.Now()
+		phaseCtx, span = telemetry.StartSpan(ctx, telemetry.SpanFrontend)
+		result.Frontend = metrics.BenchmarkFrontend(phaseCtx, httpClient)
+		telemetry.EndFrontend(span, result.Frontend)
+		recordPhase(internal.PhaseFrontend, phaseStart)
+	}
+
+	if ctx.Err() != nil {
+		return result
 	}
 
 	// Phase 3.6: Server-side benchmark API (if authenticated and --full)
@@ -435,7 +2187,9 @@ func run(c *cli.Context) error {
 		if config.Verbose {
 			fmt.Printf("Running server-side benchmark API (records=%d)...\n", config.BenchmarkRecords)
 		}
-		result.BenchmarkAPI = metrics.RunBenchmarkAPI(ctx, httpClient, config.Concurrent > 1, config.BenchmarkRecords)
+		phaseStart = time.Now()
+		result.BenchmarkAPI = metrics.RunBenchmarkAPI(ctx, httpClient, config.BenchmarkConcurrent, config.BenchmarkRecords, config.BenchmarkTimeout)
+		recordPhase(internal.PhaseBenchmarkAPI, phaseStart)
 		if result.BenchmarkAPI != nil && result.BenchmarkAPI.Response != nil {
 			// Use server-reported version if available
 			if result.BenchmarkAPI.Response.Version != "" {
@@ -444,15 +2198,103 @@ func run(c *cli.Context) error {
 		}
 	}
 
-	// Phase 4: Load test (if concurrent > 1 or explicitly requested with --full)
-	if config.Concurrent > 1 || (config.Full && config.Concurrent == 1) {
-		if config.Concurrent == 1 {
+	if ctx.Err() != nil {
+		return result
+	}
+
+	// Phase 3.7: Server-side benchmark at each record count (if authenticated and --benchmark-records-sweep)
+	if httpClient.IsAuthenticated() && len(config.BenchmarkRecordsSweep) > 0 {
+		result.BenchmarkAPISweep = metrics.BenchmarkAPISweep(ctx, httpClient, config.BenchmarkConcurrent, config.BenchmarkRecordsSweep, config.BenchmarkTimeout, func(records int) {
+			if config.Verbose {
+				fmt.Printf("Running server-side benchmark API sweep (records=%d)...\n", records)
+			}
+		})
+	}
+
+	if ctx.Err() != nil {
+		return result
+	}
+
+	// Phase 3.8: Server-sent events latency (if --sse-path)
+	if config.SSEPath != "" {
+		if config.Verbose {
+			fmt.Printf("Reading %d server-sent events from %s...\n", config.SSEEvents, config.SSEPath)
+		}
+		result.SSE = metrics.MeasureSSE(ctx, httpClient, config.SSEPath, config.SSEEvents)
+		if result.SSE.Error != "" {
+			result.Overall = "degraded"
+		}
+	}
+
+	if ctx.Err() != nil {
+		return result
+	}
+
+	// Phase 4: Load test (if concurrent > 1, explicitly requested with --full, or --stress-endpoint)
+	if config.Concurrent > 1 || (config.Full && config.Concurrent == 1) || config.StressEndpoint != "" {
+		if config.Concurrent == 1 && config.Full {
 			config.Concurrent = 5 // Default concurrency for --full
 		}
 		if config.Verbose {
-			fmt.Printf("Running load test (%d concurrent, %s)...\n", config.Concurrent, config.Duration)
+			if config.StressEndpoint != "" {
+				fmt.Printf("Stress testing %s (%d concurrent, %s)...\n", config.StressEndpoint, config.Concurrent, config.Duration)
+			} else {
+				fmt.Printf("Running load test (%d concurrent, %s)...\n", config.Concurrent, config.Duration)
+			}
+		}
+		windows := 0
+		if config.LeakDetect {
+			windows = leakDetectWindows
+		}
+		opts := metrics.LoadTestOptions{
+			Concurrent:     config.Concurrent,
+			Duration:       config.Duration,
+			Selector:       selector,
+			Strategy:       config.EndpointStrategy,
+			StressEndpoint: config.StressEndpoint,
+			Windows:        windows,
+			RPSTimeline:    config.HTMLOutput != "", // For the throughput chart of the HTML report
+			OnTick:         config.LoadTestProgress,
+			SLATargets:     config.SLATargets,
+			Percentiles:    config.Percentiles,
+			DNSCacheHits:   config.DNSCacheHits,
+
+			HistogramBuckets: config.HistogramBuckets,
+			MaxRPS:           config.MaxRPS,
+			Warmup:           config.WarmupDuration,
+			TargetRPS:        config.TargetRPS,
+
+			ThinkTime:          config.ThinkTime,
+			ThinkTimeJitterPct: config.ThinkTimeJitterPct,
+		}
+		if config.StepLoad {
+			opts.StepInterval = config.StepInterval
+			opts.StepStart = config.StepStart
+		}
+		if config.Concurrent > config.MaxIdleConnsPerHost && !config.Silent {
+			fmt.Fprintf(os.Stderr, "Warning: --concurrent %d exceeds --max-idle-conns-per-host %d; extra workers will dial new connections, adding latency\n",
+				config.Concurrent, config.MaxIdleConnsPerHost)
+		}
+		if config.AbortOnThreshold {
+			opts.AbortP95Ms = thresholds.LatencyP95MaxMs
+			opts.AbortErrorRatePct = thresholds.ErrorRateMaxPct
+		}
+		phaseStart = time.Now()
+		phaseCtx, span = telemetry.StartSpan(ctx, telemetry.SpanLoadTest)
+		result.LoadTest = metrics.LoadTestWithOptions(phaseCtx, httpClient, opts)
+		telemetry.EndLoadTest(span, result.LoadTest)
+		recordPhase(internal.PhaseLoadTest, phaseStart)
+		// Dashboards end on the final result rather than the last partial one
+		if config.LoadTestProgress != nil {
+			config.LoadTestProgress(result.LoadTest)
+		}
+
+		if result.LoadTest.EarlyAbort {
+			result.Overall = "degraded"
+			if !config.Silent {
+				fmt.Fprintf(os.Stderr, "Warning: load test aborted early: %s\n", result.LoadTest.AbortReason)
+			}
 		}
-		result.LoadTest = metrics.LoadTest(ctx, httpClient, config.Concurrent, config.Duration)
 
 		// Check error rate
 		if result.LoadTest.Failed > 0 {
@@ -463,25 +2305,284 @@ func run(c *cli.Context) error {
 		}
 	}
 
-	// Output results
-	outputResults(result, config)
+	if ctx.Err() != nil {
+		return result
+	}
+
+	// Phase 4.5: WebSocket load test (if --ws-load-test)
+	if config.WSLoadTest {
+		if config.Verbose {
+			fmt.Printf("Running WebSocket load test on %s (%d concurrent, %s)...\n", config.WSPath, config.Concurrent, config.Duration)
+		}
+		result.WebSocketLoadTest = metrics.WebSocketLoadTest(ctx, httpClient, config.WSPath, config.Concurrent, config.Duration)
+		if result.WebSocketLoadTest.Error != "" || result.WebSocketLoadTest.Failed > 0 {
+			result.Overall = "degraded"
+		}
+	}
+
+	if ctx.Err() != nil {
+		return result
+	}
+
+	// Phase 5: Concurrency profile (if --concurrency-profile)
+	if config.ConcurrencyProfile {
+		result.ConcurrencyProfile = metrics.ConcurrencyProfile(ctx, httpClient, config.ConcurrencySteps, config.StepDuration, metrics.LoadTestOptions{
+			Selector: selector,
+			Strategy: config.EndpointStrategy,
+		}, func(concurrent int) {
+			if config.Verbose {
+				fmt.Printf("Profiling concurrency %d (%s)...\n", concurrent, config.StepDuration)
+			}
+		})
+		if best, ok := metrics.OptimalConcurrency(result.ConcurrencyProfile); ok {
+			result.RecommendedConcurrency = best.Concurrent
+		}
+	}
+
+	return result
+}
+
+// runURLList runs the suite against each URL in turn, reporting each result as
+// a single run would, then writes a comparison report with one column per URL.
+// Results are stamped one second apart from a shared start time so they sort,
+// and their report files are named, in list order.
+func runURLList(ctx context.Context, c *cli.Context, config *internal.Config, urls []string, clientOpts []client.Option, selector metrics.EndpointSelector, thresholds *reporter.ThresholdConfig) error {
+	start := time.Now().UTC()
+	results := make([]*internal.BenchmarkResult, 0, len(urls))
+	for i, u := range urls {
+		urlConfig := *config
+		urlConfig.URL = u
+		// The URLs are compared with each other below instead
+		urlConfig.AutoCompare = false
+		if !config.Silent {
+			fmt.Printf("Benchmarking %s (%d of %d)...\n", u, i+1, len(urls))
+		}
+
+		httpClient := client.New(u, config.Timeout, clientOpts...)
+		var result *internal.BenchmarkResult
+		if config.User != "" && config.Pass != "" {
+			if err := httpClient.Login(ctx, config.User, config.Pass); err != nil {
+				result = newResult(&urlConfig)
+				result.Error = fmt.Sprintf("authentication failed: %v", err)
+				result.Overall = "fail"
+			}
+		}
+		if result == nil {
+			result = runSuite(ctx, &urlConfig, httpClient, selector, thresholds)
+		}
+		result.MultiURLMode = true
+		result.Timestamp = start.Add(time.Duration(i) * time.Second)
+		result.ThresholdBreaches = thresholds.Breaches(result, reporter.RunLabel(i, result))
+		interrupted := markShutdown(ctx, result)
+		runComplete(&urlConfig, result)
+		outputResults(result, &urlConfig, thresholds)
+		results = append(results, result)
+		if interrupted {
+			// The remaining URLs are skipped and no comparison is written
+			pruneOutputFiles(config)
+			return interruptedExit(config)
+		}
+	}
+	pruneOutputFiles(config)
+
+	outputDir := "."
+	if config.MarkdownOutput != "" {
+		outputDir = config.MarkdownOutput
+	}
+	comp := reporter.NewComparison(outputDir)
+	comp.SetOutputPrefix(config.OutputPrefix)
+	comp.SetThresholds(thresholds)
+	comp.SetThresholdFile(c.String("threshold-file"))
+	comp.SetRegressionsOnly(c.Bool("compare-regressions-only"))
+	reportPath, err := comp.ReportResults(results)
+	if err != nil {
+		return fmt.Errorf("generate comparison: %w", err)
+	}
+	if !config.Silent {
+		fmt.Printf("URL comparison report written to: %s\n", reportPath)
+	}
 
+	// Fail when any URL did
+	for _, result := range results {
+		if err := exitStatus(result, config); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func outputResults(result *internal.BenchmarkResult, config *internal.Config) {
-	// Console output
-	consoleReporter := reporter.NewConsole(config.Verbose)
-	consoleReporter.Report(result)
+// runRepeated runs the suite config.Repeat times, writing each run's JSON result
+// as benchmark_<timestamp>_run<N>.json, then reports the averaged result with
+// its JSON written as benchmark_<timestamp>_agg.json
+func runRepeated(ctx context.Context, config *internal.Config, httpClient *client.Client, selector metrics.EndpointSelector, thresholds *reporter.ThresholdConfig, waited time.Duration) error {
+	var results []*internal.BenchmarkResult
+	for i := 1; i <= config.Repeat; i++ {
+		if config.Verbose {
+			fmt.Printf("Starting run %d of %d...\n", i, config.Repeat)
+		}
+		result := runSuite(ctx, config, httpClient, selector, thresholds)
+		if i == 1 {
+			result.WaitedForHealthySec = waited.Seconds()
+		}
+		result.ThresholdBreaches = thresholds.Breaches(result, reporter.RunLabel(i-1, result))
+		interrupted := markShutdown(ctx, result)
+		runComplete(config, result)
+		// Per-run results skip outputResults, so they are anonymized here; the
+		// pseudonyms match across runs, so the average is anonymized too
+		if config.Anonymize {
+			reporter.Anonymize(result, config)
+		}
+		results = append(results, result)
+		if !config.Silent {
+			fmt.Printf("Run %d/%d: %s\n", i, config.Repeat, result.Overall)
+		}
+
+		if config.JSONOutput != "" {
+			jsonReporter := reporter.NewJSON(config.JSONOutput)
+			jsonReporter.SetFilename(results[0].Timestamp, fmt.Sprintf("_run%d", i))
+			writeJSON(result, config, jsonReporter)
+		}
+		if interrupted {
+			break
+		}
+	}
+
+	avg := reporter.AverageResults(results)
+	// Judge the averages themselves rather than keeping the earliest run's breaches
+	avg.ThresholdBreaches = thresholds.Breaches(avg, fmt.Sprintf("Average of %d runs", len(results)))
+	interrupted := markShutdown(ctx, avg)
 
-	// JSON output (if requested)
+	// The averaged JSON is written separately to give it the _agg suffix
+	avgConfig := *config
+	avgConfig.JSONOutput = ""
+	outputResults(avg, &avgConfig, thresholds)
 	if config.JSONOutput != "" {
 		jsonReporter := reporter.NewJSON(config.JSONOutput)
-		filepath, err := jsonReporter.Report(result)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to write JSON output: %v\n", err)
+		jsonReporter.SetFilename(avg.Timestamp, "_agg")
+		writeJSON(avg, config, jsonReporter)
+	}
+	pruneOutputFiles(config)
+
+	if interrupted {
+		return interruptedExit(config)
+	}
+	return exitStatus(avg, config)
+}
+
+// applyConfigFile sets every flag from the config file that was not given
+// on the command line
+func applyConfigFile(c *cli.Context, path string) error {
+	cfg, err := internal.LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	for name, value := range cfg.FlagValues() {
+		if c.IsSet(name) {
+			continue
+		}
+		if err := c.Set(name, value); err != nil {
+			return fmt.Errorf("config %s: invalid %s value %q: %w", path, strings.ReplaceAll(name, "-", "_"), value, err)
+		}
+	}
+	return nil
+}
+
+// applyFormats enables the reporters named in a comma-separated --format value,
+// writing each to outputDir. Explicit --json, --markdown, --csv, --html, or
+// --junit paths take precedence.
+func applyFormats(config *internal.Config, format, outputDir string) error {
+	if format == "" {
+		return nil
+	}
+	if outputDir == "" {
+		outputDir = "."
+	}
+
+	for _, name := range strings.Split(format, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "json":
+			if config.JSONOutput == "" {
+				config.JSONOutput = outputDir
+			}
+		case "markdown", "md":
+			if config.MarkdownOutput == "" {
+				config.MarkdownOutput = outputDir
+			}
+		case "csv":
+			if config.CSVOutput == "" {
+				config.CSVOutput = outputDir
+			}
+		case "html":
+			if config.HTMLOutput == "" {
+				config.HTMLOutput = outputDir
+			}
+		case "junit":
+			if config.JUnitOutput == "" {
+				config.JUnitOutput = outputDir
+			}
+		case "":
+			// Tolerate trailing or doubled commas
+		default:
+			return fmt.Errorf("unsupported --format value %q (supported: json, markdown, csv, html, junit)", strings.TrimSpace(name))
+		}
+	}
+	return nil
+}
+
+// exitStatus returns a non-zero exit for non-passing runs in silent mode,
+// where the exit status is the only signal the caller gets
+func exitStatus(result *internal.BenchmarkResult, config *internal.Config) error {
+	if expected := config.AssertOverall; expected != "" {
+		if expected == "any" || result.Overall == expected {
+			return nil
+		}
+		if config.Silent {
+			return cli.Exit("", 2)
+		}
+		return cli.Exit(fmt.Sprintf("overall status %q does not match --assert-overall %s", result.Overall, expected), 2)
+	}
+	if config.Silent && result.Overall != "pass" {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+// validateAssertOverall rejects an --assert-overall other than pass, degraded,
+// fail, or any; empty leaves the assertion off
+func validateAssertOverall(expected string) error {
+	switch expected {
+	case "", "pass", "degraded", "fail", "any":
+		return nil
+	default:
+		return fmt.Errorf("--assert-overall must be pass, degraded, fail, or any, got %q", expected)
+	}
+}
+
+// outputResults writes result to the console and every requested report and
+// export. thresholds are used by the --auto-compare report.
+func outputResults(result *internal.BenchmarkResult, config *internal.Config, thresholds *reporter.ThresholdConfig) {
+	// Anonymize before any report is written; the path pseudonyms are not kept
+	if config.Anonymize {
+		config = reporter.Anonymize(result, config)
+	}
+
+	// Console output, or go test -bench text in its place
+	if !config.Silent {
+		if config.GoBench {
+			if err := reporter.NewGoBench().WriteText(os.Stdout, result); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to write Go benchmark output: %v\n", err)
+			}
 		} else {
-			fmt.Printf("JSON report written to: %s\n", filepath)
+			consoleReporter := reporter.NewConsole(config.Verbose)
+			consoleReporter.Report(result)
+		}
+	}
+
+	// JSON output (if requested), then a comparison with the earlier results
+	if config.JSONOutput != "" {
+		writeJSON(result, config, reporter.NewJSON(config.JSONOutput))
+		if config.AutoCompare {
+			autoCompare(config, thresholds)
 		}
 	}
 
@@ -489,59 +2590,411 @@ func outputResults(result *internal.BenchmarkResult, config *internal.Config) {
 	if config.MarkdownOutput != "" {
 		mdReporter := reporter.NewMarkdown(config.MarkdownOutput, config)
 		filepath, err := mdReporter.Report(result)
+		if !config.Silent {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to write Markdown output: %v\n", err)
+			} else {
+				fmt.Printf("Markdown report written to: %s\n", filepath)
+			}
+		}
+	}
+
+	// CSV output (if requested)
+	if config.CSVOutput != "" {
+		csvReporter := reporter.NewCSV(config.CSVOutput)
+		csvReporter.SetPrefix(config.OutputPrefix)
+		filepath, err := csvReporter.Report(result)
+		if !config.Silent {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to write CSV output: %v\n", err)
+			} else {
+				fmt.Printf("CSV report written to: %s\n", filepath)
+			}
+		}
+	}
+
+	// HTML output (if requested)
+	if config.HTMLOutput != "" {
+		htmlReporter := reporter.NewHTML(config.HTMLOutput, config)
+		filepath, err := htmlReporter.Report(result)
+		if !config.Silent {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to write HTML output: %v\n", err)
+			} else {
+				fmt.Printf("HTML report written to: %s\n", filepath)
+			}
+		}
+	}
+
+	// JUnit XML output (if requested)
+	if config.JUnitOutput != "" {
+		junitReporter := reporter.NewJUnit(config.JUnitOutput)
+		junitReporter.SetPrefix(config.OutputPrefix)
+		filepath, err := junitReporter.Report(result)
+		if !config.Silent {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to write JUnit output: %v\n", err)
+			} else {
+				fmt.Printf("JUnit report written to: %s\n", filepath)
+			}
+		}
+	}
+
+	// Pushgateway export (if requested)
+	if config.PushgatewayURL != "" {
+		err := exporter.PushPrometheus(config.PushgatewayURL, config.PushgatewayJob, result)
+		if !config.Silent {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to push metrics to Pushgateway: %v\n", err)
+			} else {
+				fmt.Printf("Metrics pushed to Pushgateway: %s\n", config.PushgatewayURL)
+			}
+		}
+	}
+
+	// Elasticsearch export (if requested)
+	if config.ElasticsearchURL != "" {
+		err := exporter.IndexElasticsearch(config.ElasticsearchURL, config.ElasticsearchIndex, result,
+			exporter.WithBasicAuth(config.ElasticsearchUsername, config.ElasticsearchPassword))
+		if !config.Silent {
+			switch {
+			case errors.Is(err, exporter.ErrElasticsearchUnauthorized):
+				fmt.Fprintf(os.Stderr, "Warning: failed to index result in Elasticsearch: %v (check --elasticsearch-username and --elasticsearch-password)\n", err)
+			case errors.Is(err, exporter.ErrElasticsearchConflict):
+				fmt.Printf("Result already indexed in Elasticsearch as %s\n", exporter.ElasticsearchDocumentID(result))
+			case err != nil:
+				fmt.Fprintf(os.Stderr, "Warning: failed to index result in Elasticsearch: %v\n", err)
+			default:
+				fmt.Printf("Result indexed in Elasticsearch: %s/%s\n", config.ElasticsearchURL, config.ElasticsearchIndex)
+			}
+		}
+	}
+
+	// CI status line last, so scripts can take the final line of output
+	if config.CI {
+		fmt.Println(reporter.CILine(result))
+	}
+}
+
+// validateAutoCompare checks that --auto-compare has a JSON output directory
+// to compare and a limit of at least two reports
+func validateAutoCompare(config *internal.Config) error {
+	if !config.AutoCompare {
+		return nil
+	}
+	if config.JSONOutput == "" || strings.HasSuffix(strings.ToLower(config.JSONOutput), ".json") {
+		return fmt.Errorf("--auto-compare requires --json with a directory")
+	}
+	if config.AutoCompareLimit < 2 {
+		return fmt.Errorf("--auto-compare-limit must be at least 2, got %d", config.AutoCompareLimit)
+	}
+	return nil
+}
+
+// autoCompare writes a comparison report of the newest AutoCompareLimit
+// <prefix>_*.json reports in the JSON output directory, once it holds two or
+// more. The report goes to the Markdown directory if set, as with --compare.
+func autoCompare(config *internal.Config, thresholds *reporter.ThresholdConfig) {
+	outputDir := config.JSONOutput
+	if config.MarkdownOutput != "" {
+		outputDir = config.MarkdownOutput
+	}
+	comp := reporter.NewComparison(outputDir)
+	comp.SetOutputPrefix(config.OutputPrefix)
+	comp.SetThresholds(thresholds)
+
+	jsonFiles, err := comp.ScanDirectory(config.JSONOutput)
+	if err != nil || len(jsonFiles) < 2 {
+		if config.Verbose {
+			fmt.Printf("Skipping auto-comparison: fewer than 2 benchmark files in %s\n", config.JSONOutput)
+		}
+		return
+	}
+	// ScanDirectory sorts by filename, which starts with the run's timestamp
+	if len(jsonFiles) > config.AutoCompareLimit {
+		jsonFiles = jsonFiles[len(jsonFiles)-config.AutoCompareLimit:]
+	}
+
+	reportPath, err := comp.Report(jsonFiles)
+	if config.Silent {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write auto-comparison report: %v\n", err)
+		return
+	}
+	fmt.Printf("Auto-comparison report written to: %s\n", reportPath)
+}
+
+// validateOutputPrefix rejects an --output-prefix that is empty or would
+// leave the output directory or act as a glob pattern
+func validateOutputPrefix(prefix string) error {
+	if prefix == "" || strings.ContainsAny(prefix, `/\*?[`) {
+		return fmt.Errorf("--output-prefix must be a non-empty file name without path separators or *?[, got %q", prefix)
+	}
+	return nil
+}
+
+// pruneOutputFiles applies --max-output-files. When JSON reports go to a
+// directory, the oldest <prefix>_*.json files beyond the limit are deleted along
+// with the Markdown reports of the same runs; otherwise the Markdown directory
+// is pruned on its own.
+func pruneOutputFiles(config *internal.Config) {
+	if config.MaxOutputFiles <= 0 {
+		return
+	}
+
+	var deleted []string
+	var err error
+	// An explicit .json file path is a single report that is overwritten, not accumulated
+	if config.JSONOutput != "" && !strings.HasSuffix(strings.ToLower(config.JSONOutput), ".json") {
+		deleted, err = cleanup.PruneOldFiles(config.JSONOutput, config.OutputPrefix+"_*.json", config.MaxOutputFiles)
+		if err == nil && config.MarkdownOutput != "" {
+			var companions []string
+			companions, err = cleanup.RemoveCompanions(deleted, config.MarkdownOutput, ".md")
+			deleted = append(deleted, companions...)
+		}
+	} else if config.MarkdownOutput != "" {
+		deleted, err = cleanup.PruneOldFiles(config.MarkdownOutput, config.OutputPrefix+"_*.md", config.MaxOutputFiles)
+	}
+
+	if config.Silent {
+		return
+	}
+	for _, path := range deleted {
+		fmt.Printf("Removed old report: %s\n", path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove old reports: %v\n", err)
+	}
+}
+
+// writeJSON writes result with jsonReporter, reporting the outcome unless silent
+func writeJSON(result *internal.BenchmarkResult, config *internal.Config, jsonReporter *reporter.JSON) {
+	jsonReporter.SetPrefix(config.OutputPrefix)
+	jsonReporter.SetAppend(config.JSONAppend)
+	filepath, err := jsonReporter.Report(result)
+	if !config.Silent {
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to write Markdown output: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Warning: failed to write JSON output: %v\n", err)
 		} else {
-			fmt.Printf("Markdown report written to: %s\n", filepath)
+			fmt.Printf("JSON report written to: %s\n", filepath)
 		}
 	}
 }
 
-func runCompare(c *cli.Context, inputDir string) error {
-	// Determine output directory (same as input by default)
+// runCompare writes a comparison report for the results in inputDir, or for
+// the remote results listed at manifestURL when it is set
+func runCompare(c *cli.Context, inputDir, manifestURL string) error {
+	// Determine output directory (same as input by default, current directory for remote results)
 	outputDir := inputDir
+	if manifestURL != "" {
+		outputDir = "."
+	}
 	if mdOut := c.String("markdown"); mdOut != "" {
 		outputDir = mdOut
 	}
 
+	if err := validateOutputPrefix(c.String("output-prefix")); err != nil {
+		return err
+	}
 	comp := reporter.NewComparison(outputDir)
+	comp.SetOutputPrefix(c.String("output-prefix"))
 
 	// Set custom thresholds
-	comp.SetThresholds(&reporter.ThresholdConfig{
-		LatencyP95MaxMs:   c.Float64("threshold-p95"),
-		LatencyP99MaxMs:   c.Float64("threshold-p99"),
-		ErrorRateMaxPct:   c.Float64("threshold-error-rate"),
-		RPSMinimum:        c.Float64("threshold-rps-min"),
-		HealthResponseMax: 100, // Fixed default for now
-	})
-
-	// Scan directory for benchmark JSON files
-	jsonFiles, err := comp.ScanDirectory(inputDir)
+	thresholds, err := alertThresholds(c)
 	if err != nil {
-		return fmt.Errorf("scan directory: %w", err)
+		return err
+	}
+	comp.SetThresholds(thresholds)
+	comp.SetThresholdFile(c.String("threshold-file"))
+	comp.SetRegressionsOnly(c.Bool("compare-regressions-only"))
+	comp.SetAggregate(c.Bool("aggregate"))
+	comp.SetSchemaVersionCheck(c.Bool("schema-version-check"))
+	since := c.Duration("compare-since")
+	if since < 0 {
+		return fmt.Errorf("--compare-since must not be negative, got %s", since)
+	}
+	comp.SetSince(since)
+
+	// Download the remote results, or scan the directory for benchmark JSON files
+	var jsonFiles []string
+	source := inputDir
+	if manifestURL != "" {
+		comp.SetRemoteToken(c.String("compare-url-token"))
+		defer comp.Cleanup()
+		jsonFiles, err = comp.FetchRemote(manifestURL)
+		if err != nil {
+			return fmt.Errorf("fetch remote results: %w", err)
+		}
+		source = manifestURL
+	} else {
+		jsonFiles, err = comp.ScanDirectory(inputDir)
+		if err != nil {
+			return fmt.Errorf("scan directory: %w", err)
+		}
 	}
 
-	if c.Bool("verbose") {
-		fmt.Printf("Found %d benchmark files in %s:\n", len(jsonFiles), inputDir)
+	silent := c.Bool("silent")
+	if since > 0 && len(jsonFiles) < 2 && !silent {
+		fmt.Fprintf(os.Stderr, "Warning: only %d benchmark file from the last %s in %s; widen --compare-since to compare more runs\n",
+			len(jsonFiles), since, source)
+	}
+	if c.Bool("verbose") && !silent {
+		fmt.Printf("Found %d benchmark files in %s:\n", len(jsonFiles), source)
 		for _, f := range jsonFiles {
 			fmt.Printf("  - %s\n", filepath.Base(f))
 		}
 	}
 
-	if len(jsonFiles) < 2 {
-		return fmt.Errorf("comparison requires at least 2 benchmark files, found %d", len(jsonFiles))
-	}
-
 	// Generate comparison report
 	reportPath, err := comp.Report(jsonFiles)
 	if err != nil {
 		return fmt.Errorf("generate comparison: %w", err)
 	}
 
-	fmt.Printf("Comparison report written to: %s\n", reportPath)
+	if !silent {
+		for _, warning := range comp.SchemaWarnings() {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+		fmt.Printf("Comparison report written to: %s\n", reportPath)
+	}
+	return nil
+}
+
+// alertThresholds builds the alert thresholds from --threshold-file, if given,
+// with any explicitly set --threshold-* flags taking precedence
+func alertThresholds(c *cli.Context) (*reporter.ThresholdConfig, error) {
+	if c.String("threshold-file") == "" {
+		return &reporter.ThresholdConfig{
+			LatencyP95MaxMs:    c.Float64("threshold-p95"),
+			LatencyP99MaxMs:    c.Float64("threshold-p99"),
+			ErrorRateMaxPct:    c.Float64("threshold-error-rate"),
+			RPSMinimum:         c.Float64("threshold-rps-min"),
+			HealthResponseMax:  100, // Fixed default for now
+			DBOperationMaxMs:   c.Float64("threshold-db-max"),
+			SerializationMaxMs: c.Float64("threshold-serial-max"),
+		}, nil
+	}
+
+	thresholds, err := reporter.LoadThresholdConfig(c.String("threshold-file"))
+	if err != nil {
+		return nil, err
+	}
+	for name, field := range map[string]*float64{
+		"threshold-p95":        &thresholds.LatencyP95MaxMs,
+		"threshold-p99":        &thresholds.LatencyP99MaxMs,
+		"threshold-error-rate": &thresholds.ErrorRateMaxPct,
+		"threshold-rps-min":    &thresholds.RPSMinimum,
+		"threshold-db-max":     &thresholds.DBOperationMaxMs,
+		"threshold-serial-max": &thresholds.SerializationMaxMs,
+	} {
+		if c.IsSet(name) {
+			*field = c.Float64(name)
+		}
+	}
+	if err := thresholds.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid thresholds: %w", err)
+	}
+	return thresholds, nil
+}
+
+// runDiff prints the metrics that changed between the two JSON results in
+// diff, given as "before,after". For a file written with --json-append, its
+// newest result is used.
+func runDiff(c *cli.Context, diff string) error {
+	paths := strings.Split(diff, ",")
+	for i := range paths {
+		paths[i] = strings.TrimSpace(paths[i])
+	}
+	if len(paths) != 2 || paths[0] == "" || paths[1] == "" {
+		return fmt.Errorf("--diff requires exactly 2 comma-separated JSON files, got %q", diff)
+	}
+
+	var results [2]*internal.BenchmarkResult
+	for i, path := range paths {
+		loaded, err := reporter.NewComparison("").LoadResults([]string{path})
+		if err != nil {
+			return fmt.Errorf("load results: %w", err)
+		}
+		if len(loaded) == 0 {
+			return fmt.Errorf("load results: %s holds no results", path)
+		}
+		results[i] = loaded[len(loaded)-1]
+	}
+
+	if !c.Bool("silent") {
+		reporter.WriteDiff(os.Stdout, filepath.Base(paths[0]), filepath.Base(paths[1]), reporter.Diff(results[0], results[1]))
+	}
 	return nil
 }
 
+func runMerge(c *cli.Context, merge string) error {
+	var paths []string
+	for _, path := range strings.Split(merge, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) < 2 {
+		return fmt.Errorf("merge requires at least 2 JSON files, got %d", len(paths))
+	}
+
+	results, err := reporter.NewComparison("").LoadResults(paths)
+	if err != nil {
+		return fmt.Errorf("load results: %w", err)
+	}
+	merged := reporter.MergeResults(results)
+	// Stamp with the merge time so report files never overwrite an agent's file
+	merged.Timestamp = time.Now()
+
+	config := &internal.Config{
+		URL:            merged.Target,
+		JSONOutput:     c.String("json"),
+		JSONAppend:     c.Bool("json-append"),
+		MarkdownOutput: c.String("markdown"),
+		OutputPrefix:   c.String("output-prefix"),
+		Verbose:        c.Bool("verbose") && !c.Bool("silent"),
+		Silent:         c.Bool("silent"),
+		AssertOverall:  c.String("assert-overall"),
+		Anonymize:      c.Bool("anonymize"),
+		CommandLine:    fmt.Sprintf("actalog-bench --merge %s", strings.Join(paths, ",")),
+		Timeout:        c.Duration("timeout"),
+		GoBench:        c.Bool("go-bench"),
+		CI:             c.Bool("ci"),
+
+		AutoCompare:      c.Bool("auto-compare"),
+		AutoCompareLimit: c.Int("auto-compare-limit"),
+	}
+	if merged.LoadTest != nil {
+		config.Concurrent = merged.LoadTest.Concurrent
+		config.Duration = time.Duration(merged.LoadTest.DurationSec * float64(time.Second))
+	}
+	if err := validateOutputPrefix(config.OutputPrefix); err != nil {
+		return err
+	}
+	if err := applyFormats(config, c.String("format"), c.String("output-dir")); err != nil {
+		return err
+	}
+	if err := validateAutoCompare(config); err != nil {
+		return err
+	}
+	thresholds, err := alertThresholds(c)
+	if err != nil {
+		return err
+	}
+
+	if config.Verbose {
+		fmt.Printf("Merging %d agent results:\n", len(paths))
+		for _, path := range paths {
+			fmt.Printf("  - %s\n", filepath.Base(path))
+		}
+	}
+
+	outputResults(merged, config, thresholds)
+	return exitStatus(merged, config)
+}
+
 func getVersion(ctx context.Context, c *client.Client) string {
 	resp, err := c.Get(ctx, "/api/version")
 	if err != nil {
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/reporter"
+	"github.com/urfave/cli/v2"
+)
+
+func TestResolvePassword(t *testing.T) {
+	dir := t.TempDir()
+	passFile := filepath.Join(dir, "pass")
+	if err := os.WriteFile(passFile, []byte("file secret\r\nsecond line\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		flag    string
+		file    string
+		cmd     string
+		want    string
+		wantErr string
+	}{
+		{"none", "", "", "", "", ""},
+		{"flag", "flag secret", "", "", "flag secret", ""},
+		{"file first line", "", passFile, "", "file secret", ""},
+		{"command output", "", "", "echo secretpass", "secretpass", ""},
+		{"more than one", "flag secret", passFile, "", "", "only one of"},
+		{"missing file", "", filepath.Join(dir, "missing"), "", "", "read --pass-file"},
+		{"empty file", "", emptyFile, "", "", "is empty"},
+		{"failing command", "", "", "exit 3", "", "run --pass-cmd"},
+		{"silent command", "", "", "exit 0", "", "printed no password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePassword(tt.flag, tt.file, tt.cmd)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResolvePassword_CommandLineMasked(t *testing.T) {
+	_, err := resolvePassword("", "", "echo hunter2; exit 1")
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("expected an error that does not echo the command, got %v", err)
+	}
+}
+
+func TestReadURLList(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "urls.txt")
+	content := "# Blue/green pair\nhttps://blue.example.com\n\n  https://green.example.com  \n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	urls, err := readURLList(path)
+	if err != nil || len(urls) != 2 || urls[0] != "https://blue.example.com" || urls[1] != "https://green.example.com" {
+		t.Errorf("expected both URLs in order, got %v, %v", urls, err)
+	}
+
+	for name, content := range map[string]string{
+		"single URL":   "https://blue.example.com\n",
+		"not a URL":    "https://blue.example.com\nblue.example.com\n",
+		"wrong scheme": "https://blue.example.com\nftp://green.example.com\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readURLList(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestValidateOutputPrefix(t *testing.T) {
+	for _, prefix := range []string{"benchmark", "staging", "prod-eu.1"} {
+		if err := validateOutputPrefix(prefix); err != nil {
+			t.Errorf("expected %q to be valid, got %v", prefix, err)
+		}
+	}
+	for _, prefix := range []string{"", "../reports", `dir\name`, "prod*", "a?b", "[x]"} {
+		if err := validateOutputPrefix(prefix); err == nil {
+			t.Errorf("expected %q to be rejected", prefix)
+		}
+	}
+}
+
+func TestReadToken(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	if err := os.WriteFile(path, []byte("  header.payload.signature\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := readToken(path)
+	if err != nil || token != "header.payload.signature" {
+		t.Errorf("expected the trimmed token, got %q, %v", token, err)
+	}
+
+	if err := os.WriteFile(path, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readToken(path); err == nil {
+		t.Error("expected an error for an empty token file")
+	}
+}
+
+// commandLine parses args with the real flag set and returns buildCommandLine
+// on a single line
+func commandLine(t *testing.T, args ...string) string {
+	t.Helper()
+	var got string
+	app := newApp()
+	app.Action = func(c *cli.Context) error {
+		got = buildCommandLine(c)
+		return nil
+	}
+	if err := app.Run(append([]string{"actalog-bench"}, args...)); err != nil {
+		t.Fatalf("parse %v: %v", args, err)
+	}
+	return strings.ReplaceAll(got, " \\\n  ", " ")
+}
+
+func TestBuildCommandLine(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"only url", []string{"--url", "https://example.com"},
+			"actalog-bench --url https://example.com"},
+		{"url and auth", []string{"--url", "https://example.com", "--user", "admin", "--pass", "s3cret"},
+			"actalog-bench --url https://example.com --user admin --pass <PASSWORD>"},
+		{"password command", []string{"--url", "https://example.com", "--pass-cmd", "echo s3cret"},
+			"actalog-bench --url https://example.com --pass-cmd <COMMAND>"},
+		{"full", []string{"--url", "https://example.com", "--full", "--concurrent", "20", "--duration", "30s"},
+			"actalog-bench --url https://example.com --full --concurrent 20 --duration 30s"},
+		{"threshold p95", []string{"--url", "https://example.com", "--threshold-p95", "250"},
+			"actalog-bench --url https://example.com --threshold-p95 250"},
+		{"threshold p99", []string{"--url", "https://example.com", "--threshold-p99", "750.5"},
+			"actalog-bench --url https://example.com --threshold-p99 750.5"},
+		{"threshold error rate", []string{"--url", "https://example.com", "--threshold-error-rate", "0.5"},
+			"actalog-bench --url https://example.com --threshold-error-rate 0.5"},
+		{"threshold rps min", []string{"--url", "https://example.com", "--threshold-rps-min", "100"},
+			"actalog-bench --url https://example.com --threshold-rps-min 100"},
+		{"threshold db max", []string{"--url", "https://example.com", "--threshold-db-max", "0"},
+			"actalog-bench --url https://example.com --threshold-db-max 0"},
+		{"threshold serial max", []string{"--url", "https://example.com", "--threshold-serial-max", "2"},
+			"actalog-bench --url https://example.com --threshold-serial-max 2"},
+		{"threshold file", []string{"--url", "https://example.com", "--threshold-file", "thresholds.yaml"},
+			"actalog-bench --url https://example.com --threshold-file thresholds.yaml"},
+		{"threshold at its default", []string{"--url", "https://example.com", "--threshold-p95", "500"},
+			"actalog-bench --url https://example.com"},
+		{"compare omits benchmark flags",
+			[]string{"--compare", "results", "--url", "https://example.com", "--full", "--aggregate", "--threshold-p95", "250"},
+			"actalog-bench --compare results --aggregate --threshold-p95 250"},
+		{"compare url masks token", []string{"--compare-url", "https://ci.example.com/results.txt", "--compare-url-token", "t0ken"},
+			"actalog-bench --compare-url https://ci.example.com/results.txt --compare-url-token <TOKEN>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commandLine(t, tt.args...); got != tt.want {
+				t.Errorf("expected\n  %s\ngot\n  %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestBuildCommandLine_AllFlags sets every benchmark flag away from its
+// default and checks that each one is reproduced, so new flags are not
+// silently left out of reports
+func TestBuildCommandLine_AllFlags(t *testing.T) {
+	// Flags that select another mode or do not change the benchmark
+	skip := map[string]bool{
+		"config": true, "compare": true, "compare-url": true, "compare-url-token": true,
+		"compare-since": true, "compare-regressions-only": true, "aggregate": true,
+		"schema-version-check": true, "diff": true, "merge": true, "help": true, "version": true,
+	}
+	secrets := map[string]string{
+		"pass":                   "<PASSWORD>",
+		"pass-cmd":               "<COMMAND>",
+		"elasticsearch-password": "<PASSWORD>",
+	}
+
+	var args, names []string
+	for _, f := range newApp().Flags {
+		name := f.Names()[0]
+		if skip[name] {
+			continue
+		}
+		switch f := f.(type) {
+		case *cli.BoolFlag:
+			args = append(args, fmt.Sprintf("--%s=%t", name, !f.Value))
+		case *cli.StringFlag:
+			args = append(args, "--"+name, name+"-value")
+		case *cli.IntFlag:
+			args = append(args, "--"+name, fmt.Sprint(f.Value+2))
+		case *cli.Int64Flag:
+			args = append(args, "--"+name, fmt.Sprint(f.Value+2))
+		case *cli.Float64Flag:
+			args = append(args, "--"+name, fmt.Sprint(f.Value+2))
+		case *cli.DurationFlag:
+			args = append(args, "--"+name, (f.Value + time.Second).String())
+		default:
+			t.Fatalf("unhandled flag type %T for --%s", f, name)
+		}
+		names = append(names, name)
+	}
+
+	got := commandLine(t, args...)
+	fields := strings.Fields(got)
+	for _, name := range names {
+		found := slices.ContainsFunc(fields, func(field string) bool {
+			return field == "--"+name || strings.HasPrefix(field, "--"+name+"=")
+		})
+		if !found {
+			t.Errorf("expected --%s in %s", name, got)
+		}
+	}
+	for name, placeholder := range secrets {
+		if !strings.Contains(got, "--"+name+" "+placeholder) || strings.Contains(got, name+"-value") {
+			t.Errorf("expected --%s to be masked as %s in %s", name, placeholder, got)
+		}
+	}
+}
+
+func TestExitStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		overall string
+		assert  string
+		silent  bool
+		want    int // Exit code, 0 for a nil error
+	}{
+		{"pass", "pass", "", false, 0},
+		{"degraded without silent", "degraded", "", false, 0},
+		{"degraded with silent", "degraded", "", true, 1},
+		{"expected degraded", "degraded", "degraded", true, 0},
+		{"expected fail", "fail", "fail", false, 0},
+		{"unexpected pass", "pass", "degraded", false, 2},
+		{"unexpected fail with silent", "fail", "pass", true, 2},
+		{"any", "fail", "any", true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &internal.BenchmarkResult{Overall: tt.overall}
+			err := exitStatus(result, &internal.Config{AssertOverall: tt.assert, Silent: tt.silent})
+
+			got := 0
+			if err != nil {
+				var exitErr cli.ExitCoder
+				if !errors.As(err, &exitErr) {
+					t.Fatalf("expected an exit status, got %v", err)
+				}
+				got = exitErr.ExitCode()
+			}
+			if got != tt.want {
+				t.Errorf("expected exit status %d, got %d (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestValidateAssertOverall(t *testing.T) {
+	for _, expected := range []string{"", "pass", "degraded", "fail", "any"} {
+		if err := validateAssertOverall(expected); err != nil {
+			t.Errorf("expected %q to be valid, got %v", expected, err)
+		}
+	}
+	if err := validateAssertOverall("healthy"); err == nil {
+		t.Error("expected an error for an unknown status")
+	}
+}
+
+func TestValidateAutoCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  internal.Config
+		wantErr bool
+	}{
+		{"disabled", internal.Config{}, false},
+		{"directory", internal.Config{AutoCompare: true, JSONOutput: "results/", AutoCompareLimit: 10}, false},
+		{"no JSON output", internal.Config{AutoCompare: true, AutoCompareLimit: 10}, true},
+		{"JSON file", internal.Config{AutoCompare: true, JSONOutput: "results.json", AutoCompareLimit: 10}, true},
+		{"limit too small", internal.Config{AutoCompare: true, JSONOutput: "results/", AutoCompareLimit: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateAutoCompare(&tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAutoCompare(t *testing.T) {
+	dir := t.TempDir()
+	config := &internal.Config{JSONOutput: dir, AutoCompare: true, AutoCompareLimit: 3, Silent: true}
+
+	writeResult := func(day int) {
+		t.Helper()
+		data := fmt.Sprintf(`{"timestamp":"2026-01-%02dT12:00:00Z","target":"https://example.com","overall":"pass"}`, day)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("benchmark_2026-01-%02d_120000.json", day)), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reports := func() []string {
+		matches, _ := filepath.Glob(filepath.Join(dir, "benchmark_comparison_*.md"))
+		return matches
+	}
+
+	// A single result has nothing to compare with
+	writeResult(1)
+	autoCompare(config, reporter.DefaultThresholds())
+	if got := reports(); len(got) != 0 {
+		t.Fatalf("expected no comparison for one result, got %v", got)
+	}
+
+	for day := 2; day <= 5; day++ {
+		writeResult(day)
+	}
+	autoCompare(config, reporter.DefaultThresholds())
+	got := reports()
+	if len(got) != 1 {
+		t.Fatalf("expected one comparison report, got %v", got)
+	}
+	data, err := os.ReadFile(got[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content := string(data); !strings.Contains(content, "Comparing 3 benchmark runs") || strings.Contains(content, "2026-01-02") {
+		t.Errorf("expected only the latest 3 runs to be compared, got:\n%s", content)
+	}
+}
@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces the value of credential headers in the log
+const Redacted = "REDACTED"
+
+// credentialWords mark a header name as likely to carry a credential
+var credentialWords = []string{"token", "key", "secret", "password"}
+
+// SensitiveHeader reports whether a header's value is likely a credential:
+// Authorization, Proxy-Authorization, Cookie, or any header whose name
+// contains token, key, secret, or password. Such values are never written
+// out in clear text.
+func SensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "authorization", "proxy-authorization", "cookie":
+		return true
+	}
+	for _, word := range credentialWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Entry is one logged request
+type Entry struct {
+	Timestamp  time.Time   `json:"timestamp"`
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Status     int         `json:"status"` // 0 when no response was received
+	DurationMs float64     `json:"duration_ms"`
+	Headers    http.Header `json:"headers,omitempty"` // Request headers with credentials redacted
+}
+
+// AuditLogger writes one JSON object per request to an io.Writer. It is safe
+// for concurrent use, so load test workers can share one logger.
+type AuditLogger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+	now func() time.Time
+}
+
+// NewAuditLogger returns a logger that writes JSON Lines to w
+func NewAuditLogger(w io.Writer) *AuditLogger {
+	return &AuditLogger{enc: json.NewEncoder(w), now: time.Now}
+}
+
+// LogRequest records a request and its response status. Write failures are
+// kept rather than returned so a full disk does not abort the benchmark; see Err.
+func (l *AuditLogger) LogRequest(method, url string, status int, durationMs float64, headers http.Header) {
+	entry := Entry{
+		Method:     method,
+		URL:        url,
+		Status:     status,
+		DurationMs: durationMs,
+		Headers:    redact(headers),
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry.Timestamp = l.now().UTC()
+	if err := l.enc.Encode(entry); err != nil && l.err == nil {
+		l.err = err
+	}
+}
+
+// Err returns the first error encountered while writing the log
+func (l *AuditLogger) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// redact returns a copy of headers with credential values replaced by Redacted
+func redact(headers http.Header) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	out := headers.Clone()
+	for name, values := range out {
+		if SensitiveHeader(name) {
+			redacted := make([]string, len(values))
+			for i := range redacted {
+				redacted[i] = Redacted
+			}
+			out[name] = redacted
+		}
+	}
+	return out
+}
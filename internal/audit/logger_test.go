@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLogRequest(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewAuditLogger(&buf)
+	l.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	headers := http.Header{}
+	headers.Set("User-Agent", "actalog-bench/1.0")
+	headers.Set("Authorization", "Bearer secret")
+	headers.Set("Cookie", "session=secret")
+	headers.Set("X-Api-Key", "secret-key")
+	l.LogRequest(http.MethodGet, "https://example.com/health", 200, 12.5, headers)
+
+	line := strings.TrimSpace(buf.String())
+	if strings.Contains(line, "secret") {
+		t.Errorf("expected credentials to be redacted, got %s", line)
+	}
+
+	var e Entry
+	if err := json.Unmarshal([]byte(line), &e); err != nil {
+		t.Fatalf("expected one JSON object, got %q: %v", line, err)
+	}
+	if e.Method != "GET" || e.URL != "https://example.com/health" || e.Status != 200 || e.DurationMs != 12.5 {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if !e.Timestamp.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected timestamp %s", e.Timestamp)
+	}
+	if e.Headers.Get("Authorization") != Redacted || e.Headers.Get("Cookie") != Redacted || e.Headers.Get("X-Api-Key") != Redacted {
+		t.Errorf("expected REDACTED credentials, got %v", e.Headers)
+	}
+	if e.Headers.Get("User-Agent") != "actalog-bench/1.0" {
+		t.Errorf("expected other headers unchanged, got %v", e.Headers)
+	}
+	// The caller's headers are still sent as-is
+	if headers.Get("Authorization") != "Bearer secret" {
+		t.Error("expected redaction not to modify the request headers")
+	}
+}
+
+func TestSensitiveHeader(t *testing.T) {
+	for name, want := range map[string]bool{
+		"Authorization":       true,
+		"proxy-authorization": true,
+		"Cookie":              true,
+		"X-Api-Key":           true,
+		"X-Auth-Token":        true,
+		"X-Client-Secret":     true,
+		"X-Db-Password":       true,
+		"User-Agent":          false,
+		"Accept":              false,
+		"Content-Type":        false,
+	} {
+		if got := SensitiveHeader(name); got != want {
+			t.Errorf("SensitiveHeader(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestLogRequest_Concurrent(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewAuditLogger(&buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.LogRequest(http.MethodGet, "https://example.com/", 200, 1, nil)
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 50 {
+		t.Fatalf("expected 50 lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("expected a complete JSON object per line, got %q", line)
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestLogRequest_WriteError(t *testing.T) {
+	l := NewAuditLogger(failingWriter{})
+	l.LogRequest(http.MethodGet, "https://example.com/", 200, 1, nil)
+	if err := l.Err(); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("expected write error to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// timestampPrefix matches the benchmark_<timestamp> start shared by all report
+// files of one run, e.g. benchmark_2026-01-09_143000_run1.json and benchmark_2026-01-09_143000.md
+var timestampPrefix = regexp.MustCompile(`^benchmark_\d{4}-\d{2}-\d{2}_\d{6}`)
+
+// PruneOldFiles deletes the oldest files in dir matching the glob pattern until
+// at most maxFiles remain, and returns the deleted paths. Files are ordered by
+// name, which for timestamped benchmark files is oldest first. A maxFiles of
+// zero or less keeps every file.
+func PruneOldFiles(dir string, pattern string, maxFiles int) ([]string, error) {
+	if maxFiles <= 0 {
+		return nil, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", dir, err)
+	}
+	if len(matches) <= maxFiles {
+		return nil, nil
+	}
+	sort.Strings(matches)
+
+	var deleted []string
+	for _, path := range matches[:len(matches)-maxFiles] {
+		if err := os.Remove(path); err != nil {
+			return deleted, fmt.Errorf("remove %s: %w", path, err)
+		}
+		deleted = append(deleted, path)
+	}
+	return deleted, nil
+}
+
+// RemoveCompanions deletes the file in dir with extension ext (e.g. ".md") that
+// belongs to the same run as each of the deleted benchmark files, and returns
+// the paths it removed. Missing companions are skipped.
+func RemoveCompanions(deleted []string, dir, ext string) ([]string, error) {
+	var removed []string
+	seen := make(map[string]bool)
+	for _, path := range deleted {
+		prefix := timestampPrefix.FindString(filepath.Base(path))
+		if prefix == "" || seen[prefix] {
+			continue
+		}
+		seen[prefix] = true
+
+		companion := filepath.Join(dir, prefix+ext)
+		if err := os.Remove(companion); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return removed, fmt.Errorf("remove %s: %w", companion, err)
+		}
+		removed = append(removed, companion)
+	}
+	return removed, nil
+}
@@ -0,0 +1,93 @@
+package cleanup
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func touch(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func remaining(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestPruneOldFiles(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir,
+		"benchmark_2026-01-03_090000.json",
+		"benchmark_2026-01-01_090000.json",
+		"benchmark_2026-01-02_090000.json",
+		"notes.json",
+	)
+
+	deleted, err := PruneOldFiles(dir, "benchmark_*.json", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "benchmark_2026-01-01_090000.json")}
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("expected %v deleted, got %v", want, deleted)
+	}
+	wantLeft := []string{"benchmark_2026-01-02_090000.json", "benchmark_2026-01-03_090000.json", "notes.json"}
+	if got := remaining(t, dir); !reflect.DeepEqual(got, wantLeft) {
+		t.Errorf("expected %v left, got %v", wantLeft, got)
+	}
+}
+
+func TestPruneOldFiles_WithinLimit(t *testing.T) {
+	dir := t.TempDir()
+	touch(t, dir, "benchmark_2026-01-01_090000.json", "benchmark_2026-01-02_090000.json")
+
+	for _, maxFiles := range []int{0, -1, 2, 5} {
+		deleted, err := PruneOldFiles(dir, "benchmark_*.json", maxFiles)
+		if err != nil || len(deleted) != 0 {
+			t.Errorf("maxFiles %d: expected nothing deleted, got %v (err %v)", maxFiles, deleted, err)
+		}
+	}
+	if got := remaining(t, dir); len(got) != 2 {
+		t.Errorf("expected both files kept, got %v", got)
+	}
+}
+
+func TestRemoveCompanions(t *testing.T) {
+	jsonDir, mdDir := t.TempDir(), t.TempDir()
+	touch(t, mdDir, "benchmark_2026-01-01_090000.md", "benchmark_2026-01-02_090000.md")
+
+	deleted := []string{
+		filepath.Join(jsonDir, "benchmark_2026-01-01_090000_agg.json"),
+		filepath.Join(jsonDir, "benchmark_2026-01-01_090000_run1.json"),
+		filepath.Join(jsonDir, "benchmark_2025-12-31_090000.json"), // No Markdown report
+		filepath.Join(jsonDir, "results.json"),
+	}
+	removed, err := RemoveCompanions(deleted, mdDir, ".md")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{filepath.Join(mdDir, "benchmark_2026-01-01_090000.md")}
+	if !reflect.DeepEqual(removed, want) {
+		t.Errorf("expected %v removed, got %v", want, removed)
+	}
+	if got := remaining(t, mdDir); !reflect.DeepEqual(got, []string{"benchmark_2026-01-02_090000.md"}) {
+		t.Errorf("expected the newer Markdown report kept, got %v", got)
+	}
+}
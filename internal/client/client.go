@@ -3,16 +3,51 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	mathrand "math/rand"
 	"net"
 	"net/http"
 	"net/http/httptrace"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/johnzastrow/actalog-benchmark/internal/audit"
 )
 
+// UserAgent is sent with every request unless WithUserAgent overrides it
+const UserAgent = "actalog-bench/1.0"
+
+// DefaultMaxIdleConnsPerHost is how many idle connections to the target are
+// kept for reuse unless WithMaxIdleConnsPerHost changes it
+const DefaultMaxIdleConnsPerHost = 100
+
+// maxRedirects is the redirect limit, the same as http.Client's default
+const maxRedirects = 10
+
+// ErrSimulatedLoss fails the requests dropped by WithSimulatedNetwork
+var ErrSimulatedLoss = errors.New("simulated network loss: connection reset")
+
+// HTTPSDowngradeError reports a redirect from an https:// URL to a plain
+// http:// one, which exposes the request, including any Authorization header
+// sent before the redirect, to anyone on the network path
+type HTTPSDowngradeError struct {
+	From string // URL that redirected, with credentials redacted
+	To   string // Plain HTTP URL it redirected to
+}
+
+func (e *HTTPSDowngradeError) Error() string {
+	return fmt.Sprintf("HTTPS downgrade: %s redirected to %s", e.From, e.To)
+}
+
 // TimingInfo holds detailed timing breakdown for a request
 type TimingInfo struct {
 	DNSStart     time.Time
@@ -28,14 +63,27 @@ type TimingInfo struct {
 	ConnectDuration time.Duration
 	TLSDuration     time.Duration
 	TotalDuration   time.Duration
+
+	ConnReused bool // Request was sent on a kept-alive connection
+
+	RequestID string // Sent in the request ID header, even when no response arrived; empty when request IDs are disabled
 }
 
 // Client wraps HTTP client with auth and timing support
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	dialer     *hostDialer
 	token      string
 	timeout    time.Duration
+
+	userAgent       string // User-Agent header value
+	requestIDHeader string // Header carrying a fresh request ID, empty to disable
+	traceHeader     string // Header carrying a W3C traceparent value, empty to disable
+	traceID         string // Trace ID shared by every request's traceparent
+	maxResponseSize int64  // Most response body bytes benchmarks read, 0 for no limit
+
+	auditLogger *audit.AuditLogger // Records every request, nil to disable
 }
 
 // LoginRequest represents the login payload
@@ -54,29 +102,380 @@ type LoginResponse struct {
 	} `json:"user"`
 }
 
+// Option configures optional Client behavior
+type Option func(*options)
+
+type options struct {
+	network         string // Dial network: "tcp", "tcp4", or "tcp6"
+	userAgent       string
+	requestIDHeader string
+	traceHeader     string
+	traceID         string
+	maxResponseSize int64
+	dnsResolver     string // DNS server address, empty for the system resolver
+	hostLookup      func(ctx context.Context, host string) ([]string, error)
+	bindAddr        string // Local IP address connections are made from, empty for the system's choice
+	unixSocket      string // Unix domain socket every connection is made to, empty for TCP
+	allowDowngrade  bool   // Follow redirects from HTTPS to plain HTTP
+	maxIdlePerHost  int    // Idle connections kept per host
+	token           string
+	latency         time.Duration // Simulated delay added before every request
+	lossRate        float64       // Fraction of requests failed with ErrSimulatedLoss
+	auditLogger     *audit.AuditLogger
+}
+
+// WithNetwork restricts connections to an address family.
+// Use "tcp4" for IPv4 only, "tcp6" for IPv6 only, or "tcp" (default) for either.
+func WithNetwork(network string) Option {
+	return func(o *options) {
+		o.network = network
+	}
+}
+
+// WithUserAgent replaces the default User-Agent header; empty keeps UserAgent
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		if userAgent != "" {
+			o.userAgent = userAgent
+		}
+	}
+}
+
+// WithRequestIDHeader tags every request with a random UUID v4 in the named header
+// (e.g. "X-Request-ID") so requests can be correlated with server logs
+func WithRequestIDHeader(header string) Option {
+	return func(o *options) {
+		o.requestIDHeader = header
+	}
+}
+
+// WithTraceHeader sends a W3C Trace Context traceparent value in header (e.g.
+// "traceparent") with every request. All requests share traceID, from
+// NewTraceID, and each gets its own span ID, so the server's traces of a
+// benchmark run can be found by its trace ID. A request made within an
+// OpenTelemetry span of the same trace names that span as its parent instead.
+func WithTraceHeader(header, traceID string) Option {
+	return func(o *options) {
+		o.traceHeader = header
+		o.traceID = traceID
+	}
+}
+
+// WithMaxResponseSize caps how many response body bytes benchmarks read per
+// request, so a misbehaving server cannot exhaust memory or bandwidth. Zero (the
+// default) reads whole bodies.
+func WithMaxResponseSize(bytes int64) Option {
+	return func(o *options) {
+		o.maxResponseSize = bytes
+	}
+}
+
+// WithDNSResolver sends the client's DNS queries to server (host or host:port)
+// instead of the system resolver
+func WithDNSResolver(server string) Option {
+	return func(o *options) {
+		o.dnsResolver = server
+	}
+}
+
+// WithHostLookup resolves host names with lookup, such as a cache, before
+// dialing, trying each address returned in turn. It replaces the resolver set
+// with WithDNSResolver.
+func WithHostLookup(lookup func(ctx context.Context, host string) ([]string, error)) Option {
+	return func(o *options) {
+		o.hostLookup = lookup
+	}
+}
+
+// WithBindAddr makes every connection from the local IP address addr, e.g. to
+// choose a network interface. Only target addresses of the same IP family are
+// dialed. An empty or invalid addr leaves the choice to the system.
+func WithBindAddr(addr string) Option {
+	return func(o *options) {
+		o.bindAddr = addr
+	}
+}
+
+// WithUnixSocket sends every request over the Unix domain socket at path
+// instead of TCP. The URL's host is still used for the Host header and TLS
+// server name.
+func WithUnixSocket(path string) Option {
+	return func(o *options) {
+		o.unixSocket = path
+	}
+}
+
+// WithAllowHTTPSDowngrade follows redirects from https:// to plain http://
+// URLs. By default such a redirect fails the request with an
+// *HTTPSDowngradeError.
+func WithAllowHTTPSDowngrade(allow bool) Option {
+	return func(o *options) {
+		o.allowDowngrade = allow
+	}
+}
+
+// WithMaxIdleConnsPerHost sets how many idle connections to the target are kept
+// for reuse. When more requests run at once than this, the extra connections
+// are closed after each response and new ones dialed for later requests. Zero
+// or less keeps DefaultMaxIdleConnsPerHost.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxIdlePerHost = n
+		}
+	}
+}
+
+// WithSimulatedNetwork degrades every request as a poor network would: each is
+// held for latency plus up to 10% jitter before it is sent, and a lossRate
+// fraction (0 to 1) fail with ErrSimulatedLoss without reaching the server.
+// Zero for both leaves requests alone.
+func WithSimulatedNetwork(latency time.Duration, lossRate float64) Option {
+	return func(o *options) {
+		o.latency = latency
+		o.lossRate = lossRate
+	}
+}
+
+// WithToken authenticates every request with a pre-computed JWT, e.g. one
+// issued to a CI job, so Login is not needed
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithAuditLogger records every request the client sends, including the login,
+// with its status and duration
+func WithAuditLogger(logger *audit.AuditLogger) Option {
+	return func(o *options) {
+		o.auditLogger = logger
+	}
+}
+
+// NewResolver returns a resolver that sends every query to server ("8.8.8.8" or
+// "8.8.8.8:53"; port 53 is assumed when omitted), or net.DefaultResolver when
+// server is empty
+func NewResolver(server string) *net.Resolver {
+	if server == "" {
+		return net.DefaultResolver
+	}
+	address := ResolverAddress(server)
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// ResolverAddress returns server as host:port, adding the DNS port 53 when
+// server has no port
+func ResolverAddress(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(strings.Trim(server, "[]"), "53")
+}
+
 // New creates a new Client
-func New(baseURL string, timeout time.Duration) *Client {
+func New(baseURL string, timeout time.Duration, opts ...Option) *Client {
+	o := options{network: "tcp", userAgent: UserAgent, maxIdlePerHost: DefaultMaxIdleConnsPerHost}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Resolver:  NewResolver(o.dnsResolver),
+	}
+	if ip := net.ParseIP(o.bindAddr); ip != nil {
+		dialer.LocalAddr = &net.TCPAddr{IP: ip}
+	}
+
+	hd := &hostDialer{dialer: dialer, network: o.network, unixSocket: o.unixSocket, lookupHost: o.hostLookup}
 	transport := &http.Transport{
-		DialContext: (&net.Dialer{
-			Timeout:   timeout,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
+		DialContext:           hd.DialContext,
 		TLSHandshakeTimeout:   timeout,
 		ResponseHeaderTimeout: timeout,
 		ExpectContinueTimeout: 1 * time.Second,
-		MaxIdleConns:          100,
-		MaxIdleConnsPerHost:   100,
+		MaxIdleConns:          max(100, o.maxIdlePerHost),
+		MaxIdleConnsPerHost:   o.maxIdlePerHost,
 		IdleConnTimeout:       90 * time.Second,
 	}
+	var roundTripper http.RoundTripper = transport
+	if o.latency > 0 || o.lossRate > 0 {
+		roundTripper = &throttledTransport{base: transport, latency: o.latency, jitter: o.latency / 10, lossRate: o.lossRate}
+	}
 
 	return &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Transport: transport,
-			Timeout:   timeout,
+			Transport:     roundTripper,
+			CheckRedirect: checkRedirect(o.allowDowngrade),
+			Timeout:       timeout,
 		},
-		timeout: timeout,
+		dialer:          hd,
+		token:           o.token,
+		timeout:         timeout,
+		userAgent:       o.userAgent,
+		requestIDHeader: o.requestIDHeader,
+		traceHeader:     o.traceHeader,
+		traceID:         o.traceID,
+		maxResponseSize: o.maxResponseSize,
+		auditLogger:     o.auditLogger,
+	}
+}
+
+// hostDialer makes the client's connections, over the Unix socket when one is
+// set and otherwise to the requested address
+type hostDialer struct {
+	dialer     *net.Dialer
+	network    string
+	unixSocket string
+
+	// lookupHost resolves host names before dialing; nil leaves it to dialer
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+}
+
+// DialContext is the http.Transport DialContext of the client
+func (d *hostDialer) DialContext(ctx context.Context, _, addr string) (net.Conn, error) {
+	if d.unixSocket != "" {
+		return d.dialer.DialContext(ctx, "unix", d.unixSocket)
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if d.lookupHost == nil || err != nil || net.ParseIP(host) != nil {
+		return d.dialer.DialContext(ctx, d.network, addr)
+	}
+
+	addrs, err := d.lookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	dialErr := fmt.Errorf("no addresses found for %s", host)
+	for _, ip := range addrs {
+		conn, err := d.dialer.DialContext(ctx, d.network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
 	}
+	return nil, dialErr
+}
+
+// throttledTransport delays and drops requests for WithSimulatedNetwork
+type throttledTransport struct {
+	base     *http.Transport
+	latency  time.Duration
+	jitter   time.Duration // Most extra delay, chosen at random per request
+	lossRate float64
+}
+
+// RoundTrip waits out the simulated latency, unless the request is cancelled
+// first, then drops the request or passes it to the base transport
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay := t.latency
+	if t.jitter > 0 {
+		delay += time.Duration(mathrand.Int63n(int64(t.jitter)))
+	}
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			closeRequestBody(req)
+			return nil, req.Context().Err()
+		}
+	}
+	if t.lossRate > 0 && mathrand.Float64() < t.lossRate {
+		closeRequestBody(req)
+		return nil, ErrSimulatedLoss
+	}
+	return t.base.RoundTrip(req)
+}
+
+// closeRequestBody closes the body of a request a RoundTripper fails without
+// sending, as the http.RoundTripper contract requires
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// checkRedirect returns an http.Client CheckRedirect function that keeps the
+// default redirect limit and, unless allowDowngrade is set, refuses to follow a
+// redirect from HTTPS to plain HTTP
+func checkRedirect(allowDowngrade bool) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		if prev := via[len(via)-1]; !allowDowngrade && prev.URL.Scheme == "https" && req.URL.Scheme == "http" {
+			return &HTTPSDowngradeError{From: prev.URL.Redacted(), To: req.URL.Redacted()}
+		}
+		return nil
+	}
+}
+
+// WithTimeout returns a copy of c whose requests may take up to timeout, for
+// endpoints slower than the rest such as /api/benchmark. The copy keeps c's
+// token and options but has its own connection pool. c itself is returned
+// when timeout is zero or unchanged.
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	if timeout <= 0 || timeout == c.timeout {
+		return c
+	}
+	clone := c.cloneTransport(func(transport *http.Transport) {
+		transport.ResponseHeaderTimeout = timeout
+	})
+	clone.httpClient.Timeout = timeout
+	clone.timeout = timeout
+	return clone
+}
+
+// WithoutKeepAlives returns a copy of c that opens a new connection for every
+// request, for timing requests that pay the full connection setup. The copy
+// keeps c's token and options.
+func (c *Client) WithoutKeepAlives() *Client {
+	return c.cloneTransport(func(transport *http.Transport) {
+		transport.DisableKeepAlives = true
+	})
+}
+
+// cloneTransport returns a copy of c with a new connection pool, whose
+// transport configure may change. Any simulated network is kept.
+func (c *Client) cloneTransport(configure func(*http.Transport)) *Client {
+	clone := *c
+	throttled, isThrottled := c.httpClient.Transport.(*throttledTransport)
+	var transport *http.Transport
+	if isThrottled {
+		transport = throttled.base.Clone()
+	} else {
+		transport = c.httpClient.Transport.(*http.Transport).Clone()
+	}
+	configure(transport)
+
+	var roundTripper http.RoundTripper = transport
+	if isThrottled {
+		throttledClone := *throttled
+		throttledClone.base = transport
+		roundTripper = &throttledClone
+	}
+	clone.httpClient = &http.Client{Transport: roundTripper, CheckRedirect: c.httpClient.CheckRedirect, Timeout: c.httpClient.Timeout}
+	return &clone
+}
+
+// GetUserAgent returns the User-Agent header sent with every request
+func (c *Client) GetUserAgent() string {
+	return c.userAgent
+}
+
+// MaxResponseSize returns the response body limit set with WithMaxResponseSize
+func (c *Client) MaxResponseSize() int64 {
+	return c.maxResponseSize
 }
 
 // Login authenticates and stores the JWT token
@@ -97,7 +496,9 @@ func (c *Client) Login(ctx context.Context, email, password string) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
+	c.logRequest(req, resp, time.Since(start))
 	if err != nil {
 		return fmt.Errorf("execute login request: %w", err)
 	}
@@ -129,7 +530,39 @@ func (c *Client) Get(ctx context.Context, path string) (*http.Response, error) {
 
 // GetWithTiming performs a GET request and returns timing info
 func (c *Client) GetWithTiming(ctx context.Context, path string) (*http.Response, *TimingInfo, error) {
-	return c.doRequestWithTiming(ctx, http.MethodGet, path, nil)
+	return c.doRequestWithTiming(ctx, http.MethodGet, path, nil, nil)
+}
+
+// GetCompressed performs a GET request that accepts a gzip-encoded response.
+// Unlike Get, the body is left as sent so callers can measure the bytes on the
+// wire; a body with Content-Encoding: gzip must be decoded by the caller.
+func (c *Client) GetCompressed(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	c.addHeaders(req)
+	// Setting the header ourselves stops the transport decompressing transparently
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	return c.send(req)
+}
+
+// GetEventStream opens a server-sent events stream at path. The caller reads
+// events from the response body and must close it; the client timeout still
+// bounds the whole read.
+func (c *Client) GetEventStream(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	c.addHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	return c.send(req)
 }
 
 // Post performs a POST request with optional auth
@@ -137,6 +570,17 @@ func (c *Client) Post(ctx context.Context, path string, body io.Reader) (*http.R
 	return c.doRequest(ctx, http.MethodPost, path, body)
 }
 
+// PostWithTiming performs a POST request and returns timing info
+func (c *Client) PostWithTiming(ctx context.Context, path string, body io.Reader) (*http.Response, *TimingInfo, error) {
+	return c.doRequestWithTiming(ctx, http.MethodPost, path, body, nil)
+}
+
+// DoWithTiming performs a request with any method and returns timing info.
+// headers are set after the client's own, so they can replace them.
+func (c *Client) DoWithTiming(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, *TimingInfo, error) {
+	return c.doRequestWithTiming(ctx, method, path, body, headers)
+}
+
 func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
 	if err != nil {
@@ -145,7 +589,14 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 
 	c.addHeaders(req)
 
+	return c.send(req)
+}
+
+// send executes req and records it in the audit log
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
+	c.logRequest(req, resp, time.Since(start))
 	if err != nil {
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
@@ -153,7 +604,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 	return resp, nil
 }
 
-func (c *Client) doRequestWithTiming(ctx context.Context, method, path string, body io.Reader) (*http.Response, *TimingInfo, error) {
+func (c *Client) doRequestWithTiming(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, *TimingInfo, error) {
 	timing := &TimingInfo{}
 
 	trace := &httptrace.ClientTrace{
@@ -178,6 +629,9 @@ func (c *Client) doRequestWithTiming(ctx context.Context, method, path string, b
 			timing.TLSDone = time.Now()
 			timing.TLSDuration = timing.TLSDone.Sub(timing.TLSStart)
 		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			timing.ConnReused = info.Reused
+		},
 		GotFirstResponseByte: func() {
 			timing.FirstByte = time.Now()
 		},
@@ -192,10 +646,17 @@ func (c *Client) doRequestWithTiming(ctx context.Context, method, path string, b
 	}
 
 	c.addHeaders(req)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if c.requestIDHeader != "" {
+		timing.RequestID = req.Header.Get(c.requestIDHeader)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	timing.Done = time.Now()
 	timing.TotalDuration = timing.Done.Sub(start)
+	c.logRequest(req, resp, timing.TotalDuration)
 
 	if err != nil {
 		return nil, timing, fmt.Errorf("execute request: %w", err)
@@ -205,16 +666,112 @@ func (c *Client) doRequestWithTiming(ctx context.Context, method, path string, b
 }
 
 func (c *Client) addHeaders(req *http.Request) {
-	req.Header.Set("User-Agent", "actalog-bench/1.0")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.requestIDHeader != "" {
+		req.Header.Set(c.requestIDHeader, newRequestID())
+	}
+	if c.traceHeader != "" && c.traceID != "" {
+		req.Header.Set(c.traceHeader, traceparent(c.traceID, parentSpanID(req.Context(), c.traceID)))
+	}
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
-	if req.Method == http.MethodPost && req.Body != nil {
+	if req.Method != http.MethodGet && req.Body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 }
 
+// logRequest writes a completed request to the audit log, if one is configured.
+// A failed request is logged with status 0.
+func (c *Client) logRequest(req *http.Request, resp *http.Response, elapsed time.Duration) {
+	if c.auditLogger == nil {
+		return
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	c.auditLogger.LogRequest(req.Method, req.URL.Redacted(), status, float64(elapsed.Microseconds())/1000.0, req.Header)
+}
+
+// RequestID returns the request ID sent with the request that produced resp,
+// or an empty string when request IDs are disabled
+func (c *Client) RequestID(resp *http.Response) string {
+	if c.requestIDHeader == "" || resp == nil || resp.Request == nil {
+		return ""
+	}
+	return resp.Request.Header.Get(c.requestIDHeader)
+}
+
+// newRequestID returns a random RFC 4122 version 4 UUID
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// NewTraceID returns a random W3C Trace Context trace ID (32 hex digits) whose
+// first 8 digits are start as Unix seconds, so a benchmark's trace ID also
+// tells when it ran
+func NewTraceID(start time.Time) string {
+	var b [16]byte
+	binary.BigEndian.PutUint32(b[:4], uint32(start.Unix()))
+	if _, err := rand.Read(b[4:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// traceparent formats a W3C Trace Context traceparent header value: version
+// 00, the trace and parent span IDs, and the sampled flag so the server
+// records the trace
+func traceparent(traceID, spanID string) string {
+	return fmt.Sprintf("00-%s-%s-01", traceID, spanID)
+}
+
+// parentSpanID returns the ID of the span in ctx when it belongs to traceID,
+// so the server's spans nest under the benchmark's --otel-endpoint spans, and
+// otherwise a new span ID
+func parentSpanID(ctx context.Context, traceID string) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() && sc.TraceID().String() == traceID {
+		return sc.SpanID().String()
+	}
+	return newSpanID()
+}
+
+// newSpanID returns a random, non-zero span ID (16 hex digits)
+func newSpanID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil || b == [8]byte{} {
+		b[7] = 1 // An all-zero span ID is invalid
+	}
+	return hex.EncodeToString(b[:])
+}
+
 // GetBaseURL returns the base URL
 func (c *Client) GetBaseURL() string {
 	return c.baseURL
 }
+
+// Dial opens a TCP connection to addr (host:port) as the client's requests
+// do: through its Unix socket, from its bind address, and with its resolver
+// and IP family. Protocols the client does not speak, such as WebSocket, can
+// run over it; TLS is left to the caller.
+func (c *Client) Dial(ctx context.Context, addr string) (net.Conn, error) {
+	return c.dialer.DialContext(ctx, "tcp", addr)
+}
+
+// Header returns the headers that identify the client on every request: its
+// User-Agent and, when it has a token, its Authorization
+func (c *Client) Header() http.Header {
+	h := http.Header{}
+	h.Set("User-Agent", c.userAgent)
+	if c.token != "" {
+		h.Set("Authorization", "Bearer "+c.token)
+	}
+	return h
+}
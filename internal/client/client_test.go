@@ -1,12 +1,25 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/johnzastrow/actalog-benchmark/internal/audit"
 )
 
 func TestNew(t *testing.T) {
@@ -124,6 +137,78 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestNew_WithNetwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The test server listens on 127.0.0.1
+	c := New(server.URL, 5*time.Second, WithNetwork("tcp4"))
+	resp, err := c.Get(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("expected IPv4 request to succeed, got: %v", err)
+	}
+	resp.Body.Close()
+
+	c = New(server.URL, 5*time.Second, WithNetwork("tcp6"))
+	if resp, err := c.Get(context.Background(), "/"); err == nil {
+		resp.Body.Close()
+		t.Error("expected IPv6-only client to fail connecting to an IPv4 address")
+	}
+}
+
+func TestResolverAddress(t *testing.T) {
+	tests := []struct {
+		server   string
+		expected string
+	}{
+		{"8.8.8.8", "8.8.8.8:53"},
+		{"8.8.8.8:5353", "8.8.8.8:5353"},
+		{"dns.internal", "dns.internal:53"},
+		{"2001:4860:4860::8888", "[2001:4860:4860::8888]:53"},
+		{"[2001:4860:4860::8888]:53", "[2001:4860:4860::8888]:53"},
+	}
+
+	for _, tt := range tests {
+		if got := ResolverAddress(tt.server); got != tt.expected {
+			t.Errorf("ResolverAddress(%q) = %q, want %q", tt.server, got, tt.expected)
+		}
+	}
+}
+
+func TestNewResolver(t *testing.T) {
+	if NewResolver("") != net.DefaultResolver {
+		t.Error("expected the default resolver without a server")
+	}
+
+	// A DNS server that never answers still proves the query was sent to it
+	dns, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer dns.Close()
+	queried := make(chan struct{}, 1)
+	go func() {
+		buf := make([]byte, 512)
+		if _, _, err := dns.ReadFrom(buf); err == nil {
+			queried <- struct{}{}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := NewResolver(dns.LocalAddr().String()).LookupIPAddr(ctx, "actalog.invalid"); err == nil {
+		t.Error("expected lookup against a silent DNS server to fail")
+	}
+
+	select {
+	case <-queried:
+	default:
+		t.Error("expected the query to reach the configured DNS server")
+	}
+}
+
 func TestGet_WithAuth(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		auth := r.Header.Get("Authorization")
@@ -168,6 +253,27 @@ func TestGetWithTiming(t *testing.T) {
 	}
 }
 
+func TestGetWithTiming_ConnReused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	c := New(server.URL, 5*time.Second)
+	for i, wantReused := range []bool{false, true} {
+		resp, timing, err := c.GetWithTiming(context.Background(), "/")
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i+1, err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if timing.ConnReused != wantReused {
+			t.Errorf("request %d: expected ConnReused=%t, got %t", i+1, wantReused, timing.ConnReused)
+		}
+	}
+}
+
 func TestGetBaseURL(t *testing.T) {
 	c := New("https://test.example.com", 10*time.Second)
 
@@ -189,3 +295,426 @@ func TestIsAuthenticated(t *testing.T) {
 		t.Error("expected IsAuthenticated() to return true when token is set")
 	}
 }
+
+func TestWithRequestIDHeader(t *testing.T) {
+	seen := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen[r.Header.Get("X-Request-ID")] = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, 10*time.Second, WithRequestIDHeader("X-Request-ID"))
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	for i := 0; i < 3; i++ {
+		resp, err := c.Get(context.Background(), "/")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		resp.Body.Close()
+
+		id := c.RequestID(resp)
+		if !uuidPattern.MatchString(id) {
+			t.Errorf("expected UUID v4 request ID, got %q", id)
+		}
+		if !seen[id] {
+			t.Errorf("expected server to receive request ID %q", id)
+		}
+	}
+
+	if len(seen) != 3 {
+		t.Errorf("expected 3 unique request IDs, got %d", len(seen))
+	}
+}
+
+func TestWithTraceHeader(t *testing.T) {
+	var traceparents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	start := time.Date(2026, 1, 9, 14, 30, 0, 0, time.UTC)
+	traceID := NewTraceID(start)
+	if !strings.HasPrefix(traceID, fmt.Sprintf("%08x", start.Unix())) || len(traceID) != 32 {
+		t.Fatalf("expected 32 hex digits starting with the Unix time, got %q", traceID)
+	}
+
+	c := New(server.URL, 10*time.Second, WithTraceHeader("traceparent", traceID))
+	for i := 0; i < 2; i++ {
+		resp, err := c.Get(context.Background(), "/")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	pattern := regexp.MustCompile(`^00-` + traceID + `-[0-9a-f]{16}-01$`)
+	for _, tp := range traceparents {
+		if !pattern.MatchString(tp) {
+			t.Errorf("expected a traceparent with trace ID %s, got %q", traceID, tp)
+		}
+	}
+	if len(traceparents) != 2 || traceparents[0] == traceparents[1] {
+		t.Errorf("expected a new span ID per request, got %v", traceparents)
+	}
+
+	// A request within a span of the same trace names that span as its parent
+	tid, _ := trace.TraceIDFromHex(traceID)
+	sid, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: tid, SpanID: sid}))
+	resp, err := c.Get(ctx, "/")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp.Body.Close()
+	if want := "00-" + traceID + "-00f067aa0ba902b7-01"; traceparents[2] != want {
+		t.Errorf("expected %q, got %q", want, traceparents[2])
+	}
+}
+
+func TestRequestID_Disabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-ID") != "" {
+			t.Error("expected no request ID header by default")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, 10*time.Second)
+	resp, err := c.Get(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp.Body.Close()
+
+	if id := c.RequestID(resp); id != "" {
+		t.Errorf("expected empty request ID, got %q", id)
+	}
+}
+
+func TestGetCompressed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write([]byte("raw gzip bytes"))
+	}))
+	defer server.Close()
+
+	c := New(server.URL, 10*time.Second)
+	resp, err := c.GetCompressed(context.Background(), "/app.js")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// The body must reach the caller undecoded
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "raw gzip bytes" || resp.Header.Get("Content-Encoding") != "gzip" || resp.Uncompressed {
+		t.Errorf("expected the body as sent, got %q (uncompressed=%t)", body, resp.Uncompressed)
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, 10*time.Second, WithToken("header.payload.signature"))
+	if !c.IsAuthenticated() {
+		t.Error("expected a client with a token to be authenticated")
+	}
+	resp, err := c.Get(context.Background(), "/api/workouts")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp.Body.Close()
+
+	if got != "Bearer header.payload.signature" {
+		t.Errorf("expected the token as a bearer credential, got %q", got)
+	}
+}
+
+func TestClient_WithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, 50*time.Millisecond, WithToken("token"))
+	if _, err := c.Get(context.Background(), "/api/benchmark"); err == nil {
+		t.Fatal("expected the short client timeout to fail the request")
+	}
+
+	slow := c.WithTimeout(5 * time.Second)
+	if !slow.IsAuthenticated() {
+		t.Error("expected the copy to keep the token")
+	}
+	resp, err := slow.Get(context.Background(), "/api/benchmark")
+	if err != nil {
+		t.Fatalf("expected the longer timeout to succeed, got: %v", err)
+	}
+	resp.Body.Close()
+
+	if c.WithTimeout(0) != c || c.WithTimeout(50*time.Millisecond) != c {
+		t.Error("expected an unchanged timeout to return the same client")
+	}
+}
+
+func TestHTTPSDowngradeRedirect(t *testing.T) {
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer plain.Close()
+	secure := httptest.NewTLSServer(http.RedirectHandler(plain.URL+"/api/workouts", http.StatusFound))
+	defer secure.Close()
+
+	newClient := func(opts ...Option) *Client {
+		c := New(secure.URL, 5*time.Second, opts...)
+		// Trust the test server's certificate
+		c.httpClient.Transport.(*http.Transport).TLSClientConfig = secure.Client().Transport.(*http.Transport).TLSClientConfig
+		return c
+	}
+
+	_, err := newClient().Get(context.Background(), "/api/workouts")
+	var downgrade *HTTPSDowngradeError
+	if !errors.As(err, &downgrade) {
+		t.Fatalf("expected an HTTPS downgrade error, got: %v", err)
+	}
+	if downgrade.To != plain.URL+"/api/workouts" {
+		t.Errorf("expected the plain HTTP URL %s, got %s", plain.URL+"/api/workouts", downgrade.To)
+	}
+
+	resp, err := newClient(WithAllowHTTPSDowngrade(true)).Get(context.Background(), "/api/workouts")
+	if err != nil {
+		t.Fatalf("expected the downgrade to be followed when allowed, got: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestCheckRedirect(t *testing.T) {
+	request := func(rawURL string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, rawURL, nil)
+		return req
+	}
+
+	check := checkRedirect(false)
+	if err := check(request("https://example.com/b"), []*http.Request{request("http://example.com/a")}); err != nil {
+		t.Errorf("expected an upgrade to be followed, got: %v", err)
+	}
+	if err := check(request("https://example.com/b"), []*http.Request{request("https://example.com/a")}); err != nil {
+		t.Errorf("expected an HTTPS redirect to be followed, got: %v", err)
+	}
+	if err := checkRedirect(true)(request("http://example.com/b"), []*http.Request{request("https://example.com/a")}); err != nil {
+		t.Errorf("expected an allowed downgrade to be followed, got: %v", err)
+	}
+
+	via := make([]*http.Request, maxRedirects)
+	for i := range via {
+		via[i] = request("https://example.com/loop")
+	}
+	if err := check(request("https://example.com/loop"), via); err == nil || strings.Contains(err.Error(), "downgrade") {
+		t.Errorf("expected the redirect limit to stop the request, got: %v", err)
+	}
+}
+
+func TestWithMaxIdleConnsPerHost(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want int
+	}{
+		{"default", nil, DefaultMaxIdleConnsPerHost},
+		{"set", []Option{WithMaxIdleConnsPerHost(250)}, 250},
+		{"zero keeps default", []Option{WithMaxIdleConnsPerHost(0)}, DefaultMaxIdleConnsPerHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := New("http://localhost", time.Second, tt.opts...).httpClient.Transport.(*http.Transport)
+			if transport.MaxIdleConnsPerHost != tt.want {
+				t.Errorf("expected MaxIdleConnsPerHost %d, got %d", tt.want, transport.MaxIdleConnsPerHost)
+			}
+			if transport.MaxIdleConns < tt.want {
+				t.Errorf("expected MaxIdleConns of at least %d, got %d", tt.want, transport.MaxIdleConns)
+			}
+		})
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{"custom", "LoadProbe/2.1", "LoadProbe/2.1"},
+		{"empty keeps default", "", UserAgent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(server.URL, 10*time.Second, WithUserAgent(tt.userAgent))
+			resp, err := c.Get(context.Background(), "/")
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			resp.Body.Close()
+
+			if got != tt.want {
+				t.Errorf("expected User-Agent %q, got %q", tt.want, got)
+			}
+			if c.GetUserAgent() != tt.want {
+				t.Errorf("expected GetUserAgent %q, got %q", tt.want, c.GetUserAgent())
+			}
+		})
+	}
+}
+
+func TestWithBindAddr(t *testing.T) {
+	var remote string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remote = r.RemoteAddr
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, 10*time.Second, WithBindAddr("127.0.0.1"))
+	resp, err := c.Get(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp.Body.Close()
+
+	if host, _, _ := net.SplitHostPort(remote); host != "127.0.0.1" {
+		t.Errorf("expected connection from 127.0.0.1, got %s", remote)
+	}
+
+	// An IPv6 local address cannot reach the IPv4 server
+	c = New(server.URL, 10*time.Second, WithBindAddr("::1"))
+	if _, err := c.Get(context.Background(), "/"); err == nil {
+		t.Error("expected an error connecting across IP families")
+	}
+}
+
+func TestWithUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "actalog.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	var host string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	// Nothing listens on the URL's host, so the request must use the socket
+	c := New("http://actalog.internal", 10*time.Second, WithUnixSocket(socket))
+	resp, err := c.Get(context.Background(), "/health")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp.Body.Close()
+
+	if host != "actalog.internal" {
+		t.Errorf("expected the URL host in the Host header, got %q", host)
+	}
+}
+
+func TestWithAuditLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/login" {
+			w.Write([]byte(`{"token": "secret-token"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	c := New(server.URL, 10*time.Second, WithAuditLogger(audit.NewAuditLogger(&buf)))
+	if err := c.Login(context.Background(), "user@example.com", "password"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp, _, err := c.GetWithTiming(context.Background(), "/missing")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp.Body.Close()
+
+	logged := buf.String()
+	var entries []audit.Entry
+	dec := json.NewDecoder(strings.NewReader(logged))
+	for dec.More() {
+		var e audit.Entry
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("decode audit entry: %v", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(entries))
+	}
+	if entries[0].Method != http.MethodPost || entries[0].Status != http.StatusOK {
+		t.Errorf("expected login POST with status 200, got %s %d", entries[0].Method, entries[0].Status)
+	}
+	if entries[1].URL != server.URL+"/missing" || entries[1].Status != http.StatusNotFound {
+		t.Errorf("expected /missing with status 404, got %s %d", entries[1].URL, entries[1].Status)
+	}
+	if got := entries[1].Headers.Get("Authorization"); got != audit.Redacted {
+		t.Errorf("expected redacted Authorization header, got %q", got)
+	}
+	if strings.Contains(logged, "secret-token") {
+		t.Error("expected the token to be left out of the audit log")
+	}
+}
+
+func TestWithSimulatedNetwork(t *testing.T) {
+	var hits int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&hits, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, 5*time.Second, WithSimulatedNetwork(50*time.Millisecond, 0))
+	start := time.Now()
+	resp, err := c.Get(context.Background(), "/health")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the request held for at least 50ms, took %s", elapsed)
+	}
+
+	// WithTimeout keeps the simulated network
+	lossy := New(server.URL, 5*time.Second, WithSimulatedNetwork(0, 1)).WithTimeout(time.Minute)
+	if _, err := lossy.Get(context.Background(), "/health"); !errors.Is(err, ErrSimulatedLoss) {
+		t.Errorf("expected ErrSimulatedLoss, got %v", err)
+	}
+	if n := atomic.LoadInt64(&hits); n != 1 {
+		t.Errorf("expected dropped requests not to reach the server, got %d requests", n)
+	}
+
+	if _, ok := New(server.URL, time.Second).httpClient.Transport.(*http.Transport); !ok {
+		t.Error("expected the plain transport without a simulated network")
+	}
+}
@@ -0,0 +1,433 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile holds flag values loaded with --config. Keys match the flag names
+// with dashes replaced by underscores. Zero values mean "not set".
+type ConfigFile struct {
+	URL      string `yaml:"url" toml:"url"`
+	User     string `yaml:"user" toml:"user"`
+	Pass     string `yaml:"pass" toml:"pass"`
+	Full     bool   `yaml:"full" toml:"full"`
+	Frontend bool   `yaml:"frontend" toml:"frontend"`
+	Verbose  bool   `yaml:"verbose" toml:"verbose"`
+	Silent   bool   `yaml:"silent" toml:"silent"`
+
+	PassFile  string `yaml:"pass_file" toml:"pass_file"`
+	PassCmd   string `yaml:"pass_cmd" toml:"pass_cmd"`
+	TokenFile string `yaml:"token_file" toml:"token_file"`
+
+	JSON       string `yaml:"json" toml:"json"`
+	JSONAppend bool   `yaml:"json_append" toml:"json_append"`
+	Markdown   string `yaml:"markdown" toml:"markdown"`
+	CSV        string `yaml:"csv" toml:"csv"`
+	HTML       string `yaml:"html" toml:"html"`
+	JUnit      string `yaml:"junit" toml:"junit"`
+	Format     string `yaml:"format" toml:"format"`
+	OutputDir  string `yaml:"output_dir" toml:"output_dir"`
+
+	MaxOutputFiles int    `yaml:"max_output_files" toml:"max_output_files"`
+	OutputPrefix   string `yaml:"output_prefix" toml:"output_prefix"`
+
+	AutoCompare      bool `yaml:"auto_compare" toml:"auto_compare"`
+	AutoCompareLimit int  `yaml:"auto_compare_limit" toml:"auto_compare_limit"`
+
+	Concurrent       int    `yaml:"concurrent" toml:"concurrent"`
+	Duration         string `yaml:"duration" toml:"duration"`
+	Timeout          string `yaml:"timeout" toml:"timeout"`
+	MaxResponseSize  int64  `yaml:"max_response_size" toml:"max_response_size"`
+	BenchmarkRecords int    `yaml:"benchmark_records" toml:"benchmark_records"`
+	EndpointStrategy string `yaml:"endpoint_strategy" toml:"endpoint_strategy"`
+	EndpointsFile    string `yaml:"endpoints_file" toml:"endpoints_file"`
+	LoadEndpoints    string `yaml:"load_endpoints" toml:"load_endpoints"`
+	StressEndpoint   string `yaml:"stress_endpoint" toml:"stress_endpoint"`
+	LeakDetect       bool   `yaml:"leak_detect" toml:"leak_detect"`
+	AbortOnThreshold bool   `yaml:"abort_on_threshold" toml:"abort_on_threshold"`
+	SLATargets       string `yaml:"sla_targets" toml:"sla_targets"`
+	Percentiles      string `yaml:"percentiles" toml:"percentiles"`
+	Repeat           int    `yaml:"repeat" toml:"repeat"`
+	AssertOverall    string `yaml:"assert_overall" toml:"assert_overall"`
+	Anonymize        bool   `yaml:"anonymize" toml:"anonymize"`
+	RequestIDHeader  string `yaml:"request_id_header" toml:"request_id_header"`
+	TraceHeader      string `yaml:"trace_header" toml:"trace_header"`
+	OTelEndpoint     string `yaml:"otel_endpoint" toml:"otel_endpoint"`
+	PrometheusPort   int    `yaml:"prometheus_port" toml:"prometheus_port"`
+	UserAgent        string `yaml:"user_agent" toml:"user_agent"`
+	AuditLog         string `yaml:"audit_log" toml:"audit_log"`
+
+	BenchmarkConcurrent bool   `yaml:"benchmark_concurrent" toml:"benchmark_concurrent"`
+	BenchmarkTimeout    string `yaml:"benchmark_timeout" toml:"benchmark_timeout"`
+
+	// A pointer, since false must be distinguishable from not set
+	IncludeUserAgentVersion *bool `yaml:"include_user_agent_version" toml:"include_user_agent_version"`
+
+	SimulateLatency  string  `yaml:"simulate_latency" toml:"simulate_latency"`
+	SimulateLossRate float64 `yaml:"simulate_loss_rate" toml:"simulate_loss_rate"`
+
+	HistogramBuckets int     `yaml:"histogram_buckets" toml:"histogram_buckets"`
+	MaxRPS           float64 `yaml:"max_rps" toml:"max_rps"`
+	Warmup           string  `yaml:"warmup" toml:"warmup"`
+
+	StepLoad     bool   `yaml:"step_load" toml:"step_load"`
+	StepInterval string `yaml:"step_interval" toml:"step_interval"`
+	StepStart    int    `yaml:"step_start" toml:"step_start"`
+
+	TargetRPS float64 `yaml:"target_rps" toml:"target_rps"`
+
+	ThinkTime       string  `yaml:"think_time" toml:"think_time"`
+	ThinkTimeJitter float64 `yaml:"think_time_jitter" toml:"think_time_jitter"`
+
+	MaxIdleConnsPerHost int    `yaml:"max_idle_conns_per_host" toml:"max_idle_conns_per_host"`
+	WaitHealthy         bool   `yaml:"wait_healthy" toml:"wait_healthy"`
+	WaitTimeout         string `yaml:"wait_timeout" toml:"wait_timeout"`
+	K8sReadinessProbe   bool   `yaml:"k8s_readiness_probe" toml:"k8s_readiness_probe"`
+	GRPCHealth          bool   `yaml:"grpc_health" toml:"grpc_health"`
+	GRPCAddr            string `yaml:"grpc_addr" toml:"grpc_addr"`
+	PreferIPv4          bool   `yaml:"prefer_ipv4" toml:"prefer_ipv4"`
+	PreferIPv6          bool   `yaml:"prefer_ipv6" toml:"prefer_ipv6"`
+	ProbeKeepAlive      bool   `yaml:"probe_keepalive" toml:"probe_keepalive"`
+	Traceroute          bool   `yaml:"traceroute" toml:"traceroute"`
+	ProbeMTU            bool   `yaml:"probe_mtu" toml:"probe_mtu"`
+	ProbeHTTP3          bool   `yaml:"probe_http3" toml:"probe_http3"`
+	DNSResolver         string `yaml:"dns_resolver" toml:"dns_resolver"`
+	DoHURL              string `yaml:"doh_url" toml:"doh_url"`
+	DNSCache            bool   `yaml:"dns_cache" toml:"dns_cache"`
+	BindAddr            string `yaml:"bind_addr" toml:"bind_addr"`
+	UnixSocket          string `yaml:"unix_socket" toml:"unix_socket"`
+	AllowHTTPSDowngrade bool   `yaml:"allow_https_downgrade" toml:"allow_https_downgrade"`
+	HTTPOnly            bool   `yaml:"http_only" toml:"http_only"`
+	ColdStart           bool   `yaml:"cold_start" toml:"cold_start"`
+	SSEPath             string `yaml:"sse_path" toml:"sse_path"`
+	SSEEvents           int    `yaml:"sse_events" toml:"sse_events"`
+	EndpointSamples     int    `yaml:"endpoint_samples" toml:"endpoint_samples"`
+	EndpointOrder       string `yaml:"endpoint_order" toml:"endpoint_order"`
+	Seed                int64  `yaml:"seed" toml:"seed"`
+	BaselineJSON        string `yaml:"baseline_json" toml:"baseline_json"`
+	PushgatewayURL      string `yaml:"pushgateway_url" toml:"pushgateway_url"`
+	PushgatewayJob      string `yaml:"pushgateway_job" toml:"pushgateway_job"`
+
+	ElasticsearchURL      string `yaml:"elasticsearch_url" toml:"elasticsearch_url"`
+	ElasticsearchIndex    string `yaml:"elasticsearch_index" toml:"elasticsearch_index"`
+	ElasticsearchUsername string `yaml:"elasticsearch_username" toml:"elasticsearch_username"`
+	ElasticsearchPassword string `yaml:"elasticsearch_password" toml:"elasticsearch_password"`
+
+	ThresholdFile      string  `yaml:"threshold_file" toml:"threshold_file"`
+	ThresholdP95       float64 `yaml:"threshold_p95" toml:"threshold_p95"`
+	ThresholdP99       float64 `yaml:"threshold_p99" toml:"threshold_p99"`
+	ThresholdErrorRate float64 `yaml:"threshold_error_rate" toml:"threshold_error_rate"`
+	ThresholdRPSMin    float64 `yaml:"threshold_rps_min" toml:"threshold_rps_min"`
+	ThresholdDBMax     float64 `yaml:"threshold_db_max" toml:"threshold_db_max"`
+	ThresholdSerialMax float64 `yaml:"threshold_serial_max" toml:"threshold_serial_max"`
+}
+
+// LoadConfig reads a YAML (.yaml, .yml) or TOML (.toml) config file,
+// choosing the format from the file extension
+func LoadConfig(path string) (*ConfigFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	var cfg ConfigFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		// An empty file decodes to io.EOF and leaves every value unset
+		if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), &cfg)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("parse %s: unknown key %q", path, undecoded[0].String())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q (use .yaml, .yml, or .toml)", ext)
+	}
+
+	return &cfg, nil
+}
+
+// FlagValues returns the set values keyed by flag name, in a form accepted by
+// the CLI's flag parser
+func (cfg *ConfigFile) FlagValues() map[string]string {
+	values := make(map[string]string)
+	str := func(name, v string) {
+		if v != "" {
+			values[name] = v
+		}
+	}
+	flag := func(name string, v bool) {
+		if v {
+			values[name] = "true"
+		}
+	}
+	num := func(name string, v float64) {
+		if v != 0 {
+			values[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+
+	str("url", cfg.URL)
+	str("user", cfg.User)
+	str("pass", cfg.Pass)
+	str("pass-file", cfg.PassFile)
+	str("pass-cmd", cfg.PassCmd)
+	str("token-file", cfg.TokenFile)
+	flag("full", cfg.Full)
+	flag("frontend", cfg.Frontend)
+	flag("verbose", cfg.Verbose)
+	flag("silent", cfg.Silent)
+
+	str("json", cfg.JSON)
+	flag("json-append", cfg.JSONAppend)
+	str("markdown", cfg.Markdown)
+	str("csv", cfg.CSV)
+	str("html", cfg.HTML)
+	str("junit", cfg.JUnit)
+	str("format", cfg.Format)
+	str("output-dir", cfg.OutputDir)
+	num("max-output-files", float64(cfg.MaxOutputFiles))
+	str("output-prefix", cfg.OutputPrefix)
+	flag("auto-compare", cfg.AutoCompare)
+	num("auto-compare-limit", float64(cfg.AutoCompareLimit))
+
+	num("concurrent", float64(cfg.Concurrent))
+	str("duration", cfg.Duration)
+	str("timeout", cfg.Timeout)
+	num("max-response-size", float64(cfg.MaxResponseSize))
+	num("max-idle-conns-per-host", float64(cfg.MaxIdleConnsPerHost))
+	str("simulate-latency", cfg.SimulateLatency)
+	num("simulate-loss-rate", cfg.SimulateLossRate)
+	num("benchmark-records", float64(cfg.BenchmarkRecords))
+	flag("benchmark-concurrent", cfg.BenchmarkConcurrent)
+	str("benchmark-timeout", cfg.BenchmarkTimeout)
+	str("endpoint-strategy", cfg.EndpointStrategy)
+	str("endpoints-file", cfg.EndpointsFile)
+	str("load-endpoints", cfg.LoadEndpoints)
+	str("stress-endpoint", cfg.StressEndpoint)
+	flag("leak-detect", cfg.LeakDetect)
+	flag("abort-on-threshold", cfg.AbortOnThreshold)
+	str("sla-targets", cfg.SLATargets)
+	str("percentiles", cfg.Percentiles)
+	num("histogram-buckets", float64(cfg.HistogramBuckets))
+	num("max-rps", cfg.MaxRPS)
+	str("warmup", cfg.Warmup)
+	flag("step-load", cfg.StepLoad)
+	str("step-interval", cfg.StepInterval)
+	num("step-start", float64(cfg.StepStart))
+	num("target-rps", cfg.TargetRPS)
+	str("think-time", cfg.ThinkTime)
+	num("think-time-jitter", cfg.ThinkTimeJitter)
+	num("repeat", float64(cfg.Repeat))
+	str("assert-overall", cfg.AssertOverall)
+	flag("anonymize", cfg.Anonymize)
+	str("request-id-header", cfg.RequestIDHeader)
+	str("trace-header", cfg.TraceHeader)
+	str("otel-endpoint", cfg.OTelEndpoint)
+	num("prometheus-port", float64(cfg.PrometheusPort))
+	str("user-agent", cfg.UserAgent)
+	if cfg.IncludeUserAgentVersion != nil {
+		values["include-user-agent-version"] = strconv.FormatBool(*cfg.IncludeUserAgentVersion)
+	}
+	str("audit-log", cfg.AuditLog)
+
+	flag("wait-healthy", cfg.WaitHealthy)
+	str("wait-timeout", cfg.WaitTimeout)
+	flag("grpc-health", cfg.GRPCHealth)
+	str("grpc-addr", cfg.GRPCAddr)
+	flag("k8s-readiness-probe", cfg.K8sReadinessProbe)
+	flag("prefer-ipv4", cfg.PreferIPv4)
+	flag("prefer-ipv6", cfg.PreferIPv6)
+	flag("probe-keepalive", cfg.ProbeKeepAlive)
+	flag("traceroute", cfg.Traceroute)
+	flag("probe-mtu", cfg.ProbeMTU)
+	flag("probe-http3", cfg.ProbeHTTP3)
+	str("dns-resolver", cfg.DNSResolver)
+	str("doh-url", cfg.DoHURL)
+	flag("dns-cache", cfg.DNSCache)
+	str("bind-addr", cfg.BindAddr)
+	str("unix-socket", cfg.UnixSocket)
+	flag("allow-https-downgrade", cfg.AllowHTTPSDowngrade)
+	flag("http-only", cfg.HTTPOnly)
+	flag("cold-start", cfg.ColdStart)
+	str("sse-path", cfg.SSEPath)
+	num("sse-events", float64(cfg.SSEEvents))
+	num("endpoint-samples", float64(cfg.EndpointSamples))
+	str("endpoint-order", cfg.EndpointOrder)
+	if cfg.Seed != 0 {
+		// Formatted directly, since large seeds do not survive a float64
+		values["seed"] = strconv.FormatInt(cfg.Seed, 10)
+	}
+	str("baseline-json", cfg.BaselineJSON)
+	str("pushgateway-url", cfg.PushgatewayURL)
+	str("pushgateway-job", cfg.PushgatewayJob)
+	str("elasticsearch-url", cfg.ElasticsearchURL)
+	str("elasticsearch-index", cfg.ElasticsearchIndex)
+	str("elasticsearch-username", cfg.ElasticsearchUsername)
+	str("elasticsearch-password", cfg.ElasticsearchPassword)
+
+	str("threshold-file", cfg.ThresholdFile)
+	num("threshold-p95", cfg.ThresholdP95)
+	num("threshold-p99", cfg.ThresholdP99)
+	num("threshold-error-rate", cfg.ThresholdErrorRate)
+	num("threshold-rps-min", cfg.ThresholdRPSMin)
+	num("threshold-db-max", cfg.ThresholdDBMax)
+	num("threshold-serial-max", cfg.ThresholdSerialMax)
+
+	return values
+}
+
+// configTemplateEntry is one documented key in a generated config template
+type configTemplateEntry struct {
+	key     string
+	value   any // Default value; strings are quoted in the output
+	comment string
+}
+
+// configTemplate lists every ConfigFile key with its default and explanation
+var configTemplate = []configTemplateEntry{
+	{"url", "", "Target ActaLog instance URL (required for benchmarking)"},
+	{"user", "", "Username for authenticated tests"},
+	{"pass", "", "Password for authenticated tests (prefer pass_file or pass_cmd)"},
+	{"pass_file", "", "Read the password from the first line of this file"},
+	{"pass_cmd", "", "Run this shell command and use its output as the password"},
+	{"token_file", "", "Authenticate with the pre-computed JWT in this file instead of logging in"},
+	{"full", false, "Run the full benchmark suite (frontend and load test)"},
+	{"frontend", false, "Include frontend asset benchmarks"},
+	{"verbose", false, "Verbose output"},
+	{"silent", false, "Suppress all output; exit status 1 unless the benchmark passes"},
+	{"json", "", "Directory or file path for the JSON report"},
+	{"json_append", false, "Append to the JSON array in the json file instead of overwriting it"},
+	{"markdown", "", "Directory for the Markdown report"},
+	{"csv", "", "Directory or file path for the CSV report, one row per metric"},
+	{"html", "", "Directory for the self-contained HTML report with charts"},
+	{"junit", "", "Directory or .xml file path for the JUnit XML report used by CI systems"},
+	{"format", "", "Comma-separated output formats written to output_dir (json, markdown, csv, html, junit)"},
+	{"output_dir", ".", "Directory for reports selected with format"},
+	{"max_output_files", 0, "Keep only this many <output_prefix>_*.json reports, deleting the oldest (0 keeps all)"},
+	{"output_prefix", "benchmark", "Start of generated report filenames, e.g. staging for staging_<timestamp>.json"},
+	{"auto_compare", false, "After writing the JSON report to a directory, write a comparison of the latest results"},
+	{"auto_compare_limit", 10, "Most recent JSON reports covered by auto_compare"},
+	{"concurrent", 1, "Concurrent requests for the load test"},
+	{"duration", "10s", "Load test duration"},
+	{"timeout", "30s", "Request timeout"},
+	{"max_response_size", 10485760, "Read at most this many response body bytes per request"},
+	{"max_idle_conns_per_host", 100, "Idle connections kept for reuse; below concurrent, the load test dials new ones"},
+	{"simulate_latency", "", "Delay added before every HTTP request, e.g. \"200ms\", to simulate a slow network"},
+	{"simulate_loss_rate", 0.0, "Fraction of HTTP requests (0 to 1) failed with a simulated connection error"},
+	{"benchmark_records", 1000, "Records for the server-side benchmark (max 500000)"},
+	{"benchmark_concurrent", false, "Include concurrent operations in the server-side benchmark"},
+	{"benchmark_timeout", "", "Request timeout for the server-side benchmark (empty uses timeout)"},
+	{"endpoint_strategy", "round-robin", "Load test endpoint rotation: round-robin, random, or weighted"},
+	{"endpoints_file", "", "YAML or JSON file of endpoints, or one \"path [weight]\" or JSON object per line"},
+	{"load_endpoints", "", "Load test endpoints as comma-separated path[:weight] entries, e.g. \"/api/workouts:3,/health:1\""},
+	{"stress_endpoint", "", "Run the load test against only this path instead of /health"},
+	{"leak_detect", false, "Split the load test into 10 windows and flag a steady RPS decline"},
+	{"abort_on_threshold", false, "Stop the load test early once p95 latency or error rate crosses its alert threshold"},
+	{"sla_targets", "", "Comma-separated load test latency targets, e.g. \"200ms,500ms\"; breaches of each are counted"},
+	{"percentiles", "50,95,99", "Comma-separated load test latency percentiles to record and report"},
+	{"histogram_buckets", 10, "Equal-width buckets in the load test latency histogram (console, with verbose)"},
+	{"step_load", false, "Ramp the load test up one worker per step_interval from step_start to concurrent"},
+	{"step_interval", "5s", "Time between added workers with step_load; each interval is reported as a step"},
+	{"step_start", 1, "Workers running at the start of a step_load test"},
+	{"warmup", "", "Unmeasured load test run before the measured one, e.g. \"10s\", to warm connections and caches"},
+	{"max_rps", 0.0, "Cap the load test at this many requests per second across all workers (0 for no cap)"},
+	{"target_rps", 0.0, "Open-loop load test: requests arrive at this average rate and queue for the workers (0 for closed loop)"},
+	{"think_time", "", "Pause each load test worker this long after a response, e.g. \"500ms\", like a user reading the page"},
+	{"think_time_jitter", 0.0, "Lengthen each think_time pause by a random 0 to this percentage"},
+	{"repeat", 1, "Run the benchmark suite this many times and report the averaged result"},
+	{"assert_overall", "", "Exit 0 only when the overall status is this (pass, degraded, fail, or any), else 2"},
+	{"anonymize", false, "Redact the target URL, IP addresses, and endpoint paths from every report"},
+	{"request_id_header", "", "Send a unique UUID per request in this header (e.g. X-Request-ID)"},
+	{"trace_header", "traceparent", "Send a W3C traceparent value with the run's trace ID in this header"},
+	{"otel_endpoint", "", "Send OpenTelemetry traces of the run to this OTLP HTTP receiver (e.g. http://localhost:4318)"},
+	{"prometheus_port", 0, "Serve each run's metrics on /metrics at this port for Prometheus to scrape"},
+	{"user_agent", "", "User-Agent header for every request (default actalog-bench/<version>)"},
+	{"include_user_agent_version", true, "Append actalog-bench/<version> to a custom user_agent"},
+	{"audit_log", "", "Append every HTTP request to this file as JSON Lines, with credentials redacted"},
+	{"wait_healthy", false, "Poll /health until healthy before benchmarking"},
+	{"wait_timeout", "2m", "Maximum time to wait with wait_healthy"},
+	{"k8s_readiness_probe", false, "Only wait for /health, print READY or NOT_READY, and exit"},
+	{"grpc_health", false, "Also call the gRPC health service at grpc_addr"},
+	{"grpc_addr", "", "host:port of the gRPC health service (plaintext)"},
+	{"prefer_ipv4", false, "Connect over IPv4 for all benchmark phases"},
+	{"prefer_ipv6", false, "Connect over IPv6 for all benchmark phases"},
+	{"probe_keepalive", false, "Measure HTTP keep-alive connection reuse"},
+	{"traceroute", false, "Count network hops to the server"},
+	{"probe_mtu", false, "Estimate the path MTU to the server"},
+	{"probe_http3", false, "Also time a request over HTTP/3 (QUIC) to an https target"},
+	{"dns_resolver", "", "Resolve the target with this DNS server (e.g. 8.8.8.8:53)"},
+	{"doh_url", "", "Resolve the target for the connectivity probe with this DNS-over-HTTPS endpoint"},
+	{"dns_cache", false, "Resolve the target once per load test duration instead of for every new connection"},
+	{"bind_addr", "", "Make every benchmark connection from this local IP address"},
+	{"unix_socket", "", "Connect through this Unix domain socket instead of TCP"},
+	{"allow_https_downgrade", false, "Follow redirects from HTTPS to plain HTTP instead of failing the request"},
+	{"http_only", false, "Skip TLS timing for a plain http:// target (recorded as -1)"},
+	{"cold_start", false, "Also time each public GET endpoint over a fresh TCP connection"},
+	{"sse_path", "", "Measure time to first event and event intervals on this server-sent events path"},
+	{"sse_events", 5, "Events to read from sse_path"},
+	{"endpoint_samples", 1, "Request each GET endpoint this many times; 20 or more adds p50/p95/p99"},
+	{"endpoint_order", "default", "Endpoint benchmark order: default, alphabetical, random, or slowest-first"},
+	{"seed", 0, "Shuffle seed for endpoint_order random (0 picks one)"},
+	{"baseline_json", "", "Earlier JSON result that endpoint_order slowest-first sorts by"},
+	{"pushgateway_url", "", "Push metrics to a Prometheus Pushgateway after the run"},
+	{"pushgateway_job", "actalog_bench", "Job label for metrics pushed to the Pushgateway"},
+	{"elasticsearch_url", "", "Index the result as a document in Elasticsearch after the run"},
+	{"elasticsearch_index", "actalog-bench", "Elasticsearch index for results"},
+	{"elasticsearch_username", "", "Username for Elasticsearch basic auth"},
+	{"elasticsearch_password", "", "Password for Elasticsearch basic auth"},
+	{"threshold_file", "", "Comparison alert thresholds from a JSON or YAML file; threshold_* values override it"},
+	{"threshold_p95", 500, "Comparison alert: p95 latency above this (ms)"},
+	{"threshold_p99", 1000, "Comparison alert: p99 latency above this (ms)"},
+	{"threshold_error_rate", 1.0, "Comparison alert: error rate above this (%)"},
+	{"threshold_rps_min", 10, "Comparison alert: RPS below this"},
+	{"threshold_db_max", 50, "Comparison alert: server-side database operation above this (ms, 0 disables)"},
+	{"threshold_serial_max", 1, "Comparison alert: server-side serialization operation above this (ms, 0 disables)"},
+}
+
+// GenerateConfigTemplate returns a commented config file in the given format
+// ("yaml" or "toml") with every key at its default value
+func GenerateConfigTemplate(format string) (string, error) {
+	sep := ""
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		sep = ": "
+	case "toml":
+		sep = " = "
+	default:
+		return "", fmt.Errorf("unsupported config format %q (use yaml or toml)", format)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# actalog-bench configuration\n")
+	sb.WriteString("# Load with: actalog-bench --config <file>\n")
+	sb.WriteString("# Command-line flags override values in this file.\n")
+	for _, e := range configTemplate {
+		value := fmt.Sprint(e.value)
+		switch v := e.value.(type) {
+		case string:
+			value = strconv.Quote(v)
+		case float64:
+			value = strconv.FormatFloat(v, 'f', 1, 64)
+		}
+		sb.WriteString(fmt.Sprintf("\n# %s\n%s%s%s\n", e.comment, e.key, sep, value))
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfig_YAML(t *testing.T) {
+	path := writeConfig(t, "bench.yaml", `
+url: https://example.com
+full: true
+concurrent: 10
+duration: 30s
+threshold_p95: 250
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.URL != "https://example.com" || !cfg.Full || cfg.Concurrent != 10 || cfg.Duration != "30s" || cfg.ThresholdP95 != 250 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadConfig_TOML(t *testing.T) {
+	path := writeConfig(t, "bench.toml", `
+url = "https://example.com"
+frontend = true
+timeout = "1m"
+threshold_error_rate = 0.5
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.URL != "https://example.com" || !cfg.Frontend || cfg.Timeout != "1m" || cfg.ThresholdErrorRate != 0.5 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"unknown extension", "bench.ini", "url=x", "unsupported config file extension"},
+		{"unknown yaml key", "bench.yml", "bogus: 1\n", "bogus"},
+		{"unknown toml key", "bench.toml", "bogus = 1\n", "unknown key \"bogus\""},
+		{"bad toml", "bench.toml", "url = \n", "parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestLoadConfig_EmptyYAML(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, "empty.yaml", ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.FlagValues()) != 0 {
+		t.Errorf("expected no flag values, got %v", cfg.FlagValues())
+	}
+}
+
+func TestConfigFile_FlagValues(t *testing.T) {
+	cfg := &ConfigFile{
+		URL:          "https://example.com",
+		Full:         true,
+		Concurrent:   20,
+		OutputDir:    "./out",
+		ThresholdP95: 212.5,
+	}
+
+	values := cfg.FlagValues()
+	expected := map[string]string{
+		"url":           "https://example.com",
+		"full":          "true",
+		"concurrent":    "20",
+		"output-dir":    "./out",
+		"threshold-p95": "212.5",
+	}
+	if len(values) != len(expected) {
+		t.Errorf("expected %d values, got %d: %v", len(expected), len(values), values)
+	}
+	for name, want := range expected {
+		if values[name] != want {
+			t.Errorf("%s: expected %q, got %q", name, want, values[name])
+		}
+	}
+}
+
+func TestConfigFile_FlagValues_IncludeUserAgentVersion(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, "config.yaml", "user_agent: LoadProbe/2.1\ninclude_user_agent_version: false\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	values := cfg.FlagValues()
+	if values["user-agent"] != "LoadProbe/2.1" {
+		t.Errorf("expected user-agent LoadProbe/2.1, got %q", values["user-agent"])
+	}
+	// An explicit false must reach the flag, whose default is true
+	if values["include-user-agent-version"] != "false" {
+		t.Errorf("expected include-user-agent-version false, got %q", values["include-user-agent-version"])
+	}
+
+	if _, ok := (&ConfigFile{}).FlagValues()["include-user-agent-version"]; ok {
+		t.Error("expected include-user-agent-version unset when the key is missing")
+	}
+}
+
+func TestGenerateConfigTemplate_RoundTrip(t *testing.T) {
+	for _, format := range []string{"yaml", "toml"} {
+		t.Run(format, func(t *testing.T) {
+			template, err := GenerateConfigTemplate(format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(template, "# Request timeout\n") {
+				t.Error("expected commented explanations")
+			}
+
+			cfg, err := LoadConfig(writeConfig(t, "template."+format, template))
+			if err != nil {
+				t.Fatalf("generated template does not parse: %v", err)
+			}
+			if cfg.Timeout != "30s" || cfg.BenchmarkRecords != 1000 || cfg.ThresholdErrorRate != 1.0 || cfg.PushgatewayJob != "actalog_bench" {
+				t.Errorf("expected defaults from template, got %+v", cfg)
+			}
+		})
+	}
+
+	if _, err := GenerateConfigTemplate("ini"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestConfigTemplate_CoversConfigFile(t *testing.T) {
+	keys := make(map[string]bool)
+	for _, e := range configTemplate {
+		keys[e.key] = true
+	}
+
+	typ := reflect.TypeOf(ConfigFile{})
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if key := field.Tag.Get("toml"); !keys[key] {
+			t.Errorf("config template is missing key %q for field %s", key, field.Name)
+		}
+		if field.Tag.Get("yaml") != field.Tag.Get("toml") {
+			t.Errorf("field %s has different yaml and toml keys", field.Name)
+		}
+	}
+}
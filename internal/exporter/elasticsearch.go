@@ -0,0 +1,92 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// Elasticsearch responses that callers may want to handle differently from other failures
+var (
+	ErrElasticsearchUnauthorized = errors.New("elasticsearch rejected the credentials")
+	ErrElasticsearchConflict     = errors.New("result is already indexed")
+)
+
+// ElasticsearchOption configures optional IndexElasticsearch behavior
+type ElasticsearchOption func(*elasticsearchOptions)
+
+type elasticsearchOptions struct {
+	username string
+	password string
+}
+
+// WithBasicAuth authenticates to Elasticsearch with HTTP basic auth
+func WithBasicAuth(username, password string) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// IndexElasticsearch stores the benchmark result as a document in an
+// Elasticsearch index. The document ID is <target>_<timestamp_unix>, so indexing
+// the same result twice returns ErrElasticsearchConflict instead of a duplicate.
+func IndexElasticsearch(esURL, index string, result *internal.BenchmarkResult, opts ...ElasticsearchOption) error {
+	if index == "" {
+		return fmt.Errorf("index name is required")
+	}
+	var o elasticsearchOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal result: %w", err)
+	}
+
+	// op_type=create makes Elasticsearch reject an existing ID with 409
+	endpoint := fmt.Sprintf("%s/%s/_doc/%s?op_type=create",
+		strings.TrimSuffix(esURL, "/"), url.PathEscape(index), url.PathEscape(ElasticsearchDocumentID(result)))
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create index request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.username != "" || o.password != "" {
+		req.SetBasicAuth(o.username, o.password)
+	}
+
+	httpClient := &http.Client{Timeout: pushTimeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("index result: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrElasticsearchUnauthorized
+	case resp.StatusCode == http.StatusConflict:
+		return ErrElasticsearchConflict
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("elasticsearch returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// ElasticsearchDocumentID returns the document ID used for a result: the
+// target and the Unix time the benchmark started
+func ElasticsearchDocumentID(result *internal.BenchmarkResult) string {
+	return fmt.Sprintf("%s_%d", result.Target, result.Timestamp.Unix())
+}
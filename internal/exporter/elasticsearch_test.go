@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIndexElasticsearch(t *testing.T) {
+	var gotMethod, gotPath, gotOpType, gotUser, gotPass string
+	var gotDoc map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.EscapedPath()
+		gotOpType = r.URL.Query().Get("op_type")
+		gotUser, gotPass, _ = r.BasicAuth()
+		json.NewDecoder(r.Body).Decode(&gotDoc)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	err := IndexElasticsearch(server.URL+"/", "bench-results", sampleResult(), WithBasicAuth("elastic", "secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("expected POST, got %s", gotMethod)
+	}
+	wantPath := "/bench-results/_doc/https:%2F%2Fexample.com_1767323045"
+	if gotPath != wantPath {
+		t.Errorf("expected path %s, got %s", wantPath, gotPath)
+	}
+	if gotOpType != "create" {
+		t.Errorf("expected op_type=create, got %q", gotOpType)
+	}
+	if gotUser != "elastic" || gotPass != "secret" {
+		t.Errorf("expected basic auth elastic/secret, got %s/%s", gotUser, gotPass)
+	}
+	if gotDoc["target"] != "https://example.com" {
+		t.Errorf("expected document target https://example.com, got %v", gotDoc["target"])
+	}
+}
+
+func TestIndexElasticsearch_NoAuth(t *testing.T) {
+	var hasAuth bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _, hasAuth = r.BasicAuth()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	if err := IndexElasticsearch(server.URL, "bench", sampleResult(), WithBasicAuth("", "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasAuth {
+		t.Error("expected no Authorization header without credentials")
+	}
+}
+
+func TestIndexElasticsearch_Status(t *testing.T) {
+	tests := []struct {
+		status  int
+		wantErr error
+	}{
+		{http.StatusUnauthorized, ErrElasticsearchUnauthorized},
+		{http.StatusConflict, ErrElasticsearchConflict},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+		err := IndexElasticsearch(server.URL, "bench", sampleResult())
+		server.Close()
+
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("status %d: expected %v, got %v", tt.status, tt.wantErr, err)
+		}
+	}
+}
+
+func TestIndexElasticsearch_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "mapper_parsing_exception", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := IndexElasticsearch(server.URL, "bench", sampleResult())
+	if err == nil {
+		t.Fatal("expected error for 400 response")
+	}
+	if !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "mapper_parsing_exception") {
+		t.Errorf("expected status code and body in error, got %v", err)
+	}
+}
+
+func TestIndexElasticsearch_EmptyIndex(t *testing.T) {
+	if err := IndexElasticsearch("http://localhost", "", sampleResult()); err == nil {
+		t.Error("expected error for empty index name")
+	}
+}
@@ -0,0 +1,220 @@
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// pushTimeout bounds how long a push to the Pushgateway may take
+const pushTimeout = 30 * time.Second
+
+// PushPrometheus sends the benchmark metrics to a Prometheus Pushgateway,
+// replacing any metrics previously pushed for the same job
+func PushPrometheus(gatewayURL, jobLabel string, result *internal.BenchmarkResult) error {
+	if jobLabel == "" {
+		return fmt.Errorf("job label is required")
+	}
+
+	endpoint := strings.TrimSuffix(gatewayURL, "/") + "/metrics/job/" + url.PathEscape(jobLabel)
+	body := FormatPrometheus(result)
+
+	req, err := http.NewRequest(http.MethodPut, endpoint, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	httpClient := &http.Client{Timeout: pushTimeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("push metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("pushgateway returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// FormatPrometheus renders the benchmark result in the Prometheus text exposition format
+func FormatPrometheus(result *internal.BenchmarkResult) string {
+	w := &promWriter{}
+
+	w.gauge("actalog_bench_info", "Benchmark run metadata", []sample{
+		{labels: [][2]string{{"target", result.Target}, {"version", result.Version}, {"overall", result.Overall}}, value: 1},
+	})
+	w.gauge("actalog_bench_overall_pass", "1 if the overall benchmark status is pass", []sample{
+		{value: boolValue(result.Overall == "pass")},
+	})
+	w.gauge("actalog_bench_timestamp_seconds", "Unix time the benchmark started", []sample{
+		{value: float64(result.Timestamp.Unix())},
+	})
+
+	if conn := result.Connectivity; conn != nil {
+		w.gauge("actalog_bench_connectivity_up", "1 if a connection to the target was established", []sample{{value: boolValue(conn.Connected)}})
+		w.gauge("actalog_bench_connectivity_dns_ms", "DNS resolution time in milliseconds", []sample{{value: conn.DNSMs}})
+		w.gauge("actalog_bench_connectivity_tcp_ms", "TCP connect time in milliseconds", []sample{{value: conn.TCPMs}})
+		if conn.TLSMs > 0 {
+			w.gauge("actalog_bench_connectivity_tls_ms", "TLS handshake time in milliseconds", []sample{{value: conn.TLSMs}})
+		}
+		w.gauge("actalog_bench_connectivity_total_ms", "Total connection setup time in milliseconds", []sample{{value: conn.TotalMs}})
+	}
+
+	if health := result.Health; health != nil {
+		w.gauge("actalog_bench_health_up", "1 if the health endpoint reported healthy", []sample{{value: boolValue(health.Status == "healthy")}})
+		w.gauge("actalog_bench_health_response_ms", "Health endpoint response time in milliseconds", []sample{{value: health.ResponseMs}})
+	}
+
+	if len(result.Endpoints) > 0 {
+		var responses, successes []sample
+		for _, ep := range result.Endpoints {
+			labels := [][2]string{{"path", ep.Path}}
+			responses = append(responses, sample{labels: labels, value: ep.ResponseMs})
+			successes = append(successes, sample{labels: labels, value: boolValue(ep.Success)})
+		}
+		w.gauge("actalog_bench_endpoint_response_ms", "API endpoint response time in milliseconds", responses)
+		w.gauge("actalog_bench_endpoint_success", "1 if the API endpoint returned a 2xx response", successes)
+	}
+
+	if fe := result.Frontend; fe != nil {
+		w.gauge("actalog_bench_frontend_total_size_kb", "Total frontend asset size in kilobytes", []sample{{value: fe.TotalSizeKB}})
+		w.gauge("actalog_bench_frontend_total_time_ms", "Total frontend asset load time in milliseconds", []sample{{value: fe.TotalTimeMs}})
+	}
+
+	if lt := result.LoadTest; lt != nil {
+		w.gauge("actalog_bench_load_concurrent", "Load test concurrency", []sample{{value: float64(lt.Concurrent)}})
+		w.gauge("actalog_bench_load_rps", "Load test requests per second", []sample{{value: lt.RPS}})
+		w.gauge("actalog_bench_load_requests", "Load test request counts by outcome", []sample{
+			{labels: [][2]string{{"status", "success"}}, value: float64(lt.Successful)},
+			{labels: [][2]string{{"status", "failure"}}, value: float64(lt.Failed)},
+		})
+		w.gauge("actalog_bench_load_latency_ms", "Load test latency percentiles in milliseconds", latencyQuantiles(lt))
+		w.gauge("actalog_bench_load_latency_min_ms", "Load test minimum latency in milliseconds", []sample{{value: lt.MinLatencyMs}})
+		w.gauge("actalog_bench_load_latency_max_ms", "Load test maximum latency in milliseconds", []sample{{value: lt.MaxLatencyMs}})
+		w.gauge("actalog_bench_load_latency_avg_ms", "Load test average latency in milliseconds", []sample{{value: lt.AvgLatencyMs}})
+	}
+
+	if api := result.BenchmarkAPI; api != nil && api.Response != nil {
+		w.gauge("actalog_bench_server_duration_ms", "Server-side benchmark duration in milliseconds", []sample{{value: api.Response.TotalDurationMs}})
+
+		var ops []sample
+		for _, group := range []struct {
+			name string
+			ops  map[string]*internal.OperationResult
+		}{
+			{"database", api.Response.Database},
+			{"serialization", api.Response.Serialization},
+			{"business_logic", api.Response.BusinessLogic},
+			{"concurrent", api.Response.Concurrent},
+		} {
+			names := make([]string, 0, len(group.ops))
+			for name := range group.ops {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if op := group.ops[name]; op != nil {
+					ops = append(ops, sample{labels: [][2]string{{"group", group.name}, {"operation", name}}, value: op.DurationMs})
+				}
+			}
+		}
+		if len(ops) > 0 {
+			w.gauge("actalog_bench_server_operation_ms", "Server-side benchmark operation duration in milliseconds", ops)
+		}
+	}
+
+	return w.String()
+}
+
+// sample is a single metric value with its labels
+type sample struct {
+	labels [][2]string
+	value  float64
+}
+
+// promWriter accumulates metric families in exposition format
+type promWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *promWriter) gauge(name, help string, samples []sample) {
+	fmt.Fprintf(&w.buf, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(&w.buf, "# TYPE %s gauge\n", name)
+	for _, s := range samples {
+		w.buf.WriteString(name)
+		if len(s.labels) > 0 {
+			w.buf.WriteString("{")
+			for i, l := range s.labels {
+				if i > 0 {
+					w.buf.WriteString(",")
+				}
+				fmt.Fprintf(&w.buf, "%s=\"%s\"", l[0], escapeLabelValue(l[1]))
+			}
+			w.buf.WriteString("}")
+		}
+		fmt.Fprintf(&w.buf, " %g\n", s.value)
+	}
+}
+
+func (w *promWriter) String() string {
+	return w.buf.String()
+}
+
+// escapeLabelValue escapes backslashes, quotes, and newlines per the exposition format
+func escapeLabelValue(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	return strings.ReplaceAll(v, "\n", `\n`)
+}
+
+// latencyQuantiles returns a sample per load test latency percentile, labelled
+// with its quantile: those in Percentiles, or the fixed p50, p95, and p99 of
+// results saved before --percentiles existed
+func latencyQuantiles(lt *internal.LoadTestResult) []sample {
+	if len(lt.Percentiles) == 0 {
+		return []sample{
+			{labels: [][2]string{{"quantile", "0.5"}}, value: lt.LatencyP50Ms},
+			{labels: [][2]string{{"quantile", "0.95"}}, value: lt.LatencyP95Ms},
+			{labels: [][2]string{{"quantile", "0.99"}}, value: lt.LatencyP99Ms},
+		}
+	}
+
+	ranks := make(map[string]float64, len(lt.Percentiles))
+	for label := range lt.Percentiles {
+		if rank, err := strconv.ParseFloat(strings.TrimPrefix(label, "p"), 64); err == nil {
+			ranks[label] = rank
+		}
+	}
+	labels := make([]string, 0, len(ranks))
+	for label := range ranks {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool { return ranks[labels[i]] < ranks[labels[j]] })
+
+	samples := make([]sample, 0, len(labels))
+	for _, label := range labels {
+		// Formatted at 32-bit precision, so p99.9 is 0.999 and not 0.9990000000000001
+		quantile := strconv.FormatFloat(ranks[label]/100, 'f', -1, 32)
+		samples = append(samples, sample{labels: [][2]string{{"quantile", quantile}}, value: lt.Percentiles[label]})
+	}
+	return samples
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
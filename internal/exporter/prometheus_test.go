@@ -0,0 +1,169 @@
+package exporter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func sampleResult() *internal.BenchmarkResult {
+	return &internal.BenchmarkResult{
+		Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Target:    "https://example.com",
+		Version:   "1.2.3",
+		Overall:   "pass",
+		Connectivity: &internal.ConnectivityResult{
+			DNSMs:     1.5,
+			TCPMs:     2.5,
+			TLSMs:     10,
+			TotalMs:   14,
+			Connected: true,
+		},
+		Health: &internal.HealthResult{
+			Status:     "healthy",
+			ResponseMs: 12.5,
+		},
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/workouts", ResponseMs: 40, Success: true},
+			{Path: "/api/movements", ResponseMs: 55, Success: false},
+		},
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:    5,
+			TotalRequests: 100,
+			Successful:    98,
+			Failed:        2,
+			RPS:           42.5,
+			LatencyP50Ms:  10,
+			LatencyP95Ms:  25,
+			LatencyP99Ms:  40,
+		},
+	}
+}
+
+func TestFormatPrometheus(t *testing.T) {
+	out := FormatPrometheus(sampleResult())
+
+	expected := []string{
+		"# TYPE actalog_bench_connectivity_total_ms gauge",
+		"actalog_bench_connectivity_total_ms 14\n",
+		"actalog_bench_connectivity_tls_ms 10\n",
+		"actalog_bench_health_response_ms 12.5\n",
+		"actalog_bench_health_up 1\n",
+		"actalog_bench_load_rps 42.5\n",
+		`actalog_bench_load_latency_ms{quantile="0.95"} 25` + "\n",
+		`actalog_bench_load_latency_ms{quantile="0.99"} 40` + "\n",
+		`actalog_bench_load_requests{status="failure"} 2` + "\n",
+		`actalog_bench_endpoint_response_ms{path="/api/workouts"} 40` + "\n",
+		`actalog_bench_endpoint_success{path="/api/movements"} 0` + "\n",
+		`actalog_bench_info{target="https://example.com",version="1.2.3",overall="pass"} 1` + "\n",
+		"actalog_bench_overall_pass 1\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+
+	if strings.Contains(out, "actalog_bench_frontend") {
+		t.Error("expected no frontend metrics when frontend result is nil")
+	}
+}
+
+func TestFormatPrometheus_Minimal(t *testing.T) {
+	out := FormatPrometheus(&internal.BenchmarkResult{Overall: "fail"})
+
+	if !strings.Contains(out, "actalog_bench_overall_pass 0\n") {
+		t.Error("expected overall_pass to be 0 for failed run")
+	}
+	if strings.Contains(out, "actalog_bench_load_rps") {
+		t.Error("expected no load test metrics when load test result is nil")
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"plain", "plain"},
+		{`say "hi"`, `say \"hi\"`},
+		{`C:\path`, `C:\\path`},
+		{"two\nlines", `two\nlines`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeLabelValue(tt.input); got != tt.expected {
+			t.Errorf("escapeLabelValue(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestPushPrometheus(t *testing.T) {
+	var gotMethod, gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.EscapedPath()
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := PushPrometheus(server.URL+"/", "nightly bench", sampleResult()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotMethod != http.MethodPut {
+		t.Errorf("expected PUT, got %s", gotMethod)
+	}
+	if gotPath != "/metrics/job/nightly%20bench" {
+		t.Errorf("expected path /metrics/job/nightly%%20bench, got %s", gotPath)
+	}
+	if !strings.Contains(gotBody, "actalog_bench_load_rps 42.5") {
+		t.Error("expected pushed body to contain load RPS metric")
+	}
+}
+
+func TestPushPrometheus_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad metrics", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := PushPrometheus(server.URL, "actalog_bench", sampleResult())
+	if err == nil {
+		t.Fatal("expected error for 400 response")
+	}
+	if !strings.Contains(err.Error(), "400") {
+		t.Errorf("expected status code in error, got %v", err)
+	}
+}
+
+func TestPushPrometheus_EmptyJob(t *testing.T) {
+	if err := PushPrometheus("http://localhost", "", sampleResult()); err == nil {
+		t.Error("expected error for empty job label")
+	}
+}
+
+func TestFormatPrometheus_Percentiles(t *testing.T) {
+	result := sampleResult()
+	result.LoadTest.Percentiles = map[string]float64{"p50": 10, "p99.9": 55}
+
+	out := FormatPrometheus(result)
+	for _, want := range []string{
+		`actalog_bench_load_latency_ms{quantile="0.5"} 10` + "\n",
+		`actalog_bench_load_latency_ms{quantile="0.999"} 55` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(out, `quantile="0.95"`) {
+		t.Error("expected only the recorded percentiles")
+	}
+}
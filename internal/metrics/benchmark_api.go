@@ -5,15 +5,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/johnzastrow/actalog-benchmark/internal"
 	"github.com/johnzastrow/actalog-benchmark/internal/client"
 )
 
-// RunBenchmarkAPI calls the /api/benchmark endpoint and returns structured results
-func RunBenchmarkAPI(ctx context.Context, c *client.Client, includeConcurrent bool, recordCount int) *internal.BenchmarkAPIResult {
-	result := &internal.BenchmarkAPIResult{}
+// RunBenchmarkAPI calls the /api/benchmark endpoint and returns structured
+// results. A non-zero timeout replaces the client's request timeout for this
+// call, since large record counts take far longer than other endpoints.
+func RunBenchmarkAPI(ctx context.Context, c *client.Client, includeConcurrent bool, recordCount int, timeout time.Duration) *internal.BenchmarkAPIResult {
+	result := &internal.BenchmarkAPIResult{ConcurrentMode: includeConcurrent}
+
+	if timeout > 0 {
+		c = c.WithTimeout(timeout)
+		benchCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		ctx = benchCtx
+		result.BenchmarkTimeoutSec = timeout.Seconds()
+	}
 
 	// Build URL with query params
 	path := "/api/benchmark"
@@ -70,3 +82,71 @@ func RunBenchmarkAPI(ctx context.Context, c *client.Client, includeConcurrent bo
 
 	return result
 }
+
+// MaxBenchmarkRecords is the largest record count the benchmark endpoint accepts
+const MaxBenchmarkRecords = 500000
+
+// maxRecordsSweepPoints limits a records sweep, since each point is a full server-side benchmark
+const maxRecordsSweepPoints = 50
+
+// ParseRecordsSweep parses a "min,max,step" record count sweep into the counts
+// min, min+step, ... up to and including max
+func ParseRecordsSweep(s string) ([]int, error) {
+	fields := strings.Split(s, ",")
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("invalid records sweep %q: expected min,max,step", s)
+	}
+
+	var values [3]int
+	for i, field := range fields {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid records sweep %q: %q must be a positive integer", s, strings.TrimSpace(field))
+		}
+		values[i] = n
+	}
+
+	lo, hi, step := values[0], values[1], values[2]
+	if hi < lo {
+		return nil, fmt.Errorf("invalid records sweep %q: max %d is below min %d", s, hi, lo)
+	}
+	if hi > MaxBenchmarkRecords {
+		return nil, fmt.Errorf("invalid records sweep %q: max %d exceeds %d", s, hi, MaxBenchmarkRecords)
+	}
+
+	if points := (hi-lo)/step + 1; points > maxRecordsSweepPoints {
+		return nil, fmt.Errorf("invalid records sweep %q: %d record counts exceed the limit of %d; use a larger step", s, points, maxRecordsSweepPoints)
+	}
+
+	var counts []int
+	for n := lo; n <= hi; n += step {
+		counts = append(counts, n)
+	}
+	return counts, nil
+}
+
+// BenchmarkAPISweep runs the server-side benchmark once at each record count and
+// records the server's response, or the error, for each. timeout applies to
+// each run as in RunBenchmarkAPI.
+func BenchmarkAPISweep(ctx context.Context, c *client.Client, includeConcurrent bool, counts []int, timeout time.Duration, onStep func(records int)) []internal.BenchmarkAPISweepPoint {
+	// One client for every point, so connections are reused across the sweep
+	c = c.WithTimeout(timeout)
+	points := make([]internal.BenchmarkAPISweepPoint, 0, len(counts))
+	for _, records := range counts {
+		if ctx.Err() != nil {
+			break
+		}
+		if onStep != nil {
+			onStep(records)
+		}
+
+		r := RunBenchmarkAPI(ctx, c, includeConcurrent, records, timeout)
+		points = append(points, internal.BenchmarkAPISweepPoint{
+			RecordCount:     records,
+			TotalDurationMs: r.TotalDurationMs,
+			Response:        r.Response,
+			Error:           r.Error,
+		})
+	}
+	return points
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+)
+
+func TestParseRecordsSweep(t *testing.T) {
+	counts, err := ParseRecordsSweep("1000, 10000, 3000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []int{1000, 4000, 7000, 10000}
+	if len(counts) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, counts)
+	}
+	for i, want := range expected {
+		if counts[i] != want {
+			t.Errorf("count %d: expected %d, got %d", i, want, counts[i])
+		}
+	}
+
+	// A step that overshoots max stops before it
+	if counts, err := ParseRecordsSweep("100,250,100"); err != nil || len(counts) != 2 {
+		t.Errorf("expected 100 and 200, got %v (err %v)", counts, err)
+	}
+
+	for _, bad := range []string{"", "1000,5000", "1000,5000,0", "5000,1000,100", "a,b,c", "1,600000,100000", "1,1000,1"} {
+		if _, err := ParseRecordsSweep(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestBenchmarkAPISweep(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		records := r.URL.Query().Get("records")
+		if records == "3000" {
+			http.Error(w, "write timeout", http.StatusServiceUnavailable)
+			return
+		}
+		if records == "" {
+			records = "1000"
+		}
+		fmt.Fprintf(w, `{"overall": "pass", "record_count": %s, "total_duration_ms": 12.5}`, records)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 5*time.Second)
+	var visited []int
+	points := BenchmarkAPISweep(context.Background(), c, false, []int{1000, 2000, 3000}, 0, func(records int) {
+		visited = append(visited, records)
+	})
+
+	if len(points) != 3 || len(visited) != 3 {
+		t.Fatalf("expected 3 points and callbacks, got %d and %d", len(points), len(visited))
+	}
+	for i, p := range points[:2] {
+		if p.Response == nil || p.Response.RecordCount != p.RecordCount {
+			t.Errorf("point %d: expected a response for %d records, got %+v", i, p.RecordCount, p.Response)
+		}
+	}
+	if points[2].Response != nil || points[2].Error == "" {
+		t.Errorf("expected the failed point to keep its error, got %+v", points[2])
+	}
+}
+
+func TestRunBenchmarkAPI_ConcurrentMode(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprint(w, `{"overall": "pass", "record_count": 1000, "total_duration_ms": 12.5}`)
+	}))
+	defer server.Close()
+	c := client.New(server.URL, 5*time.Second)
+
+	result := RunBenchmarkAPI(context.Background(), c, true, 5000, 0)
+	if query != "concurrent=true&records=5000" {
+		t.Errorf("expected concurrent=true with the record count, got %q", query)
+	}
+	if !result.Success || !result.ConcurrentMode {
+		t.Errorf("expected a successful concurrent mode result, got %+v", result)
+	}
+
+	result = RunBenchmarkAPI(context.Background(), c, false, 1000, 0)
+	if query != "" || result.ConcurrentMode {
+		t.Errorf("expected no query and no concurrent mode by default, got %q and %t", query, result.ConcurrentMode)
+	}
+}
+
+func TestRunBenchmarkAPI_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, `{"overall": "pass", "record_count": 100000, "total_duration_ms": 180}`)
+	}))
+	defer server.Close()
+	c := client.New(server.URL, 50*time.Millisecond)
+
+	result := RunBenchmarkAPI(context.Background(), c, false, 100000, 5*time.Second)
+	if !result.Success {
+		t.Fatalf("expected the benchmark timeout to outlast the client timeout, got %+v", result)
+	}
+	if result.BenchmarkTimeoutSec != 5 {
+		t.Errorf("expected benchmark_timeout_sec 5, got %v", result.BenchmarkTimeoutSec)
+	}
+
+	result = RunBenchmarkAPI(context.Background(), c, false, 100000, 0)
+	if result.Success || result.BenchmarkTimeoutSec != 0 {
+		t.Errorf("expected the client timeout to apply without a benchmark timeout, got %+v", result)
+	}
+}
@@ -2,85 +2,906 @@ package metrics
 
 import (
 	"context"
-	"io"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/http/httptrace"
+	"net/url"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/johnzastrow/actalog-benchmark/internal"
 	"github.com/johnzastrow/actalog-benchmark/internal/client"
+	"golang.org/x/net/websocket"
 )
 
+// LoadTestOptions configures a load test run
+type LoadTestOptions struct {
+	Concurrent int
+	Duration   time.Duration
+	Selector   EndpointSelector // Chooses the path for each request; nil hits /health
+	Strategy   string           // Name of the selector strategy, recorded in the result
+
+	StressEndpoint string // Sends every request to this path, overriding Selector
+
+	Windows int // Splits the run into this many equal windows with their own RPS and p95; 0 disables
+
+	RPSTimeline bool // Records the requests completed in each second of the run
+
+	// OnTick receives a partial result every second while the test runs, with
+	// DurationSec set to the time elapsed so far; nil disables it
+	OnTick func(*internal.LoadTestResult)
+
+	// Early abort limits, checked every tick; zero disables each check
+	AbortP95Ms        float64 // Stops the test once the p95 latency so far exceeds this
+	AbortErrorRatePct float64 // Stops the test once the error rate exceeds this in two consecutive ticks
+
+	SLATargets []time.Duration // Counts the requests slower than each target; nil disables
+
+	Percentiles []float64 // Latency percentiles recorded in the result; nil records p50, p95, and p99
+
+	HistogramBuckets int // Buckets in the latency histogram; 0 uses DefaultHistogramBuckets
+
+	MaxRPS float64 // Caps the request rate across all workers with a token bucket; 0 disables
+
+	Warmup time.Duration // Sends requests for this long before the measured run and discards them; 0 disables
+
+	// Step load starts StepStart workers and adds one every StepInterval until
+	// Concurrent are running, recording each step's throughput and latency;
+	// a zero StepInterval starts every worker at once
+	StepInterval time.Duration
+	StepStart    int
+
+	// TargetRPS runs an open-loop test: requests arrive at this average rate
+	// as a Poisson process and queue for the workers, whatever the server's
+	// speed. Latency then counts from the arrival, including the wait for a
+	// free worker. 0 runs the usual closed-loop test.
+	TargetRPS float64
+
+	// ThinkTime pauses each worker after a response before its next request,
+	// as a user would between pages, lengthened at random by up to
+	// ThinkTimeJitterPct percent; 0 sends the next request at once
+	ThinkTime          time.Duration
+	ThinkTimeJitterPct float64
+
+	// DNSCacheHits counts the lookups answered by the DNS cache the client
+	// resolves with (client.WithHostLookup), so the test can record its own
+	// hits; nil when the client has no cache
+	DNSCacheHits func() int
+}
+
+// DefaultPercentiles are the latency percentiles used by --percentiles
+const DefaultPercentiles = "50,95,99"
+
+// DefaultHistogramBuckets is the default --histogram-buckets
+const DefaultHistogramBuckets = 10
+
+// loadTestTickInterval is how often OnTick receives a partial result and the
+// early abort limits are checked
+var loadTestTickInterval = time.Second
+
+// loadTestShutdownGrace is how long a load test waits, once it has ended, for
+// workers still inside a request before reporting without them
+var loadTestShutdownGrace = 5 * time.Second
+
+// abortMinSamples is how many latencies must be recorded before the p95 abort
+// limit applies, so a single slow first request cannot end the test
+const abortMinSamples = 20
+
+// abortErrorWindows is how many consecutive ticks must exceed the error rate
+// abort limit, so a brief burst of errors does not end the test
+const abortErrorWindows = 2
+
+// poolSaturationRatio is the share of load test requests that may open a new
+// connection, beyond the one each worker opens for its first request, before
+// the connection pool counts as saturated
+const poolSaturationRatio = 0.1
+
+// ParseSLATargets parses a comma-separated list of positive latency targets
+// such as "200ms,500ms,1s"
+func ParseSLATargets(s string) ([]time.Duration, error) {
+	var targets []time.Duration
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		d, err := time.ParseDuration(field)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid SLA target %q: must be a positive duration such as 200ms", field)
+		}
+		targets = append(targets, d)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no SLA targets given")
+	}
+	return targets, nil
+}
+
+// ParsePercentiles parses a comma-separated list of latency percentiles such
+// as "50,90,99.9", each from 0 to 100, into ascending order. A percentile
+// given twice is an error.
+func ParsePercentiles(s string) ([]float64, error) {
+	var percentiles []float64
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		p, err := strconv.ParseFloat(field, 64)
+		if err != nil || p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid percentile %q: must be a number from 0 to 100", field)
+		}
+		if slices.Contains(percentiles, p) {
+			return nil, fmt.Errorf("percentile %s given more than once", field)
+		}
+		percentiles = append(percentiles, p)
+	}
+	if len(percentiles) == 0 {
+		return nil, fmt.Errorf("no percentiles given")
+	}
+	sort.Float64s(percentiles)
+	return percentiles, nil
+}
+
+// PercentileKey names percentile p in LoadTestResult.Percentiles, e.g. "p50"
+// or "p99.9"
+func PercentileKey(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// maxErrorTimestamps bounds how many failure times a load test keeps; beyond
+// it a uniform random sample of the failures is kept
+const maxErrorTimestamps = 1000
+
+// errorClusterMinSamples is how many failures a load test needs before their
+// timing is tested for clustering
+const errorClusterMinSamples = 10
+
+// ksCriticalValue is the Kolmogorov-Smirnov coefficient for a 5% significance
+// level; the critical distance for n samples is ksCriticalValue / sqrt(n)
+const ksCriticalValue = 1.36
+
 // LoadTest runs a concurrent load test against the target
 func LoadTest(ctx context.Context, c *client.Client, concurrent int, duration time.Duration) *internal.LoadTestResult {
+	return LoadTestWithOptions(ctx, c, LoadTestOptions{
+		Concurrent: concurrent,
+		Duration:   duration,
+	})
+}
+
+// LoadTestWithOptions runs a concurrent load test using the given options
+func LoadTestWithOptions(ctx context.Context, c *client.Client, opts LoadTestOptions) *internal.LoadTestResult {
+	concurrent := opts.Concurrent
+	duration := opts.Duration
+
 	result := &internal.LoadTestResult{
 		Concurrent:  concurrent,
 		DurationSec: duration.Seconds(),
+		TargetRPS:   max(opts.MaxRPS, opts.TargetRPS),
+		WarmupSec:   opts.Warmup.Seconds(),
+
+		ThinkTimeMs:        float64(opts.ThinkTime.Microseconds()) / 1000.0,
+		ThinkTimeJitterPct: opts.ThinkTimeJitterPct,
+	}
+
+	selector := opts.Selector
+	rotation := opts.StressEndpoint == "" && selector != nil
+	if opts.StressEndpoint != "" {
+		result.StressedEndpoint = normalizePath(opts.StressEndpoint)
+		selector = NewRoundRobinSelector([]string{result.StressedEndpoint})
+	} else if selector == nil {
+		selector = NewRoundRobinSelector([]string{"/health"})
+	} else {
+		result.EndpointStrategy = opts.Strategy
+	}
+
+	if opts.Warmup > 0 {
+		warmUp(ctx, c, selector, opts)
+	}
+
+	// Taken after the warmup, so only the measured run's cache hits count
+	var dnsCacheHits int
+	if opts.DNSCacheHits != nil {
+		dnsCacheHits = opts.DNSCacheHits()
 	}
 
 	var (
 		totalRequests int64
 		successful    int64
 		failed        int64
-		latencies     []float64
-		latencyMu     sync.Mutex
+		bytesReceived int64
+		newConns      int64
+		workers       = make([]workerState, concurrent)
+		errorCounts   = make([]int64, len(internal.ErrorTypes)) // Parallel to internal.ErrorTypes
 	)
+	if rotation {
+		for i := range workers {
+			workers[i].distribution = make(map[string]int)
+		}
+	}
 
-	// Create a context that cancels after duration
+	// Create a context that cancels after duration, or sooner if the caller's
+	// is cancelled, e.g. by Ctrl+C
+	parent := ctx
 	ctx, cancel := context.WithTimeout(ctx, duration)
 	defer cancel()
 
+	// Count requests that could not reuse a pooled connection
+	traceCtx := httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if !info.Reused {
+				atomic.AddInt64(&newConns, 1)
+			}
+		},
+	})
+
 	var wg sync.WaitGroup
 	start := time.Now()
 
-	// Start concurrent workers
-	for i := 0; i < concurrent; i++ {
+	// Workers take a token before each request; nil leaves them unthrottled
+	tokens := startTokenBucket(ctx, &wg, concurrent, opts.MaxRPS)
+
+	// In an open-loop test workers take each request's arrival time from the queue
+	var (
+		arrivals      chan time.Time
+		arrived       int64
+		maxQueueDepth int64
+		serviceNanos  int64 // Time spent sending requests, excluding the wait in the queue
+	)
+	if opts.TargetRPS > 0 {
+		arrivals = make(chan time.Time, openLoopQueueSize)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dispatchArrivals(ctx, arrivals, opts.TargetRPS, &arrived, &maxQueueDepth)
+		}()
+	}
+
+	stepLoad := opts.StepInterval > 0
+	runWorker := func(worker *workerState) {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				if tokens != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case <-tokens:
+					}
+				}
+
+				requestStart := time.Now()
+				serviceStart := requestStart
+				if arrivals != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case requestStart = <-arrivals:
+					}
+					serviceStart = time.Now()
+				}
+
+				path := selector.Next()
+				resp, err := c.Get(traceCtx, path)
+				latency := float64(time.Since(requestStart).Microseconds()) / 1000.0
+				if arrivals != nil {
+					atomic.AddInt64(&serviceNanos, int64(time.Since(serviceStart)))
+				}
+
+				atomic.AddInt64(&totalRequests, 1)
+
+				ok := false
+				status := 0
+				if err == nil {
+					n, _ := drainBody(resp.Body, c.MaxResponseSize())
+					resp.Body.Close()
+					atomic.AddInt64(&bytesReceived, n)
+					status = resp.StatusCode
+					ok = status >= 200 && status < 300
+				}
+				if ok {
+					atomic.AddInt64(&successful, 1)
+				} else {
+					atomic.AddInt64(&failed, 1)
+					// A request cut short by the end of the test did not time out on the server's account
+					errorType := internal.ErrorTypeOther
+					if ctx.Err() == nil {
+						errorType = classifyLoadTestError(status, err)
+					}
+					atomic.AddInt64(&errorCounts[slices.Index(internal.ErrorTypes, errorType)], 1)
+				}
+
+				// Record latency in the worker's own slices
+				at := time.Since(start)
+				worker.mu.Lock()
+				if worker.stopped {
+					worker.mu.Unlock()
+					return
+				}
+				worker.latencies = append(worker.latencies, latency)
+				if ok {
+					worker.successful++
+				} else {
+					worker.failed++
+				}
+				if worker.distribution != nil {
+					worker.distribution[path]++
+				}
+				if opts.Windows > 0 || opts.RPSTimeline || stepLoad {
+					worker.completions = append(worker.completions, at)
+				}
+				if stepLoad && !ok {
+					worker.failedAt = append(worker.failedAt, at)
+				}
+				// A request cut short by the end of the test says nothing about when the server failed
+				if !ok && ctx.Err() == nil {
+					worker.errorTimes = sampleErrorTime(worker.errorTimes, worker.errorsSeen, float64(at.Microseconds())/1000.0)
+					worker.errorsSeen++
+				}
+				worker.mu.Unlock()
+
+				if opts.ThinkTime > 0 {
+					pause := time.NewTimer(thinkTimePause(opts.ThinkTime, opts.ThinkTimeJitterPct))
+					select {
+					case <-ctx.Done():
+						pause.Stop()
+						return
+					case <-pause.C:
+					}
+				}
+			}
+		}
+	}
+
+	// Start concurrent workers, all at once or, for step load, StepStart now
+	// and the rest one per step
+	initial := concurrent
+	if stepLoad {
+		initial = min(max(opts.StepStart, 1), concurrent)
+	}
+	started := int64(initial)
+	for i := 0; i < initial; i++ {
+		wg.Add(1)
+		go runWorker(&workers[i])
+	}
+	if initial < concurrent {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 
+			ticker := time.NewTicker(opts.StepInterval)
+			defer ticker.Stop()
+			for i := initial; i < concurrent; i++ {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					wg.Add(1)
+					atomic.AddInt64(&started, 1)
+					go runWorker(&workers[i])
+				}
+			}
+		}()
+	}
+
+	if opts.OnTick != nil || opts.AbortP95Ms > 0 || opts.AbortErrorRatePct > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var prevTotal, prevFailed int64
+			errorWindows := 0
+
+			ticker := time.NewTicker(loadTestTickInterval)
+			defer ticker.Stop()
 			for {
 				select {
 				case <-ctx.Done():
 					return
-				default:
-					requestStart := time.Now()
-					resp, err := c.Get(ctx, "/health")
-					latency := float64(time.Since(requestStart).Microseconds()) / 1000.0
+				case <-ticker.C:
+					// Summarize a copy so the workers keep appending undisturbed
+					snapshot := snapshotLatencies(workers)
 
-					atomic.AddInt64(&totalRequests, 1)
+					elapsed := time.Since(start)
+					total, failedSoFar := atomic.LoadInt64(&totalRequests), atomic.LoadInt64(&failed)
+					partial := *result
+					partial.DurationSec = elapsed.Seconds()
+					summarizeLoadTest(&partial, total, atomic.LoadInt64(&successful),
+						failedSoFar, atomic.LoadInt64(&bytesReceived), snapshot, elapsed)
+					if opts.OnTick != nil {
+						opts.OnTick(&partial)
+					}
 
-					if err != nil {
-						atomic.AddInt64(&failed, 1)
-					} else {
-						io.Copy(io.Discard, resp.Body)
-						resp.Body.Close()
+					if opts.AbortP95Ms > 0 && len(snapshot) >= abortMinSamples && partial.LatencyP95Ms > opts.AbortP95Ms {
+						result.AbortReason = fmt.Sprintf("p95 latency %.0fms exceeded threshold %.0fms after %.0fs",
+							partial.LatencyP95Ms, opts.AbortP95Ms, elapsed.Seconds())
+					}
 
-						if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-							atomic.AddInt64(&successful, 1)
+					// The error rate is judged per tick; a tick with no completed requests is skipped
+					if requests := total - prevTotal; opts.AbortErrorRatePct > 0 && requests > 0 {
+						rate := float64(failedSoFar-prevFailed) / float64(requests) * 100
+						if rate > opts.AbortErrorRatePct {
+							errorWindows++
 						} else {
-							atomic.AddInt64(&failed, 1)
+							errorWindows = 0
+						}
+						if errorWindows >= abortErrorWindows && result.AbortReason == "" {
+							result.AbortReason = fmt.Sprintf("error rate %.1f%% exceeded threshold %.1f%% for %d consecutive %s windows",
+								rate, opts.AbortErrorRatePct, abortErrorWindows, loadTestTickInterval)
 						}
 					}
+					prevTotal, prevFailed = total, failedSoFar
 
-					// Record latency
-					latencyMu.Lock()
-					latencies = append(latencies, latency)
-					latencyMu.Unlock()
+					if result.AbortReason != "" {
+						result.EarlyAbort = true
+						cancel()
+						return
+					}
 				}
 			}
 		}()
 	}
 
-	wg.Wait()
+	waitForWorkers(ctx, &wg, loadTestShutdownGrace)
 	actualDuration := time.Since(start)
+	if result.EarlyAbort || parent.Err() != nil {
+		result.DurationSec = actualDuration.Seconds()
+	}
+
+	// Workers that outlived the grace period may still be running
+	merged := mergeWorkers(workers)
+	latencies, completions := merged.latencies, merged.completions
+	total := atomic.LoadInt64(&totalRequests)
+
+	if opts.Windows > 0 {
+		result.PerWindowStats = windowStats(latencies, completions, actualDuration, opts.Windows)
+	}
+	if opts.RPSTimeline {
+		result.RPSTimeline = rpsTimeline(completions, actualDuration)
+	}
+	if arrivals != nil {
+		result.ArrivalRate = float64(atomic.LoadInt64(&arrived)) / actualDuration.Seconds()
+		result.MaxQueueDepth = int(atomic.LoadInt64(&maxQueueDepth))
+		if nanos := atomic.LoadInt64(&serviceNanos); nanos > 0 && total > 0 {
+			meanService := time.Duration(nanos / total)
+			result.ServiceRate = float64(concurrent) / meanService.Seconds()
+		}
+	}
+	if stepLoad {
+		// A duration shorter than the ramp ends before every worker has started
+		result.Concurrent = int(atomic.LoadInt64(&started))
+		// Waiting for the last workers runs slightly past the duration; that is no new step
+		result.StepResults = stepStats(latencies, completions, merged.failedAt, min(actualDuration, duration), opts.StepInterval, initial, concurrent)
+	}
+
+	// Counted before the summary sorts latencies; the order does not matter
+	if len(opts.SLATargets) > 0 {
+		result.SLABreachCount = make(map[string]int, len(opts.SLATargets))
+		for _, target := range opts.SLATargets {
+			limit := float64(target.Microseconds()) / 1000.0
+			breaches := 0
+			for _, latency := range latencies {
+				if latency > limit {
+					breaches++
+				}
+			}
+			result.SLABreachCount[target.String()] = breaches
+		}
+	}
+
+	summarizeLoadTest(result, total, atomic.LoadInt64(&successful), atomic.LoadInt64(&failed),
+		atomic.LoadInt64(&bytesReceived), latencies, actualDuration)
+	if len(latencies) > 0 {
+		percentiles := opts.Percentiles
+		if len(percentiles) == 0 {
+			percentiles = []float64{50, 95, 99}
+		}
+		result.Percentiles = make(map[string]float64, len(percentiles))
+		for _, p := range percentiles {
+			result.Percentiles[PercentileKey(p)] = Percentile(latencies, p)
+		}
+
+		buckets := opts.HistogramBuckets
+		if buckets <= 0 {
+			buckets = DefaultHistogramBuckets
+		}
+		result.LatencyHistogram = latencyHistogram(latencies, buckets)
+	}
+	result.NewConnections = int(atomic.LoadInt64(&newConns))
+	// Every worker dials once, so only the dials after that point at the pool
+	if float64(result.NewConnections-result.Concurrent) > float64(total)*poolSaturationRatio {
+		result.PoolSaturation = internal.PoolSaturated
+	}
+	if errorTimes := merged.errorTimes; len(errorTimes) > 0 {
+		sort.Float64s(errorTimes)
+		result.ErrorTimestamps = errorTimes
+		result.ErrorsClustered = isErrorClustered(errorTimes, float64(actualDuration.Microseconds())/1000.0)
+	}
+	if opts.DNSCacheHits != nil {
+		result.DNSCacheHits = opts.DNSCacheHits() - dnsCacheHits
+	}
+	result.WorkerStats = workerStats(workers)
+	if len(merged.distribution) > 0 {
+		result.EndpointDistribution = merged.distribution
+	}
+	for i, errorType := range internal.ErrorTypes {
+		if count := atomic.LoadInt64(&errorCounts[i]); count > 0 {
+			if result.ErrorBreakdown == nil {
+				result.ErrorBreakdown = make(map[string]int)
+			}
+			result.ErrorBreakdown[errorType] = int(count)
+		}
+	}
+	return result
+}
+
+// warmUp sends requests from the test's workers for opts.Warmup and discards
+// the results, so the measured run starts with pooled connections and warm
+// server caches. It honours opts.MaxRPS, and returns early if ctx ends.
+//
+// Requests still in flight when the warmup ends are allowed to finish, as
+// cancelling them would close the connections the warmup opened.
+func warmUp(ctx context.Context, c *client.Client, selector EndpointSelector, opts LoadTestOptions) {
+	warmupCtx, cancel := context.WithTimeout(ctx, opts.Warmup)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	tokens := startTokenBucket(warmupCtx, &wg, opts.Concurrent, opts.MaxRPS)
+	for i := 0; i < opts.Concurrent; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for warmupCtx.Err() == nil {
+				if tokens != nil {
+					select {
+					case <-warmupCtx.Done():
+						return
+					case <-tokens:
+					}
+				}
+				if resp, err := c.Get(ctx, selector.Next()); err == nil {
+					drainBody(resp.Body, c.MaxResponseSize())
+					resp.Body.Close()
+				}
+			}
+		}()
+	}
+	waitForWorkers(warmupCtx, &wg, loadTestShutdownGrace)
+}
+
+// thinkTimePause returns a worker's pause before its next request: thinkTime
+// plus a random 0 to jitterPct percent of it
+func thinkTimePause(thinkTime time.Duration, jitterPct float64) time.Duration {
+	return thinkTime + time.Duration(rand.Float64()*jitterPct/100*float64(thinkTime))
+}
+
+// startTokenBucket returns a token bucket for concurrent workers filled at
+// rps until ctx is done, with its filler counted in wg, or nil when rps is 0
+func startTokenBucket(ctx context.Context, wg *sync.WaitGroup, concurrent int, rps float64) chan struct{} {
+	if rps <= 0 {
+		return nil
+	}
+	tokens := make(chan struct{}, max(concurrent, 1))
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fillTokenBucket(ctx, tokens, rps)
+	}()
+	return tokens
+}
+
+// openLoopQueueSize bounds the arrivals waiting for a worker in an open-loop
+// test. Once it is full the dispatcher waits too, and the arrival rate falls
+// below the target.
+const openLoopQueueSize = 10000
+
+// dispatchArrivals queues arrival times at an average of rps per second until
+// ctx is done, with exponentially distributed gaps as in a Poisson process.
+// Arrivals follow their own schedule, so one that falls behind is sent at once
+// rather than delaying the rest. It counts the arrivals in arrived and records
+// the deepest queue in maxDepth.
+func dispatchArrivals(ctx context.Context, queue chan<- time.Time, rps float64, arrived, maxDepth *int64) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	next := time.Now()
+	for {
+		next = next.Add(time.Duration(rand.ExpFloat64() / rps * float64(time.Second)))
+		if wait := time.Until(next); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case queue <- next:
+		}
+		atomic.AddInt64(arrived, 1)
+		if depth := int64(len(queue)); depth > atomic.LoadInt64(maxDepth) {
+			atomic.StoreInt64(maxDepth, depth)
+		}
+	}
+}
+
+// tokenBucketMinInterval bounds how often the token bucket is refilled, so a
+// high --max-rps adds several tokens per tick instead of spinning
+const tokenBucketMinInterval = time.Millisecond
+
+// fillTokenBucket adds tokens to bucket at rps tokens per second until ctx is
+// done. Tokens that find the bucket full are dropped, so idle workers cannot
+// save up more than the bucket's capacity for a burst.
+func fillTokenBucket(ctx context.Context, bucket chan<- struct{}, rps float64) {
+	interval := max(time.Duration(float64(time.Second)/rps), tokenBucketMinInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	due := 0.0 // Tokens owed, including a fraction carried to the next tick
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			due += now.Sub(last).Seconds() * rps
+			last = now
+			for ; due >= 1; due-- {
+				select {
+				case bucket <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// classifyLoadTestError returns the internal.ErrorTypes entry of a failed
+// request, from its error or, when it got a response, its status code.
+// Simulated losses count as connection resets, which they stand in for.
+func classifyLoadTestError(status int, err error) string {
+	if err == nil {
+		switch {
+		case status >= 400 && status < 500:
+			return internal.ErrorTypeHTTP4xx
+		case status >= 500 && status < 600:
+			return internal.ErrorTypeHTTP5xx
+		}
+		return internal.ErrorTypeOther
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return internal.ErrorTypeTimeout
+	}
+	if errors.Is(err, client.ErrSimulatedLoss) {
+		return internal.ErrorTypeConnectionReset
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		switch msg := opErr.Error(); {
+		case strings.Contains(msg, "connection refused"):
+			return internal.ErrorTypeConnectionRefused
+		case strings.Contains(msg, "connection reset"):
+			return internal.ErrorTypeConnectionReset
+		}
+	}
+	return internal.ErrorTypeOther
+}
+
+// workerState is what one load test worker has recorded. Each worker appends
+// only to its own slices, so workers never contend with each other; the lock
+// is shared only with the brief progress snapshots and the final merge.
+type workerState struct {
+	mu           sync.Mutex
+	stopped      bool // Set once the results are merged; a late worker records nothing
+	successful   int
+	failed       int
+	latencies    []float64
+	completions  []time.Duration // Offset from start at which each latency was recorded
+	failedAt     []time.Duration // Offset from start at which each failure was recorded, for step load
+	errorTimes   []float64       // Milliseconds from start of sampled failures
+	errorsSeen   int             // Failures offered to errorTimes
+	distribution map[string]int  // Requests per path, for an endpoint rotation
+}
+
+// snapshotLatencies copies every worker's latencies recorded so far
+func snapshotLatencies(workers []workerState) []float64 {
+	var snapshot []float64
+	for i := range workers {
+		w := &workers[i]
+		w.mu.Lock()
+		snapshot = append(snapshot, w.latencies...)
+		w.mu.Unlock()
+	}
+	return snapshot
+}
+
+// mergeWorkers stops every worker from recording and combines what they
+// recorded into one workerState. latencies and completions stay parallel.
+func mergeWorkers(workers []workerState) *workerState {
+	merged := &workerState{}
+	var samples [][]float64
+	var seen []int
+	for i := range workers {
+		w := &workers[i]
+		w.mu.Lock()
+		w.stopped = true
+		merged.latencies = append(merged.latencies, w.latencies...)
+		merged.completions = append(merged.completions, w.completions...)
+		merged.failedAt = append(merged.failedAt, w.failedAt...)
+		if len(w.errorTimes) > 0 {
+			samples = append(samples, w.errorTimes)
+			seen = append(seen, w.errorsSeen)
+		}
+		for path, n := range w.distribution {
+			if merged.distribution == nil {
+				merged.distribution = make(map[string]int)
+			}
+			merged.distribution[path] += n
+		}
+		w.mu.Unlock()
+	}
+	merged.errorTimes = mergeErrorTimes(samples, seen)
+	return merged
+}
+
+// mergeErrorTimes combines the workers' failure time samples, each a uniform
+// sample of the seen failures of its worker, into one of at most
+// maxErrorTimestamps. Each worker contributes in proportion to its failures,
+// so the result stays a uniform sample of all of them.
+func mergeErrorTimes(samples [][]float64, seen []int) []float64 {
+	total := 0
+	for _, n := range seen {
+		total += n
+	}
+	var times []float64
+	for i, sample := range samples {
+		keep := len(sample)
+		if total > maxErrorTimestamps {
+			keep = min(keep, seen[i]*maxErrorTimestamps/total)
+		}
+		for _, j := range rand.Perm(len(sample))[:keep] {
+			times = append(times, sample[j])
+		}
+	}
+	return times
+}
+
+// workerStats summarizes each worker in order. latencies are sorted in place.
+func workerStats(workers []workerState) []internal.WorkerResult {
+	stats := make([]internal.WorkerResult, len(workers))
+	for i := range workers {
+		w := &workers[i]
+		w.mu.Lock()
+		sort.Float64s(w.latencies)
+		stats[i] = internal.WorkerResult{
+			WorkerID:      i + 1,
+			TotalRequests: w.successful + w.failed,
+			Successful:    w.successful,
+			Failed:        w.failed,
+			P95LatencyMs:  Percentile(w.latencies, 95),
+		}
+		if len(w.latencies) > 0 {
+			var sum float64
+			for _, l := range w.latencies {
+				sum += l
+			}
+			stats[i].AvgLatencyMs = sum / float64(len(w.latencies))
+		}
+		w.mu.Unlock()
+	}
+	return stats
+}
+
+// waitForWorkers waits for wg, but gives up once grace has passed after ctx
+// ends so that a request stuck past its deadline cannot hold up the report
+func waitForWorkers(ctx context.Context, wg *sync.WaitGroup, grace time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+	}
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+}
+
+// sampleErrorTime adds the failure at ms to the sampled times, given that seen
+// failures came before it. Once maxErrorTimestamps are held, each new failure
+// replaces a random one with a probability that keeps the sample uniform.
+func sampleErrorTime(times []float64, seen int, ms float64) []float64 {
+	if len(times) < maxErrorTimestamps {
+		return append(times, ms)
+	}
+	if i := rand.Intn(seen + 1); i < len(times) {
+		times[i] = ms
+	}
+	return times
+}
 
-	// Calculate results
-	result.TotalRequests = int(totalRequests)
+// isErrorClustered reports whether the sorted failure times, in milliseconds
+// from the start of a windowMs long test, are bunched together rather than
+// spread evenly. A one-sample Kolmogorov-Smirnov test compares them with a
+// uniform distribution over the window at the 5% significance level. Fewer
+// than errorClusterMinSamples failures are never called clustered.
+func isErrorClustered(timestamps []float64, windowMs float64) bool {
+	n := len(timestamps)
+	if n < errorClusterMinSamples || windowMs <= 0 {
+		return false
+	}
+
+	// The largest gap between the empirical and uniform distribution functions
+	// falls just before or at one of the samples
+	var d float64
+	for i, t := range timestamps {
+		uniform := math.Min(math.Max(t/windowMs, 0), 1)
+		d = math.Max(d, math.Max(float64(i+1)/float64(n)-uniform, uniform-float64(i)/float64(n)))
+	}
+	return d > ksCriticalValue/math.Sqrt(float64(n))
+}
+
+// latencyHistogram splits the range of sorted latencies into n equal-width
+// buckets and counts the latencies in each. When every latency is the same
+// there is no range to split, so a single bucket holds them all.
+func latencyHistogram(sorted []float64, n int) []internal.HistogramBucket {
+	if len(sorted) == 0 || n < 1 {
+		return nil
+	}
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if hi == lo {
+		return []internal.HistogramBucket{{LowerMs: lo, UpperMs: hi, Count: len(sorted)}}
+	}
+
+	width := (hi - lo) / float64(n)
+	buckets := make([]internal.HistogramBucket, n)
+	for i := range buckets {
+		buckets[i].LowerMs = lo + float64(i)*width
+		buckets[i].UpperMs = lo + float64(i+1)*width
+	}
+	buckets[n-1].UpperMs = hi
+	for _, l := range sorted {
+		i := min(int((l-lo)/width), n-1)
+		buckets[i].Count++
+	}
+	return buckets
+}
+
+// summarizeLoadTest fills in the request counts, RPS, and latency statistics of
+// result. latencies is sorted in place.
+func summarizeLoadTest(result *internal.LoadTestResult, total, successful, failed, bytesReceived int64, latencies []float64, elapsed time.Duration) {
+	result.TotalRequests = int(total)
 	result.Successful = int(successful)
 	result.Failed = int(failed)
-	result.RPS = float64(totalRequests) / actualDuration.Seconds()
+	result.TotalBytesReceived = bytesReceived
+	result.RPS = float64(total) / elapsed.Seconds()
 
 	// Calculate latency percentiles
 	if len(latencies) > 0 {
@@ -88,9 +909,11 @@ func LoadTest(ctx context.Context, c *client.Client, concurrent int, duration ti
 
 		result.MinLatencyMs = latencies[0]
 		result.MaxLatencyMs = latencies[len(latencies)-1]
-		result.LatencyP50Ms = percentile(latencies, 50)
-		result.LatencyP95Ms = percentile(latencies, 95)
-		result.LatencyP99Ms = percentile(latencies, 99)
+		// The fixed percentiles are always recorded, whatever --percentiles
+		// asks for, so older tooling reading the JSON keeps working
+		result.LatencyP50Ms = Percentile(latencies, 50)
+		result.LatencyP95Ms = Percentile(latencies, 95)
+		result.LatencyP99Ms = Percentile(latencies, 99)
 
 		// Calculate average
 		var sum float64
@@ -98,13 +921,298 @@ func LoadTest(ctx context.Context, c *client.Client, concurrent int, duration ti
 			sum += l
 		}
 		result.AvgLatencyMs = sum / float64(len(latencies))
+
+		// Spread: the standard deviation about the mean, from a second pass,
+		// and the interquartile range
+		var squares float64
+		for _, l := range latencies {
+			squares += (l - result.AvgLatencyMs) * (l - result.AvgLatencyMs)
+		}
+		result.StdDevLatencyMs = math.Sqrt(squares / float64(len(latencies)))
+		result.IQRLatencyMs = Percentile(latencies, 75) - Percentile(latencies, 25)
+	}
+}
+
+// windowStats splits a run of the given duration into equal windows and
+// computes the RPS and p95 latency of the requests that completed in each.
+// latencies and completions must be parallel and not yet sorted.
+func windowStats(latencies []float64, completions []time.Duration, duration time.Duration, windows int) []internal.WindowStats {
+	if windows <= 0 || duration <= 0 {
+		return nil
+	}
+
+	width := duration / time.Duration(windows)
+	if width <= 0 {
+		return nil
+	}
+
+	buckets := make([][]float64, windows)
+	for i, at := range completions {
+		w := int(at / width)
+		// Requests finishing in the final partial tick belong to the last window
+		if w >= windows {
+			w = windows - 1
+		}
+		buckets[w] = append(buckets[w], latencies[i])
+	}
+
+	stats := make([]internal.WindowStats, windows)
+	for i, bucket := range buckets {
+		stats[i] = internal.WindowStats{
+			Window:   i + 1,
+			StartSec: (time.Duration(i) * width).Seconds(),
+			Requests: len(bucket),
+			RPS:      float64(len(bucket)) / width.Seconds(),
+		}
+		if len(bucket) > 0 {
+			sort.Float64s(bucket)
+			stats[i].LatencyP95Ms = Percentile(bucket, 95)
+		}
+	}
+	return stats
+}
+
+// rpsTimeline counts the requests completed in each whole second of a run of
+// the given duration. Requests finishing in the final partial second count
+// toward the last whole one.
+func rpsTimeline(completions []time.Duration, duration time.Duration) []float64 {
+	seconds := int(duration / time.Second)
+	if seconds <= 0 {
+		return nil
+	}
+
+	timeline := make([]float64, seconds)
+	for _, at := range completions {
+		timeline[min(int(at/time.Second), seconds-1)]++
+	}
+	return timeline
+}
+
+// stepStats splits a step load test into steps of interval, the last cut short
+// by the end of the test, with first workers running in the first step and
+// one more in each step after until concurrent are running. latencies and
+// completions must be parallel and not yet sorted.
+func stepStats(latencies []float64, completions, failedAt []time.Duration, duration, interval time.Duration, first, concurrent int) []internal.StepResult {
+	if interval <= 0 || duration <= 0 {
+		return nil
+	}
+
+	steps := int((duration + interval - 1) / interval)
+	buckets := make([][]float64, steps)
+	for i, at := range completions {
+		// Requests finishing after the last step boundary belong to the last step
+		step := min(int(at/interval), steps-1)
+		buckets[step] = append(buckets[step], latencies[i])
+	}
+	failures := make([]int, steps)
+	for _, at := range failedAt {
+		failures[min(int(at/interval), steps-1)]++
+	}
+
+	stats := make([]internal.StepResult, steps)
+	for i, bucket := range buckets {
+		length := min(interval, duration-time.Duration(i)*interval)
+		stats[i] = internal.StepResult{
+			Step:        i + 1,
+			Concurrency: min(first+i, concurrent),
+			Requests:    len(bucket),
+			RPS:         float64(len(bucket)) / length.Seconds(),
+		}
+		if len(bucket) > 0 {
+			sort.Float64s(bucket)
+			stats[i].LatencyP95Ms = Percentile(bucket, 95)
+			stats[i].ErrorRatePct = float64(failures[i]) / float64(len(bucket)) * 100
+		}
+	}
+	return stats
+}
+
+// wsPingMessage is sent by each WebSocket worker; the server is expected to echo a reply
+const wsPingMessage = "ping"
+
+// WebSocketLoadTest opens concurrent WebSocket connections to path and measures
+// ping round-trip time on each until duration elapses. Every message sent must be
+// answered with one message from the server for the round trip to complete.
+// Connections are dialed like c's requests and carry its User-Agent and token.
+// A connection that cannot be opened counts as a failed message.
+func WebSocketLoadTest(ctx context.Context, c *client.Client, path string, concurrent int, duration time.Duration) *internal.WebSocketLoadTestResult {
+	path = normalizePath(path)
+	result := &internal.WebSocketLoadTestResult{
+		Path:        path,
+		Concurrent:  concurrent,
+		DurationSec: duration.Seconds(),
+	}
+
+	baseURL := c.GetBaseURL()
+	wsURL, err := webSocketURL(baseURL, path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	var (
+		totalMessages int64
+		successful    int64
+		failed        int64
+		connections   int64
+		latencies     []float64
+		mu            sync.Mutex
+		dialErr       error
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
+	var wg sync.WaitGroup
+	start := time.Now()
+
+	for i := 0; i < concurrent; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ws, err := dialWebSocket(ctx, c, wsURL, baseURL, deadline)
+			if err != nil {
+				// Dials cut short by the end of the test are not failures
+				if ctx.Err() != nil {
+					return
+				}
+				atomic.AddInt64(&totalMessages, 1)
+				atomic.AddInt64(&failed, 1)
+				mu.Lock()
+				if dialErr == nil {
+					dialErr = err
+				}
+				mu.Unlock()
+				return
+			}
+			defer ws.Close()
+			atomic.AddInt64(&connections, 1)
+
+			// Unblock a pending receive when the test ends
+			ws.SetDeadline(deadline)
+
+			for ctx.Err() == nil {
+				sendStart := time.Now()
+				err := websocket.Message.Send(ws, wsPingMessage)
+				if err == nil {
+					var reply string
+					err = websocket.Message.Receive(ws, &reply)
+				}
+				latency := float64(time.Since(sendStart).Microseconds()) / 1000.0
+
+				if err != nil {
+					// A round trip cut short by the end of the test is not a failure;
+					// the socket deadline can fire just before ctx reports done
+					if ctx.Err() != nil || !time.Now().Before(deadline) {
+						return
+					}
+					atomic.AddInt64(&totalMessages, 1)
+					atomic.AddInt64(&failed, 1)
+					return
+				}
+
+				atomic.AddInt64(&totalMessages, 1)
+				atomic.AddInt64(&successful, 1)
+				mu.Lock()
+				latencies = append(latencies, latency)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	actualDuration := time.Since(start)
+
+	result.Connections = int(connections)
+	result.TotalMessages = int(totalMessages)
+	result.Successful = int(successful)
+	result.Failed = int(failed)
+	result.MessagesPerSec = float64(successful) / actualDuration.Seconds()
+	if connections == 0 && dialErr != nil {
+		result.Error = fmt.Sprintf("websocket dial: %v", dialErr)
+	}
+
+	if len(latencies) > 0 {
+		sort.Float64s(latencies)
+
+		result.MinLatencyMs = latencies[0]
+		result.MaxLatencyMs = latencies[len(latencies)-1]
+		result.LatencyP50Ms = Percentile(latencies, 50)
+		result.LatencyP95Ms = Percentile(latencies, 95)
+		result.LatencyP99Ms = Percentile(latencies, 99)
+
+		var sum float64
+		for _, l := range latencies {
+			sum += l
+		}
+		result.AvgLatencyMs = sum / float64(len(latencies))
 	}
 
 	return result
 }
 
-// percentile calculates the p-th percentile of a sorted slice
-func percentile(sorted []float64, p float64) float64 {
+// dialWebSocket opens a WebSocket connection to wsURL over a connection from
+// c, adding TLS for wss, and sends c's identifying headers with the handshake.
+// The handshake must finish before deadline.
+func dialWebSocket(ctx context.Context, c *client.Client, wsURL, origin string, deadline time.Time) (*websocket.Conn, error) {
+	config, err := websocket.NewConfig(wsURL, origin)
+	if err != nil {
+		return nil, err
+	}
+	config.Header = c.Header()
+
+	port := config.Location.Port()
+	if port == "" {
+		port = "80"
+		if config.Location.Scheme == "wss" {
+			port = "443"
+		}
+	}
+	conn, err := c.Dial(ctx, net.JoinHostPort(config.Location.Hostname(), port))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(deadline)
+	if config.Location.Scheme == "wss" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: config.Location.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ws, nil
+}
+
+// webSocketURL converts an http(s) base URL and path into a ws(s) URL
+func webSocketURL(baseURL, path string) (string, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/") + path)
+	if err != nil {
+		return "", fmt.Errorf("invalid websocket URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	case "ws", "wss":
+	default:
+		return "", fmt.Errorf("unsupported URL scheme %q for websocket", u.Scheme)
+	}
+	return u.String(), nil
+}
+
+// Percentile calculates the p-th percentile of a sorted slice, interpolating
+// linearly between the two nearest values
+func Percentile(sorted []float64, p float64) float64 {
 	if len(sorted) == 0 {
 		return 0
 	}
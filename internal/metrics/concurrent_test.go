@@ -2,12 +2,25 @@ package metrics
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
+	"golang.org/x/net/websocket"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
 	"github.com/johnzastrow/actalog-benchmark/internal/client"
 )
 
@@ -87,6 +100,37 @@ func TestLoadTest_WithFailures(t *testing.T) {
 	if result.Successful == 0 {
 		t.Error("expected some successes")
 	}
+	// A request cut short by the end of the test, at most one per worker, counts as other
+	breakdown := result.ErrorBreakdown
+	if n := breakdown[internal.ErrorTypeHTTP5xx]; n < result.Failed-2 || n+breakdown[internal.ErrorTypeOther] != result.Failed {
+		t.Errorf("expected the %d failures counted as http_5xx, got %v", result.Failed, breakdown)
+	}
+}
+
+func TestClassifyLoadTestError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   string
+	}{
+		{"not found", 404, nil, internal.ErrorTypeHTTP4xx},
+		{"server error", 503, nil, internal.ErrorTypeHTTP5xx},
+		{"redirect", 302, nil, internal.ErrorTypeOther},
+		{"deadline", 0, fmt.Errorf("get: %w", context.DeadlineExceeded), internal.ErrorTypeTimeout},
+		{"refused", 0, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, internal.ErrorTypeConnectionRefused},
+		{"reset", 0, &url.Error{Op: "Get", URL: "http://x", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}, internal.ErrorTypeConnectionReset},
+		{"simulated loss", 0, client.ErrSimulatedLoss, internal.ErrorTypeConnectionReset},
+		{"other", 0, errors.New("unexpected EOF"), internal.ErrorTypeOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyLoadTestError(tt.status, tt.err); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
 }
 
 func TestLoadTest_Latencies(t *testing.T) {
@@ -170,6 +214,840 @@ func TestLoadTest_Concurrency(t *testing.T) {
 	}
 }
 
+func TestLoadTest_MaxRPS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent: 5,
+		Duration:   500 * time.Millisecond,
+		MaxRPS:     100,
+	})
+
+	if result.TargetRPS != 100 {
+		t.Errorf("expected target RPS 100, got %.2f", result.TargetRPS)
+	}
+	// About 50 requests in half a second; unthrottled, a local server answers thousands
+	if result.TotalRequests < 30 || result.TotalRequests > 60 {
+		t.Errorf("expected about 50 requests at 100 RPS, got %d", result.TotalRequests)
+	}
+}
+
+func TestLoadTest_Warmup(t *testing.T) {
+	var requestCount int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requestCount, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	start := time.Now()
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent: 2,
+		Duration:   200 * time.Millisecond,
+		Warmup:     200 * time.Millisecond,
+	})
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected the warmup to run before the test, finished in %s", elapsed)
+	}
+	if result.WarmupSec != 0.2 {
+		t.Errorf("expected warmup 0.2s, got %v", result.WarmupSec)
+	}
+	// Warmup requests reach the server but are not counted
+	if served := atomic.LoadInt64(&requestCount); int64(result.TotalRequests) >= served {
+		t.Errorf("expected fewer counted requests than the %d served, got %d", served, result.TotalRequests)
+	}
+	if result.NewConnections != 0 {
+		t.Errorf("expected the measured run to reuse the warmup's connections, got %d new", result.NewConnections)
+	}
+}
+
+func TestLoadTest_ThinkTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent: 2,
+		Duration:   300 * time.Millisecond,
+		ThinkTime:  100 * time.Millisecond,
+	})
+
+	// Each worker manages at most one request per 100ms pause
+	if result.TotalRequests == 0 || result.TotalRequests > 8 {
+		t.Errorf("expected think time to pace each worker to about 3 requests, got %d in total", result.TotalRequests)
+	}
+	if result.ThinkTimeMs != 100 {
+		t.Errorf("expected think time 100ms, got %v", result.ThinkTimeMs)
+	}
+}
+
+func TestThinkTimePause(t *testing.T) {
+	if got := thinkTimePause(100*time.Millisecond, 0); got != 100*time.Millisecond {
+		t.Errorf("expected exactly 100ms without jitter, got %s", got)
+	}
+	for i := 0; i < 100; i++ {
+		if got := thinkTimePause(100*time.Millisecond, 50); got < 100*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("expected a pause from 100ms to 150ms with 50%% jitter, got %s", got)
+		}
+	}
+}
+
+func TestLoadTest_TargetRPS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent: 4,
+		Duration:   500 * time.Millisecond,
+		TargetRPS:  200,
+	})
+
+	if result.TargetRPS != 200 {
+		t.Errorf("expected target RPS 200, got %.2f", result.TargetRPS)
+	}
+	// Poisson arrivals vary, but 100 are expected in half a second
+	if result.ArrivalRate < 120 || result.ArrivalRate > 280 {
+		t.Errorf("expected an arrival rate near 200, got %.2f", result.ArrivalRate)
+	}
+	if result.ServiceRate <= result.ArrivalRate {
+		t.Errorf("expected a local server to serve faster than requests arrive, got %.2f for %.2f", result.ServiceRate, result.ArrivalRate)
+	}
+}
+
+func TestLoadTest_TargetRPSQueue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// One worker serves about 50 req/s, well below the arrival rate
+	c := client.New(server.URL, 10*time.Second)
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent: 1,
+		Duration:   300 * time.Millisecond,
+		TargetRPS:  200,
+	})
+
+	if result.MaxQueueDepth < 5 {
+		t.Errorf("expected arrivals to queue, got a max depth of %d", result.MaxQueueDepth)
+	}
+	if result.ServiceRate >= result.ArrivalRate {
+		t.Errorf("expected a service rate below the arrival rate, got %.2f for %.2f", result.ServiceRate, result.ArrivalRate)
+	}
+	// Latency includes the wait in the queue, not just the 20ms on the server
+	if result.MaxLatencyMs < 60 {
+		t.Errorf("expected queued requests to take well over 20ms, got a max of %.2f", result.MaxLatencyMs)
+	}
+}
+
+func TestLoadTest_WorkerStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(2 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	result := LoadTest(context.Background(), c, 3, 200*time.Millisecond)
+
+	if len(result.WorkerStats) != 3 {
+		t.Fatalf("expected 3 workers, got %d", len(result.WorkerStats))
+	}
+	total := 0
+	for i, w := range result.WorkerStats {
+		if w.WorkerID != i+1 {
+			t.Errorf("expected worker %d, got ID %d", i+1, w.WorkerID)
+		}
+		if w.TotalRequests == 0 || w.AvgLatencyMs <= 0 || w.P95LatencyMs < w.AvgLatencyMs/2 {
+			t.Errorf("expected worker %d to record requests and latency, got %+v", w.WorkerID, w)
+		}
+		total += w.TotalRequests
+	}
+	if total != result.TotalRequests {
+		t.Errorf("expected worker requests to sum to %d, got %d", result.TotalRequests, total)
+	}
+}
+
+func TestLoadTest_BytesReceived(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 5*time.Second)
+	result := LoadTest(context.Background(), c, 2, 100*time.Millisecond)
+
+	if result.TotalBytesReceived < int64(result.Successful)*10 {
+		t.Errorf("expected at least %d bytes for %d responses, got %d", result.Successful*10, result.Successful, result.TotalBytesReceived)
+	}
+}
+
+func TestLoadTest_StressEndpoint(t *testing.T) {
+	var other int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/workouts" {
+			atomic.AddInt64(&other, 1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 5*time.Second)
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent:     2,
+		Duration:       100 * time.Millisecond,
+		Selector:       NewRoundRobinSelector([]string{"/health"}),
+		Strategy:       StrategyRoundRobin,
+		StressEndpoint: "api/workouts",
+	})
+
+	if result.StressedEndpoint != "/api/workouts" {
+		t.Errorf("expected stressed endpoint /api/workouts, got %q", result.StressedEndpoint)
+	}
+	if result.EndpointStrategy != "" || result.EndpointDistribution != nil {
+		t.Errorf("expected no endpoint strategy or distribution for a stress test, got %q and %v",
+			result.EndpointStrategy, result.EndpointDistribution)
+	}
+	if n := atomic.LoadInt64(&other); n > 0 {
+		t.Errorf("expected every request on the stressed endpoint, got %d elsewhere", n)
+	}
+}
+
+func TestLoadTest_Windows(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 5*time.Second)
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent: 2,
+		Duration:   200 * time.Millisecond,
+		Windows:    4,
+	})
+
+	if len(result.PerWindowStats) != 4 {
+		t.Fatalf("expected 4 windows, got %d", len(result.PerWindowStats))
+	}
+	total := 0
+	for i, w := range result.PerWindowStats {
+		if w.Window != i+1 {
+			t.Errorf("expected window %d, got %d", i+1, w.Window)
+		}
+		total += w.Requests
+	}
+	if total != result.TotalRequests {
+		t.Errorf("expected windows to hold all %d requests, got %d", result.TotalRequests, total)
+	}
+
+	result = LoadTestWithOptions(context.Background(), c, LoadTestOptions{Concurrent: 1, Duration: 50 * time.Millisecond})
+	if result.PerWindowStats != nil {
+		t.Errorf("expected no window stats without Windows, got %v", result.PerWindowStats)
+	}
+}
+
+func TestLoadTest_OnTick(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	saved := loadTestTickInterval
+	loadTestTickInterval = 20 * time.Millisecond
+	defer func() { loadTestTickInterval = saved }()
+
+	var ticks []*internal.LoadTestResult
+	c := client.New(server.URL, 5*time.Second)
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent: 2,
+		Duration:   150 * time.Millisecond,
+		OnTick: func(partial *internal.LoadTestResult) {
+			ticks = append(ticks, partial)
+		},
+	})
+
+	if len(ticks) == 0 {
+		t.Fatal("expected at least one partial result")
+	}
+	for i := 1; i < len(ticks); i++ {
+		if ticks[i].TotalRequests < ticks[i-1].TotalRequests {
+			t.Errorf("tick %d: request count went backwards (%d after %d)", i, ticks[i].TotalRequests, ticks[i-1].TotalRequests)
+		}
+		if ticks[i].DurationSec <= ticks[i-1].DurationSec {
+			t.Errorf("tick %d: expected elapsed time to grow, got %f after %f", i, ticks[i].DurationSec, ticks[i-1].DurationSec)
+		}
+	}
+	last := ticks[len(ticks)-1]
+	if last.TotalRequests > result.TotalRequests {
+		t.Errorf("partial result has %d requests, more than the final %d", last.TotalRequests, result.TotalRequests)
+	}
+	if last.Concurrent != 2 {
+		t.Errorf("expected partial result concurrency 2, got %d", last.Concurrent)
+	}
+}
+
+func TestLoadTest_AbortOnThreshold(t *testing.T) {
+	saved := loadTestTickInterval
+	loadTestTickInterval = 20 * time.Millisecond
+	defer func() { loadTestTickInterval = saved }()
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		opts       LoadTestOptions
+		wantAbort  bool
+		wantReason string
+	}{
+		{
+			name: "p95 latency",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(5 * time.Millisecond)
+			},
+			opts:       LoadTestOptions{AbortP95Ms: 1},
+			wantAbort:  true,
+			wantReason: "p95 latency",
+		},
+		{
+			name: "error rate",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			opts:       LoadTestOptions{AbortErrorRatePct: 50},
+			wantAbort:  true,
+			wantReason: "error rate 100.0% exceeded threshold 50.0% for 2 consecutive",
+		},
+		{
+			name: "within limits",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+			opts:      LoadTestOptions{AbortP95Ms: 10000, AbortErrorRatePct: 50},
+			wantAbort: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			tt.opts.Concurrent = 4
+			tt.opts.Duration = 2 * time.Second
+			if !tt.wantAbort {
+				tt.opts.Duration = 150 * time.Millisecond
+			}
+			result := LoadTestWithOptions(context.Background(), client.New(server.URL, 5*time.Second), tt.opts)
+
+			if result.EarlyAbort != tt.wantAbort {
+				t.Fatalf("expected early abort %t, got %t (%s)", tt.wantAbort, result.EarlyAbort, result.AbortReason)
+			}
+			if !tt.wantAbort {
+				if result.AbortReason != "" {
+					t.Errorf("expected no abort reason, got %q", result.AbortReason)
+				}
+				return
+			}
+			if !strings.Contains(result.AbortReason, tt.wantReason) {
+				t.Errorf("expected abort reason containing %q, got %q", tt.wantReason, result.AbortReason)
+			}
+			if result.DurationSec >= tt.opts.Duration.Seconds() {
+				t.Errorf("expected the recorded duration to be cut short, got %.2fs", result.DurationSec)
+			}
+		})
+	}
+}
+
+func TestLoadTest_ParentCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Cancelling the caller's context, as Ctrl+C does, ends the test early
+	// with what was measured so far
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result := LoadTest(ctx, client.New(server.URL, 10*time.Second), 2, 10*time.Second)
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the test to stop soon after cancellation, took %s", elapsed)
+	}
+	if result.DurationSec >= 1 {
+		t.Errorf("expected the actual duration to be recorded, got %.2fs", result.DurationSec)
+	}
+	if result.TotalRequests == 0 || result.LatencyP50Ms == 0 {
+		t.Errorf("expected partial results, got %+v", result)
+	}
+}
+
+func TestWaitForWorkers_Grace(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1) // A worker that never finishes
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	waitForWorkers(ctx, &wg, 50*time.Millisecond)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Errorf("expected to give up after the 50ms grace period, took %s", elapsed)
+	}
+}
+
+func TestLoadTest_PoolSaturation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The default pool keeps a connection per worker
+	pooled := LoadTest(context.Background(), client.New(server.URL, 10*time.Second), 4, 500*time.Millisecond)
+	if pooled.PoolSaturation != "" {
+		t.Errorf("expected no saturation with %d new connections for %d requests", pooled.NewConnections, pooled.TotalRequests)
+	}
+	if pooled.NewConnections < 1 || pooled.NewConnections > pooled.TotalRequests/10 {
+		t.Errorf("expected a few new connections, got %d for %d requests", pooled.NewConnections, pooled.TotalRequests)
+	}
+
+	// A server that closes every connection forces a new one per request
+	closing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Connection", "close")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer closing.Close()
+	saturated := LoadTest(context.Background(), client.New(closing.URL, 10*time.Second), 4, 500*time.Millisecond)
+	if saturated.PoolSaturation != internal.PoolSaturated {
+		t.Errorf("expected a saturated pool with %d new connections for %d requests, got %q",
+			saturated.NewConnections, saturated.TotalRequests, saturated.PoolSaturation)
+	}
+
+	// Each worker's first dial is not a sign of saturation, even when the
+	// workers are many compared with the requests
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer slow.Close()
+	busy := LoadTest(context.Background(), client.New(slow.URL, 10*time.Second), 20, 500*time.Millisecond)
+	if busy.NewConnections < busy.TotalRequests/10 {
+		t.Fatalf("expected the workers' dials to be at least 10%% of requests, got %d for %d", busy.NewConnections, busy.TotalRequests)
+	}
+	if busy.PoolSaturation != "" {
+		t.Errorf("expected no saturation from the workers' first dials, got %d new connections for %d requests",
+			busy.NewConnections, busy.TotalRequests)
+	}
+}
+
+func TestLoadTest_SLABreaches(t *testing.T) {
+	var n int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every other request is slow
+		if atomic.AddInt64(&n, 1)%2 == 0 {
+			time.Sleep(30 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	result := LoadTestWithOptions(context.Background(), client.New(server.URL, 10*time.Second), LoadTestOptions{
+		Concurrent: 2,
+		Duration:   300 * time.Millisecond,
+		SLATargets: []time.Duration{20 * time.Millisecond, time.Minute},
+	})
+
+	slow := result.SLABreachCount["20ms"]
+	if slow < 1 || slow >= result.TotalRequests {
+		t.Errorf("expected some of %d requests to breach 20ms, got %d", result.TotalRequests, slow)
+	}
+	if count, ok := result.SLABreachCount["1m0s"]; !ok || count != 0 {
+		t.Errorf("expected no breaches of 1m0s, got %v", result.SLABreachCount)
+	}
+
+	if plain := LoadTest(context.Background(), client.New(server.URL, 10*time.Second), 1, 50*time.Millisecond); plain.SLABreachCount != nil {
+		t.Errorf("expected no breach counts without SLA targets, got %v", plain.SLABreachCount)
+	}
+}
+
+func TestLoadTest_ErrorTimestamps(t *testing.T) {
+	start := time.Now()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A brief outage in the first 100ms of a 500ms test
+		if time.Since(start) < 100*time.Millisecond {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	result := LoadTest(context.Background(), client.New(server.URL, 10*time.Second), 2, 500*time.Millisecond)
+	if n := len(result.ErrorTimestamps); n < errorClusterMinSamples || n > min(result.Failed, maxErrorTimestamps) {
+		t.Fatalf("expected a timestamp for most of %d failures, got %d", result.Failed, n)
+	}
+	if !sort.Float64sAreSorted(result.ErrorTimestamps) || result.ErrorTimestamps[len(result.ErrorTimestamps)-1] > 200 {
+		t.Errorf("expected sorted failure times within the outage, got %v", result.ErrorTimestamps)
+	}
+	if !result.ErrorsClustered {
+		t.Error("expected failures during an outage to be clustered")
+	}
+}
+
+func TestIsErrorClustered(t *testing.T) {
+	uniform := make([]float64, 100)
+	clustered := make([]float64, 100)
+	for i := range uniform {
+		uniform[i] = float64(i)*100 + 50 // Spread across 10s
+		clustered[i] = 2000 + float64(i) // All within 2.0s-2.1s
+	}
+
+	if isErrorClustered(uniform, 10000) {
+		t.Error("expected evenly spread failures not to be clustered")
+	}
+	if !isErrorClustered(clustered, 10000) {
+		t.Error("expected bunched failures to be clustered")
+	}
+	if isErrorClustered(clustered[:errorClusterMinSamples-1], 10000) {
+		t.Error("expected too few failures never to be clustered")
+	}
+}
+
+func TestSampleErrorTime(t *testing.T) {
+	var times []float64
+	for i := 0; i < 3*maxErrorTimestamps; i++ {
+		times = sampleErrorTime(times, i, float64(i))
+	}
+	if len(times) != maxErrorTimestamps {
+		t.Fatalf("expected %d sampled times, got %d", maxErrorTimestamps, len(times))
+	}
+
+	// The sample should reach into every third of the failures
+	var late int
+	for _, ms := range times {
+		if ms >= 2*maxErrorTimestamps {
+			late++
+		}
+	}
+	if late == 0 || late == maxErrorTimestamps {
+		t.Errorf("expected a mix of early and late failures, got %d late of %d", late, len(times))
+	}
+}
+
+func TestMergeErrorTimes(t *testing.T) {
+	// Under the bound every sampled failure is kept
+	if times := mergeErrorTimes([][]float64{{1, 2}, {3}}, []int{2, 1}); len(times) != 3 {
+		t.Errorf("expected all 3 failure times, got %v", times)
+	}
+
+	// Over it each worker contributes in proportion to the failures it saw
+	busy := make([]float64, maxErrorTimestamps)
+	quiet := make([]float64, maxErrorTimestamps)
+	for i := range quiet {
+		quiet[i] = 1
+	}
+	times := mergeErrorTimes([][]float64{busy, quiet}, []int{3 * maxErrorTimestamps, maxErrorTimestamps})
+	if len(times) != maxErrorTimestamps {
+		t.Fatalf("expected %d failure times, got %d", maxErrorTimestamps, len(times))
+	}
+	var fromQuiet int
+	for _, ms := range times {
+		if ms == 1 {
+			fromQuiet++
+		}
+	}
+	if fromQuiet != maxErrorTimestamps/4 {
+		t.Errorf("expected %d failure times from the quieter worker, got %d", maxErrorTimestamps/4, fromQuiet)
+	}
+}
+
+func TestLoadTest_Percentiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	result := LoadTestWithOptions(context.Background(), client.New(server.URL, 10*time.Second), LoadTestOptions{
+		Concurrent:  2,
+		Duration:    100 * time.Millisecond,
+		Percentiles: []float64{90, 99.9},
+	})
+	if len(result.Percentiles) != 2 || result.Percentiles["p90"] <= 0 || result.Percentiles["p99.9"] < result.Percentiles["p90"] {
+		t.Errorf("expected p90 and p99.9, got %v", result.Percentiles)
+	}
+	if result.LatencyP50Ms <= 0 || result.LatencyP99Ms <= 0 {
+		t.Error("expected the legacy percentile fields to be filled in as well")
+	}
+
+	plain := LoadTest(context.Background(), client.New(server.URL, 10*time.Second), 1, 50*time.Millisecond)
+	if _, ok := plain.Percentiles["p95"]; !ok || len(plain.Percentiles) != 3 {
+		t.Errorf("expected p50, p95, and p99 by default, got %v", plain.Percentiles)
+	}
+}
+
+func TestParseSLATargets(t *testing.T) {
+	targets, err := ParseSLATargets(" 200ms, 1s ,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targets) != 2 || targets[0] != 200*time.Millisecond || targets[1] != time.Second {
+		t.Errorf("expected [200ms 1s], got %v", targets)
+	}
+
+	for _, bad := range []string{"", "200", "fast", "0s", "-1s"} {
+		if _, err := ParseSLATargets(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestParsePercentiles(t *testing.T) {
+	percentiles, err := ParsePercentiles(" 99.9, 50 ,90,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(percentiles) != 3 || percentiles[0] != 50 || percentiles[1] != 90 || percentiles[2] != 99.9 {
+		t.Errorf("expected [50 90 99.9], got %v", percentiles)
+	}
+	if bounds, err := ParsePercentiles("0,100"); err != nil || len(bounds) != 2 {
+		t.Errorf("expected 0 and 100 to be accepted, got %v, %v", bounds, err)
+	}
+	if key := PercentileKey(99.9); key != "p99.9" {
+		t.Errorf("expected p99.9, got %q", key)
+	}
+
+	for _, bad := range []string{"", "fast", "100.1", "-5", "50,90,50", "99,99.0"} {
+		if _, err := ParsePercentiles(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestSummarizeLoadTest_Spread(t *testing.T) {
+	var result internal.LoadTestResult
+	summarizeLoadTest(&result, 4, 4, 0, 0, []float64{40, 10, 30, 20}, time.Second)
+
+	if math.Abs(result.StdDevLatencyMs-math.Sqrt(125)) > 1e-9 {
+		t.Errorf("expected standard deviation %.4f, got %.4f", math.Sqrt(125), result.StdDevLatencyMs)
+	}
+	// p75 is 32.5 and p25 is 17.5 by linear interpolation
+	if result.IQRLatencyMs != 15 {
+		t.Errorf("expected IQR 15, got %v", result.IQRLatencyMs)
+	}
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	buckets := latencyHistogram([]float64{10, 11, 12, 14, 19, 20}, 2)
+	if len(buckets) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(buckets))
+	}
+	if b := buckets[0]; b.LowerMs != 10 || b.UpperMs != 15 || b.Count != 4 {
+		t.Errorf("unexpected first bucket %+v", b)
+	}
+	// The maximum belongs to the last bucket
+	if b := buckets[1]; b.LowerMs != 15 || b.UpperMs != 20 || b.Count != 2 {
+		t.Errorf("unexpected last bucket %+v", b)
+	}
+
+	if buckets := latencyHistogram([]float64{5, 5, 5}, 10); len(buckets) != 1 || buckets[0].Count != 3 {
+		t.Errorf("expected one bucket for identical latencies, got %+v", buckets)
+	}
+	if buckets := latencyHistogram(nil, 10); buckets != nil {
+		t.Errorf("expected no buckets without latencies, got %+v", buckets)
+	}
+}
+
+func TestStepStats(t *testing.T) {
+	latencies := []float64{10, 20, 30, 40, 50}
+	completions := []time.Duration{
+		100 * time.Millisecond, 400 * time.Millisecond, // step 1
+		600 * time.Millisecond, 900 * time.Millisecond, 1200 * time.Millisecond,
+	}
+	failedAt := []time.Duration{600 * time.Millisecond}
+
+	// The test ended 1.2s in, part way through the third step
+	stats := stepStats(latencies, completions, failedAt, 1200*time.Millisecond, 500*time.Millisecond, 1, 2)
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 steps, got %d", len(stats))
+	}
+	if stats[0].Concurrency != 1 || stats[1].Concurrency != 2 || stats[2].Concurrency != 2 {
+		t.Errorf("expected concurrency 1, 2, 2, got %d, %d, %d", stats[0].Concurrency, stats[1].Concurrency, stats[2].Concurrency)
+	}
+	if stats[1].Requests != 2 || stats[1].RPS != 4 || stats[1].ErrorRatePct != 50 {
+		t.Errorf("unexpected second step %+v", stats[1])
+	}
+	// The last step lasted 200ms, not a full interval
+	if stats[2].Requests != 1 || stats[2].RPS != 5 {
+		t.Errorf("unexpected last step %+v", stats[2])
+	}
+}
+
+func TestLoadTest_StepLoad(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent:   3,
+		Duration:     300 * time.Millisecond,
+		StepInterval: 100 * time.Millisecond,
+		StepStart:    1,
+	})
+
+	if len(result.StepResults) != 3 {
+		t.Fatalf("expected 3 steps, got %d", len(result.StepResults))
+	}
+	for i, s := range result.StepResults {
+		if s.Concurrency != i+1 || s.Requests == 0 {
+			t.Errorf("expected step %d at concurrency %d with requests, got %+v", i+1, i+1, s)
+		}
+	}
+	// The last worker joined two steps in, so it sent fewer requests than the first
+	if w := result.WorkerStats; w[2].TotalRequests >= w[0].TotalRequests {
+		t.Errorf("expected the last worker to start later, got %+v", w)
+	}
+	if result.Concurrent != 3 {
+		t.Errorf("expected a peak of 3 workers, got %d", result.Concurrent)
+	}
+
+	// A test shorter than the ramp reports the peak it reached, not --concurrent
+	short := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent:   10,
+		Duration:     250 * time.Millisecond,
+		StepInterval: 100 * time.Millisecond,
+		StepStart:    1,
+	})
+	if short.Concurrent != 3 {
+		t.Errorf("expected a peak of 3 workers, got %d", short.Concurrent)
+	}
+}
+
+func TestWindowStats(t *testing.T) {
+	latencies := []float64{10, 20, 30, 40, 50}
+	completions := []time.Duration{
+		100 * time.Millisecond, 400 * time.Millisecond, // window 1
+		600 * time.Millisecond, 900 * time.Millisecond,
+		time.Second, // at the very end, counted in the last window
+	}
+
+	stats := windowStats(latencies, completions, time.Second, 2)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 windows, got %d", len(stats))
+	}
+	if stats[0].Requests != 2 || stats[1].Requests != 3 {
+		t.Errorf("expected 2 and 3 requests, got %d and %d", stats[0].Requests, stats[1].Requests)
+	}
+	if stats[0].RPS != 4 || stats[1].RPS != 6 {
+		t.Errorf("expected 4 and 6 RPS, got %.2f and %.2f", stats[0].RPS, stats[1].RPS)
+	}
+	if stats[1].StartSec != 0.5 {
+		t.Errorf("expected second window to start at 0.5s, got %.2f", stats[1].StartSec)
+	}
+	if stats[1].LatencyP95Ms != Percentile([]float64{30, 40, 50}, 95) {
+		t.Errorf("unexpected second window p95 %.2f", stats[1].LatencyP95Ms)
+	}
+}
+
+func TestRPSTimeline(t *testing.T) {
+	completions := []time.Duration{
+		200 * time.Millisecond, 900 * time.Millisecond, // second 1
+		1500 * time.Millisecond,
+		2100 * time.Millisecond, // in the final partial second, counted in the last
+	}
+
+	timeline := rpsTimeline(completions, 2200*time.Millisecond)
+	if len(timeline) != 2 || timeline[0] != 2 || timeline[1] != 2 {
+		t.Errorf("expected [2 2], got %v", timeline)
+	}
+	if timeline := rpsTimeline(completions, 500*time.Millisecond); timeline != nil {
+		t.Errorf("expected no timeline for a run under a second, got %v", timeline)
+	}
+}
+
+func TestWebSocketLoadTest_Echo(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		// The handshake carries the client's identity
+		if h := ws.Request().Header; h.Get("Authorization") != "Bearer test-token" || h.Get("User-Agent") != "bench-ws" {
+			t.Errorf("expected the client's token and User-Agent, got %q and %q", h.Get("Authorization"), h.Get("User-Agent"))
+		}
+		io.Copy(ws, ws)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second, client.WithToken("test-token"), client.WithUserAgent("bench-ws"))
+	result := WebSocketLoadTest(context.Background(), c, "ws", 3, 200*time.Millisecond)
+
+	if result.Error != "" {
+		t.Fatalf("unexpected error: %s", result.Error)
+	}
+	if result.Path != "/ws" {
+		t.Errorf("expected normalized path /ws, got %s", result.Path)
+	}
+	if result.Connections != 3 {
+		t.Errorf("expected 3 connections, got %d", result.Connections)
+	}
+	if result.Successful == 0 || result.Failed != 0 {
+		t.Errorf("expected only successful round trips, got %d ok / %d failed", result.Successful, result.Failed)
+	}
+	if result.LatencyP50Ms <= 0 || result.LatencyP95Ms < result.LatencyP50Ms {
+		t.Errorf("unexpected latency percentiles: p50=%.3f p95=%.3f", result.LatencyP50Ms, result.LatencyP95Ms)
+	}
+}
+
+func TestWebSocketLoadTest_NotWebSocket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	result := WebSocketLoadTest(context.Background(), client.New(server.URL, 10*time.Second), "/ws", 2, 100*time.Millisecond)
+
+	if result.Error == "" {
+		t.Error("expected dial error when the endpoint does not upgrade")
+	}
+	if result.Connections != 0 {
+		t.Errorf("expected no connections, got %d", result.Connections)
+	}
+	if result.Failed != 2 || result.TotalMessages != 2 {
+		t.Errorf("expected both failed dials counted, got %d failed of %d", result.Failed, result.TotalMessages)
+	}
+}
+
+func TestWebSocketURL(t *testing.T) {
+	tests := []struct {
+		base, path, expected string
+		wantErr              bool
+	}{
+		{"http://example.com", "/ws", "ws://example.com/ws", false},
+		{"https://example.com/", "/api/live", "wss://example.com/api/live", false},
+		{"ftp://example.com", "/ws", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := webSocketURL(tt.base, tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("webSocketURL(%q, %q) error = %v, wantErr %t", tt.base, tt.path, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("webSocketURL(%q, %q) = %q, want %q", tt.base, tt.path, got, tt.expected)
+		}
+	}
+}
+
 func TestPercentile(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -217,14 +1095,14 @@ func TestPercentile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := percentile(tt.data, tt.p)
+			result := Percentile(tt.data, tt.p)
 			// Allow small floating point difference
 			diff := result - tt.expected
 			if diff < 0 {
 				diff = -diff
 			}
 			if diff > 0.01 {
-				t.Errorf("percentile(%v, %v) = %v, expected %v", tt.data, tt.p, result, tt.expected)
+				t.Errorf("Percentile(%v, %v) = %v, expected %v", tt.data, tt.p, result, tt.expected)
 			}
 		})
 	}
@@ -238,19 +1116,19 @@ func TestPercentile_LargeDataset(t *testing.T) {
 	}
 
 	// p50 should be around 500
-	p50 := percentile(data, 50)
+	p50 := Percentile(data, 50)
 	if p50 < 495 || p50 > 505 {
 		t.Errorf("p50 of 1-1000 should be around 500, got %v", p50)
 	}
 
 	// p95 should be around 950
-	p95 := percentile(data, 95)
+	p95 := Percentile(data, 95)
 	if p95 < 945 || p95 > 955 {
 		t.Errorf("p95 of 1-1000 should be around 950, got %v", p95)
 	}
 
 	// p99 should be around 990
-	p99 := percentile(data, 99)
+	p99 := Percentile(data, 99)
 	if p99 < 985 || p99 > 995 {
 		t.Errorf("p99 of 1-1000 should be around 990, got %v", p99)
 	}
@@ -3,17 +3,191 @@ package metrics
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+	"github.com/quic-go/quic-go/http3"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// keepAliveProbeRequests is the number of sequential requests made by MeasureKeepAlive
+const keepAliveProbeRequests = 10
+
+// Traceroute settings
+const (
+	traceMaxHops  = 30
+	traceBasePort = 33434 // Conventional traceroute UDP destination port
+	traceHopWait  = time.Second
+)
+
+// Path MTU discovery settings
+const (
+	ipv4HeaderLen = 20
+	ipv6HeaderLen = 40
+	tcpHeaderLen  = 20
+	udpHeaderLen  = 8
+	minIPv4MTU    = 68   // Smallest MTU every IPv4 link must carry
+	minIPv6MTU    = 1280 // Smallest MTU every IPv6 link must carry
+	maxProbeMTU   = 9000 // Jumbo frame size, the largest MTU probed over UDP
+)
+
+// IP address family preferences
+const (
+	IPFamilyAny  = ""
+	IPFamilyIPv4 = "ipv4"
+	IPFamilyIPv6 = "ipv6"
+)
+
+// DialNetwork returns the net dial network for an IP family preference
+func DialNetwork(family string) string {
+	switch family {
+	case IPFamilyIPv4:
+		return "tcp4"
+	case IPFamilyIPv6:
+		return "tcp6"
+	default:
+		return "tcp"
+	}
+}
+
+// ConnectivityOptions configures a connectivity measurement
+type ConnectivityOptions struct {
+	Family   string // Preferred IP family: IPFamilyAny, IPFamilyIPv4, or IPFamilyIPv6
+	HTTPOnly bool   // Skip the TLS handshake and record TLSMs as internal.NotApplicableMs
+
+	DNSResolver string // DNS server (host or host:port) used instead of the system resolver
+	DoHURL      string // DNS-over-HTTPS endpoint used instead of the system resolver; takes precedence over DNSResolver
+
+	BindAddr string // Local IP address to connect from; only the target's addresses of its family are probed
+
+	UnixSocket string // Unix domain socket to connect to; DNS and TCP are skipped and TCPMs times the socket connect
+
+	HTTP3 bool // Also request the target over HTTP/3 once connected, recording HTTP3Ms if the server supports it
+}
+
+// DNSCache remembers the addresses of host names for a fixed time, so a load
+// test resolves the target once rather than for every new connection. Failed
+// lookups are not cached. It is safe for concurrent use.
+type DNSCache struct {
+	lookup func(ctx context.Context, host string) ([]string, error)
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]dnsCacheEntry
+	hits    int
+}
+
+// dnsCacheEntry is the addresses of one host name and when they expire
+type dnsCacheEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// NewDNSCache returns a DNSCache that resolves host names with lookup, such
+// as one from HostLookup, and keeps each answer for ttl
+func NewDNSCache(lookup func(ctx context.Context, host string) ([]string, error), ttl time.Duration) *DNSCache {
+	return &DNSCache{lookup: lookup, ttl: ttl, entries: make(map[string]dnsCacheEntry)}
+}
+
+// HostLookup returns a function resolving host names with the DNS-over-HTTPS
+// endpoint dohURL when it is set, and otherwise with the DNS server
+// dnsResolver or, when that is empty, the system resolver
+func HostLookup(dnsResolver, dohURL string, timeout time.Duration) func(ctx context.Context, host string) ([]string, error) {
+	if dohURL == "" {
+		return client.NewResolver(dnsResolver).LookupHost
+	}
+	httpClient := &http.Client{Timeout: timeout}
+	return func(ctx context.Context, host string) ([]string, error) {
+		ips, err := lookupDoH(ctx, httpClient, dohURL, host)
+		if err != nil {
+			return nil, err
+		}
+		addrs := make([]string, len(ips))
+		for i, ip := range ips {
+			addrs[i] = ip.String()
+		}
+		return addrs, nil
+	}
+}
+
+// LookupHost returns the addresses of host, from the cache while its entry is
+// fresh and otherwise from the resolver
+func (c *DNSCache) LookupHost(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[host]; ok && time.Now().Before(entry.expires) {
+		c.hits++
+		c.mu.Unlock()
+		return entry.addrs, nil
+	}
+	c.mu.Unlock()
+
+	addrs, err := c.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[host] = dnsCacheEntry{addrs: addrs, expires: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+	return addrs, nil
+}
+
+// Hits returns how many lookups were answered from the cache
+func (c *DNSCache) Hits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits
+}
+
+// probeRootCAs verifies the server certificate in the TLS and HTTP/3 probes;
+// nil uses the system roots
+var probeRootCAs *x509.CertPool
+
+// familyProbeTimeout bounds the TCP probe of the address family the measured
+// connection did not use
+const familyProbeTimeout = 3 * time.Second
+
+// Bounds of the wait for a TLS 1.3 session ticket after the handshake
+const (
+	sessionTicketMinWait = 50 * time.Millisecond
+	sessionTicketTimeout = 2 * time.Second
 )
 
 // MeasureConnectivity measures DNS, TCP, and TLS connection timing
 func MeasureConnectivity(ctx context.Context, targetURL string, timeout time.Duration) *internal.ConnectivityResult {
+	return MeasureConnectivityWithOptions(ctx, targetURL, timeout, ConnectivityOptions{})
+}
+
+// MeasureConnectivityPreferring measures connection timing using an address from the
+// preferred IP family. Both IPv4 and IPv6 are probed separately when the host has
+// addresses of each kind, regardless of preference.
+func MeasureConnectivityPreferring(ctx context.Context, targetURL string, timeout time.Duration, family string) *internal.ConnectivityResult {
+	return MeasureConnectivityWithOptions(ctx, targetURL, timeout, ConnectivityOptions{Family: family})
+}
+
+// MeasureConnectivityWithOptions measures connection timing as configured by opts.
+// A TLS handshake is timed only for https URLs without opts.HTTPOnly.
+func MeasureConnectivityWithOptions(ctx context.Context, targetURL string, timeout time.Duration, opts ConnectivityOptions) *internal.ConnectivityResult {
 	result := &internal.ConnectivityResult{}
+	if opts.HTTPOnly {
+		result.TLSMs = internal.NotApplicableMs
+	}
 
 	parsedURL, err := url.Parse(targetURL)
 	if err != nil {
@@ -31,9 +205,24 @@ func MeasureConnectivity(ctx context.Context, targetURL string, timeout time.Dur
 		}
 	}
 
+	if opts.UnixSocket != "" {
+		return measureUnixSocket(ctx, result, parsedURL, timeout, opts)
+	}
+
 	// DNS Resolution
+	var ips []net.IPAddr
 	dnsStart := time.Now()
-	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if opts.DoHURL != "" {
+		result.DNSMethod = internal.DNSMethodDoH
+		result.DOHServer = opts.DoHURL
+		ips, err = lookupDoH(ctx, &http.Client{Timeout: timeout}, opts.DoHURL, host)
+	} else {
+		result.DNSMethod = internal.DNSMethodSystem
+		if opts.DNSResolver != "" {
+			result.DNSResolver = client.ResolverAddress(opts.DNSResolver)
+		}
+		ips, err = client.NewResolver(opts.DNSResolver).LookupIPAddr(ctx, host)
+	}
 	dnsDuration := time.Since(dnsStart)
 	result.DNSMs = float64(dnsDuration.Microseconds()) / 1000.0
 
@@ -47,12 +236,49 @@ func MeasureConnectivity(ctx context.Context, targetURL string, timeout time.Dur
 		return result
 	}
 
-	// TCP Connection
-	address := net.JoinHostPort(ips[0].IP.String(), port)
 	dialer := &net.Dialer{
 		Timeout: timeout,
 	}
 
+	// Probe each address family independently
+	ipv4, ipv6 := splitIPFamilies(ips)
+
+	// A bound connection can only reach addresses of the local address's family
+	family := opts.Family
+	if opts.BindAddr != "" {
+		local := net.ParseIP(opts.BindAddr)
+		if local == nil {
+			result.Error = fmt.Sprintf("invalid bind address %q", opts.BindAddr)
+			return result
+		}
+		dialer.LocalAddr = &net.TCPAddr{IP: local}
+		result.BoundTo = local.String()
+		if local.To4() != nil {
+			ipv6, family = nil, IPFamilyIPv4
+		} else {
+			ipv4, family = nil, IPFamilyIPv6
+		}
+	}
+
+	ip := ips[0].IP
+	switch family {
+	case IPFamilyIPv4:
+		if len(ipv4) == 0 {
+			result.Error = fmt.Sprintf("no IPv4 address for %s", host)
+			return result
+		}
+		ip = ipv4[0]
+	case IPFamilyIPv6:
+		if len(ipv6) == 0 {
+			result.Error = fmt.Sprintf("no IPv6 address for %s", host)
+			return result
+		}
+		ip = ipv6[0]
+	}
+
+	// TCP Connection
+	address := net.JoinHostPort(ip.String(), port)
+
 	tcpStart := time.Now()
 	conn, err := dialer.DialContext(ctx, "tcp", address)
 	tcpDuration := time.Since(tcpStart)
@@ -60,18 +286,72 @@ func MeasureConnectivity(ctx context.Context, targetURL string, timeout time.Dur
 
 	if err != nil {
 		result.Error = fmt.Sprintf("TCP connection failed: %v", err)
+		probeOtherFamily(ctx, result, dialer, ip, ipv4, ipv6, port)
+		return result
+	}
+	// The measured connection doubles as the probe of its own family
+	if ip.To4() != nil {
+		result.IPv4Ms = result.TCPMs
+	} else {
+		result.IPv6Ms = result.TCPMs
+	}
+
+	redial := func(ctx context.Context) (net.Conn, error) {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+	result = finishConnectivity(ctx, result, conn, redial, parsedURL, opts)
+	// Probed only now, so it neither warms the measured path nor delays it
+	probeOtherFamily(ctx, result, dialer, ip, ipv4, ipv6, port)
+	// A server without HTTP/3 is left unsupported rather than failing the check.
+	// HTTP/3 runs over TLS only, so plain HTTP targets are not probed.
+	if opts.HTTP3 && result.Connected && parsedURL.Scheme == "https" {
+		if ms, err := probeHTTP3(ctx, targetURL, timeout); err != nil {
+			result.HTTP3Error = err.Error()
+		} else {
+			result.HTTP3Ms = ms
+			result.HTTP3Supported = true
+		}
+	}
+	return result
+}
+
+// measureUnixSocket times a connection to the Unix domain socket in opts in
+// place of DNS and TCP. The socket path needs no resolution, so DNSMs stays 0.
+func measureUnixSocket(ctx context.Context, result *internal.ConnectivityResult, parsedURL *url.URL, timeout time.Duration, opts ConnectivityOptions) *internal.ConnectivityResult {
+	result.UnixSocket = opts.UnixSocket
+	dialer := &net.Dialer{Timeout: timeout}
+
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "unix", opts.UnixSocket)
+	result.TCPMs = float64(time.Since(start).Microseconds()) / 1000.0
+	if err != nil {
+		result.Error = fmt.Sprintf("Unix socket connection failed: %v", err)
 		return result
 	}
 
+	redial := func(ctx context.Context) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", opts.UnixSocket)
+	}
+	return finishConnectivity(ctx, result, conn, redial, parsedURL, opts)
+}
+
+// finishConnectivity times the TLS handshake over conn for https URLs, then
+// closes conn and totals the timings. redial opens a second connection for
+// the TLS resumption probe.
+func finishConnectivity(ctx context.Context, result *internal.ConnectivityResult, conn net.Conn, redial func(context.Context) (net.Conn, error), parsedURL *url.URL, opts ConnectivityOptions) *internal.ConnectivityResult {
 	// TLS Handshake (if HTTPS)
-	if parsedURL.Scheme == "https" {
+	if parsedURL.Scheme == "https" && !opts.HTTPOnly {
+		// The cache starts empty for every measurement, so the timed handshake
+		// is always a full one; only the resumption probe reuses its session
 		tlsConfig := &tls.Config{
-			ServerName: host,
+			ServerName:         parsedURL.Hostname(),
+			RootCAs:            probeRootCAs,
+			ClientSessionCache: tls.NewLRUClientSessionCache(1),
 		}
 
 		tlsStart := time.Now()
 		tlsConn := tls.Client(conn, tlsConfig)
-		err = tlsConn.HandshakeContext(ctx)
+		err := tlsConn.HandshakeContext(ctx)
 		tlsDuration := time.Since(tlsStart)
 		result.TLSMs = float64(tlsDuration.Microseconds()) / 1000.0
 
@@ -81,13 +361,399 @@ func MeasureConnectivity(ctx context.Context, targetURL string, timeout time.Dur
 			return result
 		}
 
+		result.TLSFullHandshakeMs = result.TLSMs
+		if tlsConn.ConnectionState().Version == tls.VersionTLS13 {
+			receiveSessionTicket(tlsConn, sessionTicketWait(result.TCPMs))
+		}
 		tlsConn.Close()
+		probeTLSResumption(ctx, result, redial, tlsConfig)
 	} else {
 		conn.Close()
 	}
 
-	result.TotalMs = result.DNSMs + result.TCPMs + result.TLSMs
+	result.TotalMs = result.DNSMs + result.TCPMs + max(result.TLSMs, 0)
 	result.Connected = true
+	if opts.HTTPOnly {
+		result.TLSMs = internal.NotApplicableMs
+	}
 
 	return result
 }
+
+// receiveSessionTicket reads from conn for wait without sending anything. A
+// TLS 1.3 server sends its session ticket after the handshake, and the client
+// only stores it in the session cache once it reads from the connection. The
+// read ends at the deadline; without a ticket, the resumption probe makes a
+// full handshake.
+func receiveSessionTicket(conn *tls.Conn, wait time.Duration) {
+	conn.SetReadDeadline(time.Now().Add(wait))
+	conn.Read(make([]byte, 1))
+}
+
+// sessionTicketWait is how long to wait for a session ticket, which arrives
+// about a round trip after the handshake: a few times the TCP connect time
+// tcpMs, within sessionTicketMinWait and sessionTicketTimeout
+func sessionTicketWait(tcpMs float64) time.Duration {
+	wait := time.Duration(4 * tcpMs * float64(time.Millisecond))
+	return min(max(wait, sessionTicketMinWait), sessionTicketTimeout)
+}
+
+// probeTLSResumption opens a second connection with redial and shakes hands
+// with config, whose session cache holds the session of the measured
+// handshake. When the server resumes it, TLSResumed and the resumed
+// handshake's time are recorded. Errors are ignored; they only mean the
+// session was not resumed.
+func probeTLSResumption(ctx context.Context, result *internal.ConnectivityResult, redial func(context.Context) (net.Conn, error), config *tls.Config) {
+	conn, err := redial(ctx)
+	if err != nil {
+		return
+	}
+	tlsConn := tls.Client(conn, config)
+	defer tlsConn.Close()
+
+	start := time.Now()
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return
+	}
+	if tlsConn.ConnectionState().DidResume {
+		result.TLSResumed = true
+		result.TLSResumedMs = float64(time.Since(start).Microseconds()) / 1000.0
+	}
+}
+
+// probeHTTP3 requests targetURL over HTTP/3 and returns the milliseconds from
+// the start of the QUIC handshake to the first byte of the response. A server
+// that does not speak HTTP/3 fails the handshake or times out, returning an
+// error. The address is resolved with the system resolver.
+func probeHTTP3(ctx context.Context, targetURL string, timeout time.Duration) (float64, error) {
+	parsedURL, err := url.Parse(targetURL)
+	if err != nil {
+		return 0, fmt.Errorf("parse URL: %w", err)
+	}
+	if parsedURL.Scheme != "https" {
+		return 0, fmt.Errorf("HTTP/3 requires an https URL, got %s", parsedURL.Scheme)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+
+	transport := &http3.Transport{TLSClientConfig: &tls.Config{RootCAs: probeRootCAs}}
+	defer transport.Close()
+
+	start := time.Now()
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return 0, fmt.Errorf("HTTP/3 request: %w", err)
+	}
+	elapsed := time.Since(start)
+	resp.Body.Close()
+	return float64(elapsed.Microseconds()) / 1000.0, nil
+}
+
+// splitIPFamilies separates resolved addresses into IPv4 and IPv6
+func splitIPFamilies(addrs []net.IPAddr) (ipv4, ipv6 []net.IP) {
+	for _, addr := range addrs {
+		if addr.IP.To4() != nil {
+			ipv4 = append(ipv4, addr.IP)
+		} else {
+			ipv6 = append(ipv6, addr.IP)
+		}
+	}
+	return ipv4, ipv6
+}
+
+// probeOtherFamily records the TCP connect time to the first address of the
+// family the measured connection to measured did not use, if the host has
+// one. The probe gives up after familyProbeTimeout, so an unreachable family
+// costs little.
+func probeOtherFamily(ctx context.Context, result *internal.ConnectivityResult, dialer *net.Dialer, measured net.IP, ipv4, ipv6 []net.IP, port string) {
+	other := ipv6
+	if measured.To4() == nil {
+		other = ipv4
+	}
+	if len(other) == 0 {
+		return
+	}
+
+	probeDialer := *dialer
+	if probeDialer.Timeout <= 0 || probeDialer.Timeout > familyProbeTimeout {
+		probeDialer.Timeout = familyProbeTimeout
+	}
+	ms := probeTCP(ctx, &probeDialer, other[0], port)
+	if measured.To4() == nil {
+		result.IPv4Ms = ms
+	} else {
+		result.IPv6Ms = ms
+	}
+}
+
+// probeTCP returns the TCP connect time to ip in milliseconds, or 0 if the connection fails
+func probeTCP(ctx context.Context, dialer *net.Dialer, ip net.IP, port string) float64 {
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip.String(), port))
+	if err != nil {
+		return 0
+	}
+	elapsed := time.Since(start)
+	conn.Close()
+	return float64(elapsed.Microseconds()) / 1000.0
+}
+
+// MeasureKeepAlive reports the fraction of sequential /health requests that reused a connection
+func MeasureKeepAlive(ctx context.Context, c *client.Client) (float64, error) {
+	return probeKeepAlive(ctx, c, "/health", keepAliveProbeRequests)
+}
+
+// probeKeepAlive makes requestCount sequential requests to path and returns the fraction
+// of requests after the first that were sent on a reused connection
+func probeKeepAlive(ctx context.Context, c *client.Client, path string, requestCount int) (float64, error) {
+	if requestCount < 2 {
+		return 0, fmt.Errorf("keep-alive probe needs at least 2 requests, got %d", requestCount)
+	}
+
+	reused := 0
+	for i := 0; i < requestCount; i++ {
+		resp, timing, err := c.GetWithTiming(ctx, path)
+		if err != nil {
+			return 0, fmt.Errorf("keep-alive probe request %d: %w", i+1, err)
+		}
+		// The body must be fully read and closed for the connection to return to the pool
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		// The first request may reuse a connection from earlier phases, so it is not counted
+		if i > 0 && timing.ConnReused {
+			reused++
+		}
+	}
+
+	return float64(reused) / float64(requestCount-1), nil
+}
+
+// MeasureRoute counts the network hops to the target URL's host and
+// returns the round-trip time to each hop
+func MeasureRoute(targetURL string, timeout time.Duration) (int, []float64, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	return measureHops(u.Hostname(), timeout)
+}
+
+// MeasurePathMTU estimates the path MTU to the target URL's host and port
+func MeasurePathMTU(targetURL string, timeout time.Duration) (int, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid URL: %w", err)
+	}
+	port := u.Port()
+	if port == "" {
+		if u.Scheme == "https" {
+			port = "443"
+		} else {
+			port = "80"
+		}
+	}
+	return discoverMTU(net.JoinHostPort(u.Hostname(), port), timeout)
+}
+
+// discoverMTU connects to addr (host:port) over TCP and adds the IP and TCP
+// header sizes to the negotiated maximum segment size. TCP options such as
+// timestamps can make this understate the MTU by a few bytes. Where the MSS
+// cannot be read it falls back to a UDP probe with the don't-fragment bit set.
+func discoverMTU(addr string, timeout time.Duration) (int, error) {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return 0, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	tcpConn := conn.(*net.TCPConn)
+	remote := tcpConn.RemoteAddr().(*net.TCPAddr)
+	headers := ipv4HeaderLen + tcpHeaderLen
+	if remote.IP.To4() == nil {
+		headers = ipv6HeaderLen + tcpHeaderLen
+	}
+
+	mss, err := tcpMaxSegment(tcpConn)
+	if err == nil && mss <= 0 {
+		err = fmt.Errorf("invalid MSS %d", mss)
+	}
+	if err == nil {
+		return mss + headers, nil
+	}
+
+	mtu, probeErr := probeUDPMTU(remote.IP, timeout)
+	if probeErr != nil {
+		return 0, fmt.Errorf("read MSS: %v; %w", err, probeErr)
+	}
+	return mtu, nil
+}
+
+// measureHops sends UDP probes with TTL 1 to 30 and times the ICMP replies.
+// Hops that do not reply are recorded as 0 ms. When raw ICMP sockets are not
+// permitted, or the host is IPv6-only, it falls back to the system
+// traceroute/tracert command.
+func measureHops(host string, timeout time.Duration) (int, []float64, error) {
+	hops, rtts, err := measureHopsRaw(host, timeout)
+	if err == nil || !errors.Is(err, errRawSocketUnavailable) {
+		return hops, rtts, err
+	}
+	return measureHopsCommand(host, timeout)
+}
+
+var errRawSocketUnavailable = errors.New("raw ICMP socket unavailable")
+
+func measureHopsRaw(host string, timeout time.Duration) (int, []float64, error) {
+	dst, err := net.ResolveIPAddr("ip4", host)
+	if err != nil {
+		// No IPv4 address; only the system command handles IPv6
+		return 0, nil, fmt.Errorf("%w: %v", errRawSocketUnavailable, err)
+	}
+
+	listener, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) || errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EACCES) {
+			return 0, nil, fmt.Errorf("%w: %v", errRawSocketUnavailable, err)
+		}
+		return 0, nil, fmt.Errorf("listen icmp: %w", err)
+	}
+	defer listener.Close()
+
+	udp, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return 0, nil, fmt.Errorf("open udp socket: %w", err)
+	}
+	defer udp.Close()
+	probe := ipv4.NewPacketConn(udp)
+
+	deadline := time.Now().Add(timeout)
+	var rtts []float64
+	for ttl := 1; ttl <= traceMaxHops; ttl++ {
+		if time.Now().After(deadline) {
+			return len(rtts), rtts, fmt.Errorf("traceroute timed out after %d hops", len(rtts))
+		}
+		if err := probe.SetTTL(ttl); err != nil {
+			return 0, nil, fmt.Errorf("set ttl: %w", err)
+		}
+
+		port := traceBasePort + ttl
+		start := time.Now()
+		if _, err := udp.WriteTo([]byte("actalog-bench"), &net.UDPAddr{IP: dst.IP, Port: port}); err != nil {
+			return 0, nil, fmt.Errorf("send probe: %w", err)
+		}
+
+		wait := start.Add(traceHopWait)
+		if wait.After(deadline) {
+			wait = deadline
+		}
+		rtt, reached := readHopReply(listener, port, wait, start)
+		rtts = append(rtts, rtt)
+		if reached {
+			return ttl, rtts, nil
+		}
+	}
+
+	return traceMaxHops, rtts, fmt.Errorf("destination not reached within %d hops", traceMaxHops)
+}
+
+// readHopReply waits until deadline for the ICMP reply to the probe sent to port.
+// It returns the round trip in ms (0 if none arrived) and whether the reply came
+// from the destination itself.
+func readHopReply(conn *icmp.PacketConn, port int, deadline, start time.Time) (float64, bool) {
+	buf := make([]byte, 1500)
+	conn.SetReadDeadline(deadline)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, false
+		}
+		elapsed := float64(time.Since(start).Microseconds()) / 1000.0
+
+		msg, err := icmp.ParseMessage(1, buf[:n]) // 1 = ICMPv4
+		if err != nil {
+			continue
+		}
+		var original []byte
+		switch body := msg.Body.(type) {
+		case *icmp.TimeExceeded:
+			original = body.Data
+		case *icmp.DstUnreach:
+			original = body.Data
+		default:
+			continue
+		}
+		if probePort(original) != port {
+			continue // Reply to another probe or another process
+		}
+		return elapsed, msg.Type == ipv4.ICMPTypeDestinationUnreachable
+	}
+}
+
+// probePort extracts the UDP destination port from the original datagram
+// quoted in an ICMP error, or -1 if it cannot be read
+func probePort(original []byte) int {
+	if len(original) < ipv4.HeaderLen {
+		return -1
+	}
+	headerLen := int(original[0]&0x0f) * 4
+	if len(original) < headerLen+4 {
+		return -1
+	}
+	return int(original[headerLen+2])<<8 | int(original[headerLen+3])
+}
+
+func measureHopsCommand(host string, timeout time.Duration) (int, []float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "tracert", "-d", "-h", strconv.Itoa(traceMaxHops), "-w", "1000", host)
+	} else {
+		cmd = exec.CommandContext(ctx, "traceroute", "-n", "-q", "1", "-w", "1", "-m", strconv.Itoa(traceMaxHops), host)
+	}
+
+	output, err := cmd.Output()
+	if err != nil && len(output) == 0 {
+		return 0, nil, fmt.Errorf("run %s: %w", cmd.Path, err)
+	}
+
+	hops, rtts := parseTracerouteOutput(string(output))
+	if hops == 0 {
+		return 0, nil, fmt.Errorf("no hops in %s output", cmd.Path)
+	}
+	return hops, rtts, nil
+}
+
+var (
+	traceHopLine = regexp.MustCompile(`^\s*(\d+)\s+(.*)$`)
+	traceRTT     = regexp.MustCompile(`<?(\d+(?:\.\d+)?)\s*ms`)
+)
+
+// parseTracerouteOutput reads hop numbers and the first RTT on each hop line
+// from traceroute or tracert output. Hops without a reply are recorded as 0 ms.
+func parseTracerouteOutput(output string) (int, []float64) {
+	var rtts []float64
+	for _, line := range strings.Split(output, "\n") {
+		m := traceHopLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		hop, _ := strconv.Atoi(m[1])
+		if hop != len(rtts)+1 {
+			continue // Not a hop line, e.g. a wrapped header
+		}
+
+		rtt := 0.0
+		if r := traceRTT.FindStringSubmatch(m[2]); r != nil {
+			rtt, _ = strconv.ParseFloat(r[1], 64)
+		}
+		rtts = append(rtts, rtt)
+	}
+	return len(rtts), rtts
+}
@@ -2,9 +2,21 @@ package metrics
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+	"github.com/quic-go/quic-go/http3"
 )
 
 func TestMeasureConnectivity_HTTP(t *testing.T) {
@@ -45,6 +57,61 @@ func TestMeasureConnectivity_HTTP(t *testing.T) {
 	}
 }
 
+func TestMeasureConnectivityWithOptions_HTTPOnly(t *testing.T) {
+	server := httptest.NewServer(nil)
+	defer server.Close()
+
+	result := MeasureConnectivityWithOptions(context.Background(), server.URL, 10*time.Second, ConnectivityOptions{HTTPOnly: true})
+
+	if !result.Connected {
+		t.Errorf("expected connected=true, error: %s", result.Error)
+	}
+	if result.TLSMs != internal.NotApplicableMs {
+		t.Errorf("expected TLS time %d (not applicable), got %f", internal.NotApplicableMs, result.TLSMs)
+	}
+
+	// The sentinel is not counted in the total
+	expectedTotal := result.DNSMs + result.TCPMs
+	if result.TotalMs != expectedTotal {
+		t.Errorf("expected total %f, got %f", expectedTotal, result.TotalMs)
+	}
+}
+
+func TestMeasureConnectivityWithOptions_UnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "actalog.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	// The host does not resolve, so success means DNS was skipped
+	result := MeasureConnectivityWithOptions(context.Background(), "http://actalog.invalid", 10*time.Second, ConnectivityOptions{UnixSocket: socket})
+	if !result.Connected {
+		t.Fatalf("expected connected=true, error: %s", result.Error)
+	}
+	if result.UnixSocket != socket || result.DNSMs != 0 {
+		t.Errorf("expected the socket path and no DNS time, got %+v", result)
+	}
+	if result.TotalMs != result.TCPMs {
+		t.Errorf("expected total %f to be the socket connect time %f", result.TotalMs, result.TCPMs)
+	}
+
+	result = MeasureConnectivityWithOptions(context.Background(), "http://actalog.invalid", 10*time.Second, ConnectivityOptions{UnixSocket: socket + ".missing"})
+	if result.Connected || !strings.Contains(result.Error, "Unix socket connection failed") {
+		t.Errorf("expected a socket connection error, got %+v", result)
+	}
+}
+
 func TestMeasureConnectivity_HTTPS(t *testing.T) {
 	server := httptest.NewTLSServer(nil)
 	defer server.Close()
@@ -65,6 +132,108 @@ func TestMeasureConnectivity_HTTPS(t *testing.T) {
 	}
 }
 
+func TestMeasureConnectivity_TLSResumption(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { requests.Add(1) }))
+	defer server.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	probeRootCAs = roots
+	defer func() { probeRootCAs = nil }()
+
+	// Every measurement makes a full handshake; only its probe resumes the session
+	for i := 0; i < 2; i++ {
+		result := MeasureConnectivity(context.Background(), server.URL, 5*time.Second)
+		if !result.Connected || result.Error != "" {
+			t.Fatalf("expected a connected result, got %+v", result)
+		}
+		if result.TLSFullHandshakeMs != result.TLSMs || result.TLSMs <= 0 {
+			t.Errorf("expected a full handshake, got tls=%f full=%f", result.TLSMs, result.TLSFullHandshakeMs)
+		}
+		if !result.TLSResumed || result.TLSResumedMs <= 0 {
+			t.Errorf("expected the probe to resume the session, got resumed=%t %fms", result.TLSResumed, result.TLSResumedMs)
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("expected no requests sent to receive the session ticket, got %d", n)
+	}
+}
+
+func TestProbeHTTP3(t *testing.T) {
+	// Borrow the httptest certificate for a QUIC listener
+	tcpServer := httptest.NewTLSServer(nil)
+	defer tcpServer.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(tcpServer.Certificate())
+	probeRootCAs = roots
+	defer func() { probeRootCAs = nil }()
+
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("UDP not available: %v", err)
+	}
+	server := &http3.Server{
+		Handler:   http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }),
+		TLSConfig: http3.ConfigureTLSConfig(&tls.Config{Certificates: tcpServer.TLS.Certificates}),
+	}
+	go server.Serve(udpConn)
+	defer server.Close()
+
+	ms, err := probeHTTP3(context.Background(), "https://"+udpConn.LocalAddr().String(), 5*time.Second)
+	if err != nil || ms <= 0 {
+		t.Errorf("expected an HTTP/3 time to first byte, got %.2fms (err %v)", ms, err)
+	}
+
+	// The TCP-only server has nothing listening for QUIC
+	if _, err := probeHTTP3(context.Background(), tcpServer.URL, 500*time.Millisecond); err == nil {
+		t.Error("expected an error from a server without HTTP/3")
+	}
+	if _, err := probeHTTP3(context.Background(), "http://127.0.0.1:80", time.Second); err == nil {
+		t.Error("expected an error for a plain http URL")
+	}
+}
+
+func TestMeasureConnectivityWithOptions_HTTP3Unsupported(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	probeRootCAs = roots
+	defer func() { probeRootCAs = nil }()
+
+	result := MeasureConnectivityWithOptions(context.Background(), server.URL, 500*time.Millisecond, ConnectivityOptions{HTTP3: true})
+	if !result.Connected || result.Error != "" {
+		t.Fatalf("expected a connected result despite no HTTP/3, got %+v", result)
+	}
+	if result.HTTP3Supported || result.HTTP3Ms != 0 || result.HTTP3Error == "" {
+		t.Errorf("expected HTTP/3 unsupported with a reason, got supported=%v %.2fms %q", result.HTTP3Supported, result.HTTP3Ms, result.HTTP3Error)
+	}
+
+	// Plain HTTP targets are not probed at all
+	plain := httptest.NewServer(nil)
+	defer plain.Close()
+	result = MeasureConnectivityWithOptions(context.Background(), plain.URL, 5*time.Second, ConnectivityOptions{HTTP3: true})
+	if result.HTTP3Supported || result.HTTP3Error != "" {
+		t.Errorf("expected no HTTP/3 probe for an http:// target, got supported=%v %q", result.HTTP3Supported, result.HTTP3Error)
+	}
+}
+
+func TestSessionTicketWait(t *testing.T) {
+	tests := []struct {
+		tcpMs float64
+		want  time.Duration
+	}{
+		{0.1, sessionTicketMinWait},
+		{100, 400 * time.Millisecond},
+		{5000, sessionTicketTimeout},
+	}
+	for _, tt := range tests {
+		if got := sessionTicketWait(tt.tcpMs); got != tt.want {
+			t.Errorf("sessionTicketWait(%f) = %s, want %s", tt.tcpMs, got, tt.want)
+		}
+	}
+}
+
 func TestMeasureConnectivity_InvalidURL(t *testing.T) {
 	result := MeasureConnectivity(context.Background(), "://invalid-url", 10*time.Second)
 
@@ -141,6 +310,55 @@ func TestMeasureConnectivity_DefaultPorts(t *testing.T) {
 	}
 }
 
+func TestDNSCache(t *testing.T) {
+	cache := NewDNSCache(net.DefaultResolver.LookupHost, time.Minute)
+	for i := 0; i < 3; i++ {
+		addrs, err := cache.LookupHost(context.Background(), "localhost")
+		if err != nil || len(addrs) == 0 {
+			t.Fatalf("expected localhost to resolve, got %v, %v", addrs, err)
+		}
+	}
+	if hits := cache.Hits(); hits != 2 {
+		t.Errorf("expected 2 hits after the first lookup, got %d", hits)
+	}
+
+	expired := NewDNSCache(net.DefaultResolver.LookupHost, 0)
+	expired.LookupHost(context.Background(), "localhost")
+	expired.LookupHost(context.Background(), "localhost")
+	if hits := expired.Hits(); hits != 0 {
+		t.Errorf("expected expired entries to be looked up again, got %d hits", hits)
+	}
+
+	if _, err := cache.LookupHost(context.Background(), "nonexistent.invalid"); err == nil {
+		t.Error("expected an error for an unresolvable host")
+	}
+}
+
+func TestLoadTest_DNSCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every request dials a new connection
+		w.Header().Set("Connection", "close")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	target := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	cache := NewDNSCache(HostLookup("", "", time.Second), time.Minute)
+	c := client.New(target, 5*time.Second, client.WithNetwork("tcp4"), client.WithHostLookup(cache.LookupHost))
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent:   1,
+		Duration:     100 * time.Millisecond,
+		DNSCacheHits: cache.Hits,
+	})
+	if result.Successful < 2 {
+		t.Fatalf("expected several successful requests, got %d (failed %d)", result.Successful, result.Failed)
+	}
+	// A request cut off by the end of the test may have dialed as well
+	if result.DNSCacheHits < result.Successful-1 {
+		t.Errorf("expected every connection after the first to hit the cache, got %d hits for %d requests", result.DNSCacheHits, result.Successful)
+	}
+}
+
 func TestMeasureConnectivity_Timeout(t *testing.T) {
 	// Use a non-routable IP that will timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
@@ -173,3 +391,242 @@ func TestMeasureConnectivity_ContextCancellation(t *testing.T) {
 		t.Error("expected connected=false when context is cancelled")
 	}
 }
+
+func TestMeasureConnectivity_IPv4Probe(t *testing.T) {
+	server := httptest.NewServer(nil) // Listens on 127.0.0.1
+	defer server.Close()
+
+	result := MeasureConnectivity(context.Background(), server.URL, 10*time.Second)
+
+	if !result.Connected {
+		t.Fatalf("expected connected=true, error: %s", result.Error)
+	}
+	if result.IPv4Ms <= 0 || result.IPv4Ms != result.TCPMs {
+		t.Errorf("expected the measured connection's time %f as the IPv4 probe, got %f", result.TCPMs, result.IPv4Ms)
+	}
+	if result.IPv6Ms != 0 {
+		t.Errorf("expected no IPv6 probe for an IPv4 literal, got %f", result.IPv6Ms)
+	}
+}
+
+func TestMeasureConnectivity_BindAddr(t *testing.T) {
+	server := httptest.NewServer(nil) // Listens on 127.0.0.1
+	defer server.Close()
+
+	result := MeasureConnectivityWithOptions(context.Background(), server.URL, 10*time.Second, ConnectivityOptions{BindAddr: "127.0.0.1"})
+	if !result.Connected {
+		t.Fatalf("expected connected=true, error: %s", result.Error)
+	}
+	if result.BoundTo != "127.0.0.1" {
+		t.Errorf("expected bound_to 127.0.0.1, got %q", result.BoundTo)
+	}
+
+	// An IPv6 local address cannot reach the IPv4-only server
+	result = MeasureConnectivityWithOptions(context.Background(), server.URL, 10*time.Second, ConnectivityOptions{BindAddr: "::1"})
+	if result.Connected || !strings.Contains(result.Error, "no IPv6 address") {
+		t.Errorf("expected a missing IPv6 address error, got connected=%t error=%q", result.Connected, result.Error)
+	}
+
+	result = MeasureConnectivityWithOptions(context.Background(), server.URL, 10*time.Second, ConnectivityOptions{BindAddr: "eth0"})
+	if result.Connected || !strings.Contains(result.Error, "invalid bind address") {
+		t.Errorf("expected an invalid bind address error, got connected=%t error=%q", result.Connected, result.Error)
+	}
+}
+
+func TestMeasureConnectivityPreferring_MissingFamily(t *testing.T) {
+	server := httptest.NewServer(nil)
+	defer server.Close()
+
+	result := MeasureConnectivityPreferring(context.Background(), server.URL, 10*time.Second, IPFamilyIPv6)
+
+	if result.Connected {
+		t.Error("expected connected=false when the preferred family has no address")
+	}
+	if result.Error == "" {
+		t.Error("expected error for missing IPv6 address")
+	}
+}
+
+func TestSplitIPFamilies(t *testing.T) {
+	addrs := []net.IPAddr{
+		{IP: net.ParseIP("::1")},
+		{IP: net.ParseIP("192.0.2.1")},
+		{IP: net.ParseIP("2001:db8::1")},
+		{IP: net.ParseIP("192.0.2.2")},
+	}
+
+	ipv4, ipv6 := splitIPFamilies(addrs)
+	if len(ipv4) != 2 || ipv4[0].String() != "192.0.2.1" {
+		t.Errorf("unexpected IPv4 addresses: %v", ipv4)
+	}
+	if len(ipv6) != 2 || ipv6[0].String() != "::1" {
+		t.Errorf("unexpected IPv6 addresses: %v", ipv6)
+	}
+}
+
+func TestDialNetwork(t *testing.T) {
+	tests := map[string]string{
+		IPFamilyAny:  "tcp",
+		IPFamilyIPv4: "tcp4",
+		IPFamilyIPv6: "tcp6",
+	}
+	for family, expected := range tests {
+		if got := DialNetwork(family); got != expected {
+			t.Errorf("DialNetwork(%q) = %q, want %q", family, got, expected)
+		}
+	}
+}
+
+func TestProbeKeepAlive_Reused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 5*time.Second)
+	reuse, err := probeKeepAlive(context.Background(), c, "/health", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reuse != 1 {
+		t.Errorf("expected full connection reuse, got %.2f", reuse)
+	}
+}
+
+func TestProbeKeepAlive_ConnectionClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Connection", "close")
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 5*time.Second)
+	reuse, err := probeKeepAlive(context.Background(), c, "/health", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reuse != 0 {
+		t.Errorf("expected no connection reuse, got %.2f", reuse)
+	}
+}
+
+func TestProbeKeepAlive_TooFewRequests(t *testing.T) {
+	c := client.New("http://localhost", time.Second)
+	if _, err := probeKeepAlive(context.Background(), c, "/health", 1); err == nil {
+		t.Error("expected error for fewer than 2 requests")
+	}
+}
+
+func TestParseTracerouteOutput_Linux(t *testing.T) {
+	output := `traceroute to 10.0.0.5 (10.0.0.5), 30 hops max, 60 byte packets
+ 1  192.168.1.1  0.512 ms
+ 2  *
+ 3  10.0.0.5  12.25 ms
+`
+	hops, rtts := parseTracerouteOutput(output)
+	if hops != 3 {
+		t.Fatalf("expected 3 hops, got %d", hops)
+	}
+	expected := []float64{0.512, 0, 12.25}
+	for i, want := range expected {
+		if rtts[i] != want {
+			t.Errorf("hop %d: expected %.3f ms, got %.3f", i+1, want, rtts[i])
+		}
+	}
+}
+
+func TestParseTracerouteOutput_Windows(t *testing.T) {
+	output := "\r\nTracing route to 10.0.0.5 over a maximum of 30 hops\r\n\r\n" +
+		"  1    <1 ms    <1 ms    <1 ms  192.168.1.1\r\n" +
+		"  2     8 ms     9 ms     8 ms  10.0.0.5\r\n\r\nTrace complete.\r\n"
+
+	hops, rtts := parseTracerouteOutput(output)
+	if hops != 2 {
+		t.Fatalf("expected 2 hops, got %d", hops)
+	}
+	if rtts[0] != 1 || rtts[1] != 8 {
+		t.Errorf("expected [1 8], got %v", rtts)
+	}
+}
+
+func TestMeasureHops_Localhost(t *testing.T) {
+	hops, rtts, err := measureHops("127.0.0.1", 5*time.Second)
+	if err != nil {
+		t.Skipf("traceroute unavailable: %v", err)
+	}
+	if hops != 1 || len(rtts) != 1 {
+		t.Errorf("expected 1 hop to localhost, got %d (%v)", hops, rtts)
+	}
+}
+
+func TestDiscoverMTU(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	mtu, err := discoverMTU(listener.Addr().String(), 5*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mtu < minIPv4MTU {
+		t.Errorf("expected MTU of at least %d, got %d", minIPv4MTU, mtu)
+	}
+}
+
+func TestDiscoverMTU_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	if _, err := discoverMTU(addr, time.Second); err == nil {
+		t.Error("expected error for closed port")
+	}
+}
+
+func TestProbeUDPMTU_Loopback(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("UDP MTU probe is only supported on Linux")
+	}
+
+	// Loopback carries 64 KiB packets, so the probe reaches its upper bound
+	mtu, err := probeUDPMTU(net.ParseIP("127.0.0.1"), time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mtu != maxProbeMTU {
+		t.Errorf("expected %d, got %d", maxProbeMTU, mtu)
+	}
+}
+
+func TestMeasureConnectivity_DNSResolver(t *testing.T) {
+	server := httptest.NewServer(nil)
+	defer server.Close()
+
+	// IP literals need no lookup, so the resolver is only recorded
+	result := MeasureConnectivityWithOptions(context.Background(), server.URL, 5*time.Second, ConnectivityOptions{DNSResolver: "10.0.0.2"})
+	if !result.Connected {
+		t.Fatalf("expected connection to succeed, got error %q", result.Error)
+	}
+	if result.DNSResolver != "10.0.0.2:53" {
+		t.Errorf("expected resolver 10.0.0.2:53, got %q", result.DNSResolver)
+	}
+
+	result = MeasureConnectivity(context.Background(), server.URL, 5*time.Second)
+	if result.DNSResolver != "" {
+		t.Errorf("expected no resolver recorded by default, got %q", result.DNSResolver)
+	}
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// dohContentType is the RFC 8484 media type for DNS wire-format messages
+const dohContentType = "application/dns-message"
+
+// maxDoHResponseSize bounds a DoH response body; DNS messages never exceed 64 KiB
+const maxDoHResponseSize = 64 * 1024
+
+// lookupDoH resolves host to its IPv4 and IPv6 addresses by POSTing A and AAAA
+// queries to the DNS-over-HTTPS endpoint dohURL, as described in RFC 8484. IP
+// literals are returned without a query, as net.Resolver does.
+func lookupDoH(ctx context.Context, httpClient *http.Client, dohURL, host string) ([]net.IPAddr, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IPAddr{{IP: ip}}, nil
+	}
+
+	name, err := dnsmessage.NewName(fqdn(host))
+	if err != nil {
+		return nil, fmt.Errorf("invalid host name %q: %w", host, err)
+	}
+
+	var ips []net.IPAddr
+	var firstErr error
+	for _, qtype := range []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA} {
+		found, err := queryDoH(ctx, httpClient, dohURL, name, qtype)
+		if err != nil {
+			// A host may have only one family, so one failed query is not fatal
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		ips = append(ips, found...)
+	}
+	if len(ips) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return ips, nil
+}
+
+// queryDoH sends a single question to dohURL and returns the addresses of the
+// answer's A or AAAA records
+func queryDoH(ctx context.Context, httpClient *http.Client, dohURL string, name dnsmessage.Name, qtype dnsmessage.Type) ([]net.IPAddr, error) {
+	// RFC 8484 recommends ID 0 so responses are cache friendly
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: name, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("pack %s query: %w", qtype, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dohURL, bytes.NewReader(packed))
+	if err != nil {
+		return nil, fmt.Errorf("create DoH request: %w", err)
+	}
+	req.Header.Set("Content-Type", dohContentType)
+	req.Header.Set("Accept", dohContentType)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("DoH request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DoH server returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDoHResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("read DoH response: %w", err)
+	}
+
+	var answer dnsmessage.Message
+	if err := answer.Unpack(body); err != nil {
+		return nil, fmt.Errorf("parse DoH response: %w", err)
+	}
+	if answer.RCode != dnsmessage.RCodeSuccess {
+		return nil, fmt.Errorf("DoH %s query for %s failed: %s", qtype, name, answer.RCode)
+	}
+
+	// CNAME records are skipped; resolvers include the target's records too
+	var ips []net.IPAddr
+	for _, rr := range answer.Answers {
+		switch rec := rr.Body.(type) {
+		case *dnsmessage.AResource:
+			ips = append(ips, net.IPAddr{IP: net.IP(rec.A[:])})
+		case *dnsmessage.AAAAResource:
+			ips = append(ips, net.IPAddr{IP: net.IP(rec.AAAA[:])})
+		}
+	}
+	return ips, nil
+}
+
+// fqdn returns host with the trailing dot of a fully qualified name
+func fqdn(host string) string {
+	if strings.HasSuffix(host, ".") {
+		return host
+	}
+	return host + "."
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// newDoHServer starts a DNS-over-HTTPS endpoint that answers A and AAAA
+// queries from records and returns NXDOMAIN for other names
+func newDoHServer(t *testing.T, records map[string][]net.IP) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != dohContentType {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var query dnsmessage.Message
+		if err := query.Unpack(body); err != nil || len(query.Questions) != 1 {
+			http.Error(w, "bad query", http.StatusBadRequest)
+			return
+		}
+		q := query.Questions[0]
+
+		reply := dnsmessage.Message{
+			Header:    dnsmessage.Header{ID: query.ID, Response: true, RCode: dnsmessage.RCodeNameError},
+			Questions: query.Questions,
+		}
+		if ips, ok := records[q.Name.String()]; ok {
+			reply.RCode = dnsmessage.RCodeSuccess
+			for _, ip := range ips {
+				header := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: 60}
+				if ip4 := ip.To4(); ip4 != nil && q.Type == dnsmessage.TypeA {
+					reply.Answers = append(reply.Answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.AResource{A: [4]byte(ip4)}})
+				} else if ip4 == nil && q.Type == dnsmessage.TypeAAAA {
+					reply.Answers = append(reply.Answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.AAAAResource{AAAA: [16]byte(ip.To16())}})
+				}
+			}
+		}
+		packed, err := reply.Pack()
+		if err != nil {
+			t.Errorf("pack reply: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", dohContentType)
+		w.Write(packed)
+	}))
+}
+
+func TestLookupDoH(t *testing.T) {
+	server := newDoHServer(t, map[string][]net.IP{
+		"app.example.test.": {net.ParseIP("192.0.2.10"), net.ParseIP("2001:db8::10")},
+	})
+	defer server.Close()
+	ctx := context.Background()
+
+	ips, err := lookupDoH(ctx, server.Client(), server.URL, "app.example.test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ips) != 2 || ips[0].IP.String() != "192.0.2.10" || ips[1].IP.String() != "2001:db8::10" {
+		t.Errorf("expected the A then AAAA record, got %v", ips)
+	}
+
+	if _, err := lookupDoH(ctx, server.Client(), server.URL, "missing.example.test"); err == nil || !strings.Contains(err.Error(), "NameError") {
+		t.Errorf("expected an NXDOMAIN error, got %v", err)
+	}
+
+	// IP literals are not sent to the server
+	ips, err = lookupDoH(ctx, server.Client(), "http://127.0.0.1:1/dns-query", "10.0.0.5")
+	if err != nil || len(ips) != 1 || ips[0].IP.String() != "10.0.0.5" {
+		t.Errorf("expected the literal address, got %v, %v", ips, err)
+	}
+}
+
+func TestLookupDoH_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := lookupDoH(context.Background(), server.Client(), server.URL, "app.example.test")
+	if err == nil || !strings.Contains(err.Error(), "status 503") {
+		t.Errorf("expected a status error, got %v", err)
+	}
+}
+
+func TestMeasureConnectivity_DoH(t *testing.T) {
+	target := httptest.NewServer(nil)
+	defer target.Close()
+	doh := newDoHServer(t, map[string][]net.IP{"app.example.test.": {net.ParseIP("127.0.0.1")}})
+	defer doh.Close()
+
+	u, _ := url.Parse(target.URL)
+	targetURL := "http://app.example.test:" + u.Port()
+	result := MeasureConnectivityWithOptions(context.Background(), targetURL, 5*time.Second, ConnectivityOptions{DoHURL: doh.URL})
+	if !result.Connected {
+		t.Fatalf("expected connection to succeed, got error %q", result.Error)
+	}
+	if result.DNSMethod != internal.DNSMethodDoH || result.DOHServer != doh.URL {
+		t.Errorf("expected DoH via %s, got method %q server %q", doh.URL, result.DNSMethod, result.DOHServer)
+	}
+
+	result = MeasureConnectivity(context.Background(), target.URL, 5*time.Second)
+	if result.DNSMethod != internal.DNSMethodSystem || result.DOHServer != "" {
+		t.Errorf("expected the system resolver by default, got method %q server %q", result.DNSMethod, result.DOHServer)
+	}
+}
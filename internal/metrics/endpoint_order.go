@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+)
+
+// Endpoint benchmark execution orders
+const (
+	EndpointOrderDefault      = "default"
+	EndpointOrderAlphabetical = "alphabetical"
+	EndpointOrderRandom       = "random"
+	EndpointOrderSlowestFirst = "slowest-first"
+)
+
+// ValidateEndpointOrder returns an error unless order is a known execution order
+func ValidateEndpointOrder(order string) error {
+	switch order {
+	case "", EndpointOrderDefault, EndpointOrderAlphabetical, EndpointOrderRandom, EndpointOrderSlowestFirst:
+		return nil
+	default:
+		return fmt.Errorf("unknown endpoint order %q (valid: %s, %s, %s, %s)",
+			order, EndpointOrderDefault, EndpointOrderAlphabetical, EndpointOrderRandom, EndpointOrderSlowestFirst)
+	}
+}
+
+// BenchmarkEndpointsInOrder measures paths like BenchmarkEndpoints, but
+// requests them in the given order; see sortEndpoints. Results are returned in
+// the order of paths, so reports line up however the run was ordered.
+func BenchmarkEndpointsInOrder(ctx context.Context, c *client.Client, paths []string, order string, seed int64, baseline *internal.BenchmarkResult) []internal.EndpointResult {
+	byPath := make(map[string]internal.EndpointResult, len(paths))
+	for _, result := range BenchmarkEndpoints(ctx, c, sortEndpoints(paths, order, baseline, seed)) {
+		byPath[result.Path] = result
+	}
+
+	results := make([]internal.EndpointResult, 0, len(paths))
+	for _, path := range paths {
+		results = append(results, byPath[path])
+	}
+	return results
+}
+
+// sortEndpoints returns a copy of paths in execution order. Alphabetical sorts
+// by path, random shuffles with seed so a run can be repeated exactly, and
+// slowest-first sorts by baseline response time, slowest first, with paths
+// the baseline did not measure last. Any other order keeps paths as given.
+func sortEndpoints(paths []string, order string, baseline *internal.BenchmarkResult, seed int64) []string {
+	sorted := append([]string(nil), paths...)
+
+	switch order {
+	case EndpointOrderAlphabetical:
+		sort.Strings(sorted)
+	case EndpointOrderRandom:
+		rng := rand.New(rand.NewSource(seed))
+		rng.Shuffle(len(sorted), func(i, j int) {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		})
+	case EndpointOrderSlowestFirst:
+		if baseline == nil {
+			break
+		}
+		previous := make(map[string]float64, len(baseline.Endpoints))
+		for _, ep := range baseline.Endpoints {
+			previous[ep.Path] = ep.ResponseMs
+		}
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a, aOK := previous[sorted[i]]
+			b, bOK := previous[sorted[j]]
+			if aOK != bOK {
+				return aOK
+			}
+			return a > b
+		})
+	}
+
+	return sorted
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+)
+
+func TestSortEndpoints(t *testing.T) {
+	paths := []string{"/health", "/api/workouts", "/api/movements", "/api/version"}
+	baseline := &internal.BenchmarkResult{Endpoints: []internal.EndpointResult{
+		{Path: "/health", ResponseMs: 2},
+		{Path: "/api/workouts", ResponseMs: 40},
+		{Path: "/api/movements", ResponseMs: 90},
+	}}
+
+	tests := []struct {
+		name     string
+		order    string
+		baseline *internal.BenchmarkResult
+		want     []string
+	}{
+		{"default", EndpointOrderDefault, nil, paths},
+		{"alphabetical", EndpointOrderAlphabetical, nil, []string{"/api/movements", "/api/version", "/api/workouts", "/health"}},
+		{"slowest first, unmeasured last", EndpointOrderSlowestFirst, baseline, []string{"/api/movements", "/api/workouts", "/health", "/api/version"}},
+		{"slowest first without a baseline", EndpointOrderSlowestFirst, nil, paths},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sortEndpoints(paths, tt.order, tt.baseline, 0); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// The same seed always gives the same shuffle, and the input is untouched
+	first := sortEndpoints(paths, EndpointOrderRandom, nil, 42)
+	if again := sortEndpoints(paths, EndpointOrderRandom, nil, 42); !reflect.DeepEqual(first, again) {
+		t.Errorf("expected seed 42 to repeat %v, got %v", first, again)
+	}
+	if len(first) != len(paths) || paths[0] != "/health" {
+		t.Errorf("expected a shuffled copy, got %v from %v", first, paths)
+	}
+}
+
+func TestValidateEndpointOrder(t *testing.T) {
+	for _, order := range []string{"", EndpointOrderDefault, EndpointOrderAlphabetical, EndpointOrderRandom, EndpointOrderSlowestFirst} {
+		if err := ValidateEndpointOrder(order); err != nil {
+			t.Errorf("expected %q to be valid, got %v", order, err)
+		}
+	}
+	if err := ValidateEndpointOrder("fastest-first"); err == nil {
+		t.Error("expected an error for an unknown order")
+	}
+}
+
+func TestBenchmarkEndpointsInOrder(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	c := client.New(server.URL, 5*time.Second)
+
+	paths := []string{"/health", "/api/workouts", "/api/movements"}
+	results := BenchmarkEndpointsInOrder(context.Background(), c, paths, EndpointOrderAlphabetical, 0, nil)
+
+	if want := []string{"/api/movements", "/api/workouts", "/health"}; !reflect.DeepEqual(requested, want) {
+		t.Errorf("expected requests in order %v, got %v", want, requested)
+	}
+	for i, r := range results {
+		if r.Path != paths[i] || !r.Success {
+			t.Errorf("result %d: expected a successful %s in the original order, got %+v", i, paths[i], r)
+		}
+	}
+}
@@ -2,11 +2,18 @@ package metrics
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/audit"
 	"github.com/johnzastrow/actalog-benchmark/internal/client"
+	"github.com/johnzastrow/actalog-benchmark/internal/telemetry"
 )
 
 // PublicEndpoints are endpoints that don't require authentication
@@ -24,38 +31,305 @@ var AuthenticatedEndpoints = []string{
 	"/api/notifications/count",
 }
 
-// BenchmarkEndpoint measures the response time for a single endpoint
-func BenchmarkEndpoint(ctx context.Context, c *client.Client, path string) internal.EndpointResult {
-	result := internal.EndpointResult{
-		Path: path,
+// BenchmarkEndpoint measures the response time for a single endpoint.
+// method defaults to GET; body is only sent with other methods.
+func BenchmarkEndpoint(ctx context.Context, c *client.Client, method, path, body string) internal.EndpointResult {
+	return benchmarkRequest(ctx, c, method, path, body, nil)
+}
+
+// benchmarkRequest is BenchmarkEndpoint with extra request headers. Each
+// request is traced as a telemetry.SpanEndpoint span.
+func benchmarkRequest(ctx context.Context, c *client.Client, method, path, body string, headers map[string]string) (result internal.EndpointResult) {
+	result = internal.EndpointResult{Path: path}
+	if method != http.MethodGet {
+		// Left empty for GET, like the built-in endpoint checks
+		result.Method = method
 	}
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanEndpoint, telemetry.EndpointAttributes(method, path)...)
+	var bodyBytes int64
+	defer func() { telemetry.EndEndpoint(span, result, bodyBytes) }()
 
 	start := time.Now()
-	resp, err := c.Get(ctx, path)
+	var resp *http.Response
+	var timing *client.TimingInfo
+	var err error
+	switch {
+	case method == http.MethodPost:
+		resp, timing, err = c.DoWithTiming(ctx, method, path, strings.NewReader(body), headers)
+	case method != "" && method != http.MethodGet:
+		// No body, and so no Content-Type, unless one was given
+		var reqBody io.Reader
+		if body != "" {
+			reqBody = strings.NewReader(body)
+		}
+		resp, timing, err = c.DoWithTiming(ctx, method, path, reqBody, headers)
+	case len(headers) > 0:
+		resp, timing, err = c.DoWithTiming(ctx, http.MethodGet, path, nil, headers)
+	default:
+		resp, timing, err = c.GetWithTiming(ctx, path)
+	}
 	result.ResponseMs = float64(time.Since(start).Microseconds()) / 1000.0
+	if timing != nil {
+		// Known even for a request that failed, to find it in the server's logs
+		result.RequestID = timing.RequestID
+		if !timing.FirstByte.IsZero() {
+			result.TTFBMs = float64(timing.FirstByte.Sub(start).Microseconds()) / 1000.0
+		}
+	}
 
 	if err != nil {
 		result.Error = err.Error()
 		result.Success = false
+		// Name just the downgrade, without the wrapping request error
+		var downgrade *client.HTTPSDowngradeError
+		if errors.As(err, &downgrade) {
+			result.HTTPSDowngrade = true
+			result.Error = downgrade.Error()
+		}
+		result.CurlCommand = curlCommandFor(c, method, path, body, headers)
+		return result
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the total time includes downloading it
+	bodyBytes, result.ResponseTruncated = drainBody(resp.Body, c.MaxResponseSize())
+	result.TotalMs = float64(time.Since(start).Microseconds()) / 1000.0
+
+	result.Deprecated, result.SunsetDate = deprecation(resp.Header)
+	result.Status = resp.StatusCode
+	result.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !result.Success {
+		result.CurlCommand = curlCommandFor(c, method, path, body, headers)
+	}
+
+	return result
+}
+
+// deprecation reads the Deprecation (RFC 9745) and Sunset (RFC 8594) response
+// headers. An endpoint with either header is deprecated; the sunset date is
+// returned as YYYY-MM-DD, or as sent when it is not a valid HTTP date.
+func deprecation(h http.Header) (bool, string) {
+	deprecated := h.Get("Deprecation") != ""
+	sunset := strings.TrimSpace(h.Get("Sunset"))
+	if sunset == "" {
+		return deprecated, ""
+	}
+	if t, err := http.ParseTime(sunset); err == nil {
+		sunset = t.UTC().Format("2006-01-02")
+	}
+	return true, sunset
+}
+
+// drainBody discards at most limit bytes of body (all of it when limit is 0),
+// returning the bytes read and whether the body was longer than limit
+func drainBody(body io.Reader, limit int64) (int64, bool) {
+	if limit <= 0 {
+		n, _ := io.Copy(io.Discard, body)
+		return n, false
+	}
+	// Read one byte past the limit to tell a body of exactly limit bytes from a longer one
+	n, _ := io.Copy(io.Discard, io.LimitReader(body, limit+1))
+	if n > limit {
+		return limit, true
+	}
+	return n, false
+}
+
+// BenchmarkEndpointCold measures a GET request over a fresh connection. c
+// should come from Client.WithoutKeepAlives, so the request carries the
+// token and connection options of the warm requests but never reuses one
+// of their connections.
+func BenchmarkEndpointCold(ctx context.Context, c *client.Client, path string) internal.EndpointResult {
+	result := internal.EndpointResult{Path: path}
+
+	start := time.Now()
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		result.ResponseMs = float64(time.Since(start).Microseconds()) / 1000.0
+		result.Error = err.Error()
 		return result
 	}
 	defer resp.Body.Close()
 
 	// Drain the body to ensure accurate timing
-	io.Copy(io.Discard, resp.Body)
+	drainBody(resp.Body, c.MaxResponseSize())
+	result.ResponseMs = float64(time.Since(start).Microseconds()) / 1000.0
 
 	result.Status = resp.StatusCode
 	result.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	return result
+}
+
+// MeasureColdStarts records a cold-connection response time for each successful
+// GET endpoint in results, requesting it again through a copy of c with
+// keep-alives disabled. Endpoints whose cold request fails are left without one.
+func MeasureColdStarts(ctx context.Context, c *client.Client, results []internal.EndpointResult) {
+	cold := c.WithoutKeepAlives()
+	for i := range results {
+		ep := &results[i]
+		if !ep.Success || (ep.Method != "" && ep.Method != http.MethodGet) {
+			continue
+		}
+		if cold := BenchmarkEndpointCold(ctx, cold, ep.Path); cold.Success {
+			ep.ColdStartMs = cold.ResponseMs
+		}
+	}
+}
+
+// MinPercentileSamples is the fewest endpoint samples for which SampleEndpoints
+// reports p50/p95/p99; smaller sample sets get mean, min, and max only
+const MinPercentileSamples = 20
 
+// SampleEndpoints requests each successful GET endpoint in results samples-1 more
+// times (the existing measurement is the first sample) and records the mean, min,
+// and max response time. With at least MinPercentileSamples successful samples it
+// also records p50, p95, and p99. Failed repeat requests are not counted, and POST
+// endpoints are skipped so their side effects are not repeated. Results from
+// custom are resent with the headers, body, and expected status of their entry.
+func SampleEndpoints(ctx context.Context, c *client.Client, results []internal.EndpointResult, custom []internal.WeightedEndpoint, samples int) {
+	if samples < 2 {
+		return
+	}
+
+	entries := make(map[string]internal.WeightedEndpoint, len(custom))
+	for _, ep := range custom {
+		method := ep.Method
+		if method == http.MethodGet {
+			method = ""
+		}
+		entries[method+" "+ep.Path] = ep
+	}
+
+	for i := range results {
+		ep := &results[i]
+		if !ep.Success || (ep.Method != "" && ep.Method != http.MethodGet) {
+			continue
+		}
+		entry, isCustom := entries[ep.Method+" "+ep.Path]
+		if !ep.CustomEndpoint || !isCustom {
+			entry = internal.WeightedEndpoint{Method: ep.Method, Path: ep.Path}
+		}
+
+		times := []float64{ep.ResponseMs}
+		for n := 1; n < samples && ctx.Err() == nil; n++ {
+			if sample := benchmarkCustomEndpoint(ctx, c, entry); sample.Success {
+				times = append(times, sample.ResponseMs)
+			}
+		}
+		sort.Float64s(times)
+
+		var sum float64
+		for _, t := range times {
+			sum += t
+		}
+		ep.SampleCount = len(times)
+		ep.SampleMeanMs = sum / float64(len(times))
+		ep.SampleMinMs = times[0]
+		ep.SampleMaxMs = times[len(times)-1]
+		if len(times) >= MinPercentileSamples {
+			ep.SampleP50Ms = Percentile(times, 50)
+			ep.SampleP95Ms = Percentile(times, 95)
+			ep.SampleP99Ms = Percentile(times, 99)
+		}
+	}
+}
+
+// BenchmarkCustomEndpoints measures endpoints loaded from an endpoints file,
+// checking each response against its expected status when one is set
+func BenchmarkCustomEndpoints(ctx context.Context, c *client.Client, endpoints []internal.WeightedEndpoint) []internal.EndpointResult {
+	results := make([]internal.EndpointResult, 0, len(endpoints))
+
+	for _, ep := range endpoints {
+		result := benchmarkCustomEndpoint(ctx, c, ep)
+		result.CustomEndpoint = true
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// benchmarkCustomEndpoint measures one endpoints file entry. Success is any 2xx
+// status unless the entry sets an expected status.
+func benchmarkCustomEndpoint(ctx context.Context, c *client.Client, ep internal.WeightedEndpoint) internal.EndpointResult {
+	result := benchmarkRequest(ctx, c, ep.Method, ep.Path, ep.Body, ep.Headers)
+	if ep.ExpectedStatus == 0 || result.Error != "" {
+		return result
+	}
+	result.Success = result.Status == ep.ExpectedStatus
+	if result.Success {
+		result.CurlCommand = ""
+	} else {
+		result.Error = fmt.Sprintf("expected status %d, got %d", ep.ExpectedStatus, result.Status)
+		if result.CurlCommand == "" {
+			result.CurlCommand = curlCommandFor(c, ep.Method, ep.Path, ep.Body, ep.Headers)
+		}
+	}
 	return result
 }
 
+// curlCommandFor builds the reproduction command for a request made by c.
+// The real token is never needed since generateCurlCommand masks it.
+func curlCommandFor(c *client.Client, method, path, body string, headers map[string]string) string {
+	token := ""
+	if c.IsAuthenticated() {
+		token = "<TOKEN>"
+	}
+	if (method != "" && method != http.MethodGet) || len(headers) > 0 {
+		return generateRequestCurlCommand(method, c.GetBaseURL(), path, token, body, c.GetUserAgent(), headers)
+	}
+	return generateCurlCommand(c.GetBaseURL(), path, token, c.GetUserAgent())
+}
+
+// generateCurlCommand returns a shell-safe curl command that reproduces a GET request.
+// A non-empty token is replaced with a <TOKEN> placeholder so reports never leak credentials.
+func generateCurlCommand(baseURL, path, token, userAgent string) string {
+	return generateRequestCurlCommand(http.MethodGet, baseURL, path, token, "", userAgent, nil)
+}
+
+// generateRequestCurlCommand is generateCurlCommand for any method, with an
+// optional JSON body and extra headers. Values of headers that look like
+// credentials are replaced with a <VALUE> placeholder.
+func generateRequestCurlCommand(method, baseURL, path, token, body, userAgent string, headers map[string]string) string {
+	parts := []string{"curl", "-i"}
+	if method != "" && method != http.MethodGet {
+		parts = append(parts, "-X", method)
+	}
+	if token != "" {
+		parts = append(parts, "-H", shellQuote("Authorization: Bearer <TOKEN>"))
+	}
+	parts = append(parts, "-H", shellQuote("User-Agent: "+userAgent))
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := headers[name]
+		if audit.SensitiveHeader(name) {
+			value = "<VALUE>"
+		}
+		parts = append(parts, "-H", shellQuote(name+": "+value))
+	}
+
+	if body != "" {
+		parts = append(parts, "-H", shellQuote("Content-Type: application/json"), "--data-raw", shellQuote(body))
+	}
+	parts = append(parts, shellQuote(strings.TrimSuffix(baseURL, "/")+path))
+	return strings.Join(parts, " ")
+}
+
+// shellQuote wraps s in single quotes, escaping any embedded single quotes
+func shellQuote(s string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", `'\''`))
+}
+
 // BenchmarkEndpoints measures multiple endpoints and returns results
 func BenchmarkEndpoints(ctx context.Context, c *client.Client, paths []string) []internal.EndpointResult {
 	results := make([]internal.EndpointResult, 0, len(paths))
 
 	for _, path := range paths {
-		result := BenchmarkEndpoint(ctx, c, path)
+		result := BenchmarkEndpoint(ctx, c, "", path, "")
 		results = append(results, result)
 	}
 
@@ -1,12 +1,20 @@
 package metrics
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/johnzastrow/actalog-benchmark/internal"
 	"github.com/johnzastrow/actalog-benchmark/internal/client"
 )
 
@@ -18,7 +26,7 @@ func TestBenchmarkEndpoint_Success(t *testing.T) {
 	defer server.Close()
 
 	c := client.New(server.URL, 10*time.Second)
-	result := BenchmarkEndpoint(context.Background(), c, "/api/test")
+	result := BenchmarkEndpoint(context.Background(), c, "", "/api/test", "")
 
 	if result.Path != "/api/test" {
 		t.Errorf("expected path '/api/test', got '%s'", result.Path)
@@ -35,6 +43,31 @@ func TestBenchmarkEndpoint_Success(t *testing.T) {
 	if result.Error != "" {
 		t.Errorf("expected no error, got '%s'", result.Error)
 	}
+	if result.CurlCommand != "" {
+		t.Errorf("expected no curl command for successful endpoint, got '%s'", result.CurlCommand)
+	}
+}
+
+func TestBenchmarkEndpoint_TTFB(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Headers go out at once; the body follows after a delay
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"data": "test"}`))
+	}))
+	defer server.Close()
+
+	result := BenchmarkEndpoint(context.Background(), client.New(server.URL, 10*time.Second), "", "/api/test", "")
+	if result.TTFBMs <= 0 {
+		t.Fatalf("expected a positive TTFB, got %v", result.TTFBMs)
+	}
+	if download := result.TotalMs - result.TTFBMs; download < 40 {
+		t.Errorf("expected the body download (%.1fms) in the total time but not the TTFB (%.1fms)", download, result.TTFBMs)
+	}
+	if result.ResponseMs > result.TTFBMs+40 {
+		t.Errorf("expected the response time (%.1fms) to stop before the body download", result.ResponseMs)
+	}
 }
 
 func TestBenchmarkEndpoint_ClientError(t *testing.T) {
@@ -45,7 +78,7 @@ func TestBenchmarkEndpoint_ClientError(t *testing.T) {
 	defer server.Close()
 
 	c := client.New(server.URL, 10*time.Second)
-	result := BenchmarkEndpoint(context.Background(), c, "/api/notfound")
+	result := BenchmarkEndpoint(context.Background(), c, "", "/api/notfound", "")
 
 	if result.Status != 404 {
 		t.Errorf("expected status 404, got %d", result.Status)
@@ -53,6 +86,29 @@ func TestBenchmarkEndpoint_ClientError(t *testing.T) {
 	if result.Success {
 		t.Error("expected success to be false for 404")
 	}
+	expectedCurl := "curl -i -H 'User-Agent: actalog-bench/1.0' '" + server.URL + "/api/notfound'"
+	if result.CurlCommand != expectedCurl {
+		t.Errorf("expected curl command %q, got %q", expectedCurl, result.CurlCommand)
+	}
+}
+
+func TestBenchmarkEndpoint_CustomUserAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "LoadProbe/2.1" {
+			t.Errorf("expected User-Agent LoadProbe/2.1, got %q", r.Header.Get("User-Agent"))
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second, client.WithUserAgent("LoadProbe/2.1"))
+	result := BenchmarkEndpoint(context.Background(), c, "", "/api/notfound", "")
+
+	// The reproduction command must send what the benchmark sent
+	expectedCurl := "curl -i -H 'User-Agent: LoadProbe/2.1' '" + server.URL + "/api/notfound'"
+	if result.CurlCommand != expectedCurl {
+		t.Errorf("expected curl command %q, got %q", expectedCurl, result.CurlCommand)
+	}
 }
 
 func TestBenchmarkEndpoint_ServerError(t *testing.T) {
@@ -62,7 +118,7 @@ func TestBenchmarkEndpoint_ServerError(t *testing.T) {
 	defer server.Close()
 
 	c := client.New(server.URL, 10*time.Second)
-	result := BenchmarkEndpoint(context.Background(), c, "/api/error")
+	result := BenchmarkEndpoint(context.Background(), c, "", "/api/error", "")
 
 	if result.Status != 500 {
 		t.Errorf("expected status 500, got %d", result.Status)
@@ -74,7 +130,7 @@ func TestBenchmarkEndpoint_ServerError(t *testing.T) {
 
 func TestBenchmarkEndpoint_ConnectionError(t *testing.T) {
 	c := client.New("http://localhost:99999", 1*time.Second)
-	result := BenchmarkEndpoint(context.Background(), c, "/api/test")
+	result := BenchmarkEndpoint(context.Background(), c, "", "/api/test", "")
 
 	if result.Success {
 		t.Error("expected success to be false for connection error")
@@ -211,3 +267,404 @@ func TestAuthenticatedEndpoints(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateCurlCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		path     string
+		token    string
+		expected string
+	}{
+		{
+			name:     "unauthenticated",
+			baseURL:  "https://example.com",
+			path:     "/health",
+			expected: "curl -i -H 'User-Agent: actalog-bench/1.0' 'https://example.com/health'",
+		},
+		{
+			name:     "token is masked",
+			baseURL:  "https://example.com/",
+			path:     "/api/workouts",
+			token:    "secret.jwt.value",
+			expected: "curl -i -H 'Authorization: Bearer <TOKEN>' -H 'User-Agent: actalog-bench/1.0' 'https://example.com/api/workouts'",
+		},
+		{
+			name:     "single quotes escaped",
+			baseURL:  "https://example.com",
+			path:     "/api/search?q=it's",
+			expected: `curl -i -H 'User-Agent: actalog-bench/1.0' 'https://example.com/api/search?q=it'\''s'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := generateCurlCommand(tt.baseURL, tt.path, tt.token, client.UserAgent)
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBenchmarkEndpoint_Post(t *testing.T) {
+	var gotMethod, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	result := BenchmarkEndpoint(context.Background(), c, http.MethodPost, "/api/workouts", `{"name":"test"}`)
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("expected POST request, got %s", gotMethod)
+	}
+	if gotBody != `{"name":"test"}` {
+		t.Errorf("expected request body to be sent, got %q", gotBody)
+	}
+	if result.Method != http.MethodPost {
+		t.Errorf("expected method POST in result, got '%s'", result.Method)
+	}
+	if !result.Success || result.Status != 201 {
+		t.Errorf("expected successful 201, got status %d success %t", result.Status, result.Success)
+	}
+}
+
+func TestBenchmarkCustomEndpoints_ExpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	results := BenchmarkCustomEndpoints(context.Background(), c, []internal.WeightedEndpoint{
+		{Path: "/api/missing", Weight: 1, ExpectedStatus: 404}, // 404 is the expected outcome
+		{Path: "/api/ok", Weight: 1, ExpectedStatus: 201},      // 200 does not match
+		{Path: "/api/ok", Weight: 1},                           // Any 2xx
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if !results[0].Success || results[0].CurlCommand != "" {
+		t.Errorf("expected matching 404 to succeed without curl command, got %+v", results[0])
+	}
+	if results[1].Success || results[1].Error == "" || results[1].CurlCommand == "" {
+		t.Errorf("expected mismatched status to fail with error and curl command, got %+v", results[1])
+	}
+	if !results[2].Success {
+		t.Errorf("expected 2xx without expected status to succeed, got %+v", results[2])
+	}
+}
+
+func TestGenerateRequestCurlCommand_Post(t *testing.T) {
+	got := generateRequestCurlCommand(http.MethodPost, "https://example.com", "/api/workouts", "", `{"name":"test"}`, client.UserAgent, nil)
+	expected := `curl -i -X POST -H 'User-Agent: actalog-bench/1.0' -H 'Content-Type: application/json' --data-raw '{"name":"test"}' 'https://example.com/api/workouts'`
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestBenchmarkCustomEndpoints_MethodsAndHeaders(t *testing.T) {
+	type request struct{ method, contentType, trace string }
+	var mu sync.Mutex
+	var received []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, request{r.Method, r.Header.Get("Content-Type"), r.Header.Get("X-Trace")})
+		mu.Unlock()
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	results := BenchmarkCustomEndpoints(context.Background(), c, []internal.WeightedEndpoint{
+		{Path: "/api/workouts/1", Method: http.MethodPut, Body: `{}`, Headers: map[string]string{"X-Trace": "bench", "X-Api-Key": "secret"}},
+		{Path: "/api/workouts/1", Method: http.MethodDelete, ExpectedStatus: 204},
+	})
+
+	expected := []request{
+		{http.MethodPut, "application/json", "bench"},
+		{http.MethodDelete, "", ""},
+	}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("expected requests %+v, got %+v", expected, received)
+	}
+	if results[0].Success || !results[0].CustomEndpoint {
+		t.Errorf("expected a failed custom endpoint, got %+v", results[0])
+	}
+	if cmd := results[0].CurlCommand; !strings.Contains(cmd, "-X PUT") || !strings.Contains(cmd, "'X-Trace: bench'") ||
+		!strings.Contains(cmd, "'X-Api-Key: <VALUE>'") || strings.Contains(cmd, "secret") {
+		t.Errorf("expected the curl command to carry the headers with the key masked, got %q", cmd)
+	}
+	if !results[1].Success || results[1].Method != http.MethodDelete {
+		t.Errorf("expected DELETE to succeed with 204, got %+v", results[1])
+	}
+}
+
+func TestBenchmarkEndpoint_RequestID(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("X-Request-ID")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second, client.WithRequestIDHeader("X-Request-ID"))
+	result := BenchmarkEndpoint(context.Background(), c, "", "/api/test", "")
+
+	if result.RequestID == "" {
+		t.Fatal("expected request ID in result")
+	}
+	if result.RequestID != received {
+		t.Errorf("expected result request ID %q to match sent header %q", result.RequestID, received)
+	}
+
+	// A request that gets no response still records the ID it was sent with
+	dropping := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("X-Request-ID")
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer dropping.Close()
+
+	c = client.New(dropping.URL, 10*time.Second, client.WithRequestIDHeader("X-Request-ID"))
+	result = BenchmarkEndpoint(context.Background(), c, "", "/api/test", "")
+	if result.Error == "" {
+		t.Fatalf("expected the dropped request to fail, got %+v", result)
+	}
+	if result.RequestID == "" || result.RequestID != received {
+		t.Errorf("expected the failed request's ID %q, got %q", received, result.RequestID)
+	}
+}
+
+func TestBenchmarkEndpointCold_FreshConnection(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != client.UserAgent {
+			t.Errorf("expected User-Agent %q, got %q", client.UserAgent, r.Header.Get("User-Agent"))
+		}
+		w.Write([]byte(`{"data": "test"}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	cold := client.New(server.URL, 10*time.Second).WithoutKeepAlives()
+	for i := 0; i < 3; i++ {
+		result := BenchmarkEndpointCold(context.Background(), cold, "/api/version")
+		if !result.Success || result.Status != 200 {
+			t.Fatalf("expected successful cold request, got %+v", result)
+		}
+		if result.ResponseMs <= 0 {
+			t.Error("expected positive response time")
+		}
+	}
+
+	if got := connections.Load(); got != 3 {
+		t.Errorf("expected a new connection per cold request, got %d connections", got)
+	}
+}
+
+func TestMeasureColdStarts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Cold requests carry the client's token
+		if r.URL.Path == "/api/workouts" && r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	results := []internal.EndpointResult{
+		{Path: "/api/version", ResponseMs: 1, Status: 200, Success: true},
+		{Path: "/api/workouts", ResponseMs: 1, Status: 200, Success: true},
+		{Path: "/api/missing", ResponseMs: 1, Status: 404, Success: false},
+		{Path: "/api/sessions", Method: http.MethodPost, ResponseMs: 1, Status: 200, Success: true},
+	}
+	MeasureColdStarts(context.Background(), client.New(server.URL, 10*time.Second, client.WithToken("test-token")), results)
+
+	for _, ep := range results[:2] {
+		if ep.ColdStartMs <= 0 {
+			t.Errorf("expected cold start time for %s", ep.Path)
+		}
+	}
+	for _, ep := range results[2:] {
+		if ep.ColdStartMs != 0 {
+			t.Errorf("expected no cold start time for %s %s, got %f", ep.Method, ep.Path, ep.ColdStartMs)
+		}
+	}
+}
+
+func TestBenchmarkEndpoint_MaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(make([]byte, 2048))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		limit         int64
+		wantTruncated bool
+	}{
+		{0, false},
+		{1024, true},
+		{2048, false},
+	}
+
+	for _, tt := range tests {
+		c := client.New(server.URL, 10*time.Second, client.WithMaxResponseSize(tt.limit))
+		result := BenchmarkEndpoint(context.Background(), c, "", "/api/dump", "")
+
+		if !result.Success {
+			t.Errorf("limit %d: expected success, got error %q", tt.limit, result.Error)
+		}
+		if result.ResponseTruncated != tt.wantTruncated {
+			t.Errorf("limit %d: expected truncated=%v, got %v", tt.limit, tt.wantTruncated, result.ResponseTruncated)
+		}
+	}
+}
+
+func TestDrainBody(t *testing.T) {
+	body := make([]byte, 100)
+
+	if n, truncated := drainBody(bytes.NewReader(body), 0); n != 100 || truncated {
+		t.Errorf("no limit: expected 100 bytes untruncated, got %d, %v", n, truncated)
+	}
+	if n, truncated := drainBody(bytes.NewReader(body), 40); n != 40 || !truncated {
+		t.Errorf("limit 40: expected 40 bytes truncated, got %d, %v", n, truncated)
+	}
+	if n, truncated := drainBody(bytes.NewReader(body), 100); n != 100 || truncated {
+		t.Errorf("limit 100: expected 100 bytes untruncated, got %d, %v", n, truncated)
+	}
+}
+
+func TestSampleEndpoints(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	results := []internal.EndpointResult{
+		{Path: "/api/version", ResponseMs: 1, Status: 200, Success: true},
+		{Path: "/health", ResponseMs: 1, Status: 200, Success: true},
+		{Path: "/api/missing", ResponseMs: 1, Status: 404, Success: false},
+		{Path: "/api/sessions", Method: http.MethodPost, ResponseMs: 1, Status: 200, Success: true},
+	}
+	SampleEndpoints(context.Background(), c, results[:1], nil, MinPercentileSamples)
+	SampleEndpoints(context.Background(), c, results[1:], nil, 5)
+
+	// The existing measurement counts as the first sample
+	if got := requests.Load(); got != int32(MinPercentileSamples-1+4) {
+		t.Errorf("expected %d requests, got %d", MinPercentileSamples-1+4, got)
+	}
+
+	ep := results[0]
+	if ep.SampleCount != MinPercentileSamples {
+		t.Errorf("expected %d samples, got %d", MinPercentileSamples, ep.SampleCount)
+	}
+	if ep.SampleMinMs > ep.SampleMeanMs || ep.SampleMeanMs > ep.SampleMaxMs {
+		t.Errorf("expected min <= mean <= max, got %f, %f, %f", ep.SampleMinMs, ep.SampleMeanMs, ep.SampleMaxMs)
+	}
+	if ep.SampleP95Ms <= 0 || ep.SampleP50Ms > ep.SampleP95Ms || ep.SampleP95Ms > ep.SampleP99Ms {
+		t.Errorf("expected ordered percentiles, got p50=%f p95=%f p99=%f", ep.SampleP50Ms, ep.SampleP95Ms, ep.SampleP99Ms)
+	}
+
+	// Below MinPercentileSamples only mean/min/max are recorded
+	if results[1].SampleCount != 5 || results[1].SampleMeanMs <= 0 {
+		t.Errorf("expected 5 samples with a mean, got %+v", results[1])
+	}
+	if results[1].SampleP95Ms != 0 {
+		t.Errorf("expected no p95 below %d samples, got %f", MinPercentileSamples, results[1].SampleP95Ms)
+	}
+	for _, ep := range results[2:] {
+		if ep.SampleCount != 0 {
+			t.Errorf("expected %s %s not to be sampled, got %d samples", ep.Method, ep.Path, ep.SampleCount)
+		}
+	}
+}
+
+func TestSampleEndpoints_CustomEntry(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		// Only requests carrying the entry's header get its expected status
+		if r.Header.Get("X-Tenant") != "bench" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	entries := []internal.WeightedEndpoint{
+		{Path: "/api/tenant", Method: http.MethodGet, Headers: map[string]string{"X-Tenant": "bench"}, ExpectedStatus: http.StatusAccepted},
+	}
+	results := BenchmarkCustomEndpoints(context.Background(), c, entries)
+	SampleEndpoints(context.Background(), c, results, entries, 5)
+
+	if got := requests.Load(); got != 5 {
+		t.Errorf("expected 5 requests, got %d", got)
+	}
+	if results[0].SampleCount != 5 {
+		t.Errorf("expected every repeat to send the entry's headers, got %d samples", results[0].SampleCount)
+	}
+	if results[0].Method != "" {
+		t.Errorf("expected no method recorded for GET, got %q", results[0].Method)
+	}
+}
+
+func TestBenchmarkEndpoint_Deprecation(t *testing.T) {
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		wantDeprecated bool
+		wantSunset     string
+	}{
+		{"none", nil, false, ""},
+		{"deprecation only", map[string]string{"Deprecation": "@1767225600"}, true, ""},
+		{"sunset HTTP date", map[string]string{"Deprecation": "true", "Sunset": "Thu, 31 Dec 2026 23:59:59 GMT"}, true, "2026-12-31"},
+		{"sunset only", map[string]string{"Sunset": "next quarter"}, true, "next quarter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			result := BenchmarkEndpoint(context.Background(), client.New(server.URL, 10*time.Second), "", "/api/legacy", "")
+			if result.Deprecated != tt.wantDeprecated {
+				t.Errorf("expected deprecated=%v, got %v", tt.wantDeprecated, result.Deprecated)
+			}
+			if result.SunsetDate != tt.wantSunset {
+				t.Errorf("expected sunset %q, got %q", tt.wantSunset, result.SunsetDate)
+			}
+		})
+	}
+}
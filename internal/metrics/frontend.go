@@ -1,9 +1,19 @@
 package metrics
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
 	"io"
+	"path"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,12 +21,29 @@ import (
 	"github.com/johnzastrow/actalog-benchmark/internal/client"
 )
 
-// Common frontend asset patterns to look for in HTML
+// Common frontend asset patterns to look for in HTML. Each match spans the
+// whole opening tag so integrityPattern can find the attribute in either order.
 var (
-	scriptPattern = regexp.MustCompile(`<script[^>]+src=["']([^"']+)["']`)
-	linkPattern   = regexp.MustCompile(`<link[^>]+href=["']([^"']+)["']`)
+	scriptPattern    = regexp.MustCompile(`<script[^>]+src=["']([^"']+)["'][^>]*>`)
+	linkPattern      = regexp.MustCompile(`<link[^>]+href=["']([^"']+)["'][^>]*>`)
+	integrityPattern = regexp.MustCompile(`\sintegrity=["']([^"']+)["']`)
 )
 
+// fingerprintPattern matches a content hash segment in a file name, such as
+// the abc123 in app.abc123.js, app-abc123.js, or abc123.chunk.js
+var fingerprintPattern = regexp.MustCompile(`(?:^|[.\-_])[a-f0-9]{6,}[.\-_]`)
+
+// sriHashes maps each Subresource Integrity algorithm to its digest function,
+// ordered weakest to strongest
+var sriHashes = []struct {
+	name string
+	sum  func([]byte) []byte
+}{
+	{"sha256", func(b []byte) []byte { h := sha256.Sum256(b); return h[:] }},
+	{"sha384", func(b []byte) []byte { h := sha512.Sum384(b); return h[:] }},
+	{"sha512", func(b []byte) []byte { h := sha512.Sum512(b); return h[:] }},
+}
+
 // BenchmarkFrontend measures frontend asset loading performance
 func BenchmarkFrontend(ctx context.Context, c *client.Client) *internal.FrontendResult {
 	result := &internal.FrontendResult{
@@ -24,7 +51,7 @@ func BenchmarkFrontend(ctx context.Context, c *client.Client) *internal.Frontend
 	}
 
 	// First, fetch the index.html
-	indexResult := fetchAsset(ctx, c, "/", "html")
+	indexResult := fetchAsset(ctx, c, "/", "html", "")
 	result.IndexHTML = &indexResult
 
 	if !indexResult.Success {
@@ -49,7 +76,7 @@ func BenchmarkFrontend(ctx context.Context, c *client.Client) *internal.Frontend
 			if strings.HasPrefix(src, "http") || strings.HasPrefix(src, "data:") {
 				continue
 			}
-			assetResult := fetchAsset(ctx, c, normalizePath(src), "js")
+			assetResult := fetchAsset(ctx, c, normalizePath(src), "js", integrityAttribute(match[0]))
 			result.Assets = append(result.Assets, assetResult)
 			result.TotalSizeKB += assetResult.SizeKB
 			result.TotalTimeMs += assetResult.ResponseMs
@@ -66,7 +93,7 @@ func BenchmarkFrontend(ctx context.Context, c *client.Client) *internal.Frontend
 				continue
 			}
 			if strings.Contains(href, ".css") {
-				assetResult := fetchAsset(ctx, c, normalizePath(href), "css")
+				assetResult := fetchAsset(ctx, c, normalizePath(href), "css", integrityAttribute(match[0]))
 				result.Assets = append(result.Assets, assetResult)
 				result.TotalSizeKB += assetResult.SizeKB
 				result.TotalTimeMs += assetResult.ResponseMs
@@ -74,17 +101,32 @@ func BenchmarkFrontend(ctx context.Context, c *client.Client) *internal.Frontend
 		}
 	}
 
+	result.AssetFilenames = assetFilenames(result.Assets)
 	return result
 }
 
-func fetchAsset(ctx context.Context, c *client.Client, path string, assetType string) internal.AssetResult {
+// assetFilenames returns the sorted, distinct paths of assets
+func assetFilenames(assets []internal.AssetResult) []string {
+	var paths []string
+	for _, asset := range assets {
+		paths = append(paths, asset.Path)
+	}
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
+
+// fetchAsset times a single asset request. A non-empty integrity is the
+// asset's SRI attribute, which is checked against the downloaded body.
+func fetchAsset(ctx context.Context, c *client.Client, path string, assetType string, integrity string) internal.AssetResult {
 	result := internal.AssetResult{
-		Path: path,
-		Type: assetType,
+		Path:          path,
+		Type:          assetType,
+		SRIProtected:  integrity != "",
+		Fingerprinted: isFingerprinted(path),
 	}
 
 	start := time.Now()
-	resp, err := c.Get(ctx, path)
+	resp, err := c.GetCompressed(ctx, path)
 	result.ResponseMs = float64(time.Since(start).Microseconds()) / 1000.0
 
 	if err != nil {
@@ -96,19 +138,125 @@ func fetchAsset(ctx context.Context, c *client.Client, path string, assetType st
 
 	result.Status = resp.StatusCode
 	result.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	result.CacheControlMaxAge, result.ImmutableCache = parseCacheControl(resp.Header.Get("Cache-Control"))
 
-	// Read body to get size
-	body, err := io.ReadAll(resp.Body)
+	// Read the body as sent, then decode it to get the content size
+	wire, err := io.ReadAll(resp.Body)
 	if err != nil {
 		result.Error = "failed to read body: " + err.Error()
 		return result
 	}
+	wireSize := resp.ContentLength
+	if wireSize < 0 {
+		wireSize = int64(len(wire)) // Chunked responses carry no Content-Length
+	}
+	result.WireTransferKB = float64(wireSize) / 1024.0
+
+	encoding := resp.Header.Get("Content-Encoding")
+	body, err := decodeBody(wire, encoding)
+	if err != nil {
+		result.Error = "failed to decompress body: " + err.Error()
+		return result
+	}
 
 	result.SizeKB = float64(len(body)) / 1024.0
+	if encoding != "" && len(body) > 0 {
+		result.CompressionRatio = float64(wireSize) / float64(len(body))
+	}
+
+	if result.SRIProtected {
+		if valid, ok := verifySRI(integrity, body); ok {
+			result.SRIValid = &valid
+		}
+	}
 
 	return result
 }
 
+// isFingerprinted reports whether the file name in assetPath contains a
+// hexadecimal content hash segment. The query string is ignored.
+func isFingerprinted(assetPath string) bool {
+	assetPath, _, _ = strings.Cut(assetPath, "?")
+	return fingerprintPattern.MatchString(path.Base(assetPath))
+}
+
+// parseCacheControl returns the max-age in seconds and whether the immutable
+// directive is present in a Cache-Control header value
+func parseCacheControl(header string) (maxAge int, immutable bool) {
+	for _, directive := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "max-age":
+			if n, err := strconv.Atoi(strings.Trim(strings.TrimSpace(value), `"`)); err == nil && n > 0 {
+				maxAge = n
+			}
+		case "immutable":
+			immutable = true
+		}
+	}
+	return maxAge, immutable
+}
+
+// decodeBody returns body decoded according to its Content-Encoding. Only gzip
+// is requested by GetCompressed, so any other encoding is an error.
+func decodeBody(body []byte, encoding string) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return io.ReadAll(zr)
+	default:
+		return nil, fmt.Errorf("unsupported Content-Encoding %q", encoding)
+	}
+}
+
+// integrityAttribute returns the integrity attribute of an HTML tag, or ""
+func integrityAttribute(tag string) string {
+	if m := integrityPattern.FindStringSubmatch(tag); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
+
+// verifySRI checks body against an SRI integrity value such as "sha384-<base64>".
+// As browsers do, only hashes using the strongest listed algorithm count and any
+// one of them matching is enough. ok is false when no listed algorithm is supported.
+func verifySRI(integrity string, body []byte) (valid bool, ok bool) {
+	strongest := -1
+	digests := make(map[int][]string)
+	for _, token := range strings.Fields(integrity) {
+		// Options follow the digest after '?' and do not affect matching
+		token, _, _ = strings.Cut(token, "?")
+		alg, digest, found := strings.Cut(token, "-")
+		if !found {
+			continue
+		}
+		for i, h := range sriHashes {
+			if h.name == strings.ToLower(alg) {
+				digests[i] = append(digests[i], digest)
+				strongest = max(strongest, i)
+			}
+		}
+	}
+	if strongest < 0 {
+		return false, false
+	}
+
+	actual := sriHashes[strongest].sum(body)
+	for _, digest := range digests[strongest] {
+		expected, err := base64.StdEncoding.DecodeString(digest)
+		if err == nil && subtle.ConstantTimeCompare(expected, actual) == 1 {
+			return true, true
+		}
+	}
+	return false, true
+}
+
 func fetchContent(ctx context.Context, c *client.Client, path string) string {
 	resp, err := c.Get(ctx, path)
 	if err != nil {
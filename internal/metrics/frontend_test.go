@@ -1,12 +1,20 @@
 package metrics
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/johnzastrow/actalog-benchmark/internal"
 	"github.com/johnzastrow/actalog-benchmark/internal/client"
 )
 
@@ -62,6 +70,10 @@ func TestBenchmarkFrontend_Basic(t *testing.T) {
 		t.Errorf("expected 2 assets (CSS and JS), got %d", len(result.Assets))
 	}
 
+	if want := []string{"/assets/app.js", "/assets/style.css"}; !reflect.DeepEqual(result.AssetFilenames, want) {
+		t.Errorf("expected asset filenames %v, got %v", want, result.AssetFilenames)
+	}
+
 	// Check totals
 	if result.TotalSizeKB <= 0 {
 		t.Error("expected positive total size")
@@ -157,6 +169,208 @@ func TestBenchmarkFrontend_DataURIsIgnored(t *testing.T) {
 	}
 }
 
+func TestBenchmarkFrontend_SRI(t *testing.T) {
+	js := []byte(`console.log("hello");`)
+	sum := sha512.Sum384(js)
+	good := "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`<link integrity="sha256-AAAA" rel="stylesheet" href="/assets/style.css">
+<script src="/assets/app.js" integrity="` + good + `"></script>
+<script src="/assets/plain.js"></script>`))
+		case "/assets/style.css":
+			w.Write([]byte(`body { margin: 0; }`))
+		default:
+			w.Write(js)
+		}
+	}))
+	defer server.Close()
+
+	result := BenchmarkFrontend(context.Background(), client.New(server.URL, 10*time.Second))
+	if len(result.Assets) != 3 {
+		t.Fatalf("expected 3 assets, got %d", len(result.Assets))
+	}
+
+	assets := make(map[string]internal.AssetResult)
+	for _, a := range result.Assets {
+		assets[a.Path] = a
+	}
+	if a := assets["/assets/app.js"]; !a.SRIProtected || a.SRIValid == nil || !*a.SRIValid {
+		t.Errorf("expected app.js to pass SRI, got protected=%t valid=%v", a.SRIProtected, a.SRIValid)
+	}
+	if a := assets["/assets/style.css"]; !a.SRIProtected || a.SRIValid == nil || *a.SRIValid {
+		t.Errorf("expected style.css to fail SRI (integrity before href), got protected=%t valid=%v", a.SRIProtected, a.SRIValid)
+	}
+	if a := assets["/assets/plain.js"]; a.SRIProtected || a.SRIValid != nil {
+		t.Errorf("expected plain.js without SRI, got protected=%t valid=%v", a.SRIProtected, a.SRIValid)
+	}
+}
+
+func TestBenchmarkFrontend_Caching(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`<script src="/assets/app.3f2a9c1d.js"></script><link rel="stylesheet" href="/assets/style.css">`))
+		case "/assets/app.3f2a9c1d.js":
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+			w.Write([]byte(`console.log("hello");`))
+		default:
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Write([]byte(`body { margin: 0; }`))
+		}
+	}))
+	defer server.Close()
+
+	result := BenchmarkFrontend(context.Background(), client.New(server.URL, 10*time.Second))
+	if len(result.Assets) != 2 {
+		t.Fatalf("expected 2 assets, got %d", len(result.Assets))
+	}
+	if a := result.Assets[0]; !a.Fingerprinted || a.CacheControlMaxAge != 31536000 || !a.ImmutableCache {
+		t.Errorf("expected fingerprinted immutable app.js cached for a year, got %+v", a)
+	}
+	if a := result.Assets[1]; a.Fingerprinted || a.CacheControlMaxAge != 0 || a.ImmutableCache {
+		t.Errorf("expected uncached style.css without a fingerprint, got %+v", a)
+	}
+}
+
+func TestBenchmarkFrontend_Compression(t *testing.T) {
+	js := []byte(strings.Repeat(`console.log("hello");`, 200))
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write(js)
+	zw.Close()
+	sum := sha256.Sum256(js)
+	integrity := "sha256-" + base64.StdEncoding.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`<script src="/assets/app.js" integrity="` + integrity + `"></script><script src="/assets/plain.js"></script>`))
+		case "/assets/app.js":
+			if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+				t.Errorf("expected gzip to be accepted, got %q", r.Header.Get("Accept-Encoding"))
+			}
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(gz.Bytes())
+		default:
+			w.Write(js)
+		}
+	}))
+	defer server.Close()
+
+	result := BenchmarkFrontend(context.Background(), client.New(server.URL, 10*time.Second))
+	if len(result.Assets) != 2 {
+		t.Fatalf("expected 2 assets, got %d", len(result.Assets))
+	}
+
+	app := result.Assets[0]
+	if app.Error != "" {
+		t.Fatalf("unexpected error: %s", app.Error)
+	}
+	if want := float64(len(js)) / 1024.0; app.SizeKB != want {
+		t.Errorf("expected the decompressed size %.2f KB, got %.2f KB", want, app.SizeKB)
+	}
+	if want := float64(gz.Len()) / 1024.0; app.WireTransferKB != want {
+		t.Errorf("expected the compressed size %.2f KB on the wire, got %.2f KB", want, app.WireTransferKB)
+	}
+	if want := float64(gz.Len()) / float64(len(js)); app.CompressionRatio != want {
+		t.Errorf("expected compression ratio %.3f, got %.3f", want, app.CompressionRatio)
+	}
+	if app.SRIValid == nil || !*app.SRIValid {
+		t.Error("expected the decompressed body to pass SRI")
+	}
+
+	plain := result.Assets[1]
+	if plain.CompressionRatio != 0 || plain.WireTransferKB != plain.SizeKB {
+		t.Errorf("expected an uncompressed asset to have no ratio and equal sizes, got %+v", plain)
+	}
+}
+
+func TestDecodeBody_UnsupportedEncoding(t *testing.T) {
+	if _, err := decodeBody([]byte("data"), "br"); err == nil {
+		t.Error("expected an error for an encoding that was not requested")
+	}
+	if _, err := decodeBody([]byte("not gzip"), "gzip"); err == nil {
+		t.Error("expected an error for a corrupt gzip body")
+	}
+}
+
+func TestIsFingerprinted(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/assets/app.abc123.js", true},
+		{"/assets/app-3f2a9c1d.css", true},
+		{"/assets/3f2a9c1d.chunk.js", true},
+		{"/assets/app.abc123.js?v=2", true},
+		{"/assets/app.js", false},
+		{"/assets/app.abc12.js", false},
+		{"/assets/main.bundle.js", false},
+		{"/abc123def/app.js", false},
+	}
+
+	for _, tt := range tests {
+		if got := isFingerprinted(tt.path); got != tt.want {
+			t.Errorf("isFingerprinted(%q) = %t, want %t", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseCacheControl(t *testing.T) {
+	tests := []struct {
+		header        string
+		wantMaxAge    int
+		wantImmutable bool
+	}{
+		{"public, max-age=31536000, immutable", 31536000, true},
+		{"Max-Age=600", 600, false},
+		{`max-age="3600"`, 3600, false},
+		{"no-cache", 0, false},
+		{"max-age=abc", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		maxAge, immutable := parseCacheControl(tt.header)
+		if maxAge != tt.wantMaxAge || immutable != tt.wantImmutable {
+			t.Errorf("parseCacheControl(%q) = %d, %t; want %d, %t", tt.header, maxAge, immutable, tt.wantMaxAge, tt.wantImmutable)
+		}
+	}
+}
+
+func TestVerifySRI(t *testing.T) {
+	body := []byte("alert(1)")
+	s256 := sha256.Sum256(body)
+	s512 := sha512.Sum512(body)
+	enc := base64.StdEncoding.EncodeToString
+
+	tests := []struct {
+		name      string
+		integrity string
+		wantValid bool
+		wantOK    bool
+	}{
+		{"sha256 match", "sha256-" + enc(s256[:]), true, true},
+		{"sha512 mismatch", "sha512-" + enc(s256[:]), false, true},
+		{"any digest of strongest algorithm", "sha512-AAAA sha512-" + enc(s512[:]), true, true},
+		{"weaker algorithm ignored", "sha256-" + enc(s256[:]) + " sha512-AAAA", false, true},
+		{"options ignored", "sha256-" + enc(s256[:]) + "?ct=text/javascript", true, true},
+		{"unsupported algorithm", "md5-AAAA", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, ok := verifySRI(tt.integrity, body)
+			if valid != tt.wantValid || ok != tt.wantOK {
+				t.Errorf("verifySRI(%q) = %t, %t; want %t, %t", tt.integrity, valid, ok, tt.wantValid, tt.wantOK)
+			}
+		})
+	}
+}
+
 func TestNormalizePath(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// CheckGRPCHealth calls grpc.health.v1.Health/Check for the whole server at
+// addr (host:port) over plaintext and maps the reply onto the statuses used by
+// CheckHealth: SERVING is "healthy", any other serving status is "unhealthy",
+// and a failed call is "error". ResponseMs is the duration of the call.
+func CheckGRPCHealth(ctx context.Context, addr string, timeout time.Duration) *internal.HealthResult {
+	result := &internal.HealthResult{}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		result.Status = "error"
+		result.Error = fmt.Sprintf("create gRPC client: %v", err)
+		return result
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The client connects lazily, so the call's duration includes connecting
+	start := time.Now()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	result.ResponseMs = float64(time.Since(start).Microseconds()) / 1000.0
+
+	if err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+		return result
+	}
+
+	if resp.GetStatus() == healthpb.HealthCheckResponse_SERVING {
+		result.Status = "healthy"
+	} else {
+		result.Status = "unhealthy"
+		result.Error = fmt.Sprintf("gRPC health status %s", resp.GetStatus())
+	}
+	return result
+}
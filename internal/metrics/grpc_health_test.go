@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// newGRPCHealthServer serves the standard health service on a local port and
+// returns its address and the service, whose status tests can change
+func newGRPCHealthServer(t *testing.T) (string, *health.Server) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), healthServer
+}
+
+func TestCheckGRPCHealth(t *testing.T) {
+	addr, healthServer := newGRPCHealthServer(t)
+
+	tests := []struct {
+		name       string
+		status     healthpb.HealthCheckResponse_ServingStatus
+		wantStatus string
+	}{
+		{"serving", healthpb.HealthCheckResponse_SERVING, "healthy"},
+		{"not serving", healthpb.HealthCheckResponse_NOT_SERVING, "unhealthy"},
+		{"unknown", healthpb.HealthCheckResponse_UNKNOWN, "unhealthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthServer.SetServingStatus("", tt.status)
+			result := CheckGRPCHealth(context.Background(), addr, 5*time.Second)
+			if result.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s (error %q)", tt.wantStatus, result.Status, result.Error)
+			}
+			if result.ResponseMs <= 0 {
+				t.Errorf("expected a positive call duration, got %f", result.ResponseMs)
+			}
+		})
+	}
+}
+
+func TestCheckGRPCHealth_Unreachable(t *testing.T) {
+	// Grab a free port, then close it so nothing is listening
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	result := CheckGRPCHealth(context.Background(), addr, time.Second)
+	if result.Status != "error" || result.Error == "" {
+		t.Errorf("expected an error result, got %+v", result)
+	}
+}
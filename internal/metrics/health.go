@@ -15,6 +15,11 @@ type HealthResponse struct {
 	Status   string `json:"status"`
 	Database string `json:"database"`
 	Version  string `json:"version"`
+
+	// Optional server resource metadata
+	Uptime            string  `json:"uptime,omitempty"`
+	ActiveConnections int     `json:"active_connections,omitempty"`
+	MemoryMB          float64 `json:"memory_mb,omitempty"`
 }
 
 // CheckHealth checks the health endpoint and returns results
@@ -49,6 +54,43 @@ func CheckHealth(ctx context.Context, c *client.Client) *internal.HealthResult {
 	}
 
 	result.Status = healthResp.Status
+	result.Uptime = healthResp.Uptime
+	result.ActiveConnections = healthResp.ActiveConnections
+	result.MemoryMB = healthResp.MemoryMB
 
 	return result
 }
+
+// WaitForHealthy polls the health endpoint every interval until it reports healthy
+// or the timeout elapses. onAttempt, if set, is called after each unhealthy check.
+// It returns the last health result and the time spent waiting.
+func WaitForHealthy(ctx context.Context, c *client.Client, timeout, interval time.Duration,
+	onAttempt func(attempt int, health *internal.HealthResult)) (*internal.HealthResult, time.Duration) {
+	start := time.Now()
+	deadline := start.Add(timeout)
+
+	for attempt := 1; ; attempt++ {
+		health := CheckHealth(ctx, c)
+		if health.Status == "healthy" {
+			return health, time.Since(start)
+		}
+		if onAttempt != nil {
+			onAttempt(attempt, health)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return health, time.Since(start)
+		}
+		wait := interval
+		if remaining < wait {
+			wait = remaining
+		}
+
+		select {
+		case <-ctx.Done():
+			return health, time.Since(start)
+		case <-time.After(wait):
+		}
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/johnzastrow/actalog-benchmark/internal"
 	"github.com/johnzastrow/actalog-benchmark/internal/client"
 )
 
@@ -44,6 +46,27 @@ func TestCheckHealth_Healthy(t *testing.T) {
 	}
 }
 
+func TestCheckHealth_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status": "healthy", "uptime": "3d4h", "active_connections": 12, "memory_mb": 128.5}`))
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	result := CheckHealth(context.Background(), c)
+
+	if result.Uptime != "3d4h" {
+		t.Errorf("expected uptime '3d4h', got '%s'", result.Uptime)
+	}
+	if result.ActiveConnections != 12 {
+		t.Errorf("expected 12 active connections, got %d", result.ActiveConnections)
+	}
+	if result.MemoryMB != 128.5 {
+		t.Errorf("expected memory 128.5 MB, got %f", result.MemoryMB)
+	}
+}
+
 func TestCheckHealth_Unhealthy(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusServiceUnavailable)
@@ -110,3 +133,55 @@ func TestCheckHealth_ConnectionError(t *testing.T) {
 		t.Error("expected error message for connection failure")
 	}
 }
+
+func TestWaitForHealthy_BecomesHealthy(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(HealthResponse{Status: "healthy"})
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	attempts := 0
+	health, waited := WaitForHealthy(context.Background(), c, 5*time.Second, 10*time.Millisecond,
+		func(attempt int, h *internal.HealthResult) {
+			attempts = attempt
+			if h.Status != "unhealthy" {
+				t.Errorf("expected status 'unhealthy' while waiting, got '%s'", h.Status)
+			}
+		})
+
+	if health.Status != "healthy" {
+		t.Errorf("expected status 'healthy', got '%s'", health.Status)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 unhealthy attempts, got %d", attempts)
+	}
+	if waited <= 0 {
+		t.Error("expected positive wait duration")
+	}
+}
+
+func TestWaitForHealthy_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	health, waited := WaitForHealthy(context.Background(), c, 100*time.Millisecond, 20*time.Millisecond, nil)
+
+	if health.Status == "healthy" {
+		t.Error("expected target to remain unhealthy")
+	}
+	if waited < 100*time.Millisecond {
+		t.Errorf("expected to wait at least the timeout, waited %s", waited)
+	}
+	if waited > 2*time.Second {
+		t.Errorf("expected wait to stop near the timeout, waited %s", waited)
+	}
+}
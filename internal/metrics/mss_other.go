@@ -0,0 +1,13 @@
+//go:build !unix
+
+package metrics
+
+import (
+	"errors"
+	"net"
+)
+
+// tcpMaxSegment is not available without the Unix TCP_MAXSEG socket option
+func tcpMaxSegment(conn *net.TCPConn) (int, error) {
+	return 0, errors.New("TCP_MAXSEG is not supported on this platform")
+}
@@ -0,0 +1,29 @@
+//go:build unix
+
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// tcpMaxSegment reads the maximum segment size negotiated for conn (TCP_MAXSEG)
+func tcpMaxSegment(conn *net.TCPConn) (int, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return 0, fmt.Errorf("access socket: %w", err)
+	}
+
+	var mss int
+	var sockErr error
+	if err := raw.Control(func(fd uintptr) {
+		mss, sockErr = syscall.GetsockoptInt(int(fd), syscall.IPPROTO_TCP, syscall.TCP_MAXSEG)
+	}); err != nil {
+		return 0, fmt.Errorf("access socket: %w", err)
+	}
+	if sockErr != nil {
+		return 0, fmt.Errorf("getsockopt TCP_MAXSEG: %w", sockErr)
+	}
+	return mss, nil
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+)
+
+// DefaultConcurrencySteps are the concurrency levels used by --concurrency-profile
+const DefaultConcurrencySteps = "1,2,5,10,20,50"
+
+// MaxProfileErrorRatePct is the error rate above which a concurrency level is
+// not considered for the optimal recommendation
+const MaxProfileErrorRatePct = 1.0
+
+// ParseConcurrencySteps parses a comma-separated list of positive concurrency levels
+func ParseConcurrencySteps(s string) ([]int, error) {
+	var steps []int
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid concurrency step %q: must be a positive integer", field)
+		}
+		steps = append(steps, n)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no concurrency steps given")
+	}
+	return steps, nil
+}
+
+// ConcurrencyProfile runs a load test at each concurrency level for stepDuration
+// and records throughput, p95 latency, and error rate for each step.
+// opts supplies the endpoint selector; its Concurrent and Duration are overridden.
+func ConcurrencyProfile(ctx context.Context, c *client.Client, steps []int, stepDuration time.Duration, opts LoadTestOptions, onStep func(concurrent int)) []internal.ConcurrencyDataPoint {
+	points := make([]internal.ConcurrencyDataPoint, 0, len(steps))
+	for _, concurrent := range steps {
+		if ctx.Err() != nil {
+			break
+		}
+		if onStep != nil {
+			onStep(concurrent)
+		}
+
+		opts.Concurrent = concurrent
+		opts.Duration = stepDuration
+		lt := LoadTestWithOptions(ctx, c, opts)
+
+		point := internal.ConcurrencyDataPoint{
+			Concurrent:   concurrent,
+			RPS:          lt.RPS,
+			LatencyP95Ms: lt.LatencyP95Ms,
+		}
+		if lt.TotalRequests > 0 {
+			point.ErrorRatePct = float64(lt.Failed) / float64(lt.TotalRequests) * 100
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+// OptimalConcurrency returns the data point with the highest RPS-to-p95-latency
+// ratio among points whose error rate is within MaxProfileErrorRatePct.
+// It returns false when no point qualifies.
+func OptimalConcurrency(points []internal.ConcurrencyDataPoint) (internal.ConcurrencyDataPoint, bool) {
+	var best internal.ConcurrencyDataPoint
+	bestRatio := -1.0
+	for _, p := range points {
+		if p.LatencyP95Ms <= 0 || p.ErrorRatePct > MaxProfileErrorRatePct {
+			continue
+		}
+		if ratio := p.RPS / p.LatencyP95Ms; ratio > bestRatio {
+			best, bestRatio = p, ratio
+		}
+	}
+	return best, bestRatio >= 0
+}
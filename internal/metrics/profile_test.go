@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+)
+
+func TestParseConcurrencySteps(t *testing.T) {
+	steps, err := ParseConcurrencySteps(DefaultConcurrencySteps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []int{1, 2, 5, 10, 20, 50}
+	if len(steps) != len(expected) {
+		t.Fatalf("expected %d steps, got %d", len(expected), len(steps))
+	}
+	for i, want := range expected {
+		if steps[i] != want {
+			t.Errorf("step %d: expected %d, got %d", i, want, steps[i])
+		}
+	}
+
+	if steps, err := ParseConcurrencySteps(" 4, 8 ,"); err != nil || len(steps) != 2 {
+		t.Errorf("expected 2 steps with whitespace and trailing comma, got %v (err %v)", steps, err)
+	}
+
+	for _, bad := range []string{"", "1,zero", "0", "-3"} {
+		if _, err := ParseConcurrencySteps(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestConcurrencyProfile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 5*time.Second)
+	var visited []int
+	points := ConcurrencyProfile(context.Background(), c, []int{1, 3}, 100*time.Millisecond, LoadTestOptions{}, func(concurrent int) {
+		visited = append(visited, concurrent)
+	})
+
+	if len(points) != 2 {
+		t.Fatalf("expected 2 data points, got %d", len(points))
+	}
+	if len(visited) != 2 || visited[0] != 1 || visited[1] != 3 {
+		t.Errorf("expected step callback for 1 and 3, got %v", visited)
+	}
+	for _, p := range points {
+		if p.RPS <= 0 {
+			t.Errorf("concurrency %d: expected positive RPS", p.Concurrent)
+		}
+		// Requests in flight when the step ends are cancelled and count as failures
+		if p.ErrorRatePct > 1 {
+			t.Errorf("concurrency %d: expected near-zero errors, got %.2f%%", p.Concurrent, p.ErrorRatePct)
+		}
+	}
+}
+
+func TestOptimalConcurrency(t *testing.T) {
+	points := []internal.ConcurrencyDataPoint{
+		{Concurrent: 1, RPS: 50, LatencyP95Ms: 20},   // 2.5
+		{Concurrent: 5, RPS: 200, LatencyP95Ms: 40},  // 5.0
+		{Concurrent: 10, RPS: 250, LatencyP95Ms: 80}, // 3.1
+		{Concurrent: 20, RPS: 900, LatencyP95Ms: 90, ErrorRatePct: 5},
+	}
+
+	best, ok := OptimalConcurrency(points)
+	if !ok {
+		t.Fatal("expected an optimal point")
+	}
+	if best.Concurrent != 5 {
+		t.Errorf("expected optimal concurrency 5, got %d", best.Concurrent)
+	}
+
+	if _, ok := OptimalConcurrency([]internal.ConcurrencyDataPoint{{Concurrent: 1, ErrorRatePct: 100}}); ok {
+		t.Error("expected no optimal point when every step exceeds the error limit")
+	}
+}
@@ -0,0 +1,371 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// Endpoint rotation strategies for the load test
+const (
+	StrategyRoundRobin = "round-robin"
+	StrategyRandom     = "random"
+	StrategyWeighted   = "weighted"
+)
+
+// EndpointSelector picks the path for the next load test request.
+// Implementations must be safe for concurrent use by multiple workers.
+type EndpointSelector interface {
+	Next() string
+}
+
+// NewEndpointSelector creates a selector for the given strategy
+func NewEndpointSelector(strategy string, endpoints []internal.WeightedEndpoint) (EndpointSelector, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints to select from")
+	}
+
+	paths := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		paths[i] = ep.Path
+	}
+
+	switch strategy {
+	case "", StrategyRoundRobin:
+		return NewRoundRobinSelector(paths), nil
+	case StrategyRandom:
+		return NewRandomSelector(paths), nil
+	case StrategyWeighted:
+		return NewWeightedSelector(endpoints)
+	default:
+		return nil, fmt.Errorf("unknown endpoint strategy %q (valid: %s, %s, %s)",
+			strategy, StrategyRoundRobin, StrategyRandom, StrategyWeighted)
+	}
+}
+
+// RoundRobinSelector cycles through the endpoints in order
+type RoundRobinSelector struct {
+	paths []string
+	next  uint64
+}
+
+// NewRoundRobinSelector creates a new round-robin selector
+func NewRoundRobinSelector(paths []string) *RoundRobinSelector {
+	return &RoundRobinSelector{paths: paths}
+}
+
+// Next returns the next path in rotation
+func (s *RoundRobinSelector) Next() string {
+	n := atomic.AddUint64(&s.next, 1) - 1
+	return s.paths[n%uint64(len(s.paths))]
+}
+
+// RandomSelector picks endpoints uniformly at random
+type RandomSelector struct {
+	paths []string
+}
+
+// NewRandomSelector creates a new uniform random selector
+func NewRandomSelector(paths []string) *RandomSelector {
+	return &RandomSelector{paths: paths}
+}
+
+// Next returns a uniformly random path
+func (s *RandomSelector) Next() string {
+	return s.paths[rand.Intn(len(s.paths))]
+}
+
+// WeightedSelector picks endpoints proportionally to their weight using
+// Vose's alias method, giving O(1) selection regardless of endpoint count
+type WeightedSelector struct {
+	paths []string
+	prob  []float64
+	alias []int
+}
+
+// NewWeightedSelector builds the alias table for the given endpoints
+func NewWeightedSelector(endpoints []internal.WeightedEndpoint) (*WeightedSelector, error) {
+	n := len(endpoints)
+	if n == 0 {
+		return nil, fmt.Errorf("no endpoints to select from")
+	}
+
+	var total float64
+	for _, ep := range endpoints {
+		if ep.Weight <= 0 {
+			return nil, fmt.Errorf("endpoint %s has non-positive weight %d", ep.Path, ep.Weight)
+		}
+		total += float64(ep.Weight)
+	}
+
+	s := &WeightedSelector{
+		paths: make([]string, n),
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+
+	// Scale weights so the average bucket is exactly 1
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, ep := range endpoints {
+		s.paths[i] = ep.Path
+		scaled[i] = float64(ep.Weight) * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		l := small[len(small)-1]
+		small = small[:len(small)-1]
+		g := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		s.prob[l] = scaled[l]
+		s.alias[l] = g
+
+		scaled[g] = scaled[g] + scaled[l] - 1
+		if scaled[g] < 1 {
+			small = append(small, g)
+		} else {
+			large = append(large, g)
+		}
+	}
+
+	// Remaining buckets are full (any leftovers are floating point residue)
+	for _, i := range large {
+		s.prob[i] = 1
+	}
+	for _, i := range small {
+		s.prob[i] = 1
+	}
+
+	return s, nil
+}
+
+// Next returns a path chosen with probability proportional to its weight
+func (s *WeightedSelector) Next() string {
+	i := rand.Intn(len(s.paths))
+	if rand.Float64() < s.prob[i] {
+		return s.paths[i]
+	}
+	return s.paths[s.alias[i]]
+}
+
+// LoadWeightedEndpoints reads an endpoints file from disk. A .yaml or .yml
+// file holds a list of endpoint entries, and a .json file may hold a JSON
+// array of them; any other file is parsed by ParseWeightedEndpoints.
+func LoadWeightedEndpoints(path string) ([]internal.WeightedEndpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("open endpoints file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return parseYAMLEndpoints(data)
+	case ".json":
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+			return parseJSONEndpointList(data)
+		}
+	}
+	return ParseWeightedEndpoints(bytes.NewReader(data))
+}
+
+// parseJSONEndpointList decodes a JSON array of endpoint entries
+func parseJSONEndpointList(data []byte) ([]internal.WeightedEndpoint, error) {
+	var endpoints []internal.WeightedEndpoint
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&endpoints); err != nil {
+		return nil, fmt.Errorf("invalid JSON endpoints file: %w", err)
+	}
+	return checkEndpointList(endpoints)
+}
+
+// parseYAMLEndpoints decodes a YAML list of endpoint entries
+func parseYAMLEndpoints(data []byte) ([]internal.WeightedEndpoint, error) {
+	var endpoints []internal.WeightedEndpoint
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&endpoints); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid YAML endpoints file: %w", err)
+	}
+	return checkEndpointList(endpoints)
+}
+
+// checkEndpointList validates the entries of a JSON or YAML endpoints file
+func checkEndpointList(endpoints []internal.WeightedEndpoint) ([]internal.WeightedEndpoint, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("endpoints file contains no endpoints")
+	}
+	for i := range endpoints {
+		if err := checkEndpoint(&endpoints[i]); err != nil {
+			return nil, fmt.Errorf("endpoint %d: %w", i+1, err)
+		}
+	}
+	return endpoints, nil
+}
+
+// ParseWeightedEndpoints parses one endpoint per line, either in "path [weight]"
+// format or as a JSON object such as
+// {"method": "POST", "path": "/api/workouts", "body": "{}", "expected_status": 201}.
+// JSON entries may also set "headers", an object of request headers.
+// Blank lines and lines starting with # are ignored. Weight defaults to 1.
+func ParseWeightedEndpoints(r io.Reader) ([]internal.WeightedEndpoint, error) {
+	var endpoints []internal.WeightedEndpoint
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "{") {
+			ep, err := parseJSONEndpoint(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			endpoints = append(endpoints, ep)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("line %d: expected \"path [weight]\", got %q", lineNum, line)
+		}
+
+		ep := internal.WeightedEndpoint{
+			Path:   normalizePath(fields[0]),
+			Weight: 1,
+		}
+		if len(fields) == 2 {
+			weight, err := strconv.Atoi(fields[1])
+			if err != nil || weight <= 0 {
+				return nil, fmt.Errorf("line %d: weight must be a positive integer, got %q", lineNum, fields[1])
+			}
+			ep.Weight = weight
+		}
+
+		endpoints = append(endpoints, ep)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read endpoints file: %w", err)
+	}
+
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("endpoints file contains no endpoints")
+	}
+
+	return endpoints, nil
+}
+
+// parseJSONEndpoint decodes a single JSON endpoint entry
+func parseJSONEndpoint(line string) (internal.WeightedEndpoint, error) {
+	var ep internal.WeightedEndpoint
+
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&ep); err != nil {
+		return ep, fmt.Errorf("invalid JSON endpoint: %w", err)
+	}
+
+	err := checkEndpoint(&ep)
+	return ep, err
+}
+
+// checkEndpoint validates a JSON or YAML endpoint entry, normalizing its path
+// and method and defaulting its weight to 1
+func checkEndpoint(ep *internal.WeightedEndpoint) error {
+	if ep.Path == "" {
+		return fmt.Errorf("endpoint is missing \"path\"")
+	}
+	ep.Path = normalizePath(ep.Path)
+
+	ep.Method = strings.ToUpper(ep.Method)
+	switch ep.Method {
+	case "", http.MethodGet:
+		ep.Method = http.MethodGet
+	case http.MethodPost, http.MethodPut, http.MethodDelete:
+	default:
+		return fmt.Errorf("unsupported method %q (valid: GET, POST, PUT, DELETE)", ep.Method)
+	}
+
+	if ep.Weight == 0 {
+		ep.Weight = 1
+	} else if ep.Weight < 0 {
+		return fmt.Errorf("weight must be a positive integer, got %d", ep.Weight)
+	}
+
+	for name := range ep.Headers {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+	}
+
+	return nil
+}
+
+// ParseLoadEndpoints parses a comma-separated list of load test endpoints, each
+// a path with an optional ":weight" suffix, e.g. "/api/workouts:3,/health".
+// Weight defaults to 1.
+func ParseLoadEndpoints(spec string) ([]internal.WeightedEndpoint, error) {
+	var endpoints []internal.WeightedEndpoint
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		ep := internal.WeightedEndpoint{Path: entry, Weight: 1}
+		if i := strings.LastIndex(entry, ":"); i >= 0 {
+			weight, err := strconv.Atoi(entry[i+1:])
+			if err != nil || weight <= 0 {
+				return nil, fmt.Errorf("weight must be a positive integer, got %q in %q", entry[i+1:], entry)
+			}
+			ep.Path, ep.Weight = entry[:i], weight
+		}
+		if ep.Path == "" {
+			return nil, fmt.Errorf("endpoint %q is missing a path", entry)
+		}
+		ep.Path = normalizePath(ep.Path)
+		endpoints = append(endpoints, ep)
+	}
+
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints given")
+	}
+	return endpoints, nil
+}
+
+// FilterGET returns the endpoints that use GET and can join the load test rotation
+func FilterGET(endpoints []internal.WeightedEndpoint) []internal.WeightedEndpoint {
+	var gets []internal.WeightedEndpoint
+	for _, ep := range endpoints {
+		if ep.Method == "" || ep.Method == http.MethodGet {
+			gets = append(gets, ep)
+		}
+	}
+	return gets
+}
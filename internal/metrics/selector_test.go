@@ -0,0 +1,378 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+)
+
+func TestRoundRobinSelector(t *testing.T) {
+	s := NewRoundRobinSelector([]string{"/a", "/b", "/c"})
+
+	expected := []string{"/a", "/b", "/c", "/a", "/b"}
+	for i, want := range expected {
+		if got := s.Next(); got != want {
+			t.Errorf("call %d: expected %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestRoundRobinSelector_Concurrent(t *testing.T) {
+	s := NewRoundRobinSelector([]string{"/a", "/b"})
+
+	var mu sync.Mutex
+	counts := make(map[string]int)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				path := s.Next()
+				mu.Lock()
+				counts[path]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if counts["/a"] != 500 || counts["/b"] != 500 {
+		t.Errorf("expected even split of 500/500, got %v", counts)
+	}
+}
+
+func TestRandomSelector(t *testing.T) {
+	paths := []string{"/a", "/b", "/c"}
+	s := NewRandomSelector(paths)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 300; i++ {
+		seen[s.Next()] = true
+	}
+	for _, p := range paths {
+		if !seen[p] {
+			t.Errorf("expected %s to be selected at least once", p)
+		}
+	}
+}
+
+func TestWeightedSelector_Distribution(t *testing.T) {
+	s, err := NewWeightedSelector([]internal.WeightedEndpoint{
+		{Path: "/heavy", Weight: 3},
+		{Path: "/light", Weight: 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	counts := make(map[string]int)
+	const n = 20000
+	for i := 0; i < n; i++ {
+		counts[s.Next()]++
+	}
+
+	ratio := float64(counts["/heavy"]) / float64(n)
+	if ratio < 0.70 || ratio > 0.80 {
+		t.Errorf("expected /heavy to be selected ~75%% of the time, got %.1f%%", ratio*100)
+	}
+}
+
+func TestWeightedSelector_SingleEndpoint(t *testing.T) {
+	s, err := NewWeightedSelector([]internal.WeightedEndpoint{{Path: "/only", Weight: 5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if got := s.Next(); got != "/only" {
+			t.Errorf("expected /only, got %s", got)
+		}
+	}
+}
+
+func TestWeightedSelector_InvalidWeight(t *testing.T) {
+	_, err := NewWeightedSelector([]internal.WeightedEndpoint{{Path: "/a", Weight: 0}})
+	if err == nil {
+		t.Error("expected error for zero weight")
+	}
+}
+
+func TestNewEndpointSelector(t *testing.T) {
+	endpoints := []internal.WeightedEndpoint{{Path: "/a", Weight: 1}}
+
+	tests := []struct {
+		strategy string
+		wantErr  bool
+	}{
+		{"", false},
+		{StrategyRoundRobin, false},
+		{StrategyRandom, false},
+		{StrategyWeighted, false},
+		{"fastest", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			s, err := NewEndpointSelector(tt.strategy, endpoints)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := s.Next(); got != "/a" {
+				t.Errorf("expected /a, got %s", got)
+			}
+		})
+	}
+
+	if _, err := NewEndpointSelector(StrategyRoundRobin, nil); err == nil {
+		t.Error("expected error for empty endpoint list")
+	}
+}
+
+func TestParseWeightedEndpoints(t *testing.T) {
+	input := `# load mix
+/api/workouts 3
+api/movements
+
+/health 1
+`
+	endpoints, err := ParseWeightedEndpoints(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []internal.WeightedEndpoint{
+		{Path: "/api/workouts", Weight: 3},
+		{Path: "/api/movements", Weight: 1},
+		{Path: "/health", Weight: 1},
+	}
+	if len(endpoints) != len(expected) {
+		t.Fatalf("expected %d endpoints, got %d", len(expected), len(endpoints))
+	}
+	for i, want := range expected {
+		if !reflect.DeepEqual(endpoints[i], want) {
+			t.Errorf("endpoint %d: expected %+v, got %+v", i, want, endpoints[i])
+		}
+	}
+}
+
+func TestParseWeightedEndpoints_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", "# nothing here\n"},
+		{"bad weight", "/health abc\n"},
+		{"negative weight", "/health -2\n"},
+		{"too many fields", "/health 1 2\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseWeightedEndpoints(strings.NewReader(tt.input)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadTestWithOptions_Selector(t *testing.T) {
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, 10*time.Second)
+	result := LoadTestWithOptions(context.Background(), c, LoadTestOptions{
+		Concurrent: 2,
+		Duration:   300 * time.Millisecond,
+		Selector:   NewRoundRobinSelector([]string{"/api/a", "/api/b"}),
+		Strategy:   StrategyRoundRobin,
+	})
+
+	if result.EndpointStrategy != StrategyRoundRobin {
+		t.Errorf("expected strategy %s, got %s", StrategyRoundRobin, result.EndpointStrategy)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if hits["/api/a"] == 0 || hits["/api/b"] == 0 {
+		t.Errorf("expected both endpoints to be hit, got %v", hits)
+	}
+	if hits["/health"] != 0 {
+		t.Errorf("expected /health not to be hit when selector is set, got %d", hits["/health"])
+	}
+
+	dist := result.EndpointDistribution
+	if len(dist) != 2 || dist["/api/a"] == 0 || dist["/api/b"] == 0 || dist["/api/a"]+dist["/api/b"] > result.TotalRequests {
+		t.Errorf("expected requests to both endpoints in the distribution, got %v of %d", dist, result.TotalRequests)
+	}
+}
+
+func TestParseLoadEndpoints(t *testing.T) {
+	endpoints, err := ParseLoadEndpoints("/api/workouts:3, api/movements:1,/health")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []internal.WeightedEndpoint{
+		{Path: "/api/workouts", Weight: 3},
+		{Path: "/api/movements", Weight: 1},
+		{Path: "/health", Weight: 1},
+	}
+	if !reflect.DeepEqual(endpoints, expected) {
+		t.Errorf("expected %+v, got %+v", expected, endpoints)
+	}
+
+	for _, spec := range []string{"", " , ", "/health:0", "/health:abc", ":3"} {
+		if _, err := ParseLoadEndpoints(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestParseWeightedEndpoints_JSONLines(t *testing.T) {
+	input := `/health 2
+{"method": "post", "path": "api/workouts", "body": "{\"name\":\"test\"}", "expected_status": 201}
+{"path": "/api/movements", "weight": 3}
+`
+	endpoints, err := ParseWeightedEndpoints(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []internal.WeightedEndpoint{
+		{Path: "/health", Weight: 2},
+		{Path: "/api/workouts", Weight: 1, Method: "POST", Body: `{"name":"test"}`, ExpectedStatus: 201},
+		{Path: "/api/movements", Weight: 3, Method: "GET"},
+	}
+	if len(endpoints) != len(expected) {
+		t.Fatalf("expected %d endpoints, got %d", len(expected), len(endpoints))
+	}
+	for i, want := range expected {
+		if !reflect.DeepEqual(endpoints[i], want) {
+			t.Errorf("endpoint %d: expected %+v, got %+v", i, want, endpoints[i])
+		}
+	}
+}
+
+func TestParseWeightedEndpoints_JSONErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"malformed", `{"path": "/health"` + "\n"},
+		{"missing path", `{"method": "GET"}` + "\n"},
+		{"unknown field", `{"path": "/health", "query": {}}` + "\n"},
+		{"unsupported method", `{"method": "PATCH", "path": "/api/workouts/1"}` + "\n"},
+		{"invalid header name", `{"path": "/health", "headers": {"X Bad": "1"}}` + "\n"},
+		{"negative weight", `{"path": "/health", "weight": -1}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseWeightedEndpoints(strings.NewReader(tt.input)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadWeightedEndpoints_Formats(t *testing.T) {
+	expected := []internal.WeightedEndpoint{
+		{Path: "/api/workouts/1", Weight: 2, Method: "PUT", Body: `{"name":"test"}`, Headers: map[string]string{"X-Trace": "bench"}},
+		{Path: "/api/workouts/1", Weight: 1, Method: "DELETE", ExpectedStatus: 204},
+	}
+
+	files := map[string]string{
+		"endpoints.yaml": `- path: /api/workouts/1
+  method: put
+  weight: 2
+  body: '{"name":"test"}'
+  headers:
+    X-Trace: bench
+- path: api/workouts/1
+  method: DELETE
+  expected_status: 204
+`,
+		"endpoints.json": `[
+  {"path": "/api/workouts/1", "method": "PUT", "weight": 2, "body": "{\"name\":\"test\"}", "headers": {"X-Trace": "bench"}},
+  {"path": "/api/workouts/1", "method": "DELETE", "expected_status": 204}
+]`,
+	}
+
+	dir := t.TempDir()
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			endpoints, err := LoadWeightedEndpoints(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(endpoints, expected) {
+				t.Errorf("expected %+v, got %+v", expected, endpoints)
+			}
+		})
+	}
+}
+
+func TestLoadWeightedEndpoints_YAMLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty", ""},
+		{"unknown field", "- path: /health\n  query: x\n"},
+		{"unsupported method", "- path: /health\n  method: PATCH\n"},
+		{"not a list", "path: /health\n"},
+	}
+
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "endpoints.yml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadWeightedEndpoints(path); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestFilterGET(t *testing.T) {
+	endpoints := []internal.WeightedEndpoint{
+		{Path: "/health", Weight: 1},
+		{Path: "/api/workouts", Weight: 1, Method: "POST"},
+		{Path: "/api/movements", Weight: 1, Method: "GET"},
+	}
+
+	gets := FilterGET(endpoints)
+	if len(gets) != 2 || gets[0].Path != "/health" || gets[1].Path != "/api/movements" {
+		t.Errorf("unexpected GET endpoints: %+v", gets)
+	}
+}
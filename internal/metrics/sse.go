@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+)
+
+// maxSSELineSize bounds a single line of an event stream
+const maxSSELineSize = 1024 * 1024
+
+// MeasureSSE subscribes to the server-sent events stream at path and reads
+// eventCount events, recording the time from the request to the first event
+// and the mean gap between the events that follow. Reading stops early when
+// the stream ends or ctx or the client timeout expires; the events received
+// so far are still reported, with Error explaining the shortfall.
+func MeasureSSE(ctx context.Context, c *client.Client, path string, eventCount int) *internal.SSEResult {
+	result := &internal.SSEResult{Path: path}
+
+	start := time.Now()
+	resp, err := c.GetEventStream(ctx, path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		result.Error = fmt.Sprintf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		return result
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+		result.Error = fmt.Sprintf("unexpected Content-Type %q, want text/event-stream", resp.Header.Get("Content-Type"))
+		return result
+	}
+	result.Connected = true
+
+	var eventTimes []time.Time
+	err = readSSEEvents(resp.Body, func() bool {
+		eventTimes = append(eventTimes, time.Now())
+		return len(eventTimes) < eventCount
+	})
+
+	result.EventsReceived = len(eventTimes)
+	if len(eventTimes) > 0 {
+		result.TimeToFirstEventMs = float64(eventTimes[0].Sub(start).Microseconds()) / 1000.0
+	}
+	if len(eventTimes) > 1 {
+		span := eventTimes[len(eventTimes)-1].Sub(eventTimes[0])
+		result.AvgIntervalMs = float64(span.Microseconds()) / 1000.0 / float64(len(eventTimes)-1)
+	}
+
+	if len(eventTimes) < eventCount {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		result.Error = fmt.Sprintf("received %d of %d events: %v", len(eventTimes), eventCount, err)
+	}
+	return result
+}
+
+// readSSEEvents reads an event stream line by line and calls onEvent as each
+// event is dispatched, until onEvent returns false or the stream ends. As in
+// the EventSource specification, a blank line dispatches the event, events
+// without data lines are dropped, and comment lines starting with ":" are
+// ignored. A clean end of the stream returns nil.
+func readSSEEvents(r io.Reader, onEvent func() bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxSSELineSize)
+
+	hasData := false
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		switch {
+		case line == "":
+			if hasData && !onEvent() {
+				return nil
+			}
+			hasData = false
+		case line == "data" || strings.HasPrefix(line, "data:"):
+			hasData = true
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal/client"
+)
+
+// newSSEServer streams events, each preceded by interval, then ends the stream
+func newSSEServer(t *testing.T, events int, interval time.Duration) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			http.Error(w, "expected an event stream request", http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		flusher := w.(http.Flusher)
+		fmt.Fprint(w, ": connected\n\n") // A comment is not an event
+		flusher.Flush()
+		for i := 1; i <= events; i++ {
+			time.Sleep(interval)
+			fmt.Fprintf(w, "event: workout\r\nid: %d\r\ndata: {\"id\": %d}\r\n\r\n", i, i)
+			flusher.Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMeasureSSE(t *testing.T) {
+	server := newSSEServer(t, 5, 20*time.Millisecond)
+	c := client.New(server.URL, 5*time.Second)
+
+	result := MeasureSSE(context.Background(), c, "/api/events", 3)
+	if !result.Connected || result.Error != "" {
+		t.Fatalf("expected a connected stream without error, got %+v", result)
+	}
+	if result.EventsReceived != 3 {
+		t.Errorf("expected to stop after 3 events, got %d", result.EventsReceived)
+	}
+	if result.TimeToFirstEventMs < 20 {
+		t.Errorf("expected the first event after at least 20ms, got %.2f", result.TimeToFirstEventMs)
+	}
+	if result.AvgIntervalMs < 15 || result.AvgIntervalMs > 500 {
+		t.Errorf("expected an interval near 20ms, got %.2f", result.AvgIntervalMs)
+	}
+}
+
+func TestMeasureSSE_StreamEndsEarly(t *testing.T) {
+	server := newSSEServer(t, 2, time.Millisecond)
+	c := client.New(server.URL, 5*time.Second)
+
+	result := MeasureSSE(context.Background(), c, "/api/events", 5)
+	if result.EventsReceived != 2 || !strings.Contains(result.Error, "received 2 of 5 events") {
+		t.Errorf("expected a shortfall error after 2 events, got %+v", result)
+	}
+}
+
+func TestMeasureSSE_NotAnEventStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status": "ok"}`)
+	}))
+	defer server.Close()
+	c := client.New(server.URL, 5*time.Second)
+
+	result := MeasureSSE(context.Background(), c, "/api/events", 1)
+	if result.Connected || !strings.Contains(result.Error, "unexpected Content-Type") {
+		t.Errorf("expected a Content-Type error, got %+v", result)
+	}
+}
+
+func TestReadSSEEvents(t *testing.T) {
+	stream := ": comment\n\nevent: ping\n\ndata: one\ndata: still one\n\ndata\n\nid: 7\ndata: three\n\ndata: unterminated"
+	events := 0
+	if err := readSSEEvents(strings.NewReader(stream), func() bool { events++; return true }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The comment, the data-less event, and the unterminated event are not dispatched
+	if events != 3 {
+		t.Errorf("expected 3 events, got %d", events)
+	}
+}
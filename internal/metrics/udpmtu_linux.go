@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"time"
+)
+
+// probeUDPMTU binary-searches for the largest UDP datagram the kernel will send
+// to ip with the don't-fragment bit set. Sends larger than the path MTU known
+// for the route fail with EMSGSIZE, so no replies or raw sockets are needed.
+func probeUDPMTU(ip net.IP, timeout time.Duration) (int, error) {
+	network, level, opt, value := "udp4", syscall.IPPROTO_IP, syscall.IP_MTU_DISCOVER, syscall.IP_PMTUDISC_DO
+	headers, lo := ipv4HeaderLen+udpHeaderLen, minIPv4MTU
+	if ip.To4() == nil {
+		network, level, opt, value = "udp6", syscall.IPPROTO_IPV6, syscall.IPV6_MTU_DISCOVER, syscall.IPV6_PMTUDISC_DO
+		headers, lo = ipv6HeaderLen+udpHeaderLen, minIPv6MTU
+	}
+
+	// An unconnected socket is used so ICMP port unreachable replies to earlier
+	// probes are not reported as errors on later sends
+	var sockErr error
+	lc := net.ListenConfig{
+		Control: func(_, _ string, c syscall.RawConn) error {
+			if err := c.Control(func(fd uintptr) {
+				sockErr = syscall.SetsockoptInt(int(fd), level, opt, value)
+			}); err != nil {
+				return err
+			}
+			return sockErr
+		},
+	}
+	conn, err := lc.ListenPacket(context.Background(), network, ":0")
+	if err != nil {
+		return 0, fmt.Errorf("open udp socket: %w", err)
+	}
+	defer conn.Close()
+	conn.SetWriteDeadline(time.Now().Add(timeout))
+
+	// lo always fits and hi never does; halve the gap until they meet
+	dst := &net.UDPAddr{IP: ip, Port: traceBasePort}
+	hi := maxProbeMTU + 1
+	buf := make([]byte, maxProbeMTU)
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		_, err := conn.WriteTo(buf[:mid-headers], dst)
+		switch {
+		case err == nil:
+			lo = mid
+		case errors.Is(err, syscall.EMSGSIZE):
+			hi = mid
+		default:
+			return 0, fmt.Errorf("send probe: %w", err)
+		}
+	}
+	return lo, nil
+}
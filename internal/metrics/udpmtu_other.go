@@ -0,0 +1,15 @@
+//go:build !linux
+
+package metrics
+
+import (
+	"errors"
+	"net"
+	"time"
+)
+
+// probeUDPMTU needs Linux's IP_MTU_DISCOVER socket option to set the
+// don't-fragment bit
+func probeUDPMTU(ip net.IP, timeout time.Duration) (int, error) {
+	return 0, errors.New("UDP MTU probe is only supported on Linux")
+}
@@ -0,0 +1,187 @@
+package reporter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// AggregateReport summarizes metrics across all runs in a comparison
+type AggregateReport struct {
+	Runs int
+
+	// Load test statistics (over runs that include a load test)
+	LoadTestRuns   int
+	MeanRPS        float64
+	StdDevRPS      float64
+	MeanP95Ms      float64
+	StdDevP95Ms    float64
+	SuccessRatePct float64 // Successful requests over total requests across all load tests
+
+	// Health check statistics (over runs that include a health check)
+	HealthRuns     int
+	MeanHealthMs   float64
+	StdDevHealthMs float64
+
+	Endpoints []EndpointAggregate
+}
+
+// EndpointAggregate summarizes a single API endpoint across runs
+type EndpointAggregate struct {
+	Path           string
+	Samples        int
+	MeanMs         float64
+	StdDevMs       float64
+	SuccessRatePct float64
+}
+
+// Aggregate computes mean and standard deviation statistics across results
+func Aggregate(results []*internal.BenchmarkResult) *AggregateReport {
+	report := &AggregateReport{Runs: len(results)}
+
+	var rps, p95, health []float64
+	var totalRequests, successful int
+	for _, r := range results {
+		if r.LoadTest != nil {
+			rps = append(rps, r.LoadTest.RPS)
+			p95 = append(p95, r.LoadTest.LatencyP95Ms)
+			totalRequests += r.LoadTest.TotalRequests
+			successful += r.LoadTest.Successful
+		}
+		if r.Health != nil {
+			health = append(health, r.Health.ResponseMs)
+		}
+	}
+
+	report.LoadTestRuns = len(rps)
+	report.MeanRPS, report.StdDevRPS = meanStdDev(rps)
+	report.MeanP95Ms, report.StdDevP95Ms = meanStdDev(p95)
+	if totalRequests > 0 {
+		report.SuccessRatePct = float64(successful) / float64(totalRequests) * 100
+	}
+
+	report.HealthRuns = len(health)
+	report.MeanHealthMs, report.StdDevHealthMs = meanStdDev(health)
+
+	for _, path := range collectEndpointPaths(results) {
+		var times []float64
+		succeeded := 0
+		for _, r := range results {
+			for _, ep := range r.Endpoints {
+				if ep.Path != path {
+					continue
+				}
+				times = append(times, ep.ResponseMs)
+				if ep.Success {
+					succeeded++
+				}
+			}
+		}
+
+		agg := EndpointAggregate{Path: path, Samples: len(times)}
+		agg.MeanMs, agg.StdDevMs = meanStdDev(times)
+		if len(times) > 0 {
+			agg.SuccessRatePct = float64(succeeded) / float64(len(times)) * 100
+		}
+		report.Endpoints = append(report.Endpoints, agg)
+	}
+
+	return report
+}
+
+// meanStdDev returns the mean and sample standard deviation of values.
+// The standard deviation is zero when fewer than two values are given.
+func meanStdDev(values []float64) (mean, stddev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean = sum / float64(len(values))
+
+	if len(values) < 2 {
+		return mean, 0
+	}
+
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(values)-1))
+}
+
+// describeVariability interprets the coefficient of variation of a metric
+func describeVariability(mean, stddev float64) string {
+	if mean == 0 {
+		return "no variation"
+	}
+	cv := stddev / mean * 100
+	switch {
+	case cv < 10:
+		return "consistent across runs"
+	case cv < 25:
+		return "moderately variable across runs"
+	default:
+		return "highly variable across runs"
+	}
+}
+
+// writeAggregateSection writes the statistical summary of all runs
+func writeAggregateSection(sb *strings.Builder, agg *AggregateReport) {
+	sb.WriteString("## Statistical Summary\n\n")
+	sb.WriteString(fmt.Sprintf("Statistics across all %d runs. Mean ± standard deviation shows the typical value and how much it varies from run to run. A standard deviation that is large relative to the mean suggests noisy measurements or unstable server performance.\n\n", agg.Runs))
+
+	if agg.LoadTestRuns == 0 && agg.HealthRuns == 0 && len(agg.Endpoints) == 0 {
+		sb.WriteString("*No aggregatable metrics found in these runs*\n\n")
+		return
+	}
+
+	hasRunMetrics := agg.LoadTestRuns > 0 || agg.HealthRuns > 0
+	if hasRunMetrics {
+		sb.WriteString("| Metric | Mean | Std Dev | Runs |\n")
+		sb.WriteString("|--------|------|---------|------|\n")
+		if agg.HealthRuns > 0 {
+			sb.WriteString(fmt.Sprintf("| Health Response | %.2f ms | %.2f ms | %d |\n", agg.MeanHealthMs, agg.StdDevHealthMs, agg.HealthRuns))
+		}
+		if agg.LoadTestRuns > 0 {
+			sb.WriteString(fmt.Sprintf("| Requests/sec | %.2f | %.2f | %d |\n", agg.MeanRPS, agg.StdDevRPS, agg.LoadTestRuns))
+			sb.WriteString(fmt.Sprintf("| p95 Latency | %.2f ms | %.2f ms | %d |\n", agg.MeanP95Ms, agg.StdDevP95Ms, agg.LoadTestRuns))
+			sb.WriteString(fmt.Sprintf("| Success Rate | %.2f%% | - | %d |\n", agg.SuccessRatePct, agg.LoadTestRuns))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(agg.Endpoints) > 0 {
+		sb.WriteString("### Endpoint Statistics\n\n")
+		sb.WriteString("| Endpoint | Mean | Std Dev | Success Rate | Samples |\n")
+		sb.WriteString("|----------|------|---------|--------------|--------|\n")
+		for _, ep := range agg.Endpoints {
+			sb.WriteString(fmt.Sprintf("| %s | %.2f ms | %.2f ms | %.1f%% | %d |\n",
+				ep.Path, ep.MeanMs, ep.StdDevMs, ep.SuccessRatePct, ep.Samples))
+		}
+		sb.WriteString("\n")
+	}
+
+	if !hasRunMetrics {
+		return
+	}
+
+	sb.WriteString("### Interpretation\n\n")
+	if agg.LoadTestRuns > 0 {
+		sb.WriteString(fmt.Sprintf("- Throughput is %.2f ± %.2f requests/sec, %s\n",
+			agg.MeanRPS, agg.StdDevRPS, describeVariability(agg.MeanRPS, agg.StdDevRPS)))
+		sb.WriteString(fmt.Sprintf("- 95th percentile latency is %.2f ± %.2f ms, %s\n",
+			agg.MeanP95Ms, agg.StdDevP95Ms, describeVariability(agg.MeanP95Ms, agg.StdDevP95Ms)))
+		sb.WriteString(fmt.Sprintf("- %.2f%% of all load test requests succeeded\n", agg.SuccessRatePct))
+	}
+	if agg.HealthRuns > 0 {
+		sb.WriteString(fmt.Sprintf("- Health check response is %.2f ± %.2f ms, %s\n",
+			agg.MeanHealthMs, agg.StdDevHealthMs, describeVariability(agg.MeanHealthMs, agg.StdDevHealthMs)))
+	}
+	sb.WriteString("\n")
+}
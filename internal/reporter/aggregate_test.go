@@ -0,0 +1,139 @@
+package reporter
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func aggregateFixture() []*internal.BenchmarkResult {
+	return []*internal.BenchmarkResult{
+		{
+			Timestamp: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			Overall:   "pass",
+			Health:    &internal.HealthResult{Status: "healthy", ResponseMs: 10},
+			Endpoints: []internal.EndpointResult{{Path: "/api/workouts", ResponseMs: 20, Success: true}},
+			LoadTest:  &internal.LoadTestResult{TotalRequests: 100, Successful: 100, RPS: 40, LatencyP95Ms: 100},
+		},
+		{
+			Timestamp: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC),
+			Overall:   "pass",
+			Health:    &internal.HealthResult{Status: "healthy", ResponseMs: 20},
+			Endpoints: []internal.EndpointResult{{Path: "/api/workouts", ResponseMs: 40, Success: false}},
+			LoadTest:  &internal.LoadTestResult{TotalRequests: 100, Successful: 90, RPS: 50, LatencyP95Ms: 120},
+		},
+		{
+			Timestamp: time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC),
+			Overall:   "pass",
+			Health:    &internal.HealthResult{Status: "healthy", ResponseMs: 30},
+			LoadTest:  &internal.LoadTestResult{TotalRequests: 200, Successful: 200, RPS: 60, LatencyP95Ms: 140},
+		},
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	agg := Aggregate(aggregateFixture())
+
+	if agg.Runs != 3 || agg.LoadTestRuns != 3 || agg.HealthRuns != 3 {
+		t.Errorf("expected 3 runs for all metrics, got runs=%d load=%d health=%d", agg.Runs, agg.LoadTestRuns, agg.HealthRuns)
+	}
+	if agg.MeanRPS != 50 {
+		t.Errorf("expected mean RPS 50, got %.2f", agg.MeanRPS)
+	}
+	if math.Abs(agg.StdDevRPS-10) > 1e-9 {
+		t.Errorf("expected RPS stddev 10, got %.4f", agg.StdDevRPS)
+	}
+	if agg.MeanP95Ms != 120 || math.Abs(agg.StdDevP95Ms-20) > 1e-9 {
+		t.Errorf("expected p95 120 ± 20, got %.2f ± %.2f", agg.MeanP95Ms, agg.StdDevP95Ms)
+	}
+	if agg.MeanHealthMs != 20 || math.Abs(agg.StdDevHealthMs-10) > 1e-9 {
+		t.Errorf("expected health 20 ± 10, got %.2f ± %.2f", agg.MeanHealthMs, agg.StdDevHealthMs)
+	}
+	// 390 of 400 requests succeeded
+	if math.Abs(agg.SuccessRatePct-97.5) > 1e-9 {
+		t.Errorf("expected success rate 97.5%%, got %.2f", agg.SuccessRatePct)
+	}
+
+	if len(agg.Endpoints) != 1 {
+		t.Fatalf("expected 1 endpoint aggregate, got %d", len(agg.Endpoints))
+	}
+	ep := agg.Endpoints[0]
+	if ep.Samples != 2 || ep.MeanMs != 30 || ep.SuccessRatePct != 50 {
+		t.Errorf("unexpected endpoint aggregate: %+v", ep)
+	}
+}
+
+func TestMeanStdDev(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []float64
+		wantMean   float64
+		wantStdDev float64
+	}{
+		{"empty", nil, 0, 0},
+		{"single", []float64{5}, 5, 0},
+		{"constant", []float64{3, 3, 3}, 3, 0},
+		{"spread", []float64{2, 4, 4, 4, 5, 5, 7, 9}, 5, 2.138089935299395},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mean, stddev := meanStdDev(tt.values)
+			if mean != tt.wantMean {
+				t.Errorf("expected mean %.4f, got %.4f", tt.wantMean, mean)
+			}
+			if math.Abs(stddev-tt.wantStdDev) > 1e-9 {
+				t.Errorf("expected stddev %.4f, got %.4f", tt.wantStdDev, stddev)
+			}
+		})
+	}
+}
+
+func TestDescribeVariability(t *testing.T) {
+	tests := []struct {
+		mean, stddev float64
+		expected     string
+	}{
+		{100, 5, "consistent across runs"},
+		{100, 15, "moderately variable across runs"},
+		{100, 40, "highly variable across runs"},
+		{0, 0, "no variation"},
+	}
+
+	for _, tt := range tests {
+		if got := describeVariability(tt.mean, tt.stddev); got != tt.expected {
+			t.Errorf("describeVariability(%.0f, %.0f) = %q, want %q", tt.mean, tt.stddev, got, tt.expected)
+		}
+	}
+}
+
+func TestReport_Aggregate(t *testing.T) {
+	c := NewComparison(t.TempDir())
+	c.SetAggregate(true)
+	content := renderComparison(t, c, aggregateFixture())
+
+	if !strings.Contains(content, "## Statistical Summary") {
+		t.Error("expected Statistical Summary section")
+	}
+	if !strings.Contains(content, "| Requests/sec | 50.00 | 10.00 | 3 |") {
+		t.Error("expected RPS aggregate row")
+	}
+	if !strings.Contains(content, "95th percentile latency is 120.00 ± 20.00 ms") {
+		t.Error("expected p95 interpretation")
+	}
+	if !strings.Contains(content, "| /api/workouts | 30.00 ms |") {
+		t.Error("expected endpoint aggregate row")
+	}
+}
+
+func TestReport_AggregateDisabled(t *testing.T) {
+	c := NewComparison(t.TempDir())
+	content := renderComparison(t, c, aggregateFixture())
+
+	if strings.Contains(content, "## Statistical Summary") {
+		t.Error("expected no Statistical Summary section by default")
+	}
+}
@@ -0,0 +1,274 @@
+package reporter
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// AnonymizedTarget replaces the target URL of an anonymized result
+const AnonymizedTarget = "https://[REDACTED]"
+
+// Replacements for redacted text and IP addresses
+const (
+	redacted   = "[REDACTED]"
+	redactedIP = "[REDACTED-IP]"
+)
+
+// urlAuthority matches the scheme and host of URLs in text, such as those in
+// url.Error messages, which may name hosts other than the target
+var urlAuthority = regexp.MustCompile(`\b([A-Za-z][A-Za-z0-9+.-]*://)[^\s/"'<>]+`)
+
+// pseudonymKey keys the hashes that name paths. It is chosen at random when
+// the program starts and never saved, so a path gets the same pseudonym from
+// every Anonymizer of one invocation, but guessing a path and hashing it
+// reveals nothing.
+var pseudonymKey = func() []byte {
+	key := make([]byte, sha256.Size)
+	rand.Read(key)
+	return key
+}()
+
+// ipCandidate matches text that may be an IPv4 or IPv6 address; matches are
+// confirmed with net.ParseIP so times such as 14:30:00 are left alone
+var ipCandidate = regexp.MustCompile(`(?:\d{1,3}\.){3}\d{1,3}|[0-9A-Fa-f]{0,4}(?::[0-9A-Fa-f]{0,4}){2,7}(?:%[0-9A-Za-z]+)?`)
+
+// Anonymizer removes what identifies the target server from results before
+// they are shared: its URL, hostname, and IP addresses, and the names of the
+// endpoints and assets benchmarked, which are replaced with pseudonyms such as
+// /api/endpoint_3f9a1c2e. A pseudonym is a keyed hash of the path, so a path
+// has the same pseudonym throughout the run, but the mapping is not kept.
+type Anonymizer struct {
+	target  string
+	secrets []string          // Literal strings replaced with [REDACTED], longest first
+	paths   map[string]string // Endpoint and asset paths seen to their pseudonyms
+}
+
+// NewAnonymizer returns an Anonymizer for results from target. Any non-empty
+// secrets, such as the login username, are redacted wherever they appear.
+func NewAnonymizer(target string, secrets ...string) *Anonymizer {
+	a := &Anonymizer{target: strings.TrimSuffix(target, "/"), paths: make(map[string]string)}
+	if u, err := url.Parse(target); err == nil && u.Host != "" && target != AnonymizedTarget {
+		secrets = append(secrets, u.Host, u.Hostname())
+	}
+	for _, s := range secrets {
+		if s != "" {
+			a.secrets = append(a.secrets, s)
+		}
+	}
+	sort.Slice(a.secrets, func(i, j int) bool { return len(a.secrets[i]) > len(a.secrets[j]) })
+	return a
+}
+
+// Path returns the pseudonym of an endpoint path, such as /api/endpoint_3f9a1c2e
+func (a *Anonymizer) Path(p string) string {
+	if p == "" {
+		return ""
+	}
+	return a.pseudonym(p, "/api/endpoint_", "")
+}
+
+// Asset returns the pseudonym of a frontend asset path or URL, such as
+// /assets/asset_3f9a1c2e.js, keeping its extension. The root path is kept.
+func (a *Anonymizer) Asset(p string) string {
+	if p == "" || p == "/" {
+		return p
+	}
+	ext := path.Ext(p)
+	if u, err := url.Parse(p); err == nil {
+		ext = path.Ext(u.Path)
+	}
+	return a.pseudonym(p, "/assets/asset_", ext)
+}
+
+// pseudonym names p by its keyed hash and remembers it for Text
+func (a *Anonymizer) pseudonym(p, prefix, suffix string) string {
+	if pseudonym, ok := a.paths[p]; ok {
+		return pseudonym
+	}
+	mac := hmac.New(sha256.New, pseudonymKey)
+	mac.Write([]byte(p))
+	pseudonym := prefix + hex.EncodeToString(mac.Sum(nil)[:4]) + suffix
+	a.paths[p] = pseudonym
+	return pseudonym
+}
+
+// Text redacts the target, the host of any URL, known endpoint and asset
+// paths, secrets, and IP addresses in free text such as error messages and
+// commands
+func (a *Anonymizer) Text(s string) string {
+	if s == "" {
+		return s
+	}
+	if a.target != "" {
+		s = strings.ReplaceAll(s, a.target, AnonymizedTarget)
+	}
+	s = urlAuthority.ReplaceAllString(s, "${1}"+redacted)
+
+	// Longer paths first, so /api/workouts/1 is not caught by /api/workouts
+	paths := make([]string, 0, len(a.paths))
+	for path := range a.paths {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool { return len(paths[i]) > len(paths[j]) })
+	for _, path := range paths {
+		s = strings.ReplaceAll(s, path, a.paths[path])
+	}
+
+	for _, secret := range a.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	s = ipCandidate.ReplaceAllStringFunc(s, func(match string) string {
+		if net.ParseIP(strings.SplitN(match, "%", 2)[0]) == nil {
+			return match
+		}
+		return redactedIP
+	})
+	return s
+}
+
+// Result anonymizes r in place and marks it Anonymized. A result that is
+// already anonymized is left as it is.
+func (a *Anonymizer) Result(r *internal.BenchmarkResult) {
+	if r.Anonymized {
+		return
+	}
+
+	// Name every path before scrubbing text, so messages mentioning one match
+	for i := range r.Endpoints {
+		r.Endpoints[i].Path = a.Path(r.Endpoints[i].Path)
+	}
+	if r.LoadTest != nil {
+		r.LoadTest.StressedEndpoint = a.Path(r.LoadTest.StressedEndpoint)
+		if dist := r.LoadTest.EndpointDistribution; len(dist) > 0 {
+			// Name paths in sorted order so pseudonyms do not depend on map order
+			paths := make([]string, 0, len(dist))
+			for path := range dist {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			r.LoadTest.EndpointDistribution = make(map[string]int, len(dist))
+			for _, path := range paths {
+				r.LoadTest.EndpointDistribution[a.Path(path)] = dist[path]
+			}
+		}
+	}
+	if r.SSE != nil {
+		r.SSE.Path = a.Path(r.SSE.Path)
+	}
+	if r.WebSocketLoadTest != nil {
+		r.WebSocketLoadTest.Path = a.Path(r.WebSocketLoadTest.Path)
+	}
+	if f := r.Frontend; f != nil {
+		if f.IndexHTML != nil {
+			f.IndexHTML.Path = a.Asset(f.IndexHTML.Path)
+		}
+		for i := range f.Assets {
+			f.Assets[i].Path = a.Asset(f.Assets[i].Path)
+		}
+		for i, name := range f.AssetFilenames {
+			f.AssetFilenames[i] = a.Asset(name)
+		}
+		sort.Strings(f.AssetFilenames)
+	}
+
+	r.Target = AnonymizedTarget
+	r.Error = a.Text(r.Error)
+	r.ShutdownReason = a.Text(r.ShutdownReason)
+	if c := r.Connectivity; c != nil {
+		c.Error = a.Text(c.Error)
+		c.HTTP3Error = a.Text(c.HTTP3Error)
+		c.DNSResolver = a.Text(c.DNSResolver)
+		c.DOHServer = a.Text(c.DOHServer)
+		if c.BoundTo != "" {
+			c.BoundTo = redactedIP
+		}
+		if c.UnixSocket != "" {
+			c.UnixSocket = redacted
+		}
+	}
+	for _, h := range []*internal.HealthResult{r.Health, r.GRPCHealth} {
+		if h != nil {
+			h.Error = a.Text(h.Error)
+		}
+	}
+	for i := range r.Endpoints {
+		r.Endpoints[i].Error = a.Text(r.Endpoints[i].Error)
+		r.Endpoints[i].CurlCommand = a.Text(r.Endpoints[i].CurlCommand)
+	}
+	if f := r.Frontend; f != nil {
+		if f.IndexHTML != nil {
+			f.IndexHTML.Error = a.Text(f.IndexHTML.Error)
+		}
+		for i := range f.Assets {
+			f.Assets[i].Error = a.Text(f.Assets[i].Error)
+		}
+	}
+	if r.LoadTest != nil {
+		r.LoadTest.AbortReason = a.Text(r.LoadTest.AbortReason)
+	}
+	if r.BenchmarkAPI != nil {
+		r.BenchmarkAPI.Error = a.Text(r.BenchmarkAPI.Error)
+	}
+	for i := range r.BenchmarkAPISweep {
+		r.BenchmarkAPISweep[i].Error = a.Text(r.BenchmarkAPISweep[i].Error)
+	}
+	if r.SSE != nil {
+		r.SSE.Error = a.Text(r.SSE.Error)
+	}
+	if r.WebSocketLoadTest != nil {
+		r.WebSocketLoadTest.Error = a.Text(r.WebSocketLoadTest.Error)
+	}
+	for i := range r.ThresholdBreaches {
+		r.ThresholdBreaches[i].Metric = a.Text(r.ThresholdBreaches[i].Metric)
+	}
+	r.Anonymized = true
+}
+
+// Anonymize anonymizes r, a result of a run with cfg, in place and returns a
+// copy of cfg to report it with. The copy has the target, login user, and the
+// addresses and paths given in flags redacted, and these are redacted in r as
+// well wherever they appear.
+func Anonymize(r *internal.BenchmarkResult, cfg *internal.Config) *internal.Config {
+	secrets := []string{cfg.User, cfg.UnixSocket, cfg.GRPCAddr}
+	if host, _, err := net.SplitHostPort(cfg.GRPCAddr); err == nil {
+		secrets = append(secrets, host)
+	}
+	target := r.Target
+	if r.Anonymized {
+		target = cfg.URL
+	}
+	a := NewAnonymizer(target, secrets...)
+	a.Result(r)
+
+	anonymized := *cfg
+	// Name the configured paths first, so the command line has them replaced
+	anonymized.StressEndpoint = a.Path(cfg.StressEndpoint)
+	anonymized.SSEPath = a.Path(cfg.SSEPath)
+	anonymized.WSPath = a.Path(cfg.WSPath)
+	for _, endpoints := range [][]internal.WeightedEndpoint{cfg.LoadEndpoints, cfg.LoadTestEndpoints} {
+		for _, ep := range endpoints {
+			a.Path(ep.Path)
+		}
+	}
+	anonymized.URL = AnonymizedTarget
+	anonymized.User = a.Text(cfg.User)
+	anonymized.CommandLine = a.Text(cfg.CommandLine)
+	anonymized.GRPCAddr = a.Text(cfg.GRPCAddr)
+	anonymized.UnixSocket = a.Text(cfg.UnixSocket)
+	anonymized.DNSResolver = a.Text(cfg.DNSResolver)
+	anonymized.DoHURL = a.Text(cfg.DoHURL)
+	if cfg.BindAddr != "" {
+		anonymized.BindAddr = redactedIP
+	}
+	return &anonymized
+}
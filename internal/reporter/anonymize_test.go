@@ -0,0 +1,179 @@
+package reporter
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestAnonymizer_Text(t *testing.T) {
+	a := NewAnonymizer("https://actalog.example.com:8443/", "alice")
+	workouts := a.Path("/api/workouts")
+	workout := a.Path("/api/workouts/1")
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"target", "GET https://actalog.example.com:8443/api/version", "GET https://[REDACTED]/api/version"},
+		{"hostname", "lookup actalog.example.com: no such host", "lookup [REDACTED]: no such host"},
+		{"other host", `Get "https://login.example.org/sso": EOF`, `Get "https://[REDACTED]/sso": EOF`},
+		{"secret", "login failed for alice", "login failed for [REDACTED]"},
+		{"IPv4", "dial tcp 203.0.113.7:443: connection refused", "dial tcp [REDACTED-IP]:443: connection refused"},
+		{"IPv6", "dial tcp [2001:db8::1]:443: timeout", "dial tcp [[REDACTED-IP]]:443: timeout"},
+		{"time kept", "retried at 14:30:00", "retried at 14:30:00"},
+		{"longest path first", "/api/workouts/1 and /api/workouts", workout + " and " + workouts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.Text(tt.in); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAnonymizer_Path(t *testing.T) {
+	a := NewAnonymizer("https://actalog.example.com")
+	p := a.Path("/api/workouts")
+	if !regexp.MustCompile(`^/api/endpoint_[0-9a-f]{8}$`).MatchString(p) {
+		t.Errorf("expected a hashed pseudonym, got %q", p)
+	}
+	if strings.Contains(p, "workouts") {
+		t.Errorf("expected the path to be hidden, got %q", p)
+	}
+	// Every Anonymizer of the run names a path alike, whatever order it sees paths in
+	b := NewAnonymizer("https://other.example.com")
+	b.Path("/api/movements")
+	if got := b.Path("/api/workouts"); got != p {
+		t.Errorf("expected the same pseudonym %q, got %q", p, got)
+	}
+	if a.Path("/api/movements") == p {
+		t.Error("expected different paths to get different pseudonyms")
+	}
+
+	asset := a.Asset("/assets/index-3f2a9c.js?v=2")
+	if !regexp.MustCompile(`^/assets/asset_[0-9a-f]{8}\.js$`).MatchString(asset) {
+		t.Errorf("expected a hashed asset name keeping its extension, got %q", asset)
+	}
+	if got := a.Asset("/"); got != "/" {
+		t.Errorf("expected the root path kept, got %q", got)
+	}
+}
+
+func TestAnonymizer_Result(t *testing.T) {
+	result := &internal.BenchmarkResult{
+		Target: "https://actalog.example.com",
+		Connectivity: &internal.ConnectivityResult{
+			DNSResolver: "192.0.2.53:53",
+			BoundTo:     "10.0.0.5",
+			UnixSocket:  "/run/actalog/app.sock",
+		},
+		Health: &internal.HealthResult{Error: `Get "https://actalog.example.com/health": dial tcp 203.0.113.7:443: i/o timeout`},
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/workouts", Success: true},
+			{
+				Path:        "/api/users/alice",
+				Error:       "status 500 from https://actalog.example.com/api/users/alice",
+				CurlCommand: "curl -H 'X-User: alice' https://actalog.example.com/api/users/alice",
+			},
+		},
+		Frontend: &internal.FrontendResult{
+			IndexHTML:      &internal.AssetResult{Path: "/"},
+			Assets:         []internal.AssetResult{{Path: "/assets/actalog-app.js", Error: "fetch https://cdn.example.net/assets/actalog-app.js: 404"}},
+			AssetFilenames: []string{"/assets/actalog-app.js"},
+		},
+		LoadTest: &internal.LoadTestResult{
+			StressedEndpoint:     "/api/workouts",
+			EndpointDistribution: map[string]int{"/api/workouts": 3},
+		},
+	}
+
+	a := NewAnonymizer(result.Target, "alice")
+	workouts := a.Path("/api/workouts")
+	a.Result(result)
+
+	if result.Target != AnonymizedTarget || !result.Anonymized {
+		t.Errorf("expected an anonymized target, got %q (anonymized %v)", result.Target, result.Anonymized)
+	}
+	if c := result.Connectivity; c.DNSResolver != "[REDACTED-IP]:53" || c.BoundTo != redactedIP || c.UnixSocket != redacted {
+		t.Errorf("expected resolver, bind address, and socket masked, got %q, %q, and %q", c.DNSResolver, c.BoundTo, c.UnixSocket)
+	}
+	if e := result.Health.Error; strings.Contains(e, "actalog.example.com") || strings.Contains(e, "203.0.113.7") {
+		t.Errorf("expected the health error scrubbed, got %q", e)
+	}
+	if p := result.Endpoints[0].Path; p != workouts {
+		t.Errorf("expected %s, got %q", workouts, p)
+	}
+	if p := result.LoadTest.StressedEndpoint; p != workouts {
+		t.Errorf("expected the stressed endpoint to share its pseudonym, got %q", p)
+	}
+	if dist := result.LoadTest.EndpointDistribution; dist[workouts] != 3 || len(dist) != 1 {
+		t.Errorf("expected the distribution keyed by pseudonym, got %v", dist)
+	}
+
+	ep := result.Endpoints[1]
+	if strings.Contains(ep.Path, "alice") {
+		t.Errorf("expected a pseudonym, got %q", ep.Path)
+	}
+	for _, s := range []string{ep.Error, ep.CurlCommand} {
+		if strings.Contains(s, "actalog.example.com") || strings.Contains(s, "alice") {
+			t.Errorf("expected no identifying details, got %q", s)
+		}
+	}
+
+	f := result.Frontend
+	asset := f.Assets[0]
+	if strings.Contains(asset.Path, "actalog") || strings.Contains(asset.Error, "actalog") || strings.Contains(asset.Error, "cdn.example.net") {
+		t.Errorf("expected the asset path and error scrubbed, got %q and %q", asset.Path, asset.Error)
+	}
+	if len(f.AssetFilenames) != 1 || f.AssetFilenames[0] != asset.Path || f.IndexHTML.Path != "/" {
+		t.Errorf("expected the asset inventory renamed alike, got %v (index %q)", f.AssetFilenames, f.IndexHTML.Path)
+	}
+
+	// Anonymizing again changes nothing
+	NewAnonymizer(result.Target).Result(result)
+	if result.Connectivity.DNSResolver != "[REDACTED-IP]:53" || result.Endpoints[1].Error != ep.Error {
+		t.Error("expected an anonymized result to be left as it is")
+	}
+}
+
+func TestAnonymize_Config(t *testing.T) {
+	result := &internal.BenchmarkResult{
+		Target:    "https://actalog.example.com",
+		Endpoints: []internal.EndpointResult{{Path: "/api/workouts"}},
+	}
+	cfg := &internal.Config{
+		URL:            "https://actalog.example.com",
+		User:           "alice",
+		GRPCAddr:       "grpc.example.com:9090",
+		UnixSocket:     "/run/actalog/app.sock",
+		StressEndpoint: "/api/workouts",
+		CommandLine:    "actalog-bench --url https://actalog.example.com --user alice --grpc-addr grpc.example.com:9090 --stress-endpoint /api/workouts",
+	}
+
+	anonymized := Anonymize(result, cfg)
+
+	if anonymized.URL != AnonymizedTarget || anonymized.StressEndpoint != result.Endpoints[0].Path {
+		t.Errorf("expected the target and stress endpoint replaced, got %q and %q", anonymized.URL, anonymized.StressEndpoint)
+	}
+	for _, s := range []string{anonymized.User, anonymized.GRPCAddr, anonymized.UnixSocket, anonymized.CommandLine} {
+		for _, secret := range []string{"alice", "actalog.example.com", "grpc.example.com", "app.sock", "/api/workouts"} {
+			if strings.Contains(s, secret) {
+				t.Errorf("expected %q redacted, got %q", secret, s)
+			}
+		}
+	}
+	if cfg.URL != "https://actalog.example.com" {
+		t.Error("expected the original config to be left as it is")
+	}
+
+	// The config of an already anonymized result is still redacted
+	if again := Anonymize(result, cfg); again.CommandLine != anonymized.CommandLine {
+		t.Errorf("expected the same command line, got %q and %q", anonymized.CommandLine, again.CommandLine)
+	}
+}
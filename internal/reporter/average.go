@@ -0,0 +1,401 @@
+package reporter
+
+import (
+	"math"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// ci95ZScore is the normal distribution z-score for a 95% confidence interval
+const ci95ZScore = 1.96
+
+// AverageResults combines repeated runs against the same target into one result.
+// Connectivity, health, endpoint, frontend, load test, server-side benchmark, and
+// phase timings are the mean over the runs that reported them. The p95 latency gets a
+// 95% confidence interval half-width (1.96 × stddev / √N). Other fields are taken
+// from the earliest run, and Overall is the worst outcome of any run.
+func AverageResults(results []*internal.BenchmarkResult) *internal.BenchmarkResult {
+	if len(results) == 0 {
+		return nil
+	}
+
+	first := results[0]
+	for _, r := range results[1:] {
+		if r.Timestamp.Before(first.Timestamp) {
+			first = r
+		}
+	}
+
+	avg := *first
+	avg.SampleCount = len(results)
+	avg.Connectivity = averageConnectivity(results)
+	avg.Health = averageHealth(results)
+	avg.Endpoints = averageEndpoints(results)
+	avg.Frontend = averageFrontend(results)
+	avg.LoadTest = averageLoadTests(results)
+	avg.BenchmarkAPI = averageBenchmarkAPI(results)
+	avg.PhaseDurations = averagePhaseDurations(results)
+
+	for _, r := range results {
+		if overallRank[r.Overall] > overallRank[avg.Overall] {
+			avg.Overall = r.Overall
+		}
+		if avg.Error == "" && r.Error != "" {
+			avg.Error = r.Error
+		}
+	}
+
+	return &avg
+}
+
+// meanOf returns the arithmetic mean of values, or zero when there are none
+func meanOf(values []float64) float64 {
+	m, _ := meanStdDev(values)
+	return m
+}
+
+// averagePhaseDurations averages each phase's duration over the runs that ran it
+func averagePhaseDurations(results []*internal.BenchmarkResult) map[string]float64 {
+	durations := make(map[string][]float64)
+	for _, r := range results {
+		for phase, ms := range r.PhaseDurations {
+			durations[phase] = append(durations[phase], ms)
+		}
+	}
+	if len(durations) == 0 {
+		return nil
+	}
+
+	avg := make(map[string]float64, len(durations))
+	for phase, values := range durations {
+		avg[phase] = meanOf(values)
+	}
+	return avg
+}
+
+// averageConnectivity averages the connection timings of all runs that measured them
+func averageConnectivity(results []*internal.BenchmarkResult) *internal.ConnectivityResult {
+	var avg *internal.ConnectivityResult
+	var dns, tcp, tls, fullTLS, resumedTLS, total, ipv4, ipv6, http3, reuse []float64
+
+	for _, r := range results {
+		cr := r.Connectivity
+		if cr == nil {
+			continue
+		}
+		if avg == nil {
+			copied := *cr
+			avg = &copied
+		}
+		avg.Connected = avg.Connected && cr.Connected
+		if avg.Error == "" {
+			avg.Error = cr.Error
+		}
+
+		dns = append(dns, cr.DNSMs)
+		tcp = append(tcp, cr.TCPMs)
+		total = append(total, cr.TotalMs)
+		// Optional timings are zero when not measured and must not drag the mean down
+		if cr.TLSMs > 0 {
+			tls = append(tls, cr.TLSMs)
+		}
+		if cr.TLSFullHandshakeMs > 0 {
+			fullTLS = append(fullTLS, cr.TLSFullHandshakeMs)
+		}
+		avg.TLSResumed = avg.TLSResumed || cr.TLSResumed
+		if cr.TLSResumedMs > 0 {
+			resumedTLS = append(resumedTLS, cr.TLSResumedMs)
+		}
+		if cr.IPv4Ms > 0 {
+			ipv4 = append(ipv4, cr.IPv4Ms)
+		}
+		if cr.IPv6Ms > 0 {
+			ipv6 = append(ipv6, cr.IPv6Ms)
+		}
+		if cr.HTTP3Supported {
+			http3 = append(http3, cr.HTTP3Ms)
+		}
+		if cr.KeepAliveReuseFraction != nil {
+			reuse = append(reuse, *cr.KeepAliveReuseFraction)
+		}
+		if avg.HTTP3Error == "" {
+			avg.HTTP3Error = cr.HTTP3Error
+		}
+	}
+
+	if avg != nil {
+		avg.DNSMs = meanOf(dns)
+		avg.TCPMs = meanOf(tcp)
+		// Keep the earliest run's TLS value (0 or NotApplicableMs) when no run timed TLS
+		if len(tls) > 0 {
+			avg.TLSMs = meanOf(tls)
+		}
+		avg.TLSFullHandshakeMs = meanOf(fullTLS)
+		avg.TLSResumedMs = meanOf(resumedTLS)
+		avg.TotalMs = meanOf(total)
+		avg.IPv4Ms = meanOf(ipv4)
+		avg.IPv6Ms = meanOf(ipv6)
+		avg.HTTP3Ms = meanOf(http3)
+		avg.HTTP3Supported = len(http3) > 0
+		avg.KeepAliveReuseFraction = nil
+		if len(reuse) > 0 {
+			mean := meanOf(reuse)
+			avg.KeepAliveReuseFraction = &mean
+		}
+		// A probe that succeeded in any run shows HTTP/3 as supported
+		if avg.HTTP3Supported {
+			avg.HTTP3Error = ""
+		}
+	}
+	return avg
+}
+
+// averageHealth averages the health check response time of all runs that checked it
+func averageHealth(results []*internal.BenchmarkResult) *internal.HealthResult {
+	var avg *internal.HealthResult
+	var response, connections, memory []float64
+
+	for _, r := range results {
+		h := r.Health
+		if h == nil {
+			continue
+		}
+		if avg == nil {
+			copied := *h
+			avg = &copied
+		}
+		// Any unhealthy run makes the averaged status unhealthy
+		if h.Status != "healthy" && avg.Status == "healthy" {
+			avg.Status = h.Status
+			avg.HTTPStatus = h.HTTPStatus
+			avg.Error = h.Error
+		}
+
+		response = append(response, h.ResponseMs)
+		if h.ActiveConnections > 0 {
+			connections = append(connections, float64(h.ActiveConnections))
+		}
+		if h.MemoryMB > 0 {
+			memory = append(memory, h.MemoryMB)
+		}
+	}
+
+	if avg != nil {
+		avg.ResponseMs = meanOf(response)
+		avg.ActiveConnections = int(math.Round(meanOf(connections)))
+		avg.MemoryMB = meanOf(memory)
+	}
+	return avg
+}
+
+// averageEndpoints averages each endpoint's response time across runs, keeping
+// the order of first appearance. An endpoint succeeds only if it succeeded in
+// every run.
+func averageEndpoints(results []*internal.BenchmarkResult) []internal.EndpointResult {
+	var avg []internal.EndpointResult
+	index := make(map[string]int)
+	var times, coldTimes, ttfbTimes, totalTimes [][]float64
+
+	for _, r := range results {
+		for _, ep := range r.Endpoints {
+			key := ep.Method + " " + ep.Path
+			i, ok := index[key]
+			if !ok {
+				i = len(avg)
+				index[key] = i
+				avg = append(avg, ep)
+				times = append(times, nil)
+				coldTimes = append(coldTimes, nil)
+				ttfbTimes = append(ttfbTimes, nil)
+				totalTimes = append(totalTimes, nil)
+			}
+			times[i] = append(times[i], ep.ResponseMs)
+			if ep.ColdStartMs > 0 {
+				coldTimes[i] = append(coldTimes[i], ep.ColdStartMs)
+			}
+			if ep.TTFBMs > 0 {
+				ttfbTimes[i] = append(ttfbTimes[i], ep.TTFBMs)
+			}
+			if ep.TotalMs > 0 {
+				totalTimes[i] = append(totalTimes[i], ep.TotalMs)
+			}
+			if !ep.Success && avg[i].Success {
+				avg[i].Success = false
+				avg[i].Status = ep.Status
+				avg[i].Error = ep.Error
+				avg[i].CurlCommand = ep.CurlCommand
+			}
+		}
+	}
+
+	for i := range avg {
+		avg[i].ResponseMs = meanOf(times[i])
+		avg[i].ColdStartMs = meanOf(coldTimes[i])
+		avg[i].TTFBMs = meanOf(ttfbTimes[i])
+		avg[i].TotalMs = meanOf(totalTimes[i])
+	}
+	return avg
+}
+
+// averageFrontend averages the frontend totals of all runs that benchmarked assets
+func averageFrontend(results []*internal.BenchmarkResult) *internal.FrontendResult {
+	var avg *internal.FrontendResult
+	var size, total []float64
+
+	for _, r := range results {
+		if r.Frontend == nil {
+			continue
+		}
+		if avg == nil {
+			copied := *r.Frontend
+			avg = &copied
+		}
+		size = append(size, r.Frontend.TotalSizeKB)
+		total = append(total, r.Frontend.TotalTimeMs)
+	}
+
+	if avg != nil {
+		avg.TotalSizeKB = meanOf(size)
+		avg.TotalTimeMs = meanOf(total)
+	}
+	return avg
+}
+
+// averageLoadTests averages every load test counter and timing across the runs
+// that ran one, and records the p95 latency confidence interval
+func averageLoadTests(results []*internal.BenchmarkResult) *internal.LoadTestResult {
+	var avg *internal.LoadTestResult
+	var duration, total, successful, failed, bytes, dnsCacheHits []float64
+	var rps, p50, p95, p99, minLat, maxLat, avgLat, stddevLat, iqrLat []float64
+	var arrivalRate, serviceRate, queueDepth []float64
+	slaBreaches := make(map[string][]float64)
+	percentiles := make(map[string][]float64)
+	errorTotals := make(map[string]int) // Summed, as a run without an error type had none
+	distributionTotals := make(map[string]int)
+
+	for _, r := range results {
+		lt := r.LoadTest
+		if lt == nil {
+			continue
+		}
+		if avg == nil {
+			copied := *lt
+			avg = &copied
+		}
+
+		duration = append(duration, lt.DurationSec)
+		total = append(total, float64(lt.TotalRequests))
+		successful = append(successful, float64(lt.Successful))
+		failed = append(failed, float64(lt.Failed))
+		bytes = append(bytes, float64(lt.TotalBytesReceived))
+		dnsCacheHits = append(dnsCacheHits, float64(lt.DNSCacheHits))
+		rps = append(rps, lt.RPS)
+		p50 = append(p50, lt.LatencyP50Ms)
+		p95 = append(p95, lt.LatencyP95Ms)
+		p99 = append(p99, lt.LatencyP99Ms)
+		minLat = append(minLat, lt.MinLatencyMs)
+		maxLat = append(maxLat, lt.MaxLatencyMs)
+		avgLat = append(avgLat, lt.AvgLatencyMs)
+		stddevLat = append(stddevLat, lt.StdDevLatencyMs)
+		iqrLat = append(iqrLat, lt.IQRLatencyMs)
+		arrivalRate = append(arrivalRate, lt.ArrivalRate)
+		serviceRate = append(serviceRate, lt.ServiceRate)
+		queueDepth = append(queueDepth, float64(lt.MaxQueueDepth))
+		for target, count := range lt.SLABreachCount {
+			slaBreaches[target] = append(slaBreaches[target], float64(count))
+		}
+		for label, ms := range lt.Percentiles {
+			percentiles[label] = append(percentiles[label], ms)
+		}
+		for errorType, count := range lt.ErrorBreakdown {
+			errorTotals[errorType] += count
+		}
+		for path, count := range lt.EndpointDistribution {
+			distributionTotals[path] += count
+		}
+	}
+
+	if avg == nil {
+		return nil
+	}
+
+	avg.DurationSec = meanOf(duration)
+	avg.TotalRequests = int(math.Round(meanOf(total)))
+	avg.Successful = int(math.Round(meanOf(successful)))
+	avg.Failed = int(math.Round(meanOf(failed)))
+	avg.TotalBytesReceived = int64(math.Round(meanOf(bytes)))
+	// Workers and histogram buckets are not comparable across runs
+	avg.WorkerStats = nil
+	avg.LatencyHistogram = nil
+	avg.DNSCacheHits = int(math.Round(meanOf(dnsCacheHits)))
+	avg.RPS = meanOf(rps)
+	avg.LatencyP50Ms = meanOf(p50)
+	avg.LatencyP99Ms = meanOf(p99)
+	avg.MinLatencyMs = meanOf(minLat)
+	avg.MaxLatencyMs = meanOf(maxLat)
+	avg.AvgLatencyMs = meanOf(avgLat)
+	avg.StdDevLatencyMs = meanOf(stddevLat)
+	avg.IQRLatencyMs = meanOf(iqrLat)
+	avg.ArrivalRate = meanOf(arrivalRate)
+	avg.ServiceRate = meanOf(serviceRate)
+	avg.MaxQueueDepth = int(math.Round(meanOf(queueDepth)))
+	if len(slaBreaches) > 0 {
+		avg.SLABreachCount = make(map[string]int, len(slaBreaches))
+		for target, counts := range slaBreaches {
+			avg.SLABreachCount[target] = int(math.Round(meanOf(counts)))
+		}
+	}
+	avg.ErrorBreakdown = nil
+	for errorType, count := range errorTotals {
+		if mean := int(math.Round(float64(count) / float64(len(total)))); mean > 0 {
+			if avg.ErrorBreakdown == nil {
+				avg.ErrorBreakdown = make(map[string]int)
+			}
+			avg.ErrorBreakdown[errorType] = mean
+		}
+	}
+	avg.EndpointDistribution = nil
+	for path, count := range distributionTotals {
+		if mean := int(math.Round(float64(count) / float64(len(total)))); mean > 0 {
+			if avg.EndpointDistribution == nil {
+				avg.EndpointDistribution = make(map[string]int)
+			}
+			avg.EndpointDistribution[path] = mean
+		}
+	}
+	if len(percentiles) > 0 {
+		avg.Percentiles = make(map[string]float64, len(percentiles))
+		for label, values := range percentiles {
+			avg.Percentiles[label] = meanOf(values)
+		}
+	}
+
+	var stddev float64
+	avg.LatencyP95Ms, stddev = meanStdDev(p95)
+	avg.LatencyP95MsCI95 = ci95ZScore * stddev / math.Sqrt(float64(len(p95)))
+	return avg
+}
+
+// averageBenchmarkAPI averages the server-side benchmark duration of all runs
+// that called it; the detailed operation timings come from the earliest run
+func averageBenchmarkAPI(results []*internal.BenchmarkResult) *internal.BenchmarkAPIResult {
+	var avg *internal.BenchmarkAPIResult
+	var durations []float64
+
+	for _, r := range results {
+		if r.BenchmarkAPI == nil {
+			continue
+		}
+		if avg == nil {
+			copied := *r.BenchmarkAPI
+			avg = &copied
+		}
+		avg.Success = avg.Success && r.BenchmarkAPI.Success
+		durations = append(durations, r.BenchmarkAPI.TotalDurationMs)
+	}
+
+	if avg != nil {
+		avg.TotalDurationMs = meanOf(durations)
+	}
+	return avg
+}
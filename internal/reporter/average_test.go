@@ -0,0 +1,115 @@
+package reporter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestAverageResults(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{
+			Timestamp:      time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			Target:         "https://example.com",
+			Version:        "1.2.3",
+			Overall:        "pass",
+			PhaseDurations: map[string]float64{internal.PhaseHealth: 10, internal.PhaseLoadTest: 10000},
+			Connectivity:   &internal.ConnectivityResult{DNSMs: 2, TCPMs: 4, TLSMs: 10, TotalMs: 16, Connected: true},
+			Health:         &internal.HealthResult{Status: "healthy", ResponseMs: 10, HTTPStatus: 200},
+			Endpoints: []internal.EndpointResult{
+				{Path: "/api/version", ResponseMs: 20, Status: 200, Success: true},
+			},
+			LoadTest: &internal.LoadTestResult{
+				Concurrent: 5, DurationSec: 10, TotalRequests: 100, Successful: 100, RPS: 10,
+				LatencyP50Ms: 10, LatencyP95Ms: 20, LatencyP99Ms: 30, AvgLatencyMs: 12,
+				MinLatencyMs: 2, MaxLatencyMs: 40,
+			},
+		},
+		{
+			Timestamp:      time.Date(2026, 1, 1, 10, 0, 30, 0, time.UTC),
+			Target:         "https://example.com",
+			Overall:        "degraded",
+			PhaseDurations: map[string]float64{internal.PhaseHealth: 20, internal.PhaseLoadTest: 10200},
+			Connectivity:   &internal.ConnectivityResult{DNSMs: 4, TCPMs: 6, TotalMs: 10, Connected: true},
+			Health:         &internal.HealthResult{Status: "healthy", ResponseMs: 20, HTTPStatus: 200},
+			Endpoints: []internal.EndpointResult{
+				{Path: "/api/version", ResponseMs: 40, Status: 500, Success: false, Error: "HTTP 500"},
+			},
+			LoadTest: &internal.LoadTestResult{
+				Concurrent: 5, DurationSec: 10, TotalRequests: 201, Successful: 190, Failed: 11, RPS: 20,
+				LatencyP50Ms: 20, LatencyP95Ms: 40, LatencyP99Ms: 60, AvgLatencyMs: 24,
+				MinLatencyMs: 4, MaxLatencyMs: 80,
+			},
+		},
+	}
+
+	avg := AverageResults(results)
+
+	if avg.SampleCount != 2 {
+		t.Errorf("expected sample count 2, got %d", avg.SampleCount)
+	}
+	if avg.Version != "1.2.3" {
+		t.Errorf("expected other fields from the earliest result, got version %q", avg.Version)
+	}
+	if avg.Overall != "degraded" {
+		t.Errorf("expected worst overall status 'degraded', got %s", avg.Overall)
+	}
+
+	cr := avg.Connectivity
+	if cr.DNSMs != 3 || cr.TCPMs != 5 || cr.TotalMs != 13 {
+		t.Errorf("unexpected averaged connectivity: %+v", cr)
+	}
+	if cr.TLSMs != 10 {
+		t.Errorf("expected TLS averaged over runs that measured it, got %f", cr.TLSMs)
+	}
+	if avg.PhaseDurations[internal.PhaseHealth] != 15 || avg.PhaseDurations[internal.PhaseLoadTest] != 10100 {
+		t.Errorf("unexpected averaged phase durations: %v", avg.PhaseDurations)
+	}
+	if avg.Health.ResponseMs != 15 {
+		t.Errorf("expected health response 15, got %f", avg.Health.ResponseMs)
+	}
+
+	if len(avg.Endpoints) != 1 {
+		t.Fatalf("expected 1 endpoint, got %d", len(avg.Endpoints))
+	}
+	if ep := avg.Endpoints[0]; ep.ResponseMs != 30 || ep.Success || ep.Status != 500 {
+		t.Errorf("expected averaged failing endpoint, got %+v", ep)
+	}
+
+	lt := avg.LoadTest
+	if lt.RPS != 15 || lt.LatencyP50Ms != 15 || lt.LatencyP95Ms != 30 || lt.LatencyP99Ms != 45 {
+		t.Errorf("unexpected averaged load test: %+v", lt)
+	}
+	if lt.TotalRequests != 151 || lt.Successful != 145 || lt.Failed != 6 {
+		t.Errorf("unexpected averaged counters: %+v", lt)
+	}
+
+	// stddev of {20, 40} is √200; CI = 1.96 × √200 / √2 = 19.6
+	if math.Abs(lt.LatencyP95MsCI95-19.6) > 1e-9 {
+		t.Errorf("expected p95 CI 19.6, got %f", lt.LatencyP95MsCI95)
+	}
+}
+
+func TestAverageResults_Empty(t *testing.T) {
+	if AverageResults(nil) != nil {
+		t.Error("expected nil for no results")
+	}
+}
+
+func TestAverageResults_SkipsMissingSections(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{Overall: "pass", LoadTest: &internal.LoadTestResult{RPS: 10, LatencyP95Ms: 20}},
+		{Overall: "pass"},
+	}
+
+	avg := AverageResults(results)
+
+	if avg.Connectivity != nil || avg.Health != nil || avg.Frontend != nil || avg.BenchmarkAPI != nil {
+		t.Errorf("expected missing sections to stay nil, got %+v", avg)
+	}
+	if avg.LoadTest.RPS != 10 || avg.LoadTest.LatencyP95MsCI95 != 0 {
+		t.Errorf("expected single load test unchanged with no CI, got %+v", avg.LoadTest)
+	}
+}
@@ -0,0 +1,97 @@
+package reporter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// Capacity estimation limits
+const (
+	capacityLatencyLimitMs = 500.0 // Highest acceptable p95 latency
+	capacityErrorLimitPct  = 1.0   // Highest acceptable error rate
+)
+
+// capacityEstimate projects load test results to the latency and error limits
+type capacityEstimate struct {
+	InFlight          float64 // Average requests in flight, by Little's Law
+	LatencyHeadroom   float64 // Load multiplier until p95 reaches the latency limit
+	ErrorHeadroom     float64 // Load multiplier until errors reach the error limit; +Inf if no errors
+	MaxSafeRPS        float64
+	MaxUsers          float64 // Concurrent users at MaxSafeRPS, by Little's Law
+	ErrorConcurrency  float64 // Concurrency at which errors reach the limit; 0 if no errors
+	LimitedByLatency  bool
+	AlreadyOverLimits bool
+}
+
+// estimateCapacity extrapolates a load test linearly: throughput, p95 latency,
+// and error rate are all assumed to grow in proportion to load. It returns
+// false when the load test has too little data to project from.
+func estimateCapacity(lt *internal.LoadTestResult) (capacityEstimate, bool) {
+	var est capacityEstimate
+	if lt == nil || lt.TotalRequests == 0 || lt.RPS <= 0 || lt.LatencyP95Ms <= 0 {
+		return est, false
+	}
+
+	// Little's Law: N = λ × W
+	est.InFlight = lt.RPS * lt.AvgLatencyMs / 1000
+
+	est.LatencyHeadroom = capacityLatencyLimitMs / lt.LatencyP95Ms
+	est.ErrorHeadroom = math.Inf(1)
+	errorRatePct := float64(lt.Failed) / float64(lt.TotalRequests) * 100
+	if errorRatePct > 0 {
+		est.ErrorHeadroom = capacityErrorLimitPct / errorRatePct
+		est.ErrorConcurrency = float64(lt.Concurrent) * est.ErrorHeadroom
+	}
+
+	headroom := math.Min(est.LatencyHeadroom, est.ErrorHeadroom)
+	est.LimitedByLatency = est.LatencyHeadroom <= est.ErrorHeadroom
+	est.AlreadyOverLimits = headroom < 1
+	est.MaxSafeRPS = lt.RPS * headroom
+	est.MaxUsers = est.MaxSafeRPS * lt.AvgLatencyMs / 1000
+
+	return est, true
+}
+
+// writeCapacitySection writes the capacity estimation for a load test
+func writeCapacitySection(sb *strings.Builder, lt *internal.LoadTestResult) {
+	est, ok := estimateCapacity(lt)
+	if !ok {
+		return
+	}
+
+	sb.WriteString("## Capacity Estimation\n\n")
+	sb.WriteString("> ⚠️ **These are estimates, not measurements.** They extrapolate a single load test linearly, ")
+	sb.WriteString("assuming throughput, latency, and errors grow in proportion to load. Real servers usually degrade faster ")
+	sb.WriteString("than linearly near saturation, so treat projections far beyond the tested load as optimistic upper bounds ")
+	sb.WriteString("and confirm them with a load test at the projected concurrency.\n\n")
+
+	sb.WriteString(fmt.Sprintf("Little's Law (N = λ × W) relates concurrency to throughput: at **%.2f requests/sec** (λ) with an ", lt.RPS))
+	sb.WriteString(fmt.Sprintf("average response time of **%.2f ms** (W), about **%.1f requests** were in flight at once ", lt.AvgLatencyMs, est.InFlight))
+	sb.WriteString(fmt.Sprintf("with %d workers configured.\n\n", lt.Concurrent))
+
+	errorConcurrency := "Not projected (no errors observed)"
+	if est.ErrorConcurrency > 0 {
+		errorConcurrency = fmt.Sprintf("~%.0f concurrent", est.ErrorConcurrency)
+	}
+
+	sb.WriteString("| Estimate | Value | Basis |\n")
+	sb.WriteString("|----------|------:|-------|\n")
+	sb.WriteString(fmt.Sprintf("| Concurrency at 1%% errors | %s | Measured error rate scaled with concurrency |\n", errorConcurrency))
+	sb.WriteString(fmt.Sprintf("| Maximum safe RPS | ~%.0f | Measured RPS × %.2f headroom to p95 < %.0f ms and errors < %.0f%% |\n",
+		est.MaxSafeRPS, math.Min(est.LatencyHeadroom, est.ErrorHeadroom), capacityLatencyLimitMs, capacityErrorLimitPct))
+	sb.WriteString(fmt.Sprintf("| Maximum concurrent users | ~%.0f | Little's Law at the maximum safe RPS |\n", est.MaxUsers))
+	sb.WriteString("\n")
+
+	sb.WriteString("### Interpretation\n\n")
+	switch {
+	case est.AlreadyOverLimits:
+		sb.WriteString("❌ **Over capacity** - The tested load already exceeds the p95 or error rate limit. Reduce concurrency or scale the server before serving more users.\n\n")
+	case est.LimitedByLatency:
+		sb.WriteString(fmt.Sprintf("The server has roughly **%.1f× headroom** before p95 latency reaches %.0f ms, which is expected to be the first limit reached.\n\n", est.LatencyHeadroom, capacityLatencyLimitMs))
+	default:
+		sb.WriteString(fmt.Sprintf("The server has roughly **%.1f× headroom** before the error rate reaches %.0f%%, which is expected to be the first limit reached.\n\n", est.ErrorHeadroom, capacityErrorLimitPct))
+	}
+}
@@ -0,0 +1,92 @@
+package reporter
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestEstimateCapacity_LatencyLimited(t *testing.T) {
+	lt := &internal.LoadTestResult{
+		Concurrent:    10,
+		TotalRequests: 10000,
+		Successful:    10000,
+		RPS:           200,
+		AvgLatencyMs:  50,
+		LatencyP95Ms:  100,
+	}
+
+	est, ok := estimateCapacity(lt)
+	if !ok {
+		t.Fatal("expected an estimate")
+	}
+	if math.Abs(est.InFlight-10) > 1e-9 {
+		t.Errorf("expected 10 requests in flight, got %.2f", est.InFlight)
+	}
+	if !est.LimitedByLatency || est.AlreadyOverLimits {
+		t.Errorf("expected latency-limited estimate within limits, got %+v", est)
+	}
+	// p95 100ms -> 5x headroom to 500ms
+	if math.Abs(est.MaxSafeRPS-1000) > 1e-9 || math.Abs(est.MaxUsers-50) > 1e-9 {
+		t.Errorf("expected 1000 RPS and 50 users, got %.2f and %.2f", est.MaxSafeRPS, est.MaxUsers)
+	}
+	if est.ErrorConcurrency != 0 {
+		t.Errorf("expected no error projection without errors, got %.2f", est.ErrorConcurrency)
+	}
+}
+
+func TestEstimateCapacity_ErrorLimited(t *testing.T) {
+	lt := &internal.LoadTestResult{
+		Concurrent:    20,
+		TotalRequests: 1000,
+		Successful:    995,
+		Failed:        5, // 0.5% errors -> 2x headroom
+		RPS:           100,
+		AvgLatencyMs:  40,
+		LatencyP95Ms:  50, // 10x latency headroom
+	}
+
+	est, _ := estimateCapacity(lt)
+	if est.LimitedByLatency {
+		t.Error("expected error-limited estimate")
+	}
+	if math.Abs(est.ErrorConcurrency-40) > 1e-9 || math.Abs(est.MaxSafeRPS-200) > 1e-9 {
+		t.Errorf("expected 40 concurrent at 1%% errors and 200 RPS, got %.2f and %.2f", est.ErrorConcurrency, est.MaxSafeRPS)
+	}
+}
+
+func TestEstimateCapacity_NoData(t *testing.T) {
+	if _, ok := estimateCapacity(nil); ok {
+		t.Error("expected no estimate for nil load test")
+	}
+	if _, ok := estimateCapacity(&internal.LoadTestResult{Concurrent: 5}); ok {
+		t.Error("expected no estimate for empty load test")
+	}
+}
+
+func TestWriteCapacitySection(t *testing.T) {
+	var sb strings.Builder
+	writeCapacitySection(&sb, &internal.LoadTestResult{
+		Concurrent: 50, TotalRequests: 1000, Successful: 900, Failed: 100,
+		RPS: 100, AvgLatencyMs: 400, LatencyP95Ms: 800,
+	})
+	content := sb.String()
+
+	if !strings.Contains(content, "## Capacity Estimation") {
+		t.Error("expected Capacity Estimation heading")
+	}
+	if !strings.Contains(content, "These are estimates, not measurements") {
+		t.Error("expected estimate caveat")
+	}
+	if !strings.Contains(content, "**Over capacity**") {
+		t.Error("expected over-capacity interpretation when p95 already exceeds 500ms")
+	}
+
+	sb.Reset()
+	writeCapacitySection(&sb, &internal.LoadTestResult{Concurrent: 1})
+	if sb.Len() != 0 {
+		t.Error("expected no section without load test data")
+	}
+}
@@ -0,0 +1,72 @@
+package reporter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// CILine formats result as a single line of space-separated KEY=value pairs for
+// CI scripts, e.g.
+//
+//	STATUS=pass TARGET=https://example.com HEALTH=12.5ms RPS=45.2 P95=123ms P99=210ms ERROR_RATE=0.1%
+//
+// Metrics from phases that did not run are left out. The line can be read with
+// eval in bash or split on spaces and "=" in other languages.
+func CILine(result *internal.BenchmarkResult) string {
+	pairs := []string{
+		"STATUS=" + result.Overall,
+		"TARGET=" + result.Target,
+	}
+
+	if h := result.Health; h != nil && h.Error == "" {
+		pairs = append(pairs, "HEALTH="+ciNumber(h.ResponseMs)+"ms")
+	}
+
+	if lt := result.LoadTest; lt != nil {
+		var errorRate float64
+		if lt.TotalRequests > 0 {
+			errorRate = float64(lt.Failed) / float64(lt.TotalRequests) * 100
+		}
+		pairs = append(pairs,
+			"RPS="+ciNumber(lt.RPS),
+			"P95="+ciNumber(lt.LatencyP95Ms)+"ms",
+			"P99="+ciNumber(lt.LatencyP99Ms)+"ms",
+			"ERROR_RATE="+ciNumber(errorRate)+"%",
+		)
+	}
+
+	if api := result.BenchmarkAPI; api != nil && api.Response != nil {
+		if ms, ok := maxOperationMs(api.Response.Database); ok {
+			pairs = append(pairs, "DB_MAX="+ciNumber(ms)+"ms")
+		}
+		if ms, ok := maxOperationMs(api.Response.Serialization); ok {
+			pairs = append(pairs, "SERIAL_MAX="+ciNumber(ms)+"ms")
+		}
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// ciNumber rounds v to one decimal place, dropping a trailing ".0"
+func ciNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
+
+// maxOperationMs returns the slowest duration among ops, and false when there are none
+func maxOperationMs(ops map[string]*internal.OperationResult) (float64, bool) {
+	var slowest float64
+	found := false
+	for _, op := range ops {
+		if op == nil {
+			continue
+		}
+		if !found || op.DurationMs > slowest {
+			slowest = op.DurationMs
+			found = true
+		}
+	}
+	return slowest, found
+}
@@ -0,0 +1,65 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestCILine(t *testing.T) {
+	result := &internal.BenchmarkResult{
+		Target:  "https://example.com",
+		Overall: "pass",
+		Health:  &internal.HealthResult{Status: "healthy", ResponseMs: 12.46},
+		LoadTest: &internal.LoadTestResult{
+			TotalRequests: 1000,
+			Failed:        1,
+			RPS:           45.2,
+			LatencyP95Ms:  123,
+			LatencyP99Ms:  210.04,
+		},
+		BenchmarkAPI: &internal.BenchmarkAPIResult{
+			Success: true,
+			Response: &internal.BenchmarkAPIResponse{
+				Database: map[string]*internal.OperationResult{
+					"insert": {DurationMs: 12.5},
+					"select": {DurationMs: 30.25},
+				},
+				Serialization: map[string]*internal.OperationResult{
+					"json": {DurationMs: 0.42},
+				},
+			},
+		},
+	}
+
+	want := "STATUS=pass TARGET=https://example.com HEALTH=12.5ms RPS=45.2 P95=123ms P99=210ms ERROR_RATE=0.1% DB_MAX=30.3ms SERIAL_MAX=0.4ms"
+	if got := CILine(result); got != want {
+		t.Errorf("CILine() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCILine_Minimal(t *testing.T) {
+	result := &internal.BenchmarkResult{
+		Target:  "https://example.com",
+		Overall: "fail",
+		Health:  &internal.HealthResult{Status: "error", Error: "connection refused"},
+	}
+
+	want := "STATUS=fail TARGET=https://example.com"
+	if got := CILine(result); got != want {
+		t.Errorf("CILine() = %q, want %q", got, want)
+	}
+}
+
+func TestCILine_NoRequests(t *testing.T) {
+	result := &internal.BenchmarkResult{
+		Target:   "https://example.com",
+		Overall:  "fail",
+		LoadTest: &internal.LoadTestResult{},
+	}
+
+	want := "STATUS=fail TARGET=https://example.com RPS=0 P95=0ms P99=0ms ERROR_RATE=0%"
+	if got := CILine(result); got != want {
+		t.Errorf("CILine() = %q, want %q", got, want)
+	}
+}
@@ -1,10 +1,14 @@
 package reporter
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -14,28 +18,43 @@ import (
 
 // ThresholdConfig defines alert thresholds for comparisons
 type ThresholdConfig struct {
-	LatencyP95MaxMs   float64 // Alert if p95 latency exceeds this
-	LatencyP99MaxMs   float64 // Alert if p99 latency exceeds this
-	ErrorRateMaxPct   float64 // Alert if error rate exceeds this percentage
-	RPSMinimum        float64 // Alert if RPS drops below this
-	HealthResponseMax float64 // Alert if health check exceeds this
+	LatencyP95MaxMs    float64 `json:"latency_p95_max_ms" yaml:"latency_p95_max_ms"`     // Alert if p95 latency exceeds this
+	LatencyP99MaxMs    float64 `json:"latency_p99_max_ms" yaml:"latency_p99_max_ms"`     // Alert if p99 latency exceeds this
+	ErrorRateMaxPct    float64 `json:"error_rate_max_pct" yaml:"error_rate_max_pct"`     // Alert if error rate exceeds this percentage
+	RPSMinimum         float64 `json:"rps_minimum" yaml:"rps_minimum"`                   // Alert if RPS drops below this
+	HealthResponseMax  float64 `json:"health_response_max" yaml:"health_response_max"`   // Alert if health check exceeds this
+	DBOperationMaxMs   float64 `json:"db_operation_max_ms" yaml:"db_operation_max_ms"`   // Alert if any server-side database operation exceeds this, 0 disables
+	SerializationMaxMs float64 `json:"serialization_max_ms" yaml:"serialization_max_ms"` // Alert if any server-side serialization operation exceeds this, 0 disables
 }
 
 // DefaultThresholds returns sensible default threshold values
 func DefaultThresholds() *ThresholdConfig {
 	return &ThresholdConfig{
-		LatencyP95MaxMs:   500,  // 500ms p95 latency threshold
-		LatencyP99MaxMs:   1000, // 1 second p99 latency threshold
-		ErrorRateMaxPct:   1.0,  // 1% error rate threshold
-		RPSMinimum:        10,   // minimum 10 requests per second
-		HealthResponseMax: 100,  // 100ms health check threshold
+		LatencyP95MaxMs:    500,  // 500ms p95 latency threshold
+		LatencyP99MaxMs:    1000, // 1 second p99 latency threshold
+		ErrorRateMaxPct:    1.0,  // 1% error rate threshold
+		RPSMinimum:         10,   // minimum 10 requests per second
+		HealthResponseMax:  100,  // 100ms health check threshold
+		DBOperationMaxMs:   50,   // 50ms per database operation
+		SerializationMaxMs: 1,    // 1ms per serialization operation
 	}
 }
 
 // Comparison reporter for comparing multiple benchmark results
 type Comparison struct {
-	outputDir  string
-	thresholds *ThresholdConfig
+	outputDir       string
+	prefix          string // Report filename prefix, empty for DefaultOutputPrefix
+	thresholds      *ThresholdConfig
+	thresholdFile   string
+	regressionsOnly bool
+	aggregate       bool
+	since           time.Duration // Only compare files with a result newer than this, 0 for all
+
+	schemaCheck    bool
+	schemaWarnings []string
+
+	remoteToken string   // Bearer token for FetchRemote
+	remoteDirs  []string // Temporary directories created by FetchRemote
 }
 
 // NewComparison creates a new comparison reporter
@@ -46,15 +65,58 @@ func NewComparison(outputDir string) *Comparison {
 	}
 }
 
+// SetOutputPrefix replaces DefaultOutputPrefix in the report filename and in
+// the pattern ScanDirectory looks for first
+func (c *Comparison) SetOutputPrefix(prefix string) {
+	c.prefix = prefix
+}
+
 // SetThresholds updates the threshold configuration
 func (c *Comparison) SetThresholds(t *ThresholdConfig) {
 	c.thresholds = t
 }
 
+// SetThresholdFile records the file the thresholds were loaded from, shown in
+// the report summary
+func (c *Comparison) SetThresholdFile(path string) {
+	c.thresholdFile = path
+}
+
+// SetRegressionsOnly limits comparison tables to rows that show a regression
+func (c *Comparison) SetRegressionsOnly(enabled bool) {
+	c.regressionsOnly = enabled
+}
+
+// SetAggregate appends a statistical summary across all runs to the report
+func (c *Comparison) SetAggregate(enabled bool) {
+	c.aggregate = enabled
+}
+
+// SetSchemaVersionCheck warns about result files written with a different schema version
+func (c *Comparison) SetSchemaVersionCheck(enabled bool) {
+	c.schemaCheck = enabled
+}
+
+// SchemaWarnings returns the schema version warnings from the last LoadResults call
+func (c *Comparison) SchemaWarnings() []string {
+	return c.schemaWarnings
+}
+
+// checkSchema records a schema version warning for a loaded result when
+// schema checking is enabled
+func (c *Comparison) checkSchema(name string, r *internal.BenchmarkResult) {
+	if !c.schemaCheck {
+		return
+	}
+	if warning := checkSchemaVersion(name, r); warning != "" {
+		c.schemaWarnings = append(c.schemaWarnings, warning)
+	}
+}
+
 // ScanDirectory finds all .json files in a directory that contain benchmark results
 func (c *Comparison) ScanDirectory(dir string) ([]string, error) {
-	// First try benchmark_*.json pattern (timestamped files from this tool)
-	pattern := filepath.Join(dir, "benchmark_*.json")
+	// First try <prefix>_*.json pattern (timestamped files from this tool)
+	pattern := filepath.Join(dir, outputPrefix(c.prefix)+"_*.json")
 	matches, err := filepath.Glob(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("scan directory: %w", err)
@@ -76,12 +138,17 @@ func (c *Comparison) ScanDirectory(dir string) ([]string, error) {
 	// Sort by filename
 	sort.Strings(matches)
 
+	if c.since > 0 {
+		return c.filterSince(matches, dir)
+	}
 	return matches, nil
 }
 
-// LoadResults loads benchmark results from JSON files
+// LoadResults loads benchmark results from JSON files, each holding one result
+// or an array of results
 func (c *Comparison) LoadResults(jsonPaths []string) ([]*internal.BenchmarkResult, error) {
 	var results []*internal.BenchmarkResult
+	c.schemaWarnings = nil
 
 	for _, path := range jsonPaths {
 		data, err := os.ReadFile(path)
@@ -89,10 +156,24 @@ func (c *Comparison) LoadResults(jsonPaths []string) ([]*internal.BenchmarkResul
 			return nil, fmt.Errorf("read %s: %w", path, err)
 		}
 
+		// Files written with --json-append hold an array of results
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+			var fileResults []*internal.BenchmarkResult
+			if err := json.Unmarshal(trimmed, &fileResults); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", path, err)
+			}
+			for i, result := range fileResults {
+				c.checkSchema(fmt.Sprintf("%s[%d]", path, i), result)
+			}
+			results = append(results, fileResults...)
+			continue
+		}
+
 		var result internal.BenchmarkResult
 		if err := json.Unmarshal(data, &result); err != nil {
 			return nil, fmt.Errorf("parse %s: %w", path, err)
 		}
+		c.checkSchema(path, &result)
 
 		results = append(results, &result)
 	}
@@ -107,18 +188,23 @@ func (c *Comparison) LoadResults(jsonPaths []string) ([]*internal.BenchmarkResul
 
 // Report generates a comparison markdown report from multiple JSON files
 func (c *Comparison) Report(jsonPaths []string) (string, error) {
-	if len(jsonPaths) < 2 {
-		return "", fmt.Errorf("comparison requires at least 2 JSON files, got %d", len(jsonPaths))
-	}
-
 	results, err := c.LoadResults(jsonPaths)
 	if err != nil {
 		return "", err
 	}
+	return c.ReportResults(results)
+}
+
+// ReportResults generates a comparison markdown report from results, compared
+// in the order given
+func (c *Comparison) ReportResults(results []*internal.BenchmarkResult) (string, error) {
+	if len(results) < 2 {
+		return "", fmt.Errorf("comparison requires at least 2 benchmark results, got %d", len(results))
+	}
 
 	// Generate filename with timestamp
 	timestamp := time.Now().Format("2006-01-02_150405")
-	filename := fmt.Sprintf("benchmark_comparison_%s.md", timestamp)
+	filename := fmt.Sprintf("%s_comparison_%s.md", outputPrefix(c.prefix), timestamp)
 	outputPath := filepath.Join(c.outputDir, filename)
 
 	var sb strings.Builder
@@ -128,11 +214,20 @@ func (c *Comparison) Report(jsonPaths []string) (string, error) {
 	sb.WriteString(fmt.Sprintf("**Generated:** %s\n\n", time.Now().Format("2006-01-02 15:04:05 MST")))
 	sb.WriteString(fmt.Sprintf("**Comparing %d benchmark runs**\n\n", len(results)))
 
+	writeSchemaWarnings(&sb, c.schemaWarnings)
+
 	// Run Overview Table
 	sb.WriteString("## Run Overview\n\n")
 	sb.WriteString("This table summarizes each benchmark run included in this comparison. The **Overall** status indicates whether all tests passed (✅), some tests showed degraded performance (⚠️), or critical tests failed (❌).\n\n")
-	sb.WriteString("| # | Timestamp | Target | Version | Overall |\n")
-	sb.WriteString("|---|-----------|--------|---------|--------|\n")
+	// Runs against a --url-list are told apart by URL rather than by time
+	multiURL := results[0].MultiURLMode
+	if multiURL {
+		sb.WriteString("| # | URL | Version | Overall |\n")
+		sb.WriteString("|---|-----|---------|--------|\n")
+	} else {
+		sb.WriteString("| # | Timestamp | Target | Version | Overall |\n")
+		sb.WriteString("|---|-----------|--------|---------|--------|\n")
+	}
 	for i, r := range results {
 		status := "✅ " + r.Overall
 		if r.Overall == "fail" {
@@ -140,6 +235,10 @@ func (c *Comparison) Report(jsonPaths []string) (string, error) {
 		} else if r.Overall == "degraded" {
 			status = "⚠️ degraded"
 		}
+		if multiURL {
+			sb.WriteString(fmt.Sprintf("| %d | %s | %s | %s |\n", i+1, r.Target, r.Version, status))
+			continue
+		}
 		sb.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %s |\n",
 			i+1,
 			r.Timestamp.Format("2006-01-02 15:04"),
@@ -149,977 +248,1204 @@ func (c *Comparison) Report(jsonPaths []string) (string, error) {
 	}
 	sb.WriteString("\n")
 
-	// Connectivity Comparison
 	if hasConnectivity(results) {
-		sb.WriteString("## Connectivity Comparison\n\n")
-		sb.WriteString("Connectivity metrics measure the time required to establish a network connection to the server. These timings are critical for understanding baseline network latency before any application logic is involved.\n\n")
-		sb.WriteString("- **DNS (Domain Name System)**: Time to resolve the server's hostname to an IP address. High values may indicate DNS server issues or network congestion.\n")
-		sb.WriteString("- **TCP (Transmission Control Protocol)**: Time to establish a TCP connection (the \"three-way handshake\"). This reflects network round-trip latency.\n")
-		sb.WriteString("- **TLS (Transport Layer Security)**: Time for the secure handshake that establishes encrypted HTTPS connections. Includes certificate verification.\n")
-		sb.WriteString("- **Total**: Combined time for all connectivity steps. Lower values indicate faster initial connection establishment.\n\n")
-		sb.WriteString("| Metric |")
-		for i := range results {
-			sb.WriteString(fmt.Sprintf(" Run %d |", i+1))
-		}
-		sb.WriteString(" Δ (Last vs First) |\n")
-
-		sb.WriteString("|--------|")
-		for range results {
-			sb.WriteString("-------:|")
-		}
-		sb.WriteString("---------------:|\n")
-
-		// DNS
-		sb.WriteString("| DNS (ms) |")
-		var firstDNS, lastDNS float64
-		for i, r := range results {
-			if r.Connectivity != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.Connectivity.DNSMs))
-				if i == 0 {
-					firstDNS = r.Connectivity.DNSMs
-				}
-				lastDNS = r.Connectivity.DNSMs
-			} else {
-				sb.WriteString(" - |")
-			}
-		}
-		sb.WriteString(formatDelta(lastDNS, firstDNS) + " |\n")
-
-		// TCP
-		sb.WriteString("| TCP (ms) |")
-		var firstTCP, lastTCP float64
-		for i, r := range results {
-			if r.Connectivity != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.Connectivity.TCPMs))
-				if i == 0 {
-					firstTCP = r.Connectivity.TCPMs
-				}
-				lastTCP = r.Connectivity.TCPMs
-			} else {
-				sb.WriteString(" - |")
-			}
-		}
-		sb.WriteString(formatDelta(lastTCP, firstTCP) + " |\n")
-
-		// TLS
-		sb.WriteString("| TLS (ms) |")
-		var firstTLS, lastTLS float64
-		for i, r := range results {
-			if r.Connectivity != nil && r.Connectivity.TLSMs > 0 {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.Connectivity.TLSMs))
-				if i == 0 {
-					firstTLS = r.Connectivity.TLSMs
-				}
-				lastTLS = r.Connectivity.TLSMs
-			} else {
-				sb.WriteString(" - |")
-			}
-		}
-		if firstTLS > 0 || lastTLS > 0 {
-			sb.WriteString(formatDelta(lastTLS, firstTLS) + " |\n")
-		} else {
-			sb.WriteString(" - |\n")
-		}
+		c.writeConnectivitySection(&sb, results)
+	}
+	if hasHealth(results) {
+		c.writeHealthSection(&sb, results)
+	}
+	if hasEndpoints(results) {
+		c.writeEndpointsSection(&sb, results)
+	}
+	if hasFrontend(results) {
+		c.writeFrontendSection(&sb, results)
+	}
+	if hasLoadTest(results) {
+		c.writeLoadTestSection(&sb, results)
+	}
+	if hasBenchmarkAPI(results) {
+		c.writeBenchmarkAPISection(&sb, results)
+	}
+	if c.aggregate {
+		writeAggregateSection(&sb, Aggregate(results))
+	}
 
-		// Total
-		sb.WriteString("| **Total (ms)** |")
-		var firstTotal, lastTotal float64
-		for i, r := range results {
-			if r.Connectivity != nil {
-				sb.WriteString(fmt.Sprintf(" **%.2f** |", r.Connectivity.TotalMs))
-				if i == 0 {
-					firstTotal = r.Connectivity.TotalMs
-				}
-				lastTotal = r.Connectivity.TotalMs
-			} else {
-				sb.WriteString(" - |")
-			}
+	// Threshold Alerts
+	alerts := c.checkThresholds(results)
+	if len(alerts) > 0 {
+		sb.WriteString("## ⚠️ Threshold Alerts\n\n")
+		sb.WriteString("Threshold alerts identify benchmark runs where critical performance metrics exceeded acceptable limits. These alerts help catch performance regressions before they impact users in production.\n\n")
+		sb.WriteString("The following metrics exceeded configured thresholds:\n\n")
+		for _, alert := range alerts {
+			sb.WriteString(fmt.Sprintf("- %s\n", alert))
 		}
-		sb.WriteString(formatDelta(lastTotal, firstTotal) + " |\n")
 		sb.WriteString("\n")
 	}
 
-	// Health Check Comparison
-	if hasHealth(results) {
-		sb.WriteString("## Health Check Comparison\n\n")
-		sb.WriteString("The health check endpoint (`/health`) provides a quick verification that the application is running and can respond to requests. This is the most basic availability test.\n\n")
-		sb.WriteString("- **Status**: Whether the application reports itself as healthy. A healthy status indicates the server is operational and database connections are working.\n")
-		sb.WriteString("- **Response Time**: How quickly the health endpoint responds. This measures basic application responsiveness without complex business logic.\n\n")
-		sb.WriteString("| Metric |")
-		for i := range results {
-			sb.WriteString(fmt.Sprintf(" Run %d |", i+1))
+	// Chart-Ready CSV Data
+	sb.WriteString("## Chart-Ready Data (CSV)\n\n")
+	sb.WriteString("The data below is formatted as CSV (Comma-Separated Values) for easy import into spreadsheet applications like Microsoft Excel, Google Sheets, or LibreOffice Calc. Copy the data between the code fences and paste into your spreadsheet to create trend charts and visualizations.\n\n")
+	sb.WriteString("### Latency Over Time\n\n")
+	sb.WriteString("Columns: Timestamp, DNS (Domain Name System resolution), TCP (connection time), Health (health endpoint response), Min/p50/p95/p99/Max (latency distribution from load testing), Avg (average latency). All times in milliseconds.\n\n")
+	sb.WriteString("```csv\n")
+	sb.WriteString("Timestamp,DNS_ms,TCP_ms,Health_ms,Min_ms,p50_ms,p95_ms,p99_ms,Max_ms,Avg_ms\n")
+	for _, r := range results {
+		dns, tcp, health := 0.0, 0.0, 0.0
+		minLat, p50, p95, p99, maxLat, avgLat := 0.0, 0.0, 0.0, 0.0, 0.0, 0.0
+		if r.Connectivity != nil {
+			dns = r.Connectivity.DNSMs
+			tcp = r.Connectivity.TCPMs
 		}
-		sb.WriteString(" Δ (Last vs First) |\n")
-
-		sb.WriteString("|--------|")
-		for range results {
-			sb.WriteString("-------:|")
+		if r.Health != nil {
+			health = r.Health.ResponseMs
 		}
-		sb.WriteString("---------------:|\n")
+		if r.LoadTest != nil {
+			minLat = r.LoadTest.MinLatencyMs
+			p50 = r.LoadTest.LatencyP50Ms
+			p95 = r.LoadTest.LatencyP95Ms
+			p99 = r.LoadTest.LatencyP99Ms
+			maxLat = r.LoadTest.MaxLatencyMs
+			avgLat = r.LoadTest.AvgLatencyMs
+		}
+		sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f,%.2f,%.2f,%.2f,%.2f,%.2f,%.2f,%.2f\n",
+			r.Timestamp.Format("2006-01-02T15:04:05"),
+			dns, tcp, health, minLat, p50, p95, p99, maxLat, avgLat))
+	}
+	sb.WriteString("```\n\n")
 
-		// Status
-		sb.WriteString("| Status |")
+	if hasLoadTest(results) {
+		sb.WriteString("### Throughput Over Time\n\n")
+		sb.WriteString("Columns: Timestamp, RPS (Requests Per Second - server throughput), Success_Rate_Pct (percentage of successful HTTP responses), Total_Requests (total number of requests made), Failed (count of failed requests).\n\n")
+		sb.WriteString("```csv\n")
+		sb.WriteString("Timestamp,RPS,Success_Rate_Pct,Total_Requests,Failed\n")
 		for _, r := range results {
-			if r.Health != nil {
-				status := "✅"
-				if r.Health.Status != "healthy" {
-					status = "❌"
+			if r.LoadTest != nil {
+				successRate := 0.0
+				if r.LoadTest.TotalRequests > 0 {
+					successRate = float64(r.LoadTest.Successful) / float64(r.LoadTest.TotalRequests) * 100
 				}
-				sb.WriteString(fmt.Sprintf(" %s %s |", status, r.Health.Status))
-			} else {
-				sb.WriteString(" - |")
+				sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f,%d,%d\n",
+					r.Timestamp.Format("2006-01-02T15:04:05"),
+					r.LoadTest.RPS, successRate,
+					r.LoadTest.TotalRequests, r.LoadTest.Failed))
 			}
 		}
-		sb.WriteString(" - |\n")
+		sb.WriteString("```\n\n")
+	}
 
-		// Response Time
-		sb.WriteString("| Response (ms) |")
-		var firstResp, lastResp float64
-		for i, r := range results {
-			if r.Health != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.Health.ResponseMs))
-				if i == 0 {
-					firstResp = r.Health.ResponseMs
-				}
-				lastResp = r.Health.ResponseMs
-			} else {
-				sb.WriteString(" - |")
+	if hasLoadTest(results) {
+		writeLatencyHeatmapCSV(&sb, results)
+		writeStepLoadCSV(&sb, results)
+	}
+
+	if hasFrontend(results) {
+		sb.WriteString("### Frontend Assets Over Time\n\n")
+		sb.WriteString("Columns: Timestamp, Total_Size_KB (combined size of all frontend assets in kilobytes), Total_Time_ms (time to download all assets in milliseconds).\n\n")
+		sb.WriteString("```csv\n")
+		sb.WriteString("Timestamp,Total_Size_KB,Total_Time_ms\n")
+		for _, r := range results {
+			if r.Frontend != nil {
+				sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f\n",
+					r.Timestamp.Format("2006-01-02T15:04:05"),
+					r.Frontend.TotalSizeKB, r.Frontend.TotalTimeMs))
 			}
 		}
-		sb.WriteString(formatDelta(lastResp, firstResp) + " |\n")
-		sb.WriteString("\n")
+		sb.WriteString("```\n\n")
 	}
 
-	// API Endpoints Comparison
 	if hasEndpoints(results) {
-		sb.WriteString("## API Endpoint Performance Comparison\n\n")
-		sb.WriteString("API endpoint testing measures the response time of individual authenticated endpoints. These tests verify that the application's core functionality is performing correctly under normal load.\n\n")
-		sb.WriteString("Each endpoint was tested with a single request to measure baseline performance. Response times under 100ms are generally considered excellent.\n\n")
-
-		// Collect all unique endpoints across all runs
+		sb.WriteString("### API Endpoints Over Time\n\n")
+		sb.WriteString("Columns: Timestamp, then response time in milliseconds for each API endpoint tested.\n\n")
 		endpointPaths := collectEndpointPaths(results)
-
 		if len(endpointPaths) > 0 {
-			sb.WriteString("| Endpoint |")
-			for i := range results {
-				sb.WriteString(fmt.Sprintf(" Run %d (ms) |", i+1))
-			}
-			sb.WriteString(" Δ (Last vs First) |\n")
-
-			sb.WriteString("|----------|")
-			for range results {
-				sb.WriteString("------------:|")
-			}
-			sb.WriteString("---------------:|\n")
-
+			sb.WriteString("```csv\n")
+			sb.WriteString("Timestamp")
 			for _, path := range endpointPaths {
-				sb.WriteString(fmt.Sprintf("| `%s` |", path))
-				var firstVal, lastVal float64
-				var firstSet bool
-				for i, r := range results {
+				sb.WriteString(fmt.Sprintf(",%s_ms", path))
+			}
+			sb.WriteString("\n")
+			for _, r := range results {
+				sb.WriteString(r.Timestamp.Format("2006-01-02T15:04:05"))
+				for _, path := range endpointPaths {
 					val, found := getEndpointResponseTime(r, path)
 					if found {
-						sb.WriteString(fmt.Sprintf(" %.2f |", val))
-						if !firstSet {
-							firstVal = val
-							firstSet = true
-						}
-						lastVal = val
+						sb.WriteString(fmt.Sprintf(",%.2f", val))
 					} else {
-						sb.WriteString(" - |")
+						sb.WriteString(",")
 					}
-					_ = i
-				}
-				if firstSet {
-					sb.WriteString(formatDelta(lastVal, firstVal) + " |\n")
-				} else {
-					sb.WriteString(" - |\n")
 				}
+				sb.WriteString("\n")
 			}
-			sb.WriteString("\n")
+			sb.WriteString("```\n\n")
 		}
 	}
 
-	// Frontend Comparison
-	if hasFrontend(results) {
-		sb.WriteString("## Frontend Assets Comparison\n\n")
-		sb.WriteString("Frontend metrics measure the size and load time of the web application's static assets (HTML, JavaScript, CSS). These directly impact user experience, especially on slower connections or mobile devices.\n\n")
-		sb.WriteString("- **Total Size (KB)**: Combined size of all frontend assets in kilobytes. Smaller bundles load faster and use less bandwidth. Size increases may indicate new features or inefficient bundling.\n")
-		sb.WriteString("- **Total Time (ms)**: Time to download all frontend assets in milliseconds. Affected by both bundle size and server response time.\n\n")
-		sb.WriteString("| Metric |")
-		for i := range results {
-			sb.WriteString(fmt.Sprintf(" Run %d |", i+1))
-		}
-		sb.WriteString(" Δ (Last vs First) |\n")
-
-		sb.WriteString("|--------|")
-		for range results {
-			sb.WriteString("-------:|")
-		}
-		sb.WriteString("---------------:|\n")
-
-		// Total Size
-		sb.WriteString("| Total Size (KB) |")
-		var firstSize, lastSize float64
-		for i, r := range results {
-			if r.Frontend != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.Frontend.TotalSizeKB))
-				if i == 0 {
-					firstSize = r.Frontend.TotalSizeKB
-				}
-				lastSize = r.Frontend.TotalSizeKB
-			} else {
-				sb.WriteString(" - |")
-			}
-		}
-		sb.WriteString(formatDeltaSize(lastSize, firstSize) + " |\n")
+	// Summary
+	sb.WriteString("## Summary\n\n")
+	score := computeHealthScore(results, c.thresholds)
+	sb.WriteString(fmt.Sprintf("**Benchmark Health Score: %.0f/100** %s\n\n", score, healthScoreRating(score)))
+	sb.WriteString("The score starts at 100 and loses 10 points per threshold alert, 5 per degraded or failed run, 15 each for a declining RPS or rising p95 latency trend, and 5 if the frontend grew between the first and last run.\n\n")
+	sb.WriteString("### Legend\n\n")
+	sb.WriteString("- **Δ (Delta)**: Change from first run to last run\n")
+	sb.WriteString("- 🟢 Improvement (faster/smaller)\n")
+	sb.WriteString("- 🔴 Regression (slower/larger)\n")
+	sb.WriteString("- ⚪ No significant change\n")
+	sb.WriteString("- 📈/📉 Consistent linear trend across all runs (shown only when R² > 0.7)\n\n")
 
-		// Total Time
-		sb.WriteString("| Total Time (ms) |")
-		var firstTime, lastTime float64
-		for i, r := range results {
-			if r.Frontend != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.Frontend.TotalTimeMs))
-				if i == 0 {
-					firstTime = r.Frontend.TotalTimeMs
-				}
-				lastTime = r.Frontend.TotalTimeMs
-			} else {
-				sb.WriteString(" - |")
-			}
+	sb.WriteString("### Threshold Configuration\n\n")
+	if c.thresholdFile != "" {
+		sb.WriteString(fmt.Sprintf("- Threshold File: `%s`\n", c.thresholdFile))
+	}
+	sb.WriteString(fmt.Sprintf("- p95 Latency Max: %.0f ms\n", c.thresholds.LatencyP95MaxMs))
+	sb.WriteString(fmt.Sprintf("- p99 Latency Max: %.0f ms\n", c.thresholds.LatencyP99MaxMs))
+	sb.WriteString(fmt.Sprintf("- Error Rate Max: %.1f%%\n", c.thresholds.ErrorRateMaxPct))
+	sb.WriteString(fmt.Sprintf("- RPS Minimum: %.0f\n", c.thresholds.RPSMinimum))
+	sb.WriteString(fmt.Sprintf("- Health Response Max: %.0f ms\n", c.thresholds.HealthResponseMax))
+	if c.thresholds.DBOperationMaxMs > 0 {
+		sb.WriteString(fmt.Sprintf("- Database Operation Max: %.0f ms\n", c.thresholds.DBOperationMaxMs))
+	}
+	if c.thresholds.SerializationMaxMs > 0 {
+		sb.WriteString(fmt.Sprintf("- Serialization Operation Max: %.2f ms\n", c.thresholds.SerializationMaxMs))
+	}
+	sb.WriteString("\n")
+
+	// Footer
+	sb.WriteString("---\n\n")
+	sb.WriteString(fmt.Sprintf("*Comparison report generated by actalog-bench at %s*\n",
+		time.Now().Format("2006-01-02 15:04:05 MST")))
+
+	// Create parent directories if needed
+	if c.outputDir != "" && c.outputDir != "." {
+		if err := os.MkdirAll(c.outputDir, 0755); err != nil {
+			return "", fmt.Errorf("create directory: %w", err)
 		}
-		sb.WriteString(formatDelta(lastTime, firstTime) + " |\n")
-		sb.WriteString("\n")
+	}
 
-		// Individual Assets section
-		assetPaths := collectAssetPaths(results)
-		if len(assetPaths) > 0 {
-			sb.WriteString("### Individual Asset Performance\n\n")
-			sb.WriteString("This table shows the size and load time for each individual frontend asset. Large or slow-loading assets are good candidates for optimization.\n\n")
+	// Write file
+	if err := os.WriteFile(outputPath, []byte(sb.String()), 0644); err != nil {
+		return "", fmt.Errorf("write comparison file: %w", err)
+	}
 
-			sb.WriteString("| Asset |")
-			for i := range results {
-				sb.WriteString(fmt.Sprintf(" Run %d Size (KB) | Run %d Time (ms) |", i+1, i+1))
-			}
-			sb.WriteString("\n")
+	return outputPath, nil
+}
 
-			sb.WriteString("|-------|")
-			for range results {
-				sb.WriteString("--------------:|---------------:|")
-			}
-			sb.WriteString("\n")
+// tableRow is a single metric row in a comparison table
+type tableRow struct {
+	label string
+	cells []string // One cell per run
+	delta string   // Change from first to last run, "-" when not applicable
+}
 
-			for _, path := range assetPaths {
-				sb.WriteString(fmt.Sprintf("| `%s` |", path))
-				for _, r := range results {
-					size, timeMs, found := getAssetMetrics(r, path)
-					if found {
-						sb.WriteString(fmt.Sprintf(" %.2f | %.2f |", size, timeMs))
-					} else {
-						sb.WriteString(" - | - |")
-					}
-				}
-				sb.WriteString("\n")
-			}
-			sb.WriteString("\n")
+// isRegression reports whether a delta cell marks a regression
+func isRegression(delta string) bool {
+	return strings.HasPrefix(delta, "🔴")
+}
+
+// hasValue reports whether any run has a value in the row
+func hasValue(row tableRow) bool {
+	return slices.ContainsFunc(row.cells, func(cell string) bool { return cell != "-" })
+}
+
+// hasRegression reports whether any row shows a regression
+func hasRegression(rows []tableRow) bool {
+	for _, row := range rows {
+		if isRegression(row.delta) {
+			return true
 		}
 	}
+	return false
+}
 
-	// Load Test Comparison
-	if hasLoadTest(results) {
-		sb.WriteString("## Load Test Comparison\n\n")
-		sb.WriteString("Load testing simulates multiple concurrent users making requests to measure how the server performs under stress. These metrics are critical for understanding capacity and identifying performance bottlenecks.\n\n")
-		sb.WriteString("### Configuration & Throughput\n\n")
-		sb.WriteString("- **Concurrent**: Number of simultaneous users (goroutines) making requests during the test.\n")
-		sb.WriteString("- **Duration (sec)**: How long the load test ran in seconds.\n")
-		sb.WriteString("- **Total Requests**: Total number of HTTP requests made during the test.\n")
-		sb.WriteString("- **Successful**: Number of requests that returned HTTP 2xx responses.\n")
-		sb.WriteString("- **Failed**: Number of requests that failed or returned error responses.\n")
-		sb.WriteString("- **RPS (Requests Per Second)**: Throughput measure showing how many requests the server can handle per second. Higher values indicate better capacity.\n")
-		sb.WriteString("- **Success Rate**: Percentage of requests that completed successfully. Values below 99% may indicate server overload.\n\n")
-		sb.WriteString("### Latency Distribution\n\n")
-		sb.WriteString("- **Min Latency**: Fastest response time observed during the test.\n")
-		sb.WriteString("- **p50 Latency (50th Percentile)**: The median response time—50% of requests completed faster than this value. Represents typical user experience.\n")
-		sb.WriteString("- **p95 Latency (95th Percentile)**: 95% of requests completed faster than this value. Helps identify slower outliers that affect some users.\n")
-		sb.WriteString("- **p99 Latency (99th Percentile)**: 99% of requests completed faster than this value. Reveals worst-case scenarios and tail latency issues.\n")
-		sb.WriteString("- **Max Latency**: Slowest response time observed during the test.\n")
-		sb.WriteString("- **Avg Latency**: Arithmetic mean of all response times. Can be skewed by outliers, so percentiles are often more meaningful.\n\n")
-		sb.WriteString("| Metric |")
-		for i := range results {
-			sb.WriteString(fmt.Sprintf(" Run %d |", i+1))
-		}
-		sb.WriteString(" Δ (Last vs First) |\n")
-
-		sb.WriteString("|--------|")
-		for range results {
-			sb.WriteString("-------:|")
-		}
-		sb.WriteString("---------------:|\n")
-
-		// Concurrent
-		sb.WriteString("| Concurrent |")
-		for _, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %d |", r.LoadTest.Concurrent))
-			} else {
-				sb.WriteString(" - |")
+// percentileRows returns a load test latency row for every percentile any of
+// results recorded, in ascending order; runs without a percentile show "-"
+func percentileRows(results []*internal.BenchmarkResult) []tableRow {
+	ranks := make(map[string]float64)
+	for _, r := range results {
+		if r.LoadTest != nil {
+			for _, p := range loadTestPercentiles(r.LoadTest) {
+				ranks[p.Label] = p.Rank
 			}
 		}
-		sb.WriteString(" - |\n")
+	}
+	labels := make([]string, 0, len(ranks))
+	for label := range ranks {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool { return ranks[labels[i]] < ranks[labels[j]] })
 
-		// Duration
-		sb.WriteString("| Duration (sec) |")
-		for _, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %.0f |", r.LoadTest.DurationSec))
-			} else {
-				sb.WriteString(" - |")
+	rows := make([]tableRow, 0, len(labels))
+	for _, label := range labels {
+		rows = append(rows, metricRow(label+" Latency (ms)", results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.LoadTest == nil {
+				return 0, false
 			}
-		}
-		sb.WriteString(" - |\n")
-
-		// Total Requests
-		sb.WriteString("| Total Requests |")
-		for _, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %d |", r.LoadTest.TotalRequests))
-			} else {
-				sb.WriteString(" - |")
+			for _, p := range loadTestPercentiles(r.LoadTest) {
+				if p.Label == label {
+					return p.Ms, true
+				}
 			}
-		}
-		sb.WriteString(" - |\n")
+			return 0, false
+		}))
+	}
+	return rows
+}
 
-		// Successful
-		sb.WriteString("| Successful |")
+// errorBreakdownRows returns a failed request count row for every error type
+// any of results recorded. Runs without failures count 0; runs whose failures
+// were not broken down, such as those saved before ErrorBreakdown existed,
+// show "-".
+func errorBreakdownRows(results []*internal.BenchmarkResult) []tableRow {
+	var rows []tableRow
+	for _, errorType := range internal.ErrorTypes {
+		seen := false
 		for _, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %d |", r.LoadTest.Successful))
-			} else {
-				sb.WriteString(" - |")
+			if r.LoadTest != nil && r.LoadTest.ErrorBreakdown[errorType] > 0 {
+				seen = true
+				break
 			}
 		}
-		sb.WriteString(" - |\n")
-
-		// Failed
-		sb.WriteString("| Failed |")
-		for _, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %d |", r.LoadTest.Failed))
-			} else {
-				sb.WriteString(" - |")
+		if !seen {
+			continue
+		}
+		rows = append(rows, metricRow("Errors: "+errorType, results, "%.0f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.LoadTest == nil || (r.LoadTest.Failed > 0 && r.LoadTest.ErrorBreakdown == nil) {
+				return 0, false
 			}
+			return float64(r.LoadTest.ErrorBreakdown[errorType]), true
+		}))
+	}
+	return rows
+}
+
+// metricRow builds a row from a per-run metric getter. The delta compares the
+// last run that reported the metric against the first run, counting the
+// first run as 0 when it did not report it.
+func metricRow(label string, results []*internal.BenchmarkResult, cellFormat string,
+	delta func(last, first float64) string, get func(r *internal.BenchmarkResult) (float64, bool)) tableRow {
+	return buildMetricRow(label, results, cellFormat, delta, get, false)
+}
+
+// pathRow builds a row for a metric only some runs may have, such as an
+// endpoint or server operation. The delta compares the last run that reported
+// the metric against the first run that reported it.
+func pathRow(label string, results []*internal.BenchmarkResult, cellFormat string,
+	delta func(last, first float64) string, get func(r *internal.BenchmarkResult) (float64, bool)) tableRow {
+	return buildMetricRow(label, results, cellFormat, delta, get, true)
+}
+
+// buildMetricRow builds the row of metricRow, or of pathRow when
+// fromFirstReported is set
+func buildMetricRow(label string, results []*internal.BenchmarkResult, cellFormat string,
+	delta func(last, first float64) string, get func(r *internal.BenchmarkResult) (float64, bool), fromFirstReported bool) tableRow {
+	row := tableRow{label: label, delta: "-"}
+	var first, last float64
+	var found bool
+	for i, r := range results {
+		val, ok := get(r)
+		if !ok {
+			row.cells = append(row.cells, "-")
+			continue
+		}
+		row.cells = append(row.cells, fmt.Sprintf(cellFormat, val))
+		if i == 0 || (fromFirstReported && !found) {
+			first = val
 		}
-		sb.WriteString(" - |\n")
+		last = val
+		found = true
+	}
+	if found {
+		row.delta = delta(last, first)
+	}
+	return row
+}
 
-		// RPS
-		sb.WriteString("| RPS |")
-		var firstRPS, lastRPS float64
-		for i, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.LoadTest.RPS))
-				if i == 0 {
-					firstRPS = r.LoadTest.RPS
-				}
-				lastRPS = r.LoadTest.RPS
-			} else {
-				sb.WriteString(" - |")
-			}
+// textRow builds an informational row with no delta
+func textRow(label string, results []*internal.BenchmarkResult, get func(r *internal.BenchmarkResult) (string, bool)) tableRow {
+	row := tableRow{label: label, delta: "-"}
+	for _, r := range results {
+		if val, found := get(r); found {
+			row.cells = append(row.cells, val)
+		} else {
+			row.cells = append(row.cells, "-")
 		}
-		sb.WriteString(formatDeltaRPS(lastRPS, firstRPS) + " |\n")
+	}
+	return row
+}
 
-		// Success Rate
-		sb.WriteString("| Success Rate |")
-		for _, r := range results {
-			if r.LoadTest != nil && r.LoadTest.TotalRequests > 0 {
-				rate := float64(r.LoadTest.Successful) / float64(r.LoadTest.TotalRequests) * 100
-				sb.WriteString(fmt.Sprintf(" %.2f%% |", rate))
-			} else {
-				sb.WriteString(" - |")
+// deltaTableHeader returns the header and separator lines for a table with
+// one column per run, runWidth dashes wide, and a trailing delta column
+func deltaTableHeader(label, runSuffix string, runWidth, runs int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("| %s |", label))
+	for i := 0; i < runs; i++ {
+		sb.WriteString(fmt.Sprintf(" Run %d%s |", i+1, runSuffix))
+	}
+	sb.WriteString(" Δ (Last vs First) |\n")
+
+	sb.WriteString("|" + strings.Repeat("-", len(label)+2) + "|")
+	for i := 0; i < runs; i++ {
+		sb.WriteString(strings.Repeat("-", runWidth) + ":|")
+	}
+	sb.WriteString("---------------:|\n")
+	return sb.String()
+}
+
+// writeSectionHeading writes a section heading. In regressions-only mode,
+// sections without any regression get a note instead of their content and
+// the function returns false so the caller can skip the rest.
+func (c *Comparison) writeSectionHeading(sb *strings.Builder, heading string, rows []tableRow) bool {
+	sb.WriteString(heading + "\n\n")
+	if c.regressionsOnly && !hasRegression(rows) {
+		sb.WriteString("*No regressions detected in this section*\n\n")
+		return false
+	}
+	return true
+}
+
+// writeTable writes rows below the given header, dropping rows without a
+// regression when regressions-only mode is enabled
+func (c *Comparison) writeTable(sb *strings.Builder, header string, rows []tableRow) {
+	if c.regressionsOnly {
+		var filtered []tableRow
+		for _, row := range rows {
+			if isRegression(row.delta) {
+				filtered = append(filtered, row)
 			}
 		}
-		sb.WriteString(" - |\n")
+		if len(filtered) == 0 {
+			sb.WriteString("*No regressions detected in this section*\n\n")
+			return
+		}
+		rows = filtered
+	}
 
-		// Min Latency
-		sb.WriteString("| Min Latency (ms) |")
-		var firstMin, lastMin float64
-		for i, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.LoadTest.MinLatencyMs))
-				if i == 0 {
-					firstMin = r.LoadTest.MinLatencyMs
-				}
-				lastMin = r.LoadTest.MinLatencyMs
-			} else {
-				sb.WriteString(" - |")
-			}
+	sb.WriteString(header)
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf("| %s |", row.label))
+		for _, cell := range row.cells {
+			sb.WriteString(fmt.Sprintf(" %s |", cell))
 		}
-		sb.WriteString(formatDelta(lastMin, firstMin) + " |\n")
+		sb.WriteString(fmt.Sprintf(" %s |\n", row.delta))
+	}
+	sb.WriteString("\n")
+}
 
-		// p50 Latency
-		sb.WriteString("| p50 Latency (ms) |")
-		var firstP50, lastP50 float64
-		for i, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.LoadTest.LatencyP50Ms))
-				if i == 0 {
-					firstP50 = r.LoadTest.LatencyP50Ms
-				}
-				lastP50 = r.LoadTest.LatencyP50Ms
-			} else {
-				sb.WriteString(" - |")
+func (c *Comparison) writeConnectivitySection(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	conn := func(get func(*internal.ConnectivityResult) float64) func(*internal.BenchmarkResult) (float64, bool) {
+		return func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.Connectivity == nil {
+				return 0, false
 			}
+			return get(r.Connectivity), true
 		}
-		sb.WriteString(formatDelta(lastP50, firstP50) + " |\n")
+	}
 
-		// p95 Latency
-		sb.WriteString("| p95 Latency (ms) |")
-		var firstP95, lastP95 float64
-		for i, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.LoadTest.LatencyP95Ms))
-				if i == 0 {
-					firstP95 = r.LoadTest.LatencyP95Ms
-				}
-				lastP95 = r.LoadTest.LatencyP95Ms
-			} else {
-				sb.WriteString(" - |")
+	dns := conn(func(cr *internal.ConnectivityResult) float64 { return cr.DNSMs })
+	tcp := conn(func(cr *internal.ConnectivityResult) float64 { return cr.TCPMs })
+
+	rows := []tableRow{
+		metricRow("DNS (ms)", results, "%.2f", formatDelta, dns),
+		metricRow("TCP (ms)", results, "%.2f", formatDelta, tcp),
+		metricRow("TLS (ms)", results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.Connectivity == nil || r.Connectivity.TLSMs <= 0 {
+				return 0, false
 			}
-		}
-		sb.WriteString(formatDelta(lastP95, firstP95) + " |\n")
+			return r.Connectivity.TLSMs, true
+		}),
+	}
 
-		// p99 Latency
-		sb.WriteString("| p99 Latency (ms) |")
-		var firstP99, lastP99 float64
-		for i, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.LoadTest.LatencyP99Ms))
-				if i == 0 {
-					firstP99 = r.LoadTest.LatencyP99Ms
-				}
-				lastP99 = r.LoadTest.LatencyP99Ms
-			} else {
-				sb.WriteString(" - |")
+	// Address family and HTTP/3 rows only when some run measured them
+	familyRows := []tableRow{
+		metricRow("IPv4 (ms)", results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.Connectivity == nil || r.Connectivity.IPv4Ms <= 0 {
+				return 0, false
 			}
+			return r.Connectivity.IPv4Ms, true
+		}),
+		metricRow("IPv6 (ms)", results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.Connectivity == nil || r.Connectivity.IPv6Ms <= 0 {
+				return 0, false
+			}
+			return r.Connectivity.IPv6Ms, true
+		}),
+	}
+	showFamilies := false
+	for _, row := range familyRows {
+		if hasValue(row) {
+			rows = append(rows, row)
+			showFamilies = true
+		}
+	}
+	http3Row := metricRow("HTTP/3 (ms)", results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+		if r.Connectivity == nil || !r.Connectivity.HTTP3Supported {
+			return 0, false
 		}
-		sb.WriteString(formatDelta(lastP99, firstP99) + " |\n")
+		return r.Connectivity.HTTP3Ms, true
+	})
+	showHTTP3 := hasValue(http3Row)
+	if showHTTP3 {
+		rows = append(rows, http3Row)
+	}
+	rows = append(rows, metricRow("**Total (ms)**", results, "**%.2f**", formatDelta, conn(func(cr *internal.ConnectivityResult) float64 { return cr.TotalMs })))
 
-		// Max Latency
-		sb.WriteString("| Max Latency (ms) |")
-		var firstMax, lastMax float64
-		for i, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.LoadTest.MaxLatencyMs))
-				if i == 0 {
-					firstMax = r.LoadTest.MaxLatencyMs
-				}
-				lastMax = r.LoadTest.MaxLatencyMs
-			} else {
-				sb.WriteString(" - |")
-			}
+	if c.writeSectionHeading(sb, "## Connectivity Comparison", rows) {
+		sb.WriteString("Connectivity metrics measure the time required to establish a network connection to the server. These timings are critical for understanding baseline network latency before any application logic is involved.\n\n")
+		sb.WriteString("- **DNS (Domain Name System)**: Time to resolve the server's hostname to an IP address. High values may indicate DNS server issues or network congestion.\n")
+		sb.WriteString("- **TCP (Transmission Control Protocol)**: Time to establish a TCP connection (the \"three-way handshake\"). This reflects network round-trip latency.\n")
+		sb.WriteString("- **TLS (Transport Layer Security)**: Time for the secure handshake that establishes encrypted HTTPS connections. Includes certificate verification.\n")
+		if showFamilies {
+			sb.WriteString("- **IPv4 / IPv6**: TCP connect time to the first address of each family, shown only when the server resolved to and accepted connections on that family.\n")
+		}
+		if showHTTP3 {
+			sb.WriteString("- **HTTP/3**: Time to the first byte of a request over QUIC, including its combined transport and TLS handshake, for runs with `--probe-http3` against a server that supports it.\n")
 		}
-		sb.WriteString(formatDelta(lastMax, firstMax) + " |\n")
+		sb.WriteString("- **Total**: Combined time for all connectivity steps. Lower values indicate faster initial connection establishment.\n\n")
+		c.writeTable(sb, deltaTableHeader("Metric", "", 7, len(results)), rows)
+		writeTrends(sb, results, []trendMetric{{"DNS", "ms", dns}, {"TCP", "ms", tcp}})
+	}
 
-		// Avg Latency
-		sb.WriteString("| Avg Latency (ms) |")
-		var firstAvg, lastAvg float64
-		for i, r := range results {
-			if r.LoadTest != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.LoadTest.AvgLatencyMs))
-				if i == 0 {
-					firstAvg = r.LoadTest.AvgLatencyMs
-				}
-				lastAvg = r.LoadTest.AvgLatencyMs
-			} else {
-				sb.WriteString(" - |")
-			}
+	// The notes explain timing shifts, so regressions-only mode keeps them
+	if changes := ipv6SupportChanges(results); len(changes) > 0 {
+		sb.WriteString("**IPv6 support changes:**\n\n")
+		for _, change := range changes {
+			sb.WriteString(fmt.Sprintf("- %s\n", change))
 		}
-		sb.WriteString(formatDelta(lastAvg, firstAvg) + " |\n")
 		sb.WriteString("\n")
 	}
 
-	// Server-Side Benchmark API Comparison
-	if hasBenchmarkAPI(results) {
-		sb.WriteString("## Server-Side Benchmark Comparison\n\n")
-		sb.WriteString("The server-side benchmark tests internal ActaLog operations directly on the server, measuring database queries, JSON serialization, and business logic calculations independent of network latency.\n\n")
-
-		// System Information Overview
-		sb.WriteString("### System Information\n\n")
-		sb.WriteString("This table shows the server environment for each benchmark run. Changes in system configuration can significantly impact performance results.\n\n")
-		sb.WriteString("| Property |")
-		for i := range results {
-			sb.WriteString(fmt.Sprintf(" Run %d |", i+1))
+	if changes := connectivityChanges(results, resolverChangeFormat, resolverName); len(changes) > 0 {
+		sb.WriteString("**DNS resolver changes** (a different resolver may return different addresses and explain DNS or TCP timing shifts):\n\n")
+		for _, change := range changes {
+			sb.WriteString(fmt.Sprintf("- %s\n", change))
 		}
 		sb.WriteString("\n")
+	}
 
-		sb.WriteString("|----------|")
-		for range results {
-			sb.WriteString("--------|")
+	if changes := connectivityChanges(results, boundToChangeFormat, boundToName); len(changes) > 0 {
+		sb.WriteString("**Local address changes** (runs made from different interfaces, such as through a service mesh sidecar and directly, differ by that path's overhead):\n\n")
+		for _, change := range changes {
+			sb.WriteString(fmt.Sprintf("- %s\n", change))
 		}
 		sb.WriteString("\n")
+	}
+}
 
-		// ActaLog Version
-		sb.WriteString("| ActaLog Version |")
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil {
-				sb.WriteString(fmt.Sprintf(" %s |", r.BenchmarkAPI.Response.Version))
-			} else {
-				sb.WriteString(" - |")
-			}
+// warmupChanges describes runs whose load test warmup differed from the
+// previous run with a load test
+func warmupChanges(results []*internal.BenchmarkResult) []string {
+	warmupName := func(lt *internal.LoadTestResult) string {
+		if lt.WarmupSec == 0 {
+			return "no warmup"
 		}
-		sb.WriteString("\n")
+		return fmt.Sprintf("a %.0fs warmup", lt.WarmupSec)
+	}
 
-		// Go Version
-		sb.WriteString("| Go Version |")
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil && r.BenchmarkAPI.Response.SystemInfo != nil {
-				sb.WriteString(fmt.Sprintf(" %s |", r.BenchmarkAPI.Response.SystemInfo.GoVersion))
-			} else {
-				sb.WriteString(" - |")
+	var changes []string
+	prevIdx := -1
+	for i, r := range results {
+		if r.LoadTest == nil {
+			continue
+		}
+		if prevIdx >= 0 {
+			prev := results[prevIdx].LoadTest
+			if prev.WarmupSec != r.LoadTest.WarmupSec {
+				changes = append(changes, fmt.Sprintf("Run %d had %s (Run %d had %s)", i+1, warmupName(r.LoadTest), prevIdx+1, warmupName(prev)))
 			}
 		}
-		sb.WriteString("\n")
+		prevIdx = i
+	}
+	return changes
+}
 
-		// Platform
-		sb.WriteString("| Platform |")
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil && r.BenchmarkAPI.Response.SystemInfo != nil {
-				sb.WriteString(fmt.Sprintf(" %s/%s |", r.BenchmarkAPI.Response.SystemInfo.GoOS, r.BenchmarkAPI.Response.SystemInfo.GoArch))
-			} else {
-				sb.WriteString(" - |")
+// Change lines of connectivityChanges for the DNS resolver and local address
+const (
+	resolverChangeFormat = "Run %d used %s (Run %d used %s)"
+	boundToChangeFormat  = "Run %d connected from %s (Run %d from %s)"
+)
+
+// connectivityChanges describes runs whose connection setting, as named by
+// describe, differs from the previous run that measured connectivity. format
+// takes the run number and setting of the run, then those of the previous run.
+func connectivityChanges(results []*internal.BenchmarkResult, format string, describe func(cr *internal.ConnectivityResult) string) []string {
+	var changes []string
+	prevIdx := -1
+	for i, r := range results {
+		if r.Connectivity == nil {
+			continue
+		}
+		if prevIdx >= 0 {
+			prev, cur := describe(results[prevIdx].Connectivity), describe(r.Connectivity)
+			if prev != cur {
+				changes = append(changes, fmt.Sprintf(format, i+1, cur, prevIdx+1, prev))
 			}
 		}
-		sb.WriteString("\n")
+		prevIdx = i
+	}
+	return changes
+}
 
-		// OS Version
-		sb.WriteString("| OS Version |")
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil && r.BenchmarkAPI.Response.SystemInfo != nil && r.BenchmarkAPI.Response.SystemInfo.OSVersion != "" {
-				osVer := r.BenchmarkAPI.Response.SystemInfo.OSVersion
-				if len(osVer) > 40 {
-					osVer = osVer[:37] + "..."
-				}
-				sb.WriteString(fmt.Sprintf(" %s |", osVer))
-			} else {
-				sb.WriteString(" - |")
+// boundToName names the local address a run connected from
+func boundToName(cr *internal.ConnectivityResult) string {
+	if cr.BoundTo == "" {
+		return "the default interface"
+	}
+	return "`" + cr.BoundTo + "`"
+}
+
+// resolverName names the DNS server or DNS-over-HTTPS endpoint a run
+// resolved the target with
+func resolverName(cr *internal.ConnectivityResult) string {
+	switch {
+	case cr.DOHServer != "":
+		return "DNS-over-HTTPS `" + cr.DOHServer + "`"
+	case cr.DNSResolver == "":
+		return "system resolver"
+	default:
+		return "`" + cr.DNSResolver + "`"
+	}
+}
+
+// ipv6SupportChanges describes runs where IPv6 connectivity appeared or disappeared
+// relative to the previous run that measured connectivity
+func ipv6SupportChanges(results []*internal.BenchmarkResult) []string {
+	var changes []string
+	prevIdx := -1
+	for i, r := range results {
+		if r.Connectivity == nil || r.Connectivity.Error != "" {
+			continue
+		}
+		if prevIdx >= 0 {
+			had := results[prevIdx].Connectivity.IPv6Ms > 0
+			has := r.Connectivity.IPv6Ms > 0
+			if !had && has {
+				changes = append(changes, fmt.Sprintf("🟢 IPv6 connectivity appeared in Run %d (not available in Run %d)", i+1, prevIdx+1))
+			} else if had && !has {
+				changes = append(changes, fmt.Sprintf("🔴 IPv6 connectivity disappeared in Run %d (available in Run %d)", i+1, prevIdx+1))
 			}
 		}
-		sb.WriteString("\n")
+		prevIdx = i
+	}
+	return changes
+}
 
-		// CPUs
-		sb.WriteString("| CPUs |")
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil && r.BenchmarkAPI.Response.SystemInfo != nil {
-				sb.WriteString(fmt.Sprintf(" %d |", r.BenchmarkAPI.Response.SystemInfo.NumCPU))
-			} else {
-				sb.WriteString(" - |")
-			}
+func (c *Comparison) writeHealthSection(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	response := func(r *internal.BenchmarkResult) (float64, bool) {
+		if r.Health == nil {
+			return 0, false
 		}
-		sb.WriteString("\n")
+		return r.Health.ResponseMs, true
+	}
+	connections := func(r *internal.BenchmarkResult) (float64, bool) {
+		if r.Health == nil || r.Health.ActiveConnections == 0 {
+			return 0, false
+		}
+		return float64(r.Health.ActiveConnections), true
+	}
 
-		// Database
-		sb.WriteString("| Database |")
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil && r.BenchmarkAPI.Response.SystemInfo != nil {
-				sb.WriteString(fmt.Sprintf(" %s %s |", r.BenchmarkAPI.Response.SystemInfo.DatabaseDriver, r.BenchmarkAPI.Response.SystemInfo.DatabaseVersion))
-			} else {
-				sb.WriteString(" - |")
+	rows := []tableRow{
+		textRow("Status", results, func(r *internal.BenchmarkResult) (string, bool) {
+			if r.Health == nil {
+				return "", false
+			}
+			status := "✅"
+			if r.Health.Status != "healthy" {
+				status = "❌"
 			}
+			return status + " " + r.Health.Status, true
+		}),
+		metricRow("Response (ms)", results, "%.2f", formatDelta, response),
+	}
+	// Only for servers whose health endpoint reports connections
+	hasConnections := false
+	for _, r := range results {
+		if _, ok := connections(r); ok {
+			hasConnections = true
+			break
 		}
-		sb.WriteString("\n\n")
+	}
+	if hasConnections {
+		rows = append(rows, pathRow("Active Connections", results, "%.0f", formatDeltaCount, connections))
+	}
 
-		// Benchmark Summary
-		sb.WriteString("### Benchmark Summary\n\n")
-		sb.WriteString("| Metric |")
-		for i := range results {
-			sb.WriteString(fmt.Sprintf(" Run %d |", i+1))
-		}
-		sb.WriteString(" Δ (Last vs First) |\n")
+	if !c.writeSectionHeading(sb, "## Health Check Comparison", rows) {
+		return
+	}
+	sb.WriteString("The health check endpoint (`/health`) provides a quick verification that the application is running and can respond to requests. This is the most basic availability test.\n\n")
+	sb.WriteString("- **Status**: Whether the application reports itself as healthy. A healthy status indicates the server is operational and database connections are working.\n")
+	sb.WriteString("- **Response Time**: How quickly the health endpoint responds. This measures basic application responsiveness without complex business logic.\n")
+	trends := []trendMetric{{"Health Response", "ms", response}}
+	if hasConnections {
+		sb.WriteString("- **Active Connections**: Open connections reported by the server. Shows - for runs whose health endpoint did not include them. More connections is neither better nor worse.\n")
+		trends = append(trends, trendMetric{"Active Connections", "conns", connections})
+	}
+	sb.WriteString("\n")
+	c.writeTable(sb, deltaTableHeader("Metric", "", 7, len(results)), rows)
+	writeTrends(sb, results, trends)
+}
 
-		sb.WriteString("|--------|")
-		for range results {
-			sb.WriteString("-------:|")
-		}
-		sb.WriteString("---------------:|\n")
+func (c *Comparison) writeEndpointsSection(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	// Collect all unique endpoints across all runs
+	endpointPaths := collectEndpointPaths(results)
 
-		// Overall Status
-		sb.WriteString("| Overall |")
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil {
-				status := "✅ " + r.BenchmarkAPI.Response.Overall
-				if r.BenchmarkAPI.Response.Overall != "pass" {
-					status = "❌ " + r.BenchmarkAPI.Response.Overall
+	var rows []tableRow
+	for _, path := range endpointPaths {
+		path := path
+		rows = append(rows, pathRow(fmt.Sprintf("`%s`", path), results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+			return getEndpointResponseTime(r, path)
+		}))
+	}
+
+	// TTFB rows only for endpoints that recorded it in some run
+	var ttfbRows []tableRow
+	for _, path := range endpointPaths {
+		row := pathRow(fmt.Sprintf("`%s`", path), results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+			for _, ep := range r.Endpoints {
+				if ep.Path == path && ep.TTFBMs > 0 {
+					return ep.TTFBMs, true
 				}
-				sb.WriteString(fmt.Sprintf(" %s |", status))
-			} else {
-				sb.WriteString(" - |")
 			}
+			return 0, false
+		})
+		if hasValue(row) {
+			ttfbRows = append(ttfbRows, row)
 		}
-		sb.WriteString(" - |\n")
+	}
 
-		// Total Duration
-		sb.WriteString("| Duration (ms) |")
-		var firstDur, lastDur float64
-		var firstDurSet bool
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil {
-				sb.WriteString(fmt.Sprintf(" %.2f |", r.BenchmarkAPI.Response.TotalDurationMs))
-				if !firstDurSet {
-					firstDur = r.BenchmarkAPI.Response.TotalDurationMs
-					firstDurSet = true
+	if !c.writeSectionHeading(sb, "## API Endpoint Performance Comparison", append(rows, ttfbRows...)) {
+		return
+	}
+	sb.WriteString("API endpoint testing measures the response time of individual authenticated endpoints. These tests verify that the application's core functionality is performing correctly under normal load.\n\n")
+	sb.WriteString("Each endpoint was tested with a single request to measure baseline performance. Response times under 100ms are generally considered excellent.\n\n")
+
+	if len(rows) > 0 {
+		c.writeTable(sb, deltaTableHeader("Endpoint", " (ms)", 12, len(results)), rows)
+	}
+
+	if len(ttfbRows) > 0 {
+		sb.WriteString("### Time to First Byte\n\n")
+		sb.WriteString("Time from sending each request to the first byte of its response, before the body is downloaded. A rising TTFB points at slower server processing; a rising response time with a steady TTFB points at larger responses.\n\n")
+		c.writeTable(sb, deltaTableHeader("Endpoint", " (ms)", 12, len(results)), ttfbRows)
+	}
+
+	if alerts := newlyDeprecatedEndpoints(results); len(alerts) > 0 {
+		sb.WriteString("**Newly deprecated endpoints:**\n\n")
+		for _, alert := range alerts {
+			sb.WriteString(fmt.Sprintf("- %s\n", alert))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// newlyDeprecatedEndpoints describes endpoints that announced deprecation in a
+// run after the previous run that tested them did not
+func newlyDeprecatedEndpoints(results []*internal.BenchmarkResult) []string {
+	var alerts []string
+	lastSeen := make(map[string]int) // Endpoint label -> index of the last run that tested it
+	for i, r := range results {
+		for _, ep := range r.Endpoints {
+			label := endpointLabel(ep)
+			if prev, ok := lastSeen[label]; ok && ep.Deprecated && !endpointDeprecated(results[prev], label) {
+				alert := fmt.Sprintf("⚠️ `%s` deprecated in Run %d (not in Run %d)", label, i+1, prev+1)
+				if ep.SunsetDate != "" {
+					alert += ", sunset " + ep.SunsetDate
 				}
-				lastDur = r.BenchmarkAPI.Response.TotalDurationMs
-			} else {
-				sb.WriteString(" - |")
+				alerts = append(alerts, alert)
 			}
+			lastSeen[label] = i
 		}
-		if firstDurSet {
-			sb.WriteString(formatDelta(lastDur, firstDur) + " |\n")
-		} else {
-			sb.WriteString(" - |\n")
-		}
+	}
+	return alerts
+}
 
-		// Total Operations
-		sb.WriteString("| Total Ops |")
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil {
-				sb.WriteString(fmt.Sprintf(" %d |", r.BenchmarkAPI.Response.TotalOperations))
-			} else {
-				sb.WriteString(" - |")
-			}
+// endpointDeprecated reports whether the endpoint with label was deprecated in r
+func endpointDeprecated(r *internal.BenchmarkResult, label string) bool {
+	for _, ep := range r.Endpoints {
+		if endpointLabel(ep) == label {
+			return ep.Deprecated
 		}
-		sb.WriteString(" - |\n")
+	}
+	return false
+}
 
-		// Successful Operations
-		sb.WriteString("| Successful |")
-		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil {
-				sb.WriteString(fmt.Sprintf(" %d |", r.BenchmarkAPI.Response.SuccessfulOperations))
-			} else {
-				sb.WriteString(" - |")
+func (c *Comparison) writeFrontendSection(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	rows := []tableRow{
+		metricRow("Total Size (KB)", results, "%.2f", formatDeltaSize, func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.Frontend == nil {
+				return 0, false
 			}
-		}
-		sb.WriteString(" - |\n")
+			return r.Frontend.TotalSizeKB, true
+		}),
+		metricRow("Total Time (ms)", results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.Frontend == nil {
+				return 0, false
+			}
+			return r.Frontend.TotalTimeMs, true
+		}),
+	}
+
+	if !c.writeSectionHeading(sb, "## Frontend Assets Comparison", rows) {
+		return
+	}
+	sb.WriteString("Frontend metrics measure the size and load time of the web application's static assets (HTML, JavaScript, CSS). These directly impact user experience, especially on slower connections or mobile devices.\n\n")
+	sb.WriteString("- **Total Size (KB)**: Combined size of all frontend assets in kilobytes. Smaller bundles load faster and use less bandwidth. Size increases may indicate new features or inefficient bundling.\n")
+	sb.WriteString("- **Total Time (ms)**: Time to download all frontend assets in milliseconds. Affected by both bundle size and server response time.\n\n")
+	c.writeTable(sb, deltaTableHeader("Metric", "", 7, len(results)), rows)
+
+	// Individual assets have no delta column, so they are omitted when only regressions are shown
+	assetPaths := collectAssetPaths(results)
+	if len(assetPaths) == 0 || c.regressionsOnly {
+		return
+	}
+
+	sb.WriteString("### Individual Asset Performance\n\n")
+	sb.WriteString("This table shows the size and load time for each individual frontend asset. Large or slow-loading assets are good candidates for optimization.\n\n")
 
-		// Failed Operations
-		sb.WriteString("| Failed |")
+	sb.WriteString("| Asset |")
+	for i := range results {
+		sb.WriteString(fmt.Sprintf(" Run %d Size (KB) | Run %d Time (ms) |", i+1, i+1))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("|-------|")
+	for range results {
+		sb.WriteString("--------------:|---------------:|")
+	}
+	sb.WriteString("\n")
+
+	for _, path := range assetPaths {
+		sb.WriteString(fmt.Sprintf("| `%s` |", path))
 		for _, r := range results {
-			if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil {
-				sb.WriteString(fmt.Sprintf(" %d |", r.BenchmarkAPI.Response.FailedOperations))
+			size, timeMs, found := getAssetMetrics(r, path)
+			if found {
+				sb.WriteString(fmt.Sprintf(" %.2f | %.2f |", size, timeMs))
 			} else {
-				sb.WriteString(" - |")
+				sb.WriteString(" - | - |")
 			}
 		}
-		sb.WriteString(" - |\n")
 		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
 
-		// Database Operations Comparison
-		if hasDBOperations(results) {
-			sb.WriteString("### Database Operations\n\n")
-			sb.WriteString("| Operation |")
-			for i := range results {
-				sb.WriteString(fmt.Sprintf(" Run %d (ms) |", i+1))
-			}
-			sb.WriteString(" Δ (Last vs First) |\n")
+	writeAssetInventoryChanges(sb, results)
+}
 
-			sb.WriteString("|-----------|")
-			for range results {
-				sb.WriteString("-----------:|")
-			}
-			sb.WriteString("---------------:|\n")
-
-			dbOpNames := collectDBOperationNames(results)
-			for _, opName := range dbOpNames {
-				sb.WriteString(fmt.Sprintf("| %s |", opName))
-				var firstVal, lastVal float64
-				var firstSet bool
-				for _, r := range results {
-					if val, found := getDBOperationDuration(r, opName); found {
-						sb.WriteString(fmt.Sprintf(" %.2f |", val))
-						if !firstSet {
-							firstVal = val
-							firstSet = true
-						}
-						lastVal = val
-					} else {
-						sb.WriteString(" - |")
-					}
-				}
-				if firstSet {
-					sb.WriteString(formatDelta(lastVal, firstVal) + " |\n")
-				} else {
-					sb.WriteString(" - |\n")
-				}
-			}
-			sb.WriteString("\n")
+// writeAssetInventoryChanges writes which asset filenames appeared or
+// disappeared between consecutive runs that recorded them. Bundlers put a
+// content hash in asset filenames, so a changed inventory marks a new build.
+func writeAssetInventoryChanges(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	var runs []int
+	for i, r := range results {
+		if r.Frontend != nil && len(r.Frontend.AssetFilenames) > 0 {
+			runs = append(runs, i)
 		}
+	}
+	if len(runs) < 2 {
+		return
+	}
 
-		// Serialization Operations Comparison
-		if hasSerializationOps(results) {
-			sb.WriteString("### Serialization Operations\n\n")
-			sb.WriteString("| Operation |")
-			for i := range results {
-				sb.WriteString(fmt.Sprintf(" Run %d (ms) |", i+1))
-			}
-			sb.WriteString(" Δ (Last vs First) |\n")
+	inventories := make([]map[string]bool, len(runs))
+	seen := make(map[string]bool)
+	for i, run := range runs {
+		inventories[i] = make(map[string]bool)
+		for _, name := range results[run].Frontend.AssetFilenames {
+			inventories[i][name] = true
+			seen[name] = true
+		}
+	}
+	all := slices.Sorted(maps.Keys(seen))
 
-			sb.WriteString("|-----------|")
-			for range results {
-				sb.WriteString("-----------:|")
-			}
-			sb.WriteString("---------------:|\n")
-
-			serOpNames := collectSerializationOpNames(results)
-			for _, opName := range serOpNames {
-				sb.WriteString(fmt.Sprintf("| %s |", opName))
-				var firstVal, lastVal float64
-				var firstSet bool
-				for _, r := range results {
-					if val, found := getSerializationOpDuration(r, opName); found {
-						sb.WriteString(fmt.Sprintf(" %.2f |", val))
-						if !firstSet {
-							firstVal = val
-							firstSet = true
-						}
-						lastVal = val
-					} else {
-						sb.WriteString(" - |")
-					}
-				}
-				if firstSet {
-					sb.WriteString(formatDelta(lastVal, firstVal) + " |\n")
-				} else {
-					sb.WriteString(" - |\n")
-				}
-			}
-			sb.WriteString("\n")
+	var deployments []string
+	for i := 1; i < len(runs); i++ {
+		if !maps.Equal(inventories[i-1], inventories[i]) {
+			deployments = append(deployments, fmt.Sprintf("Run %d → Run %d", runs[i-1]+1, runs[i]+1))
 		}
+	}
 
-		// Business Logic Operations Comparison
-		if hasBusinessLogicOps(results) {
-			sb.WriteString("### Business Logic Operations\n\n")
-			sb.WriteString("| Operation |")
-			for i := range results {
-				sb.WriteString(fmt.Sprintf(" Run %d (ms) |", i+1))
-			}
-			sb.WriteString(" Δ (Last vs First) |\n")
+	sb.WriteString("### Asset Inventory Changes\n\n")
+	if len(deployments) == 0 {
+		sb.WriteString("*Asset filenames are unchanged across all runs, so the same frontend build was served.*\n\n")
+		return
+	}
+	sb.WriteString(fmt.Sprintf("Asset filenames changed between %s, which indicates a new frontend build was deployed. ", strings.Join(deployments, ", ")))
+	sb.WriteString("🆕 marks an asset that first appeared, 🗑️ one that was removed, and ✅ one that was unchanged.\n\n")
+
+	sb.WriteString("| Asset |")
+	for i := 1; i < len(runs); i++ {
+		sb.WriteString(fmt.Sprintf(" Run %d → Run %d |", runs[i-1]+1, runs[i]+1))
+	}
+	sb.WriteString("\n|-------|")
+	for i := 1; i < len(runs); i++ {
+		sb.WriteString(":---:|")
+	}
+	sb.WriteString("\n")
 
-			sb.WriteString("|-----------|")
-			for range results {
-				sb.WriteString("-----------:|")
+	for _, name := range all {
+		sb.WriteString(fmt.Sprintf("| `%s` |", name))
+		for i := 1; i < len(runs); i++ {
+			before, after := inventories[i-1][name], inventories[i][name]
+			switch {
+			case before && after:
+				sb.WriteString(" ✅ |")
+			case after:
+				sb.WriteString(" 🆕 |")
+			case before:
+				sb.WriteString(" 🗑️ |")
+			default:
+				sb.WriteString(" - |")
 			}
-			sb.WriteString("---------------:|\n")
-
-			blOpNames := collectBusinessLogicOpNames(results)
-			for _, opName := range blOpNames {
-				sb.WriteString(fmt.Sprintf("| %s |", opName))
-				var firstVal, lastVal float64
-				var firstSet bool
-				for _, r := range results {
-					if val, found := getBusinessLogicOpDuration(r, opName); found {
-						sb.WriteString(fmt.Sprintf(" %.2f |", val))
-						if !firstSet {
-							firstVal = val
-							firstSet = true
-						}
-						lastVal = val
-					} else {
-						sb.WriteString(" - |")
-					}
-				}
-				if firstSet {
-					sb.WriteString(formatDelta(lastVal, firstVal) + " |\n")
-				} else {
-					sb.WriteString(" - |\n")
-				}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+func (c *Comparison) writeLoadTestSection(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	load := func(get func(*internal.LoadTestResult) float64) func(*internal.BenchmarkResult) (float64, bool) {
+		return func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.LoadTest == nil {
+				return 0, false
 			}
-			sb.WriteString("\n")
+			return get(r.LoadTest), true
 		}
-
-		// Concurrent Operations Comparison
-		if hasConcurrentOps(results) {
-			sb.WriteString("### Concurrent Operations\n\n")
-			sb.WriteString("| Operation |")
-			for i := range results {
-				sb.WriteString(fmt.Sprintf(" Run %d (ms) |", i+1))
+	}
+	loadText := func(get func(*internal.LoadTestResult) string) func(*internal.BenchmarkResult) (string, bool) {
+		return func(r *internal.BenchmarkResult) (string, bool) {
+			if r.LoadTest == nil {
+				return "", false
 			}
-			sb.WriteString(" Δ (Last vs First) |\n")
+			return get(r.LoadTest), true
+		}
+	}
 
-			sb.WriteString("|-----------|")
-			for range results {
-				sb.WriteString("-----------:|")
-			}
-			sb.WriteString("---------------:|\n")
-
-			concOpNames := collectConcurrentOpNames(results)
-			for _, opName := range concOpNames {
-				sb.WriteString(fmt.Sprintf("| %s |", opName))
-				var firstVal, lastVal float64
-				var firstSet bool
-				for _, r := range results {
-					if val, found := getConcurrentOpDuration(r, opName); found {
-						sb.WriteString(fmt.Sprintf(" %.2f |", val))
-						if !firstSet {
-							firstVal = val
-							firstSet = true
-						}
-						lastVal = val
-					} else {
-						sb.WriteString(" - |")
-					}
-				}
-				if firstSet {
-					sb.WriteString(formatDelta(lastVal, firstVal) + " |\n")
-				} else {
-					sb.WriteString(" - |\n")
-				}
+	// Results saved before the spread metrics existed have none to show
+	spread := func(get func(*internal.LoadTestResult) float64) func(*internal.BenchmarkResult) (float64, bool) {
+		return func(r *internal.BenchmarkResult) (float64, bool) {
+			if r.LoadTest == nil || (r.LoadTest.StdDevLatencyMs == 0 && r.LoadTest.IQRLatencyMs == 0) {
+				return 0, false
 			}
-			sb.WriteString("\n")
+			return get(r.LoadTest), true
 		}
 	}
 
-	// Threshold Alerts
-	alerts := c.checkThresholds(results)
-	if len(alerts) > 0 {
-		sb.WriteString("## ⚠️ Threshold Alerts\n\n")
-		sb.WriteString("Threshold alerts identify benchmark runs where critical performance metrics exceeded acceptable limits. These alerts help catch performance regressions before they impact users in production.\n\n")
-		sb.WriteString("The following metrics exceeded configured thresholds:\n\n")
-		for _, alert := range alerts {
-			sb.WriteString(fmt.Sprintf("- %s\n", alert))
+	rps := load(func(lt *internal.LoadTestResult) float64 { return lt.RPS })
+	p95 := load(func(lt *internal.LoadTestResult) float64 { return lt.LatencyP95Ms })
+
+	rows := []tableRow{
+		textRow("Concurrent", results, loadText(func(lt *internal.LoadTestResult) string { return fmt.Sprintf("%d", lt.Concurrent) })),
+		textRow("Duration (sec)", results, loadText(func(lt *internal.LoadTestResult) string { return fmt.Sprintf("%.0f", lt.DurationSec) })),
+	}
+	if slices.ContainsFunc(results, func(r *internal.BenchmarkResult) bool { return r.LoadTest != nil && r.LoadTest.WarmupSec > 0 }) {
+		rows = append(rows, textRow("Warmup (sec)", results, loadText(func(lt *internal.LoadTestResult) string { return fmt.Sprintf("%.0f", lt.WarmupSec) })))
+	}
+	// Think time lowers RPS by design, so runs with different pauses are not like for like
+	if slices.ContainsFunc(results, func(r *internal.BenchmarkResult) bool { return r.LoadTest != nil && r.LoadTest.ThinkTimeMs > 0 }) {
+		rows = append(rows, textRow("Think Time (ms)", results, loadText(func(lt *internal.LoadTestResult) string { return fmt.Sprintf("%.0f", lt.ThinkTimeMs) })))
+	}
+	rows = append(rows,
+		textRow("Total Requests", results, loadText(func(lt *internal.LoadTestResult) string { return fmt.Sprintf("%d", lt.TotalRequests) })),
+		textRow("Successful", results, loadText(func(lt *internal.LoadTestResult) string { return fmt.Sprintf("%d", lt.Successful) })),
+		textRow("Failed", results, loadText(func(lt *internal.LoadTestResult) string { return fmt.Sprintf("%d", lt.Failed) })),
+		metricRow("RPS", results, "%.2f", formatDeltaRPS, rps),
+		textRow("Success Rate", results, func(r *internal.BenchmarkResult) (string, bool) {
+			if r.LoadTest == nil || r.LoadTest.TotalRequests == 0 {
+				return "", false
+			}
+			rate := float64(r.LoadTest.Successful) / float64(r.LoadTest.TotalRequests) * 100
+			return fmt.Sprintf("%.2f%%", rate), true
+		}),
+		metricRow("Min Latency (ms)", results, "%.2f", formatDelta, load(func(lt *internal.LoadTestResult) float64 { return lt.MinLatencyMs })),
+	)
+	rows = append(rows, percentileRows(results)...)
+	rows = append(rows,
+		metricRow("Max Latency (ms)", results, "%.2f", formatDelta, load(func(lt *internal.LoadTestResult) float64 { return lt.MaxLatencyMs })),
+		metricRow("Avg Latency (ms)", results, "%.2f", formatDelta, load(func(lt *internal.LoadTestResult) float64 { return lt.AvgLatencyMs })),
+		metricRow("Latency Std Dev (ms)", results, "%.2f", formatDelta, spread(func(lt *internal.LoadTestResult) float64 { return lt.StdDevLatencyMs })),
+		metricRow("Latency IQR (ms)", results, "%.2f", formatDelta, spread(func(lt *internal.LoadTestResult) float64 { return lt.IQRLatencyMs })),
+	)
+	rows = append(rows, errorBreakdownRows(results)...)
+
+	if !c.writeSectionHeading(sb, "## Load Test Comparison", rows) {
+		return
+	}
+	sb.WriteString("Load testing simulates multiple concurrent users making requests to measure how the server performs under stress. These metrics are critical for understanding capacity and identifying performance bottlenecks.\n\n")
+	sb.WriteString("### Configuration & Throughput\n\n")
+	sb.WriteString("- **Concurrent**: Number of simultaneous users (goroutines) making requests during the test.\n")
+	sb.WriteString("- **Duration (sec)**: How long the load test ran in seconds.\n")
+	sb.WriteString("- **Total Requests**: Total number of HTTP requests made during the test.\n")
+	sb.WriteString("- **Successful**: Number of requests that returned HTTP 2xx responses.\n")
+	sb.WriteString("- **Failed**: Number of requests that failed or returned error responses.\n")
+	sb.WriteString("- **RPS (Requests Per Second)**: Throughput measure showing how many requests the server can handle per second. Higher values indicate better capacity.\n")
+	sb.WriteString("- **Success Rate**: Percentage of requests that completed successfully. Values below 99% may indicate server overload.\n\n")
+	sb.WriteString("### Latency Distribution\n\n")
+	sb.WriteString("- **Min Latency**: Fastest response time observed during the test.\n")
+	sb.WriteString("- **p50 Latency (50th Percentile)**: The median response time—50% of requests completed faster than this value. Represents typical user experience.\n")
+	sb.WriteString("- **p95 Latency (95th Percentile)**: 95% of requests completed faster than this value. Helps identify slower outliers that affect some users.\n")
+	sb.WriteString("- **p99 Latency (99th Percentile)**: 99% of requests completed faster than this value. Reveals worst-case scenarios and tail latency issues.\n")
+	sb.WriteString("- **Max Latency**: Slowest response time observed during the test.\n")
+	sb.WriteString("- **Avg Latency**: Arithmetic mean of all response times. Can be skewed by outliers, so percentiles are often more meaningful.\n")
+	sb.WriteString("- **Latency Std Dev / IQR**: How widely response times vary, as the standard deviation about the mean and the range of the middle half (p75 - p25). An IQR above the p50 latency suggests requests fall into two groups.\n")
+	sb.WriteString("- **Errors**: Failed requests of each type (timeouts, refused or reset connections, HTTP 4xx and 5xx responses, and other errors), for the types any run recorded.\n\n")
+	c.writeTable(sb, deltaTableHeader("Metric", "", 7, len(results)), rows)
+	if changes := warmupChanges(results); len(changes) > 0 {
+		sb.WriteString("**Warmup changes** (without a warmup, connection setup and cold caches slow the first requests and can lower RPS):\n\n")
+		for _, change := range changes {
+			sb.WriteString(fmt.Sprintf("- %s\n", change))
 		}
 		sb.WriteString("\n")
 	}
+	writeTrends(sb, results, []trendMetric{{"RPS", "req/s", rps}, {"p95 Latency", "ms", p95}})
+}
 
-	// Chart-Ready CSV Data
-	sb.WriteString("## Chart-Ready Data (CSV)\n\n")
-	sb.WriteString("The data below is formatted as CSV (Comma-Separated Values) for easy import into spreadsheet applications like Microsoft Excel, Google Sheets, or LibreOffice Calc. Copy the data between the code fences and paste into your spreadsheet to create trend charts and visualizations.\n\n")
-	sb.WriteString("### Latency Over Time\n\n")
-	sb.WriteString("Columns: Timestamp, DNS (Domain Name System resolution), TCP (connection time), Health (health endpoint response), Min/p50/p95/p99/Max (latency distribution from load testing), Avg (average latency). All times in milliseconds.\n\n")
-	sb.WriteString("```csv\n")
-	sb.WriteString("Timestamp,DNS_ms,TCP_ms,Health_ms,Min_ms,p50_ms,p95_ms,p99_ms,Max_ms,Avg_ms\n")
-	for _, r := range results {
-		dns, tcp, health := 0.0, 0.0, 0.0
-		minLat, p50, p95, p99, maxLat, avgLat := 0.0, 0.0, 0.0, 0.0, 0.0, 0.0
-		if r.Connectivity != nil {
-			dns = r.Connectivity.DNSMs
-			tcp = r.Connectivity.TCPMs
+func (c *Comparison) writeBenchmarkAPISection(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	api := func(r *internal.BenchmarkResult) *internal.BenchmarkAPIResponse {
+		if r.BenchmarkAPI == nil {
+			return nil
 		}
-		if r.Health != nil {
-			health = r.Health.ResponseMs
-		}
-		if r.LoadTest != nil {
-			minLat = r.LoadTest.MinLatencyMs
-			p50 = r.LoadTest.LatencyP50Ms
-			p95 = r.LoadTest.LatencyP95Ms
-			p99 = r.LoadTest.LatencyP99Ms
-			maxLat = r.LoadTest.MaxLatencyMs
-			avgLat = r.LoadTest.AvgLatencyMs
+		return r.BenchmarkAPI.Response
+	}
+	apiCount := func(get func(*internal.BenchmarkAPIResponse) int) func(*internal.BenchmarkResult) (string, bool) {
+		return func(r *internal.BenchmarkResult) (string, bool) {
+			if resp := api(r); resp != nil {
+				return fmt.Sprintf("%d", get(resp)), true
+			}
+			return "", false
 		}
-		sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f,%.2f,%.2f,%.2f,%.2f,%.2f,%.2f,%.2f\n",
-			r.Timestamp.Format("2006-01-02T15:04:05"),
-			dns, tcp, health, minLat, p50, p95, p99, maxLat, avgLat))
 	}
-	sb.WriteString("```\n\n")
 
-	if hasLoadTest(results) {
-		sb.WriteString("### Throughput Over Time\n\n")
-		sb.WriteString("Columns: Timestamp, RPS (Requests Per Second - server throughput), Success_Rate_Pct (percentage of successful HTTP responses), Total_Requests (total number of requests made), Failed (count of failed requests).\n\n")
-		sb.WriteString("```csv\n")
-		sb.WriteString("Timestamp,RPS,Success_Rate_Pct,Total_Requests,Failed\n")
-		for _, r := range results {
-			if r.LoadTest != nil {
-				successRate := 0.0
-				if r.LoadTest.TotalRequests > 0 {
-					successRate = float64(r.LoadTest.Successful) / float64(r.LoadTest.TotalRequests) * 100
-				}
-				sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f,%d,%d\n",
-					r.Timestamp.Format("2006-01-02T15:04:05"),
-					r.LoadTest.RPS, successRate,
-					r.LoadTest.TotalRequests, r.LoadTest.Failed))
-			}
+	summaryRows := []tableRow{
+		textRow("Overall", results, func(r *internal.BenchmarkResult) (string, bool) {
+			resp := api(r)
+			if resp == nil {
+				return "", false
+			}
+			if resp.Overall != "pass" {
+				return "❌ " + resp.Overall, true
+			}
+			return "✅ " + resp.Overall, true
+		}),
+		pathRow("Duration (ms)", results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+			if resp := api(r); resp != nil {
+				return resp.TotalDurationMs, true
+			}
+			return 0, false
+		}),
+		textRow("Total Ops", results, apiCount(func(resp *internal.BenchmarkAPIResponse) int { return resp.TotalOperations })),
+		textRow("Successful", results, apiCount(func(resp *internal.BenchmarkAPIResponse) int { return resp.SuccessfulOperations })),
+		textRow("Failed", results, apiCount(func(resp *internal.BenchmarkAPIResponse) int { return resp.FailedOperations })),
+	}
+
+	opRows := func(names []string, get func(r *internal.BenchmarkResult, name string) (float64, bool)) []tableRow {
+		var rows []tableRow
+		for _, name := range names {
+			name := name
+			rows = append(rows, pathRow(name, results, "%.2f", formatDelta, func(r *internal.BenchmarkResult) (float64, bool) {
+				return get(r, name)
+			}))
 		}
-		sb.WriteString("```\n\n")
+		return rows
+	}
+	dbRows := opRows(collectDBOperationNames(results), getDBOperationDuration)
+	serRows := opRows(collectSerializationOpNames(results), getSerializationOpDuration)
+	blRows := opRows(collectBusinessLogicOpNames(results), getBusinessLogicOpDuration)
+	concRows := opRows(collectConcurrentOpNames(results), getConcurrentOpDuration)
+
+	var allRows []tableRow
+	for _, rows := range [][]tableRow{summaryRows, dbRows, serRows, blRows, concRows} {
+		allRows = append(allRows, rows...)
 	}
+	if !c.writeSectionHeading(sb, "## Server-Side Benchmark Comparison", allRows) {
+		return
+	}
+	sb.WriteString("The server-side benchmark tests internal ActaLog operations directly on the server, measuring database queries, JSON serialization, and business logic calculations independent of network latency.\n\n")
 
-	if hasFrontend(results) {
-		sb.WriteString("### Frontend Assets Over Time\n\n")
-		sb.WriteString("Columns: Timestamp, Total_Size_KB (combined size of all frontend assets in kilobytes), Total_Time_ms (time to download all assets in milliseconds).\n\n")
-		sb.WriteString("```csv\n")
-		sb.WriteString("Timestamp,Total_Size_KB,Total_Time_ms\n")
+	// System information has no delta column, so it is omitted when only regressions are shown
+	if !c.regressionsOnly {
+		c.writeSystemInfoTable(sb, results)
+	}
+	writeEnvironmentChanges(sb, results)
+
+	sb.WriteString("### Benchmark Summary\n\n")
+	c.writeTable(sb, deltaTableHeader("Metric", "", 7, len(results)), summaryRows)
+
+	opHeader := deltaTableHeader("Operation", " (ms)", 11, len(results))
+	if hasDBOperations(results) {
+		sb.WriteString("### Database Operations\n\n")
+		c.writeTable(sb, opHeader, dbRows)
+	}
+	if hasSerializationOps(results) {
+		sb.WriteString("### Serialization Operations\n\n")
+		c.writeTable(sb, opHeader, serRows)
+	}
+	if hasBusinessLogicOps(results) {
+		sb.WriteString("### Business Logic Operations\n\n")
+		c.writeTable(sb, opHeader, blRows)
+	}
+	if hasConcurrentOps(results) {
+		sb.WriteString("### Concurrent Operations\n\n")
+		c.writeTable(sb, opHeader, concRows)
+	}
+}
+
+func (c *Comparison) writeSystemInfoTable(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	sb.WriteString("### System Information\n\n")
+	sb.WriteString("This table shows the server environment for each benchmark run. Changes in system configuration can significantly impact performance results.\n\n")
+	sb.WriteString("| Property |")
+	for i := range results {
+		sb.WriteString(fmt.Sprintf(" Run %d |", i+1))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("|----------|")
+	for range results {
+		sb.WriteString("--------|")
+	}
+	sb.WriteString("\n")
+
+	properties := []struct {
+		label string
+		get   func(r *internal.BenchmarkResult) (string, bool)
+	}{
+		{"ActaLog Version", func(r *internal.BenchmarkResult) (string, bool) {
+			if r.BenchmarkAPI == nil || r.BenchmarkAPI.Response == nil {
+				return "", false
+			}
+			return r.BenchmarkAPI.Response.Version, true
+		}},
+		{"Go Version", func(r *internal.BenchmarkResult) (string, bool) {
+			if si := systemInfo(r); si != nil {
+				return si.GoVersion, true
+			}
+			return "", false
+		}},
+		{"Platform", func(r *internal.BenchmarkResult) (string, bool) {
+			if si := systemInfo(r); si != nil {
+				return si.GoOS + "/" + si.GoArch, true
+			}
+			return "", false
+		}},
+		{"OS Version", func(r *internal.BenchmarkResult) (string, bool) {
+			si := systemInfo(r)
+			if si == nil || si.OSVersion == "" {
+				return "", false
+			}
+			osVer := si.OSVersion
+			if len(osVer) > 40 {
+				osVer = osVer[:37] + "..."
+			}
+			return osVer, true
+		}},
+		{"CPUs", func(r *internal.BenchmarkResult) (string, bool) {
+			if si := systemInfo(r); si != nil {
+				return fmt.Sprintf("%d", si.NumCPU), true
+			}
+			return "", false
+		}},
+		{"Database", func(r *internal.BenchmarkResult) (string, bool) {
+			if si := systemInfo(r); si != nil {
+				return si.DatabaseDriver + " " + si.DatabaseVersion, true
+			}
+			return "", false
+		}},
+	}
+
+	for _, prop := range properties {
+		sb.WriteString(fmt.Sprintf("| %s |", prop.label))
 		for _, r := range results {
-			if r.Frontend != nil {
-				sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f\n",
-					r.Timestamp.Format("2006-01-02T15:04:05"),
-					r.Frontend.TotalSizeKB, r.Frontend.TotalTimeMs))
+			if val, found := prop.get(r); found {
+				sb.WriteString(fmt.Sprintf(" %s |", val))
+			} else {
+				sb.WriteString(" - |")
 			}
 		}
-		sb.WriteString("```\n\n")
+		sb.WriteString("\n")
 	}
+	sb.WriteString("\n")
+}
 
-	if hasEndpoints(results) {
-		sb.WriteString("### API Endpoints Over Time\n\n")
-		sb.WriteString("Columns: Timestamp, then response time in milliseconds for each API endpoint tested.\n\n")
-		endpointPaths := collectEndpointPaths(results)
-		if len(endpointPaths) > 0 {
-			sb.WriteString("```csv\n")
-			sb.WriteString("Timestamp")
-			for _, path := range endpointPaths {
-				sb.WriteString(fmt.Sprintf(",%s_ms", path))
-			}
-			sb.WriteString("\n")
-			for _, r := range results {
-				sb.WriteString(r.Timestamp.Format("2006-01-02T15:04:05"))
-				for _, path := range endpointPaths {
-					val, found := getEndpointResponseTime(r, path)
-					if found {
-						sb.WriteString(fmt.Sprintf(",%.2f", val))
-					} else {
-						sb.WriteString(",")
-					}
-				}
-				sb.WriteString("\n")
+// Helper functions
+
+// systemInfo returns the server environment reported by the benchmark API, if any
+func systemInfo(r *internal.BenchmarkResult) *internal.SystemInfo {
+	if r.BenchmarkAPI == nil || r.BenchmarkAPI.Response == nil {
+		return nil
+	}
+	return r.BenchmarkAPI.Response.SystemInfo
+}
+
+// environmentChange is a SystemInfo field that differs between two runs
+type environmentChange struct {
+	label      string
+	first      string
+	last       string
+	influencer bool // Likely to affect benchmark timings
+}
+
+// diffSystemInfo compares the environment of the first and last runs that report one
+func diffSystemInfo(results []*internal.BenchmarkResult) (changes []environmentChange, firstRun, lastRun int) {
+	firstRun, lastRun = -1, -1
+	for i, r := range results {
+		if systemInfo(r) != nil {
+			if firstRun < 0 {
+				firstRun = i
 			}
-			sb.WriteString("```\n\n")
+			lastRun = i
 		}
 	}
+	if firstRun < 0 || firstRun == lastRun {
+		return nil, firstRun, lastRun
+	}
 
-	// Summary
-	sb.WriteString("## Summary\n\n")
-	sb.WriteString("### Legend\n\n")
-	sb.WriteString("- **Δ (Delta)**: Change from first run to last run\n")
-	sb.WriteString("- 🟢 Improvement (faster/smaller)\n")
-	sb.WriteString("- 🔴 Regression (slower/larger)\n")
-	sb.WriteString("- ⚪ No significant change\n\n")
-
-	sb.WriteString("### Threshold Configuration\n\n")
-	sb.WriteString(fmt.Sprintf("- p95 Latency Max: %.0f ms\n", c.thresholds.LatencyP95MaxMs))
-	sb.WriteString(fmt.Sprintf("- p99 Latency Max: %.0f ms\n", c.thresholds.LatencyP99MaxMs))
-	sb.WriteString(fmt.Sprintf("- Error Rate Max: %.1f%%\n", c.thresholds.ErrorRateMaxPct))
-	sb.WriteString(fmt.Sprintf("- RPS Minimum: %.0f\n", c.thresholds.RPSMinimum))
-	sb.WriteString(fmt.Sprintf("- Health Response Max: %.0f ms\n", c.thresholds.HealthResponseMax))
-	sb.WriteString("\n")
+	first, last := systemInfo(results[firstRun]), systemInfo(results[lastRun])
+	fields := []struct {
+		label       string
+		first, last string
+		influencer  bool
+	}{
+		{"Go Version", first.GoVersion, last.GoVersion, true},
+		{"OS", first.GoOS, last.GoOS, false},
+		{"Architecture", first.GoArch, last.GoArch, false},
+		{"OS Version", first.OSVersion, last.OSVersion, false},
+		{"CPUs", fmt.Sprintf("%d", first.NumCPU), fmt.Sprintf("%d", last.NumCPU), true},
+		{"Database Driver", first.DatabaseDriver, last.DatabaseDriver, false},
+		{"Database Version", first.DatabaseVersion, last.DatabaseVersion, true},
+	}
+	for _, f := range fields {
+		if f.first != f.last {
+			changes = append(changes, environmentChange{label: f.label, first: f.first, last: f.last, influencer: f.influencer})
+		}
+	}
+	return changes, firstRun, lastRun
+}
 
-	// Footer
-	sb.WriteString("---\n\n")
-	sb.WriteString(fmt.Sprintf("*Comparison report generated by actalog-bench at %s*\n",
-		time.Now().Format("2006-01-02 15:04:05 MST")))
+// writeEnvironmentChanges writes the SystemInfo diff between the first and last runs
+func writeEnvironmentChanges(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	changes, firstRun, lastRun := diffSystemInfo(results)
+	if firstRun < 0 || firstRun == lastRun {
+		return
+	}
 
-	// Create parent directories if needed
-	if c.outputDir != "" && c.outputDir != "." {
-		if err := os.MkdirAll(c.outputDir, 0755); err != nil {
-			return "", fmt.Errorf("create directory: %w", err)
-		}
+	sb.WriteString("### Environment Changes\n\n")
+	if len(changes) == 0 {
+		sb.WriteString(fmt.Sprintf("*No environment changes between Run %d and Run %d*\n\n", firstRun+1, lastRun+1))
+		return
 	}
 
-	// Write file
-	if err := os.WriteFile(outputPath, []byte(sb.String()), 0644); err != nil {
-		return "", fmt.Errorf("write comparison file: %w", err)
+	sb.WriteString(fmt.Sprintf("The server environment changed between Run %d and Run %d. Performance differences in the tables below may be caused by these changes rather than by application code.\n\n", firstRun+1, lastRun+1))
+	sb.WriteString(fmt.Sprintf("| Property | Run %d | Run %d | Status |\n", firstRun+1, lastRun+1))
+	sb.WriteString("|----------|--------|--------|--------|\n")
+	for _, ch := range changes {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | ⚠️ changed |\n", ch.label, ch.first, ch.last))
 	}
+	sb.WriteString("\n")
 
-	return outputPath, nil
+	for _, ch := range changes {
+		if ch.influencer {
+			sb.WriteString(fmt.Sprintf("- **%s** changed from %s to %s. This is a likely performance influencer, so compare server-side timings with care.\n", ch.label, ch.first, ch.last))
+		}
+	}
+	sb.WriteString("\n")
 }
 
-// Helper functions
-
 func hasConnectivity(results []*internal.BenchmarkResult) bool {
 	for _, r := range results {
 		if r.Connectivity != nil {
@@ -1156,10 +1482,112 @@ func hasLoadTest(results []*internal.BenchmarkResult) bool {
 	return false
 }
 
+// heatmapBuckets are the latency ranges of the heatmap rows, in milliseconds;
+// the last bucket is open-ended
+var heatmapBuckets = []struct {
+	label  string
+	lo, hi float64
+}{
+	{"0-10ms", 0, 10},
+	{"10-50ms", 10, 50},
+	{"50-100ms", 50, 100},
+	{"100-250ms", 100, 250},
+	{"250-500ms", 250, 500},
+	{"500-1000ms", 500, 1000},
+	{"1000ms+", 1000, math.Inf(1)},
+}
+
+// writeLatencyHeatmapCSV writes the share of load test requests in each
+// latency bucket, one column per run. Results keep only latency percentiles,
+// not raw samples, so each run's distribution is estimated by linear
+// interpolation between its min, p50, p95, p99 and max.
+func writeLatencyHeatmapCSV(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	var runs []*internal.BenchmarkResult
+	for _, r := range results {
+		if r.LoadTest != nil && r.LoadTest.TotalRequests > 0 {
+			runs = append(runs, r)
+		}
+	}
+	if len(runs) == 0 {
+		return
+	}
+
+	sb.WriteString("### Latency Heatmap (CSV)\n\n")
+	sb.WriteString("Rows are latency buckets and columns are load test runs; each cell is the estimated percentage of requests in that bucket, interpolated from the run's latency percentiles. Import as a table and apply a color scale to see the distribution shift between runs.\n\n")
+	sb.WriteString("```csv\n")
+	sb.WriteString("Bucket")
+	for _, r := range runs {
+		sb.WriteString("," + r.Timestamp.Format("2006-01-02T15:04:05"))
+	}
+	sb.WriteString("\n")
+	for _, b := range heatmapBuckets {
+		sb.WriteString(b.label)
+		for _, r := range runs {
+			pct := latencyCDF(r.LoadTest, b.hi) - latencyCDF(r.LoadTest, b.lo)
+			sb.WriteString(fmt.Sprintf(",%.2f", pct))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("```\n\n")
+}
+
+// writeStepLoadCSV writes each step of the --step-load runs, one row per run
+// and step, so concurrency can be charted against latency
+func writeStepLoadCSV(sb *strings.Builder, results []*internal.BenchmarkResult) {
+	if !slices.ContainsFunc(results, func(r *internal.BenchmarkResult) bool { return r.LoadTest != nil && len(r.LoadTest.StepResults) > 0 }) {
+		return
+	}
+
+	sb.WriteString("### Step Load (CSV)\n\n")
+	sb.WriteString("Columns: Timestamp (the run), Step, Concurrency (workers running during the step), RPS, p95_ms (95th percentile latency in the step), Error_Rate_Pct. Chart p95 and RPS against concurrency to see where each run started to degrade.\n\n")
+	sb.WriteString("```csv\n")
+	sb.WriteString("Timestamp,Step,Concurrency,RPS,p95_ms,Error_Rate_Pct\n")
+	for _, r := range results {
+		if r.LoadTest == nil {
+			continue
+		}
+		for _, s := range r.LoadTest.StepResults {
+			sb.WriteString(fmt.Sprintf("%s,%d,%d,%.2f,%.2f,%.2f\n",
+				r.Timestamp.Format("2006-01-02T15:04:05"), s.Step, s.Concurrency, s.RPS, s.LatencyP95Ms, s.ErrorRatePct))
+		}
+	}
+	sb.WriteString("```\n\n")
+}
+
+// latencyCDF estimates the percentage of lt's requests faster than ms by
+// interpolating linearly between its recorded percentiles
+func latencyCDF(lt *internal.LoadTestResult, ms float64) float64 {
+	points := []struct{ ms, pct float64 }{
+		{lt.MinLatencyMs, 0},
+		{lt.LatencyP50Ms, 50},
+		{lt.LatencyP95Ms, 95},
+		{lt.LatencyP99Ms, 99},
+		{lt.MaxLatencyMs, 100},
+	}
+	if ms < points[0].ms {
+		return 0
+	}
+	last := points[len(points)-1]
+	if ms >= last.ms {
+		return 100
+	}
+	for i := len(points) - 2; i >= 0; i-- {
+		lo, hi := points[i], points[i+1]
+		if ms >= lo.ms && hi.ms > ms {
+			return lo.pct + (ms-lo.ms)/(hi.ms-lo.ms)*(hi.pct-lo.pct)
+		}
+	}
+	return 100
+}
+
 func formatDelta(last, first float64) string {
 	if first == 0 && last == 0 {
 		return "-"
 	}
+	// A not-applicable sentinel has no meaningful change
+	if first == internal.NotApplicableMs || last == internal.NotApplicableMs {
+		return "-"
+	}
 	if first == 0 {
 		return fmt.Sprintf("🔴 +%.2f", last)
 	}
@@ -1177,6 +1605,19 @@ func formatDelta(last, first float64) string {
 	return "⚪ ~0"
 }
 
+// formatDeltaCount formats the change in a count that is neither better nor
+// worse when it grows, such as open connections
+func formatDeltaCount(last, first float64) string {
+	diff := last - first
+	if diff == 0 {
+		return "⚪ ~0"
+	}
+	if first == 0 {
+		return fmt.Sprintf("%+.0f", diff)
+	}
+	return fmt.Sprintf("%+.0f (%+.1f%%)", diff, diff/first*100)
+}
+
 func formatDeltaSize(last, first float64) string {
 	if first == 0 && last == 0 {
 		return "-"
@@ -1455,49 +1896,116 @@ func getConcurrentOpDuration(r *internal.BenchmarkResult, opName string) (float6
 	return 0, false
 }
 
-// checkThresholds evaluates all results against configured thresholds
+// checkThresholds evaluates all results against configured thresholds. The
+// comparison's own thresholds are applied, rather than any breaches recorded
+// in the results, so older files and changed thresholds are judged alike.
 func (c *Comparison) checkThresholds(results []*internal.BenchmarkResult) []string {
 	var alerts []string
-
 	for i, r := range results {
-		runLabel := fmt.Sprintf("Run %d (%s)", i+1, r.Timestamp.Format("2006-01-02 15:04"))
-
-		// Health check threshold
-		if r.Health != nil && r.Health.ResponseMs > c.thresholds.HealthResponseMax {
-			alerts = append(alerts, fmt.Sprintf("🔴 **%s**: Health response %.2f ms exceeds threshold %.0f ms",
-				runLabel, r.Health.ResponseMs, c.thresholds.HealthResponseMax))
+		for _, b := range c.thresholds.Breaches(r, RunLabel(i, r)) {
+			alerts = append(alerts, formatBreach(b))
 		}
+	}
+	return alerts
+}
 
-		// Load test thresholds
-		if r.LoadTest != nil {
-			// p95 latency
-			if r.LoadTest.LatencyP95Ms > c.thresholds.LatencyP95MaxMs {
-				alerts = append(alerts, fmt.Sprintf("🔴 **%s**: p95 latency %.2f ms exceeds threshold %.0f ms",
-					runLabel, r.LoadTest.LatencyP95Ms, c.thresholds.LatencyP95MaxMs))
-			}
+// formatBreach describes a threshold breach as a Markdown alert line
+func formatBreach(b internal.ThresholdBreach) string {
+	return fmt.Sprintf("🔴 **%s**: %s", b.RunLabel, breachDetail(b))
+}
 
-			// p99 latency
-			if r.LoadTest.LatencyP99Ms > c.thresholds.LatencyP99MaxMs {
-				alerts = append(alerts, fmt.Sprintf("🔴 **%s**: p99 latency %.2f ms exceeds threshold %.0f ms",
-					runLabel, r.LoadTest.LatencyP99Ms, c.thresholds.LatencyP99MaxMs))
-			}
+// breachDetail describes a threshold breach, e.g. "p95 latency 620.00 ms
+// exceeds threshold 500 ms"
+func breachDetail(b internal.ThresholdBreach) string {
+	var detail string
+	switch {
+	case b.Metric == metricHealthResponse:
+		detail = fmt.Sprintf("Health response %.2f ms exceeds threshold %.0f ms", b.Actual, b.Threshold)
+	case b.Metric == metricLatencyP95:
+		detail = fmt.Sprintf("p95 latency %.2f ms exceeds threshold %.0f ms", b.Actual, b.Threshold)
+	case b.Metric == metricLatencyP99:
+		detail = fmt.Sprintf("p99 latency %.2f ms exceeds threshold %.0f ms", b.Actual, b.Threshold)
+	case b.Metric == metricErrorRate:
+		detail = fmt.Sprintf("Error rate %.2f%% exceeds threshold %.1f%%", b.Actual, b.Threshold)
+	case b.Metric == metricRPS:
+		detail = fmt.Sprintf("RPS %.2f below minimum threshold %.0f", b.Actual, b.Threshold)
+	case strings.HasPrefix(b.Metric, metricDatabasePrefix):
+		detail = fmt.Sprintf("Database operation %s %.2f ms exceeds threshold %.0f ms",
+			strings.TrimPrefix(b.Metric, metricDatabasePrefix), b.Actual, b.Threshold)
+	case strings.HasPrefix(b.Metric, metricSerializationPrefix):
+		detail = fmt.Sprintf("Serialization operation %s %.3f ms exceeds threshold %.2f ms",
+			strings.TrimPrefix(b.Metric, metricSerializationPrefix), b.Actual, b.Threshold)
+	case strings.HasPrefix(b.Metric, metricHTTPSDowngradePrefix):
+		detail = fmt.Sprintf("HTTPS downgrade: `%s` redirected to plain HTTP",
+			strings.TrimPrefix(b.Metric, metricHTTPSDowngradePrefix))
+	default:
+		detail = fmt.Sprintf("%s %.2f crosses threshold %.2f", b.Metric, b.Actual, b.Threshold)
+	}
+	return detail
+}
 
-			// Error rate
-			if r.LoadTest.TotalRequests > 0 {
-				errorRate := float64(r.LoadTest.Failed) / float64(r.LoadTest.TotalRequests) * 100
-				if errorRate > c.thresholds.ErrorRateMaxPct {
-					alerts = append(alerts, fmt.Sprintf("🔴 **%s**: Error rate %.2f%% exceeds threshold %.1f%%",
-						runLabel, errorRate, c.thresholds.ErrorRateMaxPct))
-				}
-			}
+// Health score deductions, from a starting score of 100
+const (
+	scorePerBreach       = 10 // Each threshold breach in any run
+	scorePerDegradedRun  = 5  // Each run whose overall status is not pass
+	scoreRPSDecline      = 15 // RPS trending down across runs
+	scoreLatencyIncrease = 15 // p95 latency trending up across runs
+	scoreFrontendGrowth  = 5  // Frontend larger in the last run than the first
+)
 
-			// RPS minimum
-			if r.LoadTest.RPS < c.thresholds.RPSMinimum {
-				alerts = append(alerts, fmt.Sprintf("🔴 **%s**: RPS %.2f below minimum threshold %.0f",
-					runLabel, r.LoadTest.RPS, c.thresholds.RPSMinimum))
-			}
+// computeHealthScore condenses the comparison into one 0-100 number for
+// dashboards and executive summaries. Trends use the same criteria as the
+// 📈/📉 annotations, so they need at least three runs.
+func computeHealthScore(results []*internal.BenchmarkResult, thresholds *ThresholdConfig) float64 {
+	score := 100.0
+
+	for i, r := range results {
+		score -= float64(scorePerBreach * len(thresholds.Breaches(r, RunLabel(i, r))))
+		if r.Overall == "degraded" || r.Overall == "fail" {
+			score -= scorePerDegradedRun
 		}
 	}
 
-	return alerts
+	rps := func(r *internal.BenchmarkResult) (float64, bool) {
+		if r.LoadTest == nil {
+			return 0, false
+		}
+		return r.LoadTest.RPS, true
+	}
+	p95 := func(r *internal.BenchmarkResult) (float64, bool) {
+		if r.LoadTest == nil {
+			return 0, false
+		}
+		return r.LoadTest.LatencyP95Ms, true
+	}
+	if slope, _, ok := metricTrend(results, rps); ok && slope < 0 {
+		score -= scoreRPSDecline
+	}
+	if slope, _, ok := metricTrend(results, p95); ok && slope > 0 {
+		score -= scoreLatencyIncrease
+	}
+
+	var frontend []*internal.FrontendResult
+	for _, r := range results {
+		if r.Frontend != nil {
+			frontend = append(frontend, r.Frontend)
+		}
+	}
+	if len(frontend) >= 2 && frontend[len(frontend)-1].TotalSizeKB > frontend[0].TotalSizeKB {
+		score -= scoreFrontendGrowth
+	}
+
+	return math.Max(score, 0)
+}
+
+// healthScoreRating returns the colored interpretation shown beside a score
+func healthScoreRating(score float64) string {
+	switch {
+	case score > 80:
+		return "🟢 Healthy"
+	case score > 50:
+		return "🟡 Needs attention"
+	default:
+		return "🔴 Unhealthy"
+	}
 }
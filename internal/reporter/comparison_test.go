@@ -2,6 +2,7 @@ package reporter
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -41,6 +42,12 @@ func TestDefaultThresholds(t *testing.T) {
 	if th.HealthResponseMax != 100 {
 		t.Errorf("expected HealthResponseMax 100, got %f", th.HealthResponseMax)
 	}
+	if th.DBOperationMaxMs != 50 {
+		t.Errorf("expected DBOperationMaxMs 50, got %f", th.DBOperationMaxMs)
+	}
+	if th.SerializationMaxMs != 1 {
+		t.Errorf("expected SerializationMaxMs 1, got %f", th.SerializationMaxMs)
+	}
 }
 
 func TestSetThresholds(t *testing.T) {
@@ -78,6 +85,37 @@ func TestScanDirectory(t *testing.T) {
 	}
 }
 
+func TestScanDirectory_OutputPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, name := range []string{"staging_2026-01-01.json", "staging_2026-01-02.json", "production_2026-01-01.json"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("{}"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	c := NewComparison(tmpDir)
+	c.SetOutputPrefix("staging")
+	files, err := c.ScanDirectory(tmpDir)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(files) != 2 || filepath.Base(files[0]) != "staging_2026-01-01.json" {
+		t.Errorf("expected only the 2 staging files, got %v", files)
+	}
+
+	reportPath, err := c.ReportResults([]*internal.BenchmarkResult{
+		{Timestamp: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), Overall: "pass"},
+		{Timestamp: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), Overall: "pass"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.HasPrefix(filepath.Base(reportPath), "staging_comparison_") {
+		t.Errorf("expected a staging_comparison_ report, got %s", reportPath)
+	}
+}
+
 func TestScanDirectory_FallbackToAnyJSON(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -154,13 +192,57 @@ func TestLoadResults(t *testing.T) {
 	}
 }
 
+func TestLoadResults_Array(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A --json-append file holds several results, mixed with a single-result file
+	appended := []*internal.BenchmarkResult{
+		{Timestamp: time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC), Version: "1.0.2", Overall: "pass"},
+		{Timestamp: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), Version: "1.0.0", Overall: "pass"},
+	}
+	data, _ := json.Marshal(appended)
+	arrayPath := filepath.Join(tmpDir, "results.json")
+	if err := os.WriteFile(arrayPath, data, 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	data, _ = json.Marshal(&internal.BenchmarkResult{Timestamp: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), Version: "1.0.1", Overall: "pass"})
+	singlePath := filepath.Join(tmpDir, "single.json")
+	if err := os.WriteFile(singlePath, data, 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	c := NewComparison(tmpDir)
+	c.SetSchemaVersionCheck(true)
+	loaded, err := c.LoadResults([]string{arrayPath, singlePath})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(loaded) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(loaded))
+	}
+	for i, want := range []string{"1.0.0", "1.0.1", "1.0.2"} {
+		if loaded[i].Version != want {
+			t.Errorf("result %d: expected version %s, got %s", i, want, loaded[i].Version)
+		}
+	}
+	if len(c.SchemaWarnings()) != 3 || !strings.Contains(c.SchemaWarnings()[0], "results.json[0]") {
+		t.Errorf("expected a schema warning per unversioned result, got %v", c.SchemaWarnings())
+	}
+}
+
 func TestReport_MinimumFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 
+	path := filepath.Join(tmpDir, "single.json")
+	data, _ := json.Marshal(&internal.BenchmarkResult{Timestamp: time.Now(), Overall: "pass"})
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
 	c := NewComparison(tmpDir)
-	_, err := c.Report([]string{"single.json"})
+	_, err := c.Report([]string{path})
 	if err == nil {
-		t.Error("expected error for less than 2 files")
+		t.Fatal("expected error for less than 2 results")
 	}
 	if !strings.Contains(err.Error(), "at least 2") {
 		t.Errorf("expected 'at least 2' error, got: %v", err)
@@ -321,6 +403,7 @@ func TestReport_Success(t *testing.T) {
 		"### Frontend Assets Over Time",
 		"### API Endpoints Over Time",
 		"## Summary",
+		"**Benchmark Health Score: ",
 	}
 
 	for _, section := range sections {
@@ -376,6 +459,48 @@ func TestCheckThresholds(t *testing.T) {
 	}
 }
 
+func TestCheckThresholds_BenchmarkAPI(t *testing.T) {
+	c := NewComparison("/tmp")
+
+	results := []*internal.BenchmarkResult{
+		{
+			Timestamp: time.Now(),
+			BenchmarkAPI: &internal.BenchmarkAPIResult{
+				Response: &internal.BenchmarkAPIResponse{
+					Database: map[string]*internal.OperationResult{
+						"bulk_insert": {DurationMs: 120}, // Exceeds default of 50
+						"select_all":  {DurationMs: 10},
+					},
+					Serialization: map[string]*internal.OperationResult{
+						"json_marshal":   {DurationMs: 2.5}, // Exceeds default of 1
+						"json_unmarshal": {DurationMs: 0.5},
+					},
+				},
+			},
+		},
+	}
+
+	alerts := c.checkThresholds(results)
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d: %v", len(alerts), alerts)
+	}
+	if !strings.Contains(alerts[0], "bulk_insert") {
+		t.Errorf("expected database alert for bulk_insert, got %s", alerts[0])
+	}
+	if !strings.Contains(alerts[1], "json_marshal") {
+		t.Errorf("expected serialization alert for json_marshal, got %s", alerts[1])
+	}
+
+	// Zero thresholds disable the checks
+	th := DefaultThresholds()
+	th.DBOperationMaxMs = 0
+	th.SerializationMaxMs = 0
+	c.SetThresholds(th)
+	if alerts := c.checkThresholds(results); len(alerts) != 0 {
+		t.Errorf("expected no alerts with disabled thresholds, got %v", alerts)
+	}
+}
+
 func TestFormatDelta(t *testing.T) {
 	tests := []struct {
 		last, first float64
@@ -386,6 +511,8 @@ func TestFormatDelta(t *testing.T) {
 		{110, 100, "🔴"}, // Regression (slower)
 		{50, 0, "🔴"},    // First is zero
 		{0, 0, "-"},      // Both zero
+		{-1, 50, "-"},    // Last not applicable
+		{50, -1, "-"},    // First not applicable
 	}
 
 	for _, tt := range tests {
@@ -432,6 +559,74 @@ func TestFormatDeltaRPS(t *testing.T) {
 	}
 }
 
+func TestFormatDeltaCount(t *testing.T) {
+	tests := []struct {
+		last, first float64
+		want        string
+	}{
+		{8, 8, "⚪ ~0"},
+		{12, 8, "+4 (+50.0%)"},
+		{4, 8, "-4 (-50.0%)"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDeltaCount(tt.last, tt.first); got != tt.want {
+			t.Errorf("formatDeltaCount(%f, %f) = %s, want %s", tt.last, tt.first, got, tt.want)
+		}
+	}
+}
+
+func TestWriteHealthSection_ActiveConnections(t *testing.T) {
+	c := NewComparison(t.TempDir())
+
+	var sb strings.Builder
+	c.writeHealthSection(&sb, []*internal.BenchmarkResult{
+		{Health: &internal.HealthResult{Status: "healthy", ResponseMs: 5}},
+		{Health: &internal.HealthResult{Status: "healthy", ResponseMs: 6}},
+	})
+	if strings.Contains(sb.String(), "Active Connections") {
+		t.Errorf("expected no Active Connections row when no run reports them, got:\n%s", sb.String())
+	}
+
+	sb.Reset()
+	c.writeHealthSection(&sb, []*internal.BenchmarkResult{
+		{Health: &internal.HealthResult{Status: "healthy", ResponseMs: 5, ActiveConnections: 8}},
+		{Health: &internal.HealthResult{Status: "healthy", ResponseMs: 6, ActiveConnections: 12}},
+	})
+	if !strings.Contains(sb.String(), "| Active Connections | 8 | 12 | +4 (+50.0%) |") {
+		t.Errorf("expected the Active Connections row with a neutral delta, got:\n%s", sb.String())
+	}
+}
+
+func TestWriteConnectivitySection_OptionalRows(t *testing.T) {
+	c := NewComparison(t.TempDir())
+
+	var sb strings.Builder
+	c.writeConnectivitySection(&sb, []*internal.BenchmarkResult{
+		{Connectivity: &internal.ConnectivityResult{DNSMs: 1, TCPMs: 2, TotalMs: 3}},
+		{Connectivity: &internal.ConnectivityResult{DNSMs: 1, TCPMs: 3, TotalMs: 4}},
+	})
+	for _, label := range []string{"IPv4", "IPv6", "HTTP/3"} {
+		if strings.Contains(sb.String(), label) {
+			t.Errorf("expected no %s row or legend when no run measured it, got:\n%s", label, sb.String())
+		}
+	}
+
+	sb.Reset()
+	c.writeConnectivitySection(&sb, []*internal.BenchmarkResult{
+		{Connectivity: &internal.ConnectivityResult{DNSMs: 1, TCPMs: 2, TotalMs: 3, IPv4Ms: 2}},
+		{Connectivity: &internal.ConnectivityResult{DNSMs: 1, TCPMs: 3, TotalMs: 4, IPv4Ms: 3, HTTP3Supported: true, HTTP3Ms: 9}},
+	})
+	for _, want := range []string{"| IPv4 (ms) |", "- **IPv4 / IPv6**", "| HTTP/3 (ms) |", "- **HTTP/3**"} {
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("expected %q, got:\n%s", want, sb.String())
+		}
+	}
+	if strings.Contains(sb.String(), "IPv6 (ms)") {
+		t.Errorf("expected no IPv6 row when no run measured it, got:\n%s", sb.String())
+	}
+}
+
 func TestHasConnectivity(t *testing.T) {
 	resultsWithConn := []*internal.BenchmarkResult{
 		{Connectivity: &internal.ConnectivityResult{}},
@@ -1208,3 +1403,542 @@ func TestReport_WithConcurrentOps(t *testing.T) {
 		t.Error("expected parallel_writes operation to be present")
 	}
 }
+
+// writeResultFiles writes each result as a JSON file in dir and returns the paths
+func writeResultFiles(t *testing.T, dir string, results []*internal.BenchmarkResult) []string {
+	t.Helper()
+
+	var paths []string
+	for i, r := range results {
+		data, err := json.Marshal(r)
+		if err != nil {
+			t.Fatalf("failed to marshal result: %v", err)
+		}
+		path := filepath.Join(dir, fmt.Sprintf("benchmark_%d.json", i))
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// renderComparison runs the comparison report and returns its content
+func renderComparison(t *testing.T, c *Comparison, results []*internal.BenchmarkResult) string {
+	t.Helper()
+
+	paths := writeResultFiles(t, t.TempDir(), results)
+	outputPath, err := c.Report(paths)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	return string(content)
+}
+
+func regressionFixture() []*internal.BenchmarkResult {
+	return []*internal.BenchmarkResult{
+		{
+			Timestamp:    time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			Overall:      "pass",
+			Connectivity: &internal.ConnectivityResult{DNSMs: 1.0, TCPMs: 40.0, TotalMs: 41.0, Connected: true},
+			Health:       &internal.HealthResult{Status: "healthy", ResponseMs: 30.0},
+			LoadTest:     &internal.LoadTestResult{TotalRequests: 100, Successful: 100, RPS: 50.0, LatencyP95Ms: 40.0},
+		},
+		{
+			Timestamp:    time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC),
+			Overall:      "pass",
+			Connectivity: &internal.ConnectivityResult{DNSMs: 1.0, TCPMs: 40.0, TotalMs: 41.0, Connected: true},
+			Health:       &internal.HealthResult{Status: "healthy", ResponseMs: 30.0},
+			LoadTest:     &internal.LoadTestResult{TotalRequests: 100, Successful: 100, RPS: 40.0, LatencyP95Ms: 40.0},
+		},
+	}
+}
+
+func TestReport_RegressionsOnly(t *testing.T) {
+	c := NewComparison(t.TempDir())
+	c.SetRegressionsOnly(true)
+	content := renderComparison(t, c, regressionFixture())
+
+	// RPS dropped, so the load test section keeps only that row
+	if !strings.Contains(content, "| RPS |") {
+		t.Error("expected regressed RPS row to be shown")
+	}
+	if strings.Contains(content, "| p95 Latency (ms) |") {
+		t.Error("expected unchanged p95 row to be hidden")
+	}
+	if strings.Contains(content, "| Concurrent |") {
+		t.Error("expected informational rows to be hidden")
+	}
+
+	// Connectivity is unchanged, so the section is replaced by a note
+	idx := strings.Index(content, "## Connectivity Comparison")
+	if idx < 0 {
+		t.Fatal("expected connectivity section heading")
+	}
+	if !strings.HasPrefix(content[idx:], "## Connectivity Comparison\n\n*No regressions detected in this section*") {
+		t.Error("expected no-regressions note at top of connectivity section")
+	}
+	if strings.Contains(content, "| DNS (ms) |") {
+		t.Error("expected connectivity table to be skipped")
+	}
+}
+
+func TestReport_RegressionsOnlyKeepsIPv6Notes(t *testing.T) {
+	results := regressionFixture()
+	results[0].Connectivity.IPv6Ms = 12.0
+
+	c := NewComparison(t.TempDir())
+	c.SetRegressionsOnly(true)
+	content := renderComparison(t, c, results)
+
+	if !strings.Contains(content, "IPv6 connectivity disappeared in Run 2") {
+		t.Error("expected the IPv6 support change to be listed in regressions-only mode")
+	}
+}
+
+func TestReport_RegressionsOnlyDisabled(t *testing.T) {
+	c := NewComparison(t.TempDir())
+	content := renderComparison(t, c, regressionFixture())
+
+	for _, row := range []string{"| DNS (ms) |", "| p95 Latency (ms) |", "| Concurrent |"} {
+		if !strings.Contains(content, row) {
+			t.Errorf("expected row %q in full report", row)
+		}
+	}
+	if strings.Contains(content, "No regressions detected") {
+		t.Error("expected no regression notes when filtering is disabled")
+	}
+}
+
+func TestMetricRow(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{},
+		{Health: &internal.HealthResult{ResponseMs: 50}},
+		{Health: &internal.HealthResult{ResponseMs: 100}},
+	}
+	response := func(r *internal.BenchmarkResult) (float64, bool) {
+		if r.Health == nil {
+			return 0, false
+		}
+		return r.Health.ResponseMs, true
+	}
+	row := metricRow("Response", results, "%.1f", formatDelta, response)
+
+	if len(row.cells) != 3 || row.cells[0] != "-" || row.cells[1] != "50.0" || row.cells[2] != "100.0" {
+		t.Errorf("unexpected cells: %v", row.cells)
+	}
+	// Delta is measured from the first run, counted as 0 when it lacks the metric
+	if row.delta != formatDelta(100, 0) {
+		t.Errorf("expected delta %q, got %q", formatDelta(100, 0), row.delta)
+	}
+
+	// Per-path rows measure from the first run that reported the metric
+	row = pathRow("Response", results, "%.1f", formatDelta, response)
+	if row.delta != formatDelta(100, 50) {
+		t.Errorf("expected delta %q, got %q", formatDelta(100, 50), row.delta)
+	}
+	if !isRegression(row.delta) {
+		t.Error("expected slower response to be a regression")
+	}
+}
+
+func TestDeltaTableHeader(t *testing.T) {
+	// The separators match the hand-written tables of earlier reports
+	want := "| Metric | Run 1 | Run 2 | Δ (Last vs First) |\n|--------|-------:|-------:|---------------:|\n"
+	if got := deltaTableHeader("Metric", "", 7, 2); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	want = "| Endpoint | Run 1 (ms) | Δ (Last vs First) |\n|----------|------------:|---------------:|\n"
+	if got := deltaTableHeader("Endpoint", " (ms)", 12, 1); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestErrorBreakdownRows(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{LoadTest: &internal.LoadTestResult{}},
+		{LoadTest: &internal.LoadTestResult{Failed: 3}}, // Saved before error types were recorded
+		{LoadTest: &internal.LoadTestResult{Failed: 4, ErrorBreakdown: map[string]int{internal.ErrorTypeHTTP5xx: 4}}},
+	}
+
+	rows := errorBreakdownRows(results)
+	if len(rows) != 1 || rows[0].label != "Errors: http_5xx" {
+		t.Fatalf("expected a single http_5xx row, got %v", rows)
+	}
+	if cells := rows[0].cells; cells[0] != "0" || cells[1] != "-" || cells[2] != "4" {
+		t.Errorf("unexpected cells: %v", cells)
+	}
+	if !isRegression(rows[0].delta) {
+		t.Errorf("expected more errors to be a regression, got %q", rows[0].delta)
+	}
+}
+
+func TestIPv6SupportChanges(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{Connectivity: &internal.ConnectivityResult{Connected: true, IPv4Ms: 5}},
+		{Connectivity: &internal.ConnectivityResult{Connected: true, IPv4Ms: 5, IPv6Ms: 6}},
+		{Connectivity: &internal.ConnectivityResult{Error: "DNS lookup failed"}}, // Skipped
+		{Connectivity: &internal.ConnectivityResult{Connected: true, IPv4Ms: 5}},
+	}
+
+	changes := ipv6SupportChanges(results)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d: %v", len(changes), changes)
+	}
+	if !strings.Contains(changes[0], "appeared in Run 2") {
+		t.Errorf("expected IPv6 to appear in Run 2, got %s", changes[0])
+	}
+	if !strings.Contains(changes[1], "disappeared in Run 4 (available in Run 2)") {
+		t.Errorf("expected IPv6 to disappear in Run 4, got %s", changes[1])
+	}
+
+	if changes := ipv6SupportChanges(results[:1]); len(changes) != 0 {
+		t.Errorf("expected no changes for a single run, got %v", changes)
+	}
+}
+
+func TestDNSResolverChanges(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{Connectivity: &internal.ConnectivityResult{Connected: true}},
+		{Connectivity: &internal.ConnectivityResult{Connected: true, DNSResolver: "10.0.0.2:53"}},
+		{}, // Skipped
+		{Connectivity: &internal.ConnectivityResult{Connected: true, DNSResolver: "10.0.0.2:53"}},
+		{Connectivity: &internal.ConnectivityResult{Connected: true}},
+		{Connectivity: &internal.ConnectivityResult{Connected: true, DOHServer: "https://dns.example.com/dns-query"}},
+	}
+
+	changes := connectivityChanges(results, resolverChangeFormat, resolverName)
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %d: %v", len(changes), changes)
+	}
+	if changes[0] != "Run 2 used `10.0.0.2:53` (Run 1 used system resolver)" {
+		t.Errorf("unexpected first change: %s", changes[0])
+	}
+	if changes[1] != "Run 5 used system resolver (Run 4 used `10.0.0.2:53`)" {
+		t.Errorf("unexpected second change: %s", changes[1])
+	}
+	if changes[2] != "Run 6 used DNS-over-HTTPS `https://dns.example.com/dns-query` (Run 5 used system resolver)" {
+		t.Errorf("unexpected third change: %s", changes[2])
+	}
+}
+
+func TestBoundToChanges(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{Connectivity: &internal.ConnectivityResult{Connected: true, BoundTo: "10.1.0.5"}},
+		{Connectivity: &internal.ConnectivityResult{Connected: true, BoundTo: "10.1.0.5"}},
+		{}, // Skipped
+		{Connectivity: &internal.ConnectivityResult{Connected: true}},
+	}
+
+	changes := connectivityChanges(results, boundToChangeFormat, boundToName)
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d: %v", len(changes), changes)
+	}
+	if changes[0] != "Run 4 connected from the default interface (Run 2 from `10.1.0.5`)" {
+		t.Errorf("unexpected change: %s", changes[0])
+	}
+}
+
+func TestWarmupChanges(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{LoadTest: &internal.LoadTestResult{}},
+		{}, // Skipped
+		{LoadTest: &internal.LoadTestResult{WarmupSec: 10}},
+		{LoadTest: &internal.LoadTestResult{WarmupSec: 10}},
+	}
+
+	changes := warmupChanges(results)
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d: %v", len(changes), changes)
+	}
+	if changes[0] != "Run 3 had a 10s warmup (Run 1 had no warmup)" {
+		t.Errorf("unexpected change: %s", changes[0])
+	}
+}
+
+func TestNewlyDeprecatedEndpoints(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{Endpoints: []internal.EndpointResult{{Path: "/api/legacy"}, {Path: "/api/old", Deprecated: true}}},
+		{Endpoints: []internal.EndpointResult{{Path: "/api/old", Deprecated: true}}},
+		{Endpoints: []internal.EndpointResult{
+			{Path: "/api/legacy", Deprecated: true, SunsetDate: "2026-12-31"},
+			{Path: "/api/new", Deprecated: true}, // First seen deprecated, not newly deprecated
+		}},
+	}
+
+	alerts := newlyDeprecatedEndpoints(results)
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d: %v", len(alerts), alerts)
+	}
+	if alerts[0] != "⚠️ `/api/legacy` deprecated in Run 3 (not in Run 1), sunset 2026-12-31" {
+		t.Errorf("unexpected alert: %s", alerts[0])
+	}
+}
+
+func systemInfoResult(day int, si *internal.SystemInfo) *internal.BenchmarkResult {
+	return &internal.BenchmarkResult{
+		Timestamp: time.Date(2026, 1, day, 10, 0, 0, 0, time.UTC),
+		Overall:   "pass",
+		BenchmarkAPI: &internal.BenchmarkAPIResult{
+			Success: true,
+			Response: &internal.BenchmarkAPIResponse{
+				Overall:         "pass",
+				TotalDurationMs: 30,
+				SystemInfo:      si,
+			},
+		},
+	}
+}
+
+func TestDiffSystemInfo(t *testing.T) {
+	base := internal.SystemInfo{
+		GoVersion: "go1.21.0", GoOS: "linux", GoArch: "amd64",
+		NumCPU: 8, DatabaseDriver: "sqlite3", DatabaseVersion: "3.40.0",
+	}
+	upgraded := base
+	upgraded.GoVersion = "go1.22.0"
+	upgraded.NumCPU = 4
+	upgraded.GoArch = "arm64"
+
+	results := []*internal.BenchmarkResult{
+		{Timestamp: time.Now()}, // No benchmark API data
+		systemInfoResult(2, &base),
+		systemInfoResult(3, &upgraded),
+	}
+
+	changes, firstRun, lastRun := diffSystemInfo(results)
+	if firstRun != 1 || lastRun != 2 {
+		t.Errorf("expected runs 1 and 2 to be compared, got %d and %d", firstRun, lastRun)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %d: %+v", len(changes), changes)
+	}
+
+	influencers := 0
+	for _, ch := range changes {
+		if ch.influencer {
+			influencers++
+		}
+	}
+	if influencers != 2 {
+		t.Errorf("expected Go version and CPU count to be influencers, got %d", influencers)
+	}
+}
+
+func TestReport_EnvironmentChanges(t *testing.T) {
+	before := &internal.SystemInfo{GoVersion: "go1.21.0", GoOS: "linux", GoArch: "amd64", NumCPU: 8, DatabaseDriver: "sqlite3", DatabaseVersion: "3.40.0"}
+	after := &internal.SystemInfo{GoVersion: "go1.22.0", GoOS: "linux", GoArch: "amd64", NumCPU: 8, DatabaseDriver: "sqlite3", DatabaseVersion: "3.45.1"}
+
+	c := NewComparison(t.TempDir())
+	content := renderComparison(t, c, []*internal.BenchmarkResult{
+		systemInfoResult(1, before),
+		systemInfoResult(2, after),
+	})
+
+	if !strings.Contains(content, "### Environment Changes") {
+		t.Error("expected Environment Changes section")
+	}
+	if !strings.Contains(content, "| Go Version | go1.21.0 | go1.22.0 | ⚠️ changed |") {
+		t.Error("expected Go version change row")
+	}
+	if !strings.Contains(content, "**Database Version** changed from 3.40.0 to 3.45.1. This is a likely performance influencer") {
+		t.Error("expected database version to be called out as a performance influencer")
+	}
+
+	content = renderComparison(t, c, []*internal.BenchmarkResult{
+		systemInfoResult(1, before),
+		systemInfoResult(2, before),
+	})
+	if !strings.Contains(content, "*No environment changes between Run 1 and Run 2*") {
+		t.Error("expected no-change note when environments match")
+	}
+}
+
+func TestWriteStepLoadCSV(t *testing.T) {
+	ts := time.Date(2026, 1, 9, 14, 30, 0, 0, time.UTC)
+	results := []*internal.BenchmarkResult{
+		{Timestamp: ts, LoadTest: &internal.LoadTestResult{StepResults: []internal.StepResult{
+			{Step: 1, Concurrency: 1, RPS: 50, LatencyP95Ms: 12},
+			{Step: 2, Concurrency: 2, RPS: 90, LatencyP95Ms: 15, ErrorRatePct: 1.5},
+		}}},
+		{Timestamp: ts.Add(time.Hour), LoadTest: &internal.LoadTestResult{}}, // No steps, so no rows
+	}
+
+	var sb strings.Builder
+	writeStepLoadCSV(&sb, results)
+	content := sb.String()
+	want := "Timestamp,Step,Concurrency,RPS,p95_ms,Error_Rate_Pct\n" +
+		"2026-01-09T14:30:00,1,1,50.00,12.00,0.00\n" +
+		"2026-01-09T14:30:00,2,2,90.00,15.00,1.50\n```"
+	if !strings.Contains(content, "### Step Load (CSV)") || !strings.Contains(content, want) {
+		t.Errorf("unexpected step load CSV:\n%s", content)
+	}
+
+	sb.Reset()
+	writeStepLoadCSV(&sb, results[1:])
+	if sb.Len() != 0 {
+		t.Errorf("expected nothing without step load runs, got:\n%s", sb.String())
+	}
+}
+
+func TestWriteLatencyHeatmapCSV(t *testing.T) {
+	ts := time.Date(2026, 1, 9, 14, 30, 0, 0, time.UTC)
+	results := []*internal.BenchmarkResult{
+		{Timestamp: ts, LoadTest: &internal.LoadTestResult{
+			TotalRequests: 1000, MinLatencyMs: 5, LatencyP50Ms: 20, LatencyP95Ms: 80, LatencyP99Ms: 200, MaxLatencyMs: 400,
+		}},
+		{Timestamp: ts.Add(time.Hour)}, // No load test, so no column
+		{Timestamp: ts.Add(2 * time.Hour), LoadTest: &internal.LoadTestResult{
+			TotalRequests: 1000, MinLatencyMs: 2, LatencyP50Ms: 4, LatencyP95Ms: 6, LatencyP99Ms: 8, MaxLatencyMs: 9,
+		}},
+	}
+
+	var sb strings.Builder
+	writeLatencyHeatmapCSV(&sb, results)
+	content := sb.String()
+
+	for _, want := range []string{
+		"### Latency Heatmap (CSV)",
+		"Bucket,2026-01-09T14:30:00,2026-01-09T16:30:00\n",
+		"0-10ms,16.67,100.00\n",
+		"10-50ms,55.83,0.00\n",
+		"50-100ms,23.17,0.00\n",
+		"100-250ms,3.58,0.00\n",
+		"250-500ms,0.75,0.00\n",
+		"1000ms+,0.00,0.00\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in heatmap, got:\n%s", want, content)
+		}
+	}
+
+	sb.Reset()
+	writeLatencyHeatmapCSV(&sb, results[1:2])
+	if sb.Len() != 0 {
+		t.Errorf("expected no heatmap without load tests, got:\n%s", sb.String())
+	}
+}
+
+func TestWriteAssetInventoryChanges(t *testing.T) {
+	frontend := func(names ...string) *internal.FrontendResult {
+		return &internal.FrontendResult{AssetFilenames: names}
+	}
+	results := []*internal.BenchmarkResult{
+		{Frontend: frontend("/assets/app.abc123.js", "/assets/style.css")},
+		{Frontend: frontend("/assets/app.abc123.js", "/assets/style.css")},
+		{}, // No frontend benchmark, so no column
+		{Frontend: frontend("/assets/app.def456.js", "/assets/style.css")},
+	}
+
+	var sb strings.Builder
+	writeAssetInventoryChanges(&sb, results)
+	content := sb.String()
+
+	for _, want := range []string{
+		"### Asset Inventory Changes",
+		"Asset filenames changed between Run 2 → Run 4, which indicates a new frontend build was deployed.",
+		"| Asset | Run 1 → Run 2 | Run 2 → Run 4 |\n",
+		"| `/assets/app.abc123.js` | ✅ | 🗑️ |\n",
+		"| `/assets/app.def456.js` | - | 🆕 |\n",
+		"| `/assets/style.css` | ✅ | ✅ |\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in inventory, got:\n%s", want, content)
+		}
+	}
+
+	sb.Reset()
+	writeAssetInventoryChanges(&sb, results[:2])
+	if want := "*Asset filenames are unchanged across all runs"; !strings.Contains(sb.String(), want) {
+		t.Errorf("expected %q for identical inventories, got:\n%s", want, sb.String())
+	}
+
+	sb.Reset()
+	writeAssetInventoryChanges(&sb, results[2:])
+	if sb.Len() != 0 {
+		t.Errorf("expected no inventory with fewer than two recorded runs, got:\n%s", sb.String())
+	}
+}
+
+func TestComputeHealthScore(t *testing.T) {
+	ts := time.Date(2026, 1, 9, 14, 30, 0, 0, time.UTC)
+	run := func(i int, overall string, rps, p95, sizeKB float64) *internal.BenchmarkResult {
+		return &internal.BenchmarkResult{
+			Timestamp: ts.Add(time.Duration(i) * time.Hour),
+			Overall:   overall,
+			LoadTest:  &internal.LoadTestResult{TotalRequests: 1000, RPS: rps, LatencyP95Ms: p95, LatencyP99Ms: p95},
+			Frontend:  &internal.FrontendResult{TotalSizeKB: sizeKB},
+		}
+	}
+	thresholds := DefaultThresholds()
+
+	tests := []struct {
+		name    string
+		results []*internal.BenchmarkResult
+		want    float64
+	}{
+		{"steady", []*internal.BenchmarkResult{
+			run(0, "pass", 100, 50, 500), run(1, "pass", 101, 50, 500), run(2, "pass", 100, 50, 500),
+		}, 100},
+		{"declining RPS and rising p95", []*internal.BenchmarkResult{
+			run(0, "pass", 100, 50, 500), run(1, "pass", 90, 60, 500), run(2, "pass", 80, 70, 500),
+		}, 70},
+		{"degraded run, breach, and larger frontend", []*internal.BenchmarkResult{
+			run(0, "pass", 100, 50, 500), run(1, "degraded", 100, 600, 550),
+		}, 80},
+		{"floored at zero", []*internal.BenchmarkResult{
+			run(0, "fail", 1, 5000, 500), run(1, "fail", 1, 5000, 500), run(2, "fail", 1, 5000, 500),
+		}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeHealthScore(tt.results, thresholds); got != tt.want {
+				t.Errorf("expected score %.0f, got %.0f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHealthScoreRating(t *testing.T) {
+	for score, want := range map[float64]string{100: "🟢", 81: "🟢", 80: "🟡", 51: "🟡", 50: "🔴", 0: "🔴"} {
+		if got := healthScoreRating(score); !strings.HasPrefix(got, want) {
+			t.Errorf("healthScoreRating(%.0f) = %s, want prefix %s", score, got, want)
+		}
+	}
+}
+
+func TestReportResults_MultiURL(t *testing.T) {
+	tmpDir := t.TempDir()
+	start := time.Date(2026, 1, 9, 14, 30, 0, 0, time.UTC)
+	results := []*internal.BenchmarkResult{
+		{Timestamp: start, Target: "https://blue.example.com", Version: "1.4.0", Overall: "pass", MultiURLMode: true,
+			Health: &internal.HealthResult{Status: "healthy", ResponseMs: 20}},
+		{Timestamp: start.Add(time.Second), Target: "https://green.example.com", Version: "1.5.0", Overall: "degraded", MultiURLMode: true,
+			Health: &internal.HealthResult{Status: "healthy", ResponseMs: 25}},
+	}
+
+	reportPath, err := NewComparison(tmpDir).ReportResults(results)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(reportPath)
+	content := string(data)
+	for _, want := range []string{
+		"| # | URL | Version | Overall |",
+		"| 1 | https://blue.example.com | 1.4.0 | ✅ pass |",
+		"| 2 | https://green.example.com | 1.5.0 | ⚠️ degraded |",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in content", want)
+		}
+	}
+	if strings.Contains(content, "| # | Timestamp |") {
+		t.Error("expected the URL column in place of Timestamp")
+	}
+}
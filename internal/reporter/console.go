@@ -2,6 +2,7 @@ package reporter
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/fatih/color"
@@ -30,6 +31,10 @@ func (c *Console) Report(result *internal.BenchmarkResult) {
 		c.printHealth(result.Health)
 	}
 
+	if result.GRPCHealth != nil {
+		c.printGRPCHealth(result.GRPCHealth)
+	}
+
 	if len(result.Endpoints) > 0 {
 		c.printEndpoints(result.Endpoints)
 	}
@@ -38,10 +43,22 @@ func (c *Console) Report(result *internal.BenchmarkResult) {
 		c.printFrontend(result.Frontend)
 	}
 
+	if result.SSE != nil {
+		c.printSSE(result.SSE)
+	}
+
 	if result.LoadTest != nil {
 		c.printLoadTest(result.LoadTest)
 	}
 
+	if result.WebSocketLoadTest != nil {
+		c.printWebSocketLoadTest(result.WebSocketLoadTest)
+	}
+
+	if len(result.ConcurrencyProfile) > 0 {
+		c.printConcurrencyProfile(result.ConcurrencyProfile, result.RecommendedConcurrency)
+	}
+
 	if result.BenchmarkAPI != nil {
 		c.printBenchmarkAPI(result.BenchmarkAPI)
 	}
@@ -61,6 +78,12 @@ func (c *Console) printHeader(result *internal.BenchmarkResult) {
 	if result.Version != "" {
 		fmt.Printf("║ Version: %-52s ║\n", truncate(result.Version, 52))
 	}
+	if result.AgentCount > 0 {
+		fmt.Printf("║ Agents:  %-52d ║\n", result.AgentCount)
+	}
+	if result.SimulatedLatencyMs > 0 || result.SimulatedLossRate > 0 {
+		fmt.Printf("║ Network: %-52s ║\n", fmt.Sprintf("simulated, +%.0fms latency, %.1f%% loss", result.SimulatedLatencyMs, result.SimulatedLossRate*100))
+	}
 	cyan.Println("╚══════════════════════════════════════════════════════════════╝")
 	fmt.Println()
 }
@@ -73,12 +96,34 @@ func (c *Console) printConnectivity(conn *internal.ConnectivityResult) {
 	if conn.Error != "" {
 		fmt.Printf("│ %-60s │\n", color.RedString("Error: %s", truncate(conn.Error, 52)))
 	} else {
-		fmt.Printf("│ DNS Resolution:     %7.1fms                                 │\n", conn.DNSMs)
-		fmt.Printf("│ TCP Connect:        %7.1fms                                 │\n", conn.TCPMs)
+		if conn.UnixSocket != "" {
+			fmt.Printf("│ Socket Connect:     %7.1fms                                 │\n", conn.TCPMs)
+		} else {
+			fmt.Printf("│ DNS Resolution:     %7.1fms                                 │\n", conn.DNSMs)
+			fmt.Printf("│ TCP Connect:        %7.1fms                                 │\n", conn.TCPMs)
+		}
+		if conn.IPv4Ms > 0 {
+			fmt.Printf("│   IPv4 Probe:       %7.1fms                                 │\n", conn.IPv4Ms)
+		}
+		if conn.IPv6Ms > 0 {
+			fmt.Printf("│   IPv6 Probe:       %7.1fms                                 │\n", conn.IPv6Ms)
+		}
 		if conn.TLSMs > 0 {
 			fmt.Printf("│ TLS Handshake:      %7.1fms                                 │\n", conn.TLSMs)
 		}
+		if conn.HTTP3Supported {
+			fmt.Printf("│ HTTP/3 First Byte:  %7.1fms                                 │\n", conn.HTTP3Ms)
+		}
 		fmt.Printf("│ Total:              %7.1fms                                 │\n", conn.TotalMs)
+		if reuse := conn.KeepAliveReuseFraction; reuse != nil {
+			fmt.Printf("│ Keep-Alive Reuse:   %7.1f%%                                  │\n", *reuse*100)
+		}
+		if conn.HopCount > 0 {
+			fmt.Printf("│ Network Hops:       %7d                                   │\n", conn.HopCount)
+		}
+		if conn.PathMTU > 0 {
+			fmt.Printf("│ Path MTU:           %7d bytes                             │\n", conn.PathMTU)
+		}
 	}
 
 	yellow.Println("└──────────────────────────────────────────────────────────────┘")
@@ -111,6 +156,27 @@ func (c *Console) printHealth(health *internal.HealthResult) {
 	fmt.Println()
 }
 
+func (c *Console) printGRPCHealth(health *internal.HealthResult) {
+	yellow := color.New(color.FgYellow)
+
+	yellow.Println("┌─ gRPC Health Check ──────────────────────────────────────────┐")
+
+	statusStr := color.RedString("✗ %s", health.Status)
+	if health.Status == "healthy" {
+		statusStr = color.GreenString("✓ healthy")
+	}
+
+	fmt.Printf("│ Status:             %-40s │\n", statusStr)
+	fmt.Printf("│ Response Time:      %7.1fms                                 │\n", health.ResponseMs)
+
+	if health.Error != "" {
+		fmt.Printf("│ Error: %-54s │\n", truncate(health.Error, 54))
+	}
+
+	yellow.Println("└──────────────────────────────────────────────────────────────┘")
+	fmt.Println()
+}
+
 func (c *Console) printEndpoints(endpoints []internal.EndpointResult) {
 	yellow := color.New(color.FgYellow)
 	green := color.New(color.FgGreen)
@@ -124,8 +190,39 @@ func (c *Console) printEndpoints(endpoints []internal.EndpointResult) {
 			status = red.Sprint("✗")
 		}
 
-		path := truncate(ep.Path, 20)
-		fmt.Printf("│ %-20s %7.1fms  %s                            │\n", path, ep.ResponseMs, status)
+		warning := "  "
+		if ep.ResponseTruncated || ep.Deprecated {
+			warning = " " + yellow.Sprint("⚠")
+		}
+
+		path := truncate(endpointLabel(ep), 20)
+		fmt.Printf("│ %-20s %7.1fms  %s%s                          │\n", path, ep.ResponseMs, status, warning)
+
+		if ep.HTTPSDowngrade {
+			fmt.Printf("│   %s │\n", red.Sprintf("%-58s", "CRITICAL: redirected from HTTPS to plain HTTP"))
+		}
+		if ep.ResponseTruncated {
+			fmt.Printf("│   %-58s │\n", "Response body truncated at --max-response-size")
+		}
+		if ep.Deprecated {
+			note := "Deprecated"
+			if ep.SunsetDate != "" {
+				note += ", sunset " + ep.SunsetDate
+			}
+			fmt.Printf("│   %-58s │\n", truncate(note, 58))
+		}
+
+		if c.verbose && ep.TTFBMs > 0 && ep.TotalMs > 0 {
+			fmt.Printf("│   %-58s │\n", fmt.Sprintf("TTFB %.1fms, body download %.1fms", ep.TTFBMs, ep.TotalMs-ep.TTFBMs))
+		}
+		if c.verbose && ep.RequestID != "" {
+			fmt.Printf("│   Request ID: %-46s │\n", ep.RequestID)
+		}
+
+		// Not wrapped so the command stays copy-pasteable
+		if c.verbose && !ep.Success && ep.CurlCommand != "" {
+			fmt.Printf("│   $ %s\n", ep.CurlCommand)
+		}
 	}
 
 	yellow.Println("└──────────────────────────────────────────────────────────────┘")
@@ -169,12 +266,81 @@ func (c *Console) printFrontend(frontend *internal.FrontendResult) {
 	fmt.Println()
 }
 
+func (c *Console) printSSE(sse *internal.SSEResult) {
+	yellow := color.New(color.FgYellow)
+
+	yellow.Println("┌─ Server-Sent Events ─────────────────────────────────────────┐")
+
+	statusStr := color.GreenString("✓ connected")
+	if !sse.Connected {
+		statusStr = color.RedString("✗ not connected")
+	}
+
+	fmt.Printf("│ Path:               %-40s │\n", truncate(sse.Path, 40))
+	fmt.Printf("│ Status:             %-40s │\n", statusStr)
+	fmt.Printf("│ Events Received:    %7d                                  │\n", sse.EventsReceived)
+	if sse.EventsReceived > 0 {
+		fmt.Printf("│ First Event:        %7.1fms                                 │\n", sse.TimeToFirstEventMs)
+	}
+	if sse.EventsReceived > 1 {
+		fmt.Printf("│ Avg Interval:       %7.1fms                                 │\n", sse.AvgIntervalMs)
+	}
+
+	if sse.Error != "" {
+		fmt.Printf("│ Error: %-54s │\n", truncate(sse.Error, 54))
+	}
+
+	yellow.Println("└──────────────────────────────────────────────────────────────┘")
+	fmt.Println()
+}
+
+// histogramBarWidth is the length of the longest bar in a console histogram
+const histogramBarWidth = 30
+
+// histogramLines renders latency buckets as bars scaled so the fullest bucket
+// is histogramBarWidth long, each line showing the bucket bounds and count
+func histogramLines(buckets []internal.HistogramBucket) []string {
+	most := 0
+	for _, b := range buckets {
+		most = max(most, b.Count)
+	}
+
+	lines := make([]string, 0, len(buckets))
+	for _, b := range buckets {
+		bar := 0
+		if most > 0 {
+			bar = int(math.Round(float64(b.Count) / float64(most) * histogramBarWidth))
+		}
+		lines = append(lines, fmt.Sprintf("%8.1f-%-8.1f %-*s %d",
+			b.LowerMs, b.UpperMs, histogramBarWidth, strings.Repeat("█", bar), b.Count))
+	}
+	return lines
+}
+
 func (c *Console) printLoadTest(load *internal.LoadTestResult) {
 	yellow := color.New(color.FgYellow)
 
 	header := fmt.Sprintf("Load Test (%d concurrent, %.0fs)", load.Concurrent, load.DurationSec)
+	if load.StressedEndpoint != "" {
+		header = fmt.Sprintf("Stress Test: %s (%d concurrent, %.0fs)", load.StressedEndpoint, load.Concurrent, load.DurationSec)
+	}
 	yellow.Printf("┌─ %-58s ─┐\n", header)
 
+	if load.WarmupSec > 0 {
+		fmt.Printf("│ %-60s │\n", fmt.Sprintf("After a %.0fs warmup (not counted)", load.WarmupSec))
+	}
+	if load.ThinkTimeMs > 0 {
+		thinkTime := fmt.Sprintf("Think time %.0fms between requests", load.ThinkTimeMs)
+		if load.ThinkTimeJitterPct > 0 {
+			thinkTime += fmt.Sprintf(" (+0-%.0f%%)", load.ThinkTimeJitterPct)
+		}
+		fmt.Printf("│ %-60s │\n", thinkTime)
+	}
+	if load.EarlyAbort {
+		color.New(color.FgRed, color.Bold).Printf("│ %-60s │\n", fmt.Sprintf("ABORTED EARLY after %.0fs", load.DurationSec))
+		fmt.Printf("│ %-60s │\n", truncate(load.AbortReason, 60))
+	}
+
 	successRate := float64(load.Successful) / float64(load.TotalRequests) * 100
 	failRate := float64(load.Failed) / float64(load.TotalRequests) * 100
 
@@ -182,12 +348,85 @@ func (c *Console) printLoadTest(load *internal.LoadTestResult) {
 	fmt.Printf("│ Successful:         %7d (%.1f%%)                            │\n", load.Successful, successRate)
 	fmt.Printf("│ Failed:             %7d (%.1f%%)                             │\n", load.Failed, failRate)
 	fmt.Printf("│ RPS:                %7.1f req/s                             │\n", load.RPS)
-	fmt.Printf("│ Latency p50:        %7.1fms                                 │\n", load.LatencyP50Ms)
-	fmt.Printf("│ Latency p95:        %7.1fms                                 │\n", load.LatencyP95Ms)
-	fmt.Printf("│ Latency p99:        %7.1fms                                 │\n", load.LatencyP99Ms)
+	if load.TargetRPS > 0 {
+		fmt.Printf("│ Target RPS:         %7.1f req/s                             │\n", load.TargetRPS)
+	}
+	if load.ArrivalRate > 0 {
+		fmt.Printf("│ Arrival Rate:       %7.1f req/s                             │\n", load.ArrivalRate)
+		fmt.Printf("│ Service Rate:       %7.1f req/s                             │\n", load.ServiceRate)
+		fmt.Printf("│ Max Queue Depth:    %7d                                   │\n", load.MaxQueueDepth)
+	}
+	for _, p := range loadTestPercentiles(load) {
+		fmt.Printf("│ %-20s%7.1fms                                 │\n", "Latency "+p.Label+":", p.Ms)
+	}
 	fmt.Printf("│ Min Latency:        %7.1fms                                 │\n", load.MinLatencyMs)
 	fmt.Printf("│ Max Latency:        %7.1fms                                 │\n", load.MaxLatencyMs)
 	fmt.Printf("│ Avg Latency:        %7.1fms                                 │\n", load.AvgLatencyMs)
+	fmt.Printf("│ Std Deviation:      %7.1fms                                 │\n", load.StdDevLatencyMs)
+	fmt.Printf("│ IQR (p75-p25):      %7.1fms                                 │\n", load.IQRLatencyMs)
+	if load.PoolSaturation == internal.PoolSaturated {
+		yellow.Printf("│ %-60s │\n", fmt.Sprintf("Connection pool saturated: %d new connections", load.NewConnections))
+	}
+	if c.verbose && len(load.LatencyHistogram) > 0 {
+		fmt.Printf("│ %-60s │\n", "")
+		fmt.Printf("│ %-60s │\n", "Latency histogram (ms)")
+		for _, line := range histogramLines(load.LatencyHistogram) {
+			fmt.Printf("│ %-60s │\n", line)
+		}
+	}
+	if c.verbose && len(load.WorkerStats) > 0 {
+		fmt.Printf("│ %-60s │\n", "")
+		fmt.Printf("│ %-60s │\n", "Worker   Requests   Failed   Avg (ms)   p95 (ms)")
+		for _, w := range load.WorkerStats {
+			fmt.Printf("│ %-60s │\n", fmt.Sprintf("%6d   %8d   %6d   %8.1f   %8.1f", w.WorkerID, w.TotalRequests, w.Failed, w.AvgLatencyMs, w.P95LatencyMs))
+		}
+	}
+
+	yellow.Println("└──────────────────────────────────────────────────────────────┘")
+	fmt.Println()
+}
+
+func (c *Console) printWebSocketLoadTest(ws *internal.WebSocketLoadTestResult) {
+	yellow := color.New(color.FgYellow)
+
+	header := fmt.Sprintf("WebSocket Load Test (%d concurrent, %.0fs)", ws.Concurrent, ws.DurationSec)
+	yellow.Printf("┌─ %-58s ─┐\n", header)
+
+	if ws.Error != "" {
+		fmt.Printf("│ %-60s │\n", color.RedString("Error: %s", truncate(ws.Error, 52)))
+	} else {
+		fmt.Printf("│ Connections:        %7d                                   │\n", ws.Connections)
+		fmt.Printf("│ Round Trips:        %7d                                   │\n", ws.Successful)
+		fmt.Printf("│ Failed:             %7d                                   │\n", ws.Failed)
+		fmt.Printf("│ Messages/sec:       %7.1f                                   │\n", ws.MessagesPerSec)
+		fmt.Printf("│ RTT p50:            %7.1fms                                 │\n", ws.LatencyP50Ms)
+		fmt.Printf("│ RTT p95:            %7.1fms                                 │\n", ws.LatencyP95Ms)
+		fmt.Printf("│ RTT p99:            %7.1fms                                 │\n", ws.LatencyP99Ms)
+	}
+
+	yellow.Println("└──────────────────────────────────────────────────────────────┘")
+	fmt.Println()
+}
+
+func (c *Console) printConcurrencyProfile(points []internal.ConcurrencyDataPoint, recommended int) {
+	yellow := color.New(color.FgYellow)
+
+	yellow.Println("┌─ Concurrency Profile ────────────────────────────────────────┐")
+	fmt.Printf("│ %-10s %12s %12s %10s              │\n", "Concurrent", "RPS", "p95", "Errors")
+
+	for _, p := range points {
+		marker := " "
+		if p.Concurrent == recommended {
+			marker = color.GreenString("★")
+		}
+		fmt.Printf("│ %-10d %12.1f %10.1fms %9.1f%% %s            │\n", p.Concurrent, p.RPS, p.LatencyP95Ms, p.ErrorRatePct, marker)
+	}
+
+	if recommended > 0 {
+		fmt.Printf("│ %-60s │\n", fmt.Sprintf("Recommended: %d concurrent (best RPS/p95 ratio)", recommended))
+	} else {
+		fmt.Printf("│ %-60s │\n", color.RedString("No step stayed under the error rate limit"))
+	}
 
 	yellow.Println("└──────────────────────────────────────────────────────────────┘")
 	fmt.Println()
@@ -329,9 +568,28 @@ func (c *Console) printOverall(result *internal.BenchmarkResult) {
 	} else {
 		red.Printf("Overall: ✗ %s\n", strings.ToUpper(result.Overall))
 	}
+	if result.LoadTest != nil && result.LoadTest.EarlyAbort {
+		red.Printf("Load test aborted early: %s\n", result.LoadTest.AbortReason)
+	}
+	if result.GracefulShutdown {
+		red.Printf("Run stopped early: %s\n", result.ShutdownReason)
+	}
+	for _, ep := range result.Endpoints {
+		if ep.HTTPSDowngrade {
+			red.Printf("CRITICAL: %s redirected from HTTPS to plain HTTP (%s)\n", endpointLabel(ep), ep.Error)
+		}
+	}
 	fmt.Println()
 }
 
+// endpointLabel returns the endpoint path, prefixed with its method when not GET
+func endpointLabel(ep internal.EndpointResult) string {
+	if ep.Method != "" && ep.Method != "GET" {
+		return ep.Method + " " + ep.Path
+	}
+	return ep.Path
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
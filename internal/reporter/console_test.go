@@ -1,8 +1,10 @@
 package reporter
 
 import (
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/johnzastrow/actalog-benchmark/internal"
 )
@@ -200,6 +202,41 @@ func TestConsole_Report_FailedEndpoints(t *testing.T) {
 	c.Report(result)
 }
 
+func TestConsole_Report_FailedEndpointsVerbose(t *testing.T) {
+	c := NewConsole(true)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "degraded",
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/success", ResponseMs: 20.5, Status: 200, Success: true},
+			{Path: "/api/fail", ResponseMs: 5, Status: 500, Success: false,
+				CurlCommand: "curl -i -H 'User-Agent: actalog-bench/1.0' 'https://example.com/api/fail'"},
+		},
+	}
+
+	// Should not panic when printing curl commands for failed endpoints
+	c.Report(result)
+}
+
+func TestConsole_Report_EndpointWarnings(t *testing.T) {
+	c := NewConsole(false)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/dump", ResponseMs: 950, Status: 200, Success: true, ResponseTruncated: true},
+			{Path: "/api/legacy", ResponseMs: 20, Status: 200, Success: true, Deprecated: true, SunsetDate: "2026-12-31"},
+		},
+	}
+
+	// Should not panic when flagging truncated and deprecated responses
+	c.Report(result)
+}
+
 func TestConsole_Report_FailedFrontendAssets(t *testing.T) {
 	c := NewConsole(false)
 
@@ -247,3 +284,84 @@ func TestConsole_Report_NoTLS(t *testing.T) {
 	// Should not panic and should skip TLS line
 	c.Report(result)
 }
+
+func TestConsole_Report_ConcurrencyProfile(t *testing.T) {
+	c := NewConsole(false)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		ConcurrencyProfile: []internal.ConcurrencyDataPoint{
+			{Concurrent: 1, RPS: 50, LatencyP95Ms: 20},
+			{Concurrent: 5, RPS: 200, LatencyP95Ms: 40},
+		},
+		RecommendedConcurrency: 5,
+	}
+
+	// Should not panic with or without a recommendation
+	c.Report(result)
+	result.RecommendedConcurrency = 0
+	c.Report(result)
+}
+
+func TestConsole_Report_WebSocketLoadTest(t *testing.T) {
+	c := NewConsole(false)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		WebSocketLoadTest: &internal.WebSocketLoadTestResult{
+			Path: "/ws", Concurrent: 2, Connections: 2, Successful: 100, LatencyP95Ms: 5,
+		},
+	}
+
+	// Should not panic with successful or failed WebSocket results
+	c.Report(result)
+	result.WebSocketLoadTest = &internal.WebSocketLoadTestResult{Path: "/ws", Concurrent: 2, Error: "websocket dial: bad status"}
+	c.Report(result)
+}
+
+func TestHistogramLines(t *testing.T) {
+	lines := histogramLines([]internal.HistogramBucket{
+		{LowerMs: 10, UpperMs: 15, Count: 40},
+		{LowerMs: 15, UpperMs: 20, Count: 0},
+		{LowerMs: 20, UpperMs: 25, Count: 20},
+	})
+
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	if got := strings.Count(lines[0], "█"); got != histogramBarWidth {
+		t.Errorf("expected the fullest bucket to be %d wide, got %d: %q", histogramBarWidth, got, lines[0])
+	}
+	if got := strings.Count(lines[2], "█"); got != histogramBarWidth/2 {
+		t.Errorf("expected half a bar for half the count, got %d: %q", got, lines[2])
+	}
+	if !strings.Contains(lines[0], "10.0-15.0") || !strings.HasSuffix(lines[0], " 40") {
+		t.Errorf("expected bounds and count, got %q", lines[0])
+	}
+	for _, line := range lines {
+		if n := utf8.RuneCountInString(line); n > 60 {
+			t.Errorf("expected a line to fit the 60 column box, got %d: %q", n, line)
+		}
+	}
+}
+
+func TestEndpointLabel(t *testing.T) {
+	tests := []struct {
+		ep       internal.EndpointResult
+		expected string
+	}{
+		{internal.EndpointResult{Path: "/health"}, "/health"},
+		{internal.EndpointResult{Path: "/health", Method: "GET"}, "/health"},
+		{internal.EndpointResult{Path: "/api/workouts", Method: "POST"}, "POST /api/workouts"},
+	}
+
+	for _, tt := range tests {
+		if got := endpointLabel(tt.ep); got != tt.expected {
+			t.Errorf("endpointLabel(%+v) = %q, want %q", tt.ep, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package reporter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// csvHeader names the columns of a CSV report
+var csvHeader = []string{"run_id", "phase", "metric_name", "value", "unit"}
+
+// CSV reporter writing one row per metric observation, in a long format that
+// pandas, R, spreadsheets, and SQL COPY load without reshaping
+type CSV struct {
+	outputPath string
+	prefix     string // Generated filename prefix, empty for DefaultOutputPrefix
+}
+
+// NewCSV creates a new CSV reporter
+func NewCSV(outputPath string) *CSV {
+	return &CSV{outputPath: outputPath}
+}
+
+// SetPrefix replaces DefaultOutputPrefix in generated filenames, e.g. with
+// "staging" for staging_<timestamp>.csv. Explicit file paths are unaffected.
+func (c *CSV) SetPrefix(prefix string) {
+	c.prefix = prefix
+}
+
+// csvRow is one metric observation of a CSV report
+type csvRow struct {
+	phase, metric string
+	value         float64
+	unit          string
+}
+
+// Report writes the benchmark results to a CSV file
+// If outputPath is a directory, generates a timestamped filename
+// If outputPath is a file, uses it directly
+func (c *CSV) Report(result *internal.BenchmarkResult) (string, error) {
+	outputFile := c.outputPath
+	info, err := os.Stat(c.outputPath)
+	isDir := (err == nil && info.IsDir()) || strings.HasSuffix(c.outputPath, "/")
+	if isDir || !strings.HasSuffix(strings.ToLower(c.outputPath), ".csv") {
+		filename := fmt.Sprintf("%s_%s.csv", outputPrefix(c.prefix), result.Timestamp.Format("2006-01-02_150405"))
+		outputFile = filepath.Join(c.outputPath, filename)
+	}
+
+	dir := filepath.Dir(outputFile)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("create directory: %w", err)
+		}
+	}
+
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return "", fmt.Errorf("create file: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	runID := result.Timestamp.Format(time.RFC3339)
+	if err := w.Write(csvHeader); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+	for _, row := range csvRows(result) {
+		record := []string{runID, row.phase, row.metric, strconv.FormatFloat(row.value, 'f', -1, 64), row.unit}
+		if err := w.Write(record); err != nil {
+			return "", fmt.Errorf("write file: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+
+	return outputFile, nil
+}
+
+// csvRows returns the metric observations of result, phase by phase. Endpoint
+// metrics are named <endpoint>.<metric>, as in Diff.
+func csvRows(result *internal.BenchmarkResult) []csvRow {
+	var rows []csvRow
+	add := func(phase, metric string, value float64, unit string) {
+		rows = append(rows, csvRow{phase, metric, value, unit})
+	}
+
+	if conn := result.Connectivity; conn != nil {
+		add("connectivity", "dns_ms", conn.DNSMs, "ms")
+		add("connectivity", "tcp_ms", conn.TCPMs, "ms")
+		// No row for plain HTTP, whose TLS time is 0 or NotApplicableMs
+		if conn.TLSMs > 0 {
+			add("connectivity", "tls_ms", conn.TLSMs, "ms")
+		}
+		add("connectivity", "total_ms", conn.TotalMs, "ms")
+	}
+
+	if h := result.Health; h != nil {
+		add("health", "response_ms", h.ResponseMs, "ms")
+	}
+
+	for _, ep := range result.Endpoints {
+		label := endpointLabel(ep)
+		add("endpoint", label+".response_ms", ep.ResponseMs, "ms")
+		if ep.TTFBMs > 0 {
+			add("endpoint", label+".ttfb_ms", ep.TTFBMs, "ms")
+		}
+		if ep.TotalMs > 0 {
+			add("endpoint", label+".total_ms", ep.TotalMs, "ms")
+		}
+		if ep.Status != 0 {
+			add("endpoint", label+".status", float64(ep.Status), "code")
+		}
+		add("endpoint", label+".success", boolValue(ep.Success), "bool")
+	}
+
+	if fe := result.Frontend; fe != nil {
+		add("frontend", "total_size_kb", fe.TotalSizeKB, "KB")
+		add("frontend", "total_time_ms", fe.TotalTimeMs, "ms")
+	}
+
+	if lt := result.LoadTest; lt != nil {
+		add("loadtest", "rps", lt.RPS, "req/s")
+		if lt.TotalRequests > 0 {
+			add("loadtest", "success_rate", float64(lt.Successful)/float64(lt.TotalRequests)*100, "%")
+		}
+		add("loadtest", "total_requests", float64(lt.TotalRequests), "count")
+		add("loadtest", "failed", float64(lt.Failed), "count")
+		for _, p := range loadTestPercentiles(lt) {
+			add("loadtest", "latency_"+p.Label+"_ms", p.Ms, "ms")
+		}
+		add("loadtest", "min_latency_ms", lt.MinLatencyMs, "ms")
+		add("loadtest", "max_latency_ms", lt.MaxLatencyMs, "ms")
+		add("loadtest", "avg_latency_ms", lt.AvgLatencyMs, "ms")
+	}
+
+	return rows
+}
+
+// boolValue returns 1 for true and 0 for false
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+package reporter
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestCSV_Report(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC),
+		Target:    "https://example.com",
+		Connectivity: &internal.ConnectivityResult{
+			DNSMs:   10.5,
+			TCPMs:   25.3,
+			TLSMs:   45.2,
+			TotalMs: 81,
+		},
+		Health: &internal.HealthResult{ResponseMs: 15.5},
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/workouts", ResponseMs: 42, Status: 200, Success: true},
+			{Path: "/api/workouts", Method: "POST", ResponseMs: 60, Status: 500},
+		},
+		LoadTest: &internal.LoadTestResult{
+			TotalRequests: 200,
+			Successful:    150,
+			Failed:        50,
+			RPS:           20,
+			Percentiles:   map[string]float64{"p99.9": 300, "p50": 40, "p99": 250},
+		},
+	}
+
+	c := NewCSV(tmpDir)
+	c.SetPrefix("staging")
+	writtenPath, err := c.Report(result)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if want := filepath.Join(tmpDir, "staging_2026-01-03_120000.csv"); writtenPath != want {
+		t.Errorf("expected %s, got %s", want, writtenPath)
+	}
+
+	f, err := os.Open(writtenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("expected valid CSV, got: %v", err)
+	}
+
+	if !slices.Equal(records[0], csvHeader) {
+		t.Errorf("expected header %v, got %v", csvHeader, records[0])
+	}
+	rows := make(map[string][]string)
+	var loadTestMetrics []string
+	for _, record := range records[1:] {
+		if record[0] != "2026-01-03T12:00:00Z" {
+			t.Errorf("expected run_id from the timestamp, got %q", record[0])
+		}
+		rows[record[1]+" "+record[2]] = record[3:]
+		if record[1] == "loadtest" {
+			loadTestMetrics = append(loadTestMetrics, record[2])
+		}
+	}
+
+	tests := []struct {
+		key         string
+		value, unit string
+	}{
+		{"connectivity dns_ms", "10.5", "ms"},
+		{"connectivity tls_ms", "45.2", "ms"},
+		{"connectivity total_ms", "81", "ms"},
+		{"health response_ms", "15.5", "ms"},
+		{"endpoint /api/workouts.response_ms", "42", "ms"},
+		{"endpoint POST /api/workouts.status", "500", "code"},
+		{"endpoint POST /api/workouts.success", "0", "bool"},
+		{"loadtest rps", "20", "req/s"},
+		{"loadtest success_rate", "75", "%"},
+		{"loadtest latency_p99.9_ms", "300", "ms"},
+	}
+	for _, tt := range tests {
+		if got := rows[tt.key]; !slices.Equal(got, []string{tt.value, tt.unit}) {
+			t.Errorf("%s: expected %s %s, got %v", tt.key, tt.value, tt.unit, got)
+		}
+	}
+
+	// Percentiles are listed in ascending order
+	p50, p99, p999 := slices.Index(loadTestMetrics, "latency_p50_ms"), slices.Index(loadTestMetrics, "latency_p99_ms"), slices.Index(loadTestMetrics, "latency_p99.9_ms")
+	if p50 < 0 || p99 < p50 || p999 < p99 {
+		t.Errorf("expected ascending percentiles, got %v", loadTestMetrics)
+	}
+	if _, ok := rows["frontend total_size_kb"]; ok {
+		t.Error("expected no frontend rows without a frontend result")
+	}
+}
+
+func TestCSVRows_PlainHTTP(t *testing.T) {
+	for _, tls := range []float64{0, internal.NotApplicableMs} {
+		result := &internal.BenchmarkResult{
+			Connectivity: &internal.ConnectivityResult{DNSMs: 1, TCPMs: 2, TLSMs: tls, TotalMs: 3},
+		}
+		for _, row := range csvRows(result) {
+			if row.metric == "tls_ms" {
+				t.Errorf("expected no tls_ms row for TLS time %v, got %v", tls, row.value)
+			}
+		}
+	}
+}
+
+func TestCSV_Report_FilePath(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "nested", "results.csv")
+
+	writtenPath, err := NewCSV(outputPath).Report(&internal.BenchmarkResult{Timestamp: time.Now()})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if writtenPath != outputPath {
+		t.Errorf("expected %s, got %s", outputPath, writtenPath)
+	}
+}
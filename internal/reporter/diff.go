@@ -0,0 +1,110 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// DiffRow is a metric that changed between two results
+type DiffRow struct {
+	Metric string // Dotted name, e.g. load_test.rps or endpoints./api/workouts.response_ms
+	Before float64
+	After  float64
+	Delta  string // Change as formatted for comparison reports, e.g. "🔴 +4.20 (+12.0%)"
+}
+
+// diffMetric is a metric compared by Diff, with the delta formatter that
+// knows whether it is better higher or lower
+type diffMetric struct {
+	name          string
+	before, after float64
+	format        func(last, first float64) string
+}
+
+// Diff returns the metrics that changed from before to after: connectivity,
+// health, endpoints matched by method and path, frontend totals, and load test
+// throughput, latency, and error rate. Metrics missing from either result, and
+// changes too small to show in a comparison report, are left out.
+func Diff(before, after *internal.BenchmarkResult) []DiffRow {
+	var metrics []diffMetric
+	add := func(name string, b, a float64, format func(last, first float64) string) {
+		metrics = append(metrics, diffMetric{name, b, a, format})
+	}
+
+	if b, a := before.Connectivity, after.Connectivity; b != nil && a != nil {
+		add("connectivity.dns_ms", b.DNSMs, a.DNSMs, formatDelta)
+		add("connectivity.tcp_ms", b.TCPMs, a.TCPMs, formatDelta)
+		// A plain HTTP run has no handshake to compare
+		if b.TLSMs != internal.NotApplicableMs && a.TLSMs != internal.NotApplicableMs {
+			add("connectivity.tls_ms", b.TLSMs, a.TLSMs, formatDelta)
+		}
+		add("connectivity.total_ms", b.TotalMs, a.TotalMs, formatDelta)
+	}
+
+	if b, a := before.Health, after.Health; b != nil && a != nil {
+		add("health.response_ms", b.ResponseMs, a.ResponseMs, formatDelta)
+	}
+
+	beforeEndpoints := make(map[string]internal.EndpointResult, len(before.Endpoints))
+	for _, ep := range before.Endpoints {
+		beforeEndpoints[endpointLabel(ep)] = ep
+	}
+	for _, a := range after.Endpoints {
+		label := endpointLabel(a)
+		if b, ok := beforeEndpoints[label]; ok {
+			add("endpoints."+label+".response_ms", b.ResponseMs, a.ResponseMs, formatDelta)
+		}
+	}
+
+	if b, a := before.Frontend, after.Frontend; b != nil && a != nil {
+		add("frontend.total_size_kb", b.TotalSizeKB, a.TotalSizeKB, formatDeltaSize)
+		add("frontend.total_time_ms", b.TotalTimeMs, a.TotalTimeMs, formatDelta)
+	}
+
+	if b, a := before.LoadTest, after.LoadTest; b != nil && a != nil {
+		add("load_test.rps", b.RPS, a.RPS, formatDeltaRPS)
+		add("load_test.latency_p50_ms", b.LatencyP50Ms, a.LatencyP50Ms, formatDelta)
+		add("load_test.latency_p95_ms", b.LatencyP95Ms, a.LatencyP95Ms, formatDelta)
+		add("load_test.latency_p99_ms", b.LatencyP99Ms, a.LatencyP99Ms, formatDelta)
+		add("load_test.error_rate_pct", loadTestErrorRate(b), loadTestErrorRate(a), formatDelta)
+	}
+
+	var rows []DiffRow
+	for _, m := range metrics {
+		delta := m.format(m.after, m.before)
+		if delta == "-" || delta == "⚪ ~0" {
+			continue
+		}
+		rows = append(rows, DiffRow{Metric: m.name, Before: m.before, After: m.after, Delta: delta})
+	}
+	return rows
+}
+
+// loadTestErrorRate returns the percentage of failed load test requests
+func loadTestErrorRate(lt *internal.LoadTestResult) float64 {
+	if lt.TotalRequests == 0 {
+		return 0
+	}
+	return float64(lt.Failed) / float64(lt.TotalRequests) * 100
+}
+
+// WriteDiff writes rows as a compact metric = before → after table, headed by
+// the names of the two results
+func WriteDiff(w io.Writer, beforeName, afterName string, rows []DiffRow) {
+	fmt.Fprintf(w, "Diff: %s → %s\n\n", beforeName, afterName)
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No metrics changed.")
+		return
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row.Metric))
+	}
+	for _, row := range rows {
+		values := fmt.Sprintf("%.2f → %.2f", row.Before, row.After)
+		fmt.Fprintf(w, "%-*s = %-22s %s\n", width, row.Metric, values, row.Delta)
+	}
+}
@@ -0,0 +1,70 @@
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestDiff(t *testing.T) {
+	before := &internal.BenchmarkResult{
+		Connectivity: &internal.ConnectivityResult{DNSMs: 10, TCPMs: 5, TLSMs: internal.NotApplicableMs, TotalMs: 15},
+		Health:       &internal.HealthResult{ResponseMs: 20},
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/workouts", ResponseMs: 40},
+			{Path: "/api/removed", ResponseMs: 10},
+		},
+		LoadTest: &internal.LoadTestResult{RPS: 100, LatencyP50Ms: 10, LatencyP95Ms: 50, LatencyP99Ms: 80, TotalRequests: 1000},
+	}
+	after := &internal.BenchmarkResult{
+		Connectivity: &internal.ConnectivityResult{DNSMs: 10, TCPMs: 5, TLSMs: 30, TotalMs: 15}, // Now over HTTPS
+		Health:       &internal.HealthResult{ResponseMs: 20.005},                                // Within the noise
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/workouts", ResponseMs: 30},
+			{Path: "/api/added", ResponseMs: 10},
+		},
+		Frontend: &internal.FrontendResult{TotalSizeKB: 500}, // Not in before
+		LoadTest: &internal.LoadTestResult{RPS: 80, LatencyP50Ms: 10, LatencyP95Ms: 60, LatencyP99Ms: 80, TotalRequests: 1000, Failed: 20},
+	}
+
+	rows := Diff(before, after)
+	want := []DiffRow{
+		{Metric: "endpoints./api/workouts.response_ms", Before: 40, After: 30, Delta: "🟢 -10.00 (-25.0%)"},
+		{Metric: "load_test.rps", Before: 100, After: 80, Delta: "🔴 -20.00 (-20.0%)"},
+		{Metric: "load_test.latency_p95_ms", Before: 50, After: 60, Delta: "🔴 +10.00 (+20.0%)"},
+		{Metric: "load_test.error_rate_pct", Before: 0, After: 2, Delta: "🔴 +2.00"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d changed metrics, got %d: %+v", len(want), len(rows), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d: expected %+v, got %+v", i, want[i], rows[i])
+		}
+	}
+
+	if rows := Diff(before, before); len(rows) != 0 {
+		t.Errorf("expected no changes between a result and itself, got %+v", rows)
+	}
+}
+
+func TestWriteDiff(t *testing.T) {
+	var sb strings.Builder
+	WriteDiff(&sb, "before.json", "after.json", []DiffRow{
+		{Metric: "load_test.rps", Before: 100, After: 80, Delta: "🔴 -20.00 (-20.0%)"},
+		{Metric: "health.response_ms", Before: 20, After: 15, Delta: "🟢 -5.00 (-25.0%)"},
+	})
+	want := "Diff: before.json → after.json\n\n" +
+		"load_test.rps      = 100.00 → 80.00         🔴 -20.00 (-20.0%)\n" +
+		"health.response_ms = 20.00 → 15.00          🟢 -5.00 (-25.0%)\n"
+	if sb.String() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, sb.String())
+	}
+
+	sb.Reset()
+	WriteDiff(&sb, "a.json", "b.json", nil)
+	if !strings.Contains(sb.String(), "No metrics changed.") {
+		t.Errorf("expected a no-change message, got %q", sb.String())
+	}
+}
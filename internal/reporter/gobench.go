@@ -0,0 +1,87 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// GoBench reporter writes results in `go test -bench` text format for benchstat
+type GoBench struct{}
+
+// NewGoBench creates a new Go benchmark format reporter
+func NewGoBench() *GoBench {
+	return &GoBench{}
+}
+
+// WriteText writes one benchmark line per measurement to w.
+// Load test throughput becomes ns/op (1e9 / RPS) with latency percentiles as
+// extra metrics; single requests are reported as one iteration of their duration.
+func (g *GoBench) WriteText(w io.Writer, result *internal.BenchmarkResult) error {
+	var sb strings.Builder
+
+	// Configuration lines are carried through by benchstat
+	sb.WriteString(fmt.Sprintf("target: %s\n", result.Target))
+	if result.Version != "" {
+		sb.WriteString(fmt.Sprintf("version: %s\n", result.Version))
+	}
+
+	if result.Health != nil && result.Health.Error == "" {
+		writeBenchLine(&sb, "BenchmarkHealth", 1, 1, msToNs(result.Health.ResponseMs))
+	}
+
+	for _, ep := range result.Endpoints {
+		if !ep.Success {
+			continue
+		}
+		name := "BenchmarkEndpoint_" + benchNamePart(ep.Path)
+		if ep.Method != "" && ep.Method != "GET" {
+			name += "_" + ep.Method
+		}
+		writeBenchLine(&sb, name, 1, 1, msToNs(ep.ResponseMs))
+	}
+
+	if lt := result.LoadTest; lt != nil && lt.RPS > 0 {
+		writeBenchLine(&sb, "BenchmarkLoadTest", lt.Concurrent, lt.TotalRequests, 1e9/lt.RPS,
+			fmt.Sprintf("%.2f p50-ms", lt.LatencyP50Ms),
+			fmt.Sprintf("%.2f p95-ms", lt.LatencyP95Ms),
+			fmt.Sprintf("%.2f p99-ms", lt.LatencyP99Ms),
+			fmt.Sprintf("%.2f req/s", lt.RPS))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeBenchLine writes a single "BenchmarkName-N  iterations  value ns/op ..." line
+func writeBenchLine(sb *strings.Builder, name string, procs, iterations int, nsPerOp float64, extra ...string) {
+	if procs < 1 {
+		procs = 1
+	}
+	sb.WriteString(fmt.Sprintf("%s-%d\t%8d\t%12.0f ns/op", name, procs, iterations, nsPerOp))
+	for _, metric := range extra {
+		sb.WriteString("\t" + metric)
+	}
+	sb.WriteString("\n")
+}
+
+// benchNamePart converts an endpoint path into a benchmark name segment,
+// e.g. "/api/pr-movements" becomes "api_pr_movements"
+func benchNamePart(path string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.Trim(path, "/"))
+	if name == "" {
+		return "root"
+	}
+	return name
+}
+
+func msToNs(ms float64) float64 {
+	return ms * 1e6
+}
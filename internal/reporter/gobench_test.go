@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestGoBench_WriteText(t *testing.T) {
+	result := &internal.BenchmarkResult{
+		Target:  "https://example.com",
+		Version: "1.2.3",
+		Health:  &internal.HealthResult{Status: "healthy", ResponseMs: 12.5},
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/pr-movements", ResponseMs: 40, Success: true},
+			{Path: "/api/workouts", Method: "POST", ResponseMs: 20, Success: true},
+			{Path: "/api/broken", ResponseMs: 5, Success: false},
+		},
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:    5,
+			TotalRequests: 986,
+			RPS:           100,
+			LatencyP50Ms:  49.8,
+			LatencyP95Ms:  59.8,
+			LatencyP99Ms:  67,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewGoBench().WriteText(&buf, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	expected := []string{
+		"target: https://example.com\n",
+		"version: 1.2.3\n",
+		"BenchmarkHealth-1\t       1\t    12500000 ns/op\n",
+		"BenchmarkEndpoint_api_pr_movements-1\t       1\t    40000000 ns/op\n",
+		"BenchmarkEndpoint_api_workouts_POST-1\t",
+		"BenchmarkLoadTest-5\t     986\t    10000000 ns/op\t49.80 p50-ms\t59.80 p95-ms\t67.00 p99-ms\t100.00 req/s\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "api_broken") {
+		t.Error("expected failed endpoints to be omitted")
+	}
+}
+
+func TestGoBench_WriteText_NoLoadTest(t *testing.T) {
+	var buf bytes.Buffer
+	result := &internal.BenchmarkResult{
+		Target:   "https://example.com",
+		LoadTest: &internal.LoadTestResult{Concurrent: 1},
+	}
+	if err := NewGoBench().WriteText(&buf, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "BenchmarkLoadTest") {
+		t.Error("expected no load test line when RPS is zero")
+	}
+}
+
+func TestBenchNamePart(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/workouts", "api_workouts"},
+		{"/api/notifications/count", "api_notifications_count"},
+		{"/api/wods?limit=10", "api_wods_limit_10"},
+		{"/", "root"},
+	}
+
+	for _, tt := range tests {
+		if got := benchNamePart(tt.path); got != tt.expected {
+			t.Errorf("benchNamePart(%q) = %q, want %q", tt.path, got, tt.expected)
+		}
+	}
+}
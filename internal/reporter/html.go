@@ -0,0 +1,445 @@
+package reporter
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// maxLabelLength caps chart labels; longer ones are truncated
+const maxLabelLength = 32
+
+// chartJS is Chart.js, embedded so the report opens offline
+//
+//go:embed assets/chart.min.js
+var chartJS []byte
+
+// chartJSURL loads chartJS as a data URL
+var chartJSURL = template.URL("data:text/javascript;base64," + base64.StdEncoding.EncodeToString(chartJS))
+
+// chartPalette colours chart series and pie slices, in order
+var chartPalette = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+
+// Inline Markdown the HTML reporter converts
+var (
+	markdownBold   = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	markdownItalic = regexp.MustCompile(`\*([^*\s][^*]*?)\*`)
+	tableSeparator = regexp.MustCompile(`^:?-+:?$`)
+)
+
+// HTML reporter writing a single self-contained page: the sections and tables
+// of the Markdown report, styled, under Chart.js charts. Chart.js is embedded
+// in the page, which needs no external resources, so it opens offline and can
+// be attached to a ticket or email as it is.
+type HTML struct {
+	outputPath string
+	config     *internal.Config
+}
+
+// NewHTML creates a new HTML reporter
+func NewHTML(outputPath string, config *internal.Config) *HTML {
+	return &HTML{
+		outputPath: outputPath,
+		config:     config,
+	}
+}
+
+// htmlChart is a titled chart of an HTML report
+type htmlChart struct {
+	Title  string
+	Config map[string]any // Chart.js configuration, written to the page as JSON
+}
+
+// htmlPageData fills htmlPage
+type htmlPageData struct {
+	Title   string
+	Header  template.HTML // Report title and generation time
+	Charts  []htmlChart
+	ChartJS template.URL
+	Body    template.HTML // The remaining sections of the report
+}
+
+var htmlPage = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, Helvetica, Arial, sans-serif; max-width: 1140px; margin: 2rem auto; padding: 0 1rem; color: #24292f; line-height: 1.5; }
+h1, h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .3rem; }
+table { border-collapse: collapse; margin: 1rem 0; }
+th, td { border: 1px solid #d0d7de; padding: .35rem .75rem; text-align: left; }
+th { background: #f6f8fa; }
+tr:nth-child(even) td { background: #fafbfc; }
+.num { text-align: right; font-variant-numeric: tabular-nums; }
+code { background: #f6f8fa; padding: .1rem .3rem; border-radius: 4px; font-size: 90%; }
+pre { background: #f6f8fa; padding: .75rem 1rem; border-radius: 4px; overflow-x: auto; }
+pre code { padding: 0; }
+li.nested { margin-left: 1.5rem; }
+blockquote { margin: 1rem 0; padding: .25rem 1rem; border-left: 4px solid #f28e2b; background: #fff8f0; }
+.charts { display: grid; grid-template-columns: repeat(auto-fit, minmax(540px, 1fr)); gap: 1rem; margin: 1rem 0 2rem; }
+figure { margin: 0; padding: .75rem; border: 1px solid #d0d7de; border-radius: 6px; }
+figcaption { font-weight: 600; margin-bottom: .5rem; }
+</style>
+</head>
+<body>
+{{.Header}}
+{{- if .Charts}}
+<h2>Charts</h2>
+<section class="charts">
+{{- range $i, $chart := .Charts}}
+<figure>
+<figcaption>{{$chart.Title}}</figcaption>
+<canvas id="chart-{{$i}}" width="520" height="260"></canvas>
+</figure>
+{{- end}}
+</section>
+<script src="{{.ChartJS}}"></script>
+<script>
+{{- range $i, $chart := .Charts}}
+new Chart(document.getElementById("chart-{{$i}}"), {{$chart.Config}});
+{{- end}}
+</script>
+{{- end}}
+{{.Body}}
+</body>
+</html>
+`))
+
+// Report writes the benchmark results to an HTML file
+func (h *HTML) Report(result *internal.BenchmarkResult) (string, error) {
+	outputFile := h.outputPath
+	info, err := os.Stat(h.outputPath)
+	isDir := (err == nil && info.IsDir()) || strings.HasSuffix(h.outputPath, "/")
+	if isDir || !strings.HasSuffix(strings.ToLower(h.outputPath), ".html") {
+		filename := fmt.Sprintf("%s_%s.html", outputPrefix(h.config.OutputPrefix), result.Timestamp.Format("2006-01-02_150405"))
+		outputFile = filepath.Join(h.outputPath, filename)
+	}
+
+	// The first section heading ends the header, so the charts sit above the details
+	markdown := NewMarkdown(filepath.Dir(outputFile), h.config).render(result)
+	header, body := markdown, ""
+	if i := strings.Index(markdown, "\n## "); i >= 0 {
+		header, body = markdown[:i], markdown[i+1:]
+	}
+
+	var buf bytes.Buffer
+	err = htmlPage.Execute(&buf, htmlPageData{
+		Title:   "ActaLog Benchmark Report - " + result.Timestamp.Format("2006-01-02 15:04:05"),
+		Header:  template.HTML(markdownToHTML(header)),
+		Charts:  htmlCharts(result),
+		ChartJS: chartJSURL,
+		Body:    template.HTML(markdownToHTML(body)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("render html: %w", err)
+	}
+
+	// Create parent directories if they don't exist
+	dir := filepath.Dir(outputFile)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("create directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(outputFile, buf.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("write html file: %w", err)
+	}
+
+	return outputFile, nil
+}
+
+// htmlCharts returns the charts for the phases result includes: load test
+// latency percentiles and throughput over time, endpoint response times, and
+// frontend asset sizes by type
+func htmlCharts(result *internal.BenchmarkResult) []htmlChart {
+	var charts []htmlChart
+
+	if lt := result.LoadTest; lt != nil && lt.TotalRequests > 0 {
+		var labels []string
+		var values []float64
+		for _, p := range loadTestPercentiles(lt) {
+			labels = append(labels, p.Label)
+			values = append(values, p.Ms)
+		}
+		charts = append(charts, htmlChart{"Load Test Latency Percentiles (ms)", barChartConfig("bar", "yAxes", labels, values)})
+
+		if len(lt.RPSTimeline) > 1 {
+			labels := make([]string, len(lt.RPSTimeline))
+			for i := range lt.RPSTimeline {
+				labels[i] = fmt.Sprintf("%ds", i)
+			}
+			charts = append(charts, htmlChart{"Load Test Throughput (req/s by second)", lineChartConfig(labels, lt.RPSTimeline)})
+		}
+	}
+
+	if len(result.Endpoints) > 0 {
+		labels := make([]string, len(result.Endpoints))
+		values := make([]float64, len(result.Endpoints))
+		for i, ep := range result.Endpoints {
+			labels[i] = truncate(endpointLabel(ep), maxLabelLength)
+			values[i] = ep.ResponseMs
+		}
+		charts = append(charts, htmlChart{"Endpoint Response Times (ms)", barChartConfig("horizontalBar", "xAxes", labels, values)})
+	}
+
+	if fe := result.Frontend; fe != nil {
+		sizes := make(map[string]float64)
+		if fe.IndexHTML != nil {
+			sizes["html"] += fe.IndexHTML.SizeKB
+		}
+		for _, asset := range fe.Assets {
+			assetType := asset.Type
+			if assetType == "" {
+				assetType = "other"
+			}
+			sizes[assetType] += asset.SizeKB
+		}
+		var types []string
+		for assetType, kb := range sizes {
+			if kb > 0 {
+				types = append(types, assetType)
+			}
+		}
+		if len(types) > 0 {
+			// Largest first, so colours follow the legend order
+			sort.Slice(types, func(i, j int) bool {
+				if sizes[types[i]] != sizes[types[j]] {
+					return sizes[types[i]] > sizes[types[j]]
+				}
+				return types[i] < types[j]
+			})
+			values := make([]float64, len(types))
+			for i, assetType := range types {
+				values[i] = sizes[assetType]
+			}
+			charts = append(charts, htmlChart{"Frontend Asset Size by Type (KB)", pieChartConfig(types, values)})
+		}
+	}
+
+	return charts
+}
+
+// barChartConfig configures a bar chart of chartType ("bar" or
+// "horizontalBar") whose value axis, "yAxes" or "xAxes", starts at zero
+func barChartConfig(chartType, valueAxis string, labels []string, values []float64) map[string]any {
+	return map[string]any{
+		"type": chartType,
+		"data": map[string]any{
+			"labels":   labels,
+			"datasets": []map[string]any{{"data": values, "backgroundColor": chartColors(len(values))}},
+		},
+		"options": map[string]any{
+			"animation": map[string]any{"duration": 0},
+			"legend":    map[string]any{"display": false},
+			"scales":    map[string]any{valueAxis: []map[string]any{{"ticks": map[string]any{"beginAtZero": true}}}},
+		},
+	}
+}
+
+// lineChartConfig configures a line chart of values over labels
+func lineChartConfig(labels []string, values []float64) map[string]any {
+	return map[string]any{
+		"type": "line",
+		"data": map[string]any{
+			"labels":   labels,
+			"datasets": []map[string]any{{"data": values, "borderColor": chartColors(1)[0], "fill": false}},
+		},
+		"options": map[string]any{
+			"animation": map[string]any{"duration": 0},
+			"legend":    map[string]any{"display": false},
+			"scales":    map[string]any{"yAxes": []map[string]any{{"ticks": map[string]any{"beginAtZero": true}}}},
+		},
+	}
+}
+
+// pieChartConfig configures a pie chart with a legend of the labels
+func pieChartConfig(labels []string, values []float64) map[string]any {
+	return map[string]any{
+		"type": "pie",
+		"data": map[string]any{
+			"labels":   labels,
+			"datasets": []map[string]any{{"data": values, "backgroundColor": chartColors(len(values))}},
+		},
+		"options": map[string]any{
+			"animation": map[string]any{"duration": 0},
+			"legend":    map[string]any{"position": "right"},
+		},
+	}
+}
+
+// chartColors returns the palette colours of n series, reusing the palette
+// when it runs out
+func chartColors(n int) []string {
+	colors := make([]string, n)
+	for i := range colors {
+		colors[i] = chartPalette[i%len(chartPalette)]
+	}
+	return colors
+}
+
+// markdownToHTML converts the Markdown written by the Markdown reporter:
+// headings, pipe tables, lists, block quotes, fenced code, rules, paragraphs,
+// and bold, italic, and code spans. All text is escaped before any markup is
+// added; only the <details> and <summary> tags the reporter writes itself
+// are kept as HTML.
+func markdownToHTML(markdown string) string {
+	var sb strings.Builder
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			sb.WriteString("<p>" + inlineMarkdown(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	lines := strings.Split(markdown, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " ")
+		switch {
+		case strings.HasPrefix(line, "```"):
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(lines[i], "```"); i++ {
+				code = append(code, lines[i])
+			}
+			sb.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case line == "":
+			flush()
+		case line == "<details>" || line == "</details>":
+			flush()
+			sb.WriteString(line + "\n")
+		case strings.HasPrefix(line, "<summary>") && strings.HasSuffix(line, "</summary>"):
+			flush()
+			summary := strings.TrimSuffix(strings.TrimPrefix(line, "<summary>"), "</summary>")
+			sb.WriteString("<summary>" + inlineMarkdown(summary) + "</summary>\n")
+		case line == "---":
+			flush()
+			sb.WriteString("<hr>\n")
+		case strings.HasPrefix(line, "#"):
+			flush()
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			fmt.Fprintf(&sb, "<h%d>%s</h%d>\n", level, inlineMarkdown(strings.TrimSpace(line[level:])), level)
+		case strings.HasPrefix(line, "|"):
+			flush()
+			var rows []string
+			for ; i < len(lines) && strings.HasPrefix(lines[i], "|"); i++ {
+				rows = append(rows, strings.TrimSpace(lines[i]))
+			}
+			i--
+			writeHTMLTable(&sb, rows)
+		case strings.HasPrefix(line, "- "):
+			flush()
+			sb.WriteString("<ul>\n")
+			for ; i < len(lines) && (strings.HasPrefix(lines[i], "- ") || strings.HasPrefix(lines[i], "  ")); i++ {
+				if strings.HasPrefix(lines[i], "- ") {
+					sb.WriteString("<li>" + inlineMarkdown(lines[i][2:]) + "</li>\n")
+				} else {
+					// Continuation and nested items join the item above
+					item := strings.TrimPrefix(strings.TrimSpace(lines[i]), "- ")
+					sb.WriteString("<li class=\"nested\">" + inlineMarkdown(item) + "</li>\n")
+				}
+			}
+			i--
+			sb.WriteString("</ul>\n")
+		case strings.HasPrefix(line, ">"):
+			flush()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(lines[i], ">"); i++ {
+				quote = append(quote, strings.TrimSpace(strings.TrimPrefix(lines[i], ">")))
+			}
+			i--
+			sb.WriteString("<blockquote><p>" + inlineMarkdown(strings.Join(quote, " ")) + "</p></blockquote>\n")
+		default:
+			paragraph = append(paragraph, strings.TrimSpace(line))
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+// writeHTMLTable writes the rows of a Markdown pipe table as an HTML table,
+// right-aligning the columns the separator row marks with a trailing colon
+func writeHTMLTable(sb *strings.Builder, rows []string) {
+	header := tableCells(rows[0])
+	rightAligned := make([]bool, len(header))
+	body := rows[1:]
+	if len(body) > 0 {
+		separator := tableCells(body[0])
+		isSeparator := true
+		for _, cell := range separator {
+			isSeparator = isSeparator && tableSeparator.MatchString(cell)
+		}
+		if isSeparator {
+			for i, cell := range separator {
+				if i < len(rightAligned) {
+					rightAligned[i] = strings.HasSuffix(cell, ":")
+				}
+			}
+			body = body[1:]
+		}
+	}
+
+	cell := func(tag, text string, i int) {
+		class := ""
+		if i < len(rightAligned) && rightAligned[i] {
+			class = ` class="num"`
+		}
+		fmt.Fprintf(sb, "<%s%s>%s</%s>", tag, class, inlineMarkdown(text), tag)
+	}
+
+	sb.WriteString("<table>\n<thead><tr>")
+	for i, text := range header {
+		cell("th", text, i)
+	}
+	sb.WriteString("</tr></thead>\n<tbody>\n")
+	for _, row := range body {
+		sb.WriteString("<tr>")
+		for i, text := range tableCells(row) {
+			cell("td", text, i)
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+}
+
+// tableCells splits a Markdown table row into its trimmed cells
+func tableCells(row string) []string {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// inlineMarkdown escapes text and converts its code spans, bold, and italics
+func inlineMarkdown(text string) string {
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		escaped := html.EscapeString(part)
+		// Odd parts lie between backticks; an unmatched backtick is kept as is
+		if i%2 == 1 && i < len(parts)-1 {
+			parts[i] = "<code>" + escaped + "</code>"
+			continue
+		}
+		escaped = markdownBold.ReplaceAllString(escaped, "<strong>$1</strong>")
+		parts[i] = markdownItalic.ReplaceAllString(escaped, "<em>$1</em>")
+		if i%2 == 1 {
+			parts[i] = "`" + parts[i]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,154 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestHTML_Report(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", OutputPrefix: "staging"}
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC),
+		Target:    "https://example.com",
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/workouts", ResponseMs: 42, Status: 200, Success: true},
+			{Path: "/api/<script>", ResponseMs: 60, Status: 500, Error: "unexpected <script>alert(1)</script>"},
+		},
+		Frontend: &internal.FrontendResult{
+			IndexHTML:   &internal.AssetResult{Path: "/", SizeKB: 2},
+			TotalSizeKB: 152,
+			Assets: []internal.AssetResult{
+				{Path: "/assets/app.js", SizeKB: 120, Type: "js"},
+				{Path: "/assets/app.css", SizeKB: 30, Type: "css"},
+			},
+		},
+		LoadTest: &internal.LoadTestResult{
+			TotalRequests: 200,
+			Successful:    200,
+			RPS:           20,
+			Percentiles:   map[string]float64{"p50": 40, "p95": 120, "p99": 250},
+			RPSTimeline:   []float64{18, 22, 20},
+		},
+	}
+
+	writtenPath, err := NewHTML(tmpDir, config).Report(result)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if want := filepath.Join(tmpDir, "staging_2026-01-03_120000.html"); writtenPath != want {
+		t.Errorf("expected %s, got %s", want, writtenPath)
+	}
+
+	data, err := os.ReadFile(writtenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<h1>",
+		"<table>",
+		"Load Test Latency Percentiles (ms)",
+		"Load Test Throughput (req/s by second)",
+		"Endpoint Response Times (ms)",
+		"Frontend Asset Size by Type (KB)",
+		`<canvas id="chart-3"`,
+		`<script src="data:text/javascript;base64,`,
+		`"type":"horizontalBar"`,
+		`"labels":["0s","1s","2s"]`,
+		`"labels":["js","css","html"]`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected page to contain %q", want)
+		}
+	}
+
+	// Self-contained: Chart.js is embedded and nothing is fetched from elsewhere
+	for _, unwanted := range []string{"<link", `src="http`, "<img"} {
+		if strings.Contains(page, unwanted) {
+			t.Errorf("expected no %q in a self-contained page", unwanted)
+		}
+	}
+	if !strings.Contains(page, "&lt;script&gt;") || strings.Contains(page, "<script>alert") || strings.Contains(page, "/api/<script>") {
+		t.Error("expected result text to be escaped in the tables and the chart data")
+	}
+	if strings.Index(page, "<h2>Charts</h2>") > strings.Index(page, "<h2>") {
+		t.Error("expected the charts before the report sections")
+	}
+}
+
+func TestHTML_Report_FilePath(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "nested", "report.html")
+
+	writtenPath, err := NewHTML(outputPath, &internal.Config{}).Report(&internal.BenchmarkResult{Timestamp: time.Now()})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if writtenPath != outputPath {
+		t.Errorf("expected %s, got %s", outputPath, writtenPath)
+	}
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	markdown := strings.Join([]string{
+		"## Results",
+		"",
+		"Ran for **30s** against `/api/workouts`.",
+		"",
+		"| Metric | Value |",
+		"|--------|------:|",
+		"| p50 | 12.0 ms |",
+		"",
+		"- first",
+		"- second",
+		"",
+		"> **Note:** slow",
+		"",
+		"```bash",
+		"curl -H 'A: <b>'",
+		"```",
+		"",
+		"<details>",
+		"<summary>Failed <img src=x onerror=alert(1)></summary>",
+		"</details>",
+		"",
+		"**<b>bold</b>** and `<i>`",
+		"",
+		"---",
+		"*Report generated*",
+	}, "\n")
+
+	got := markdownToHTML(markdown)
+
+	for _, want := range []string{
+		"<h2>Results</h2>",
+		"<p>Ran for <strong>30s</strong> against <code>/api/workouts</code>.</p>",
+		"<th>Metric</th><th class=\"num\">Value</th>",
+		"<td>p50</td><td class=\"num\">12.0 ms</td>",
+		"<ul>\n<li>first</li>\n<li>second</li>\n</ul>",
+		"<blockquote><p><strong>Note:</strong> slow</p></blockquote>",
+		"<pre><code>curl -H &#39;A: &lt;b&gt;&#39;</code></pre>",
+		"<details>\n<summary>Failed &lt;img src=x onerror=alert(1)&gt;</summary>\n</details>",
+		"<p><strong>&lt;b&gt;bold&lt;/b&gt;</strong> and <code>&lt;i&gt;</code></p>",
+		"<hr>",
+		"<p><em>Report generated</em></p>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "|---") {
+		t.Error("expected the separator row to be consumed")
+	}
+	if strings.Contains(got, "<img") || strings.Contains(got, "<b>") {
+		t.Errorf("expected markup in the text escaped, got:\n%s", got)
+	}
+}
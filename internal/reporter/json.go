@@ -1,18 +1,37 @@
 package reporter
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/johnzastrow/actalog-benchmark/internal"
 )
 
+// DefaultOutputPrefix starts generated report filenames, as in
+// benchmark_<timestamp>.json, unless --output-prefix replaces it
+const DefaultOutputPrefix = "benchmark"
+
+// outputPrefix returns prefix, or DefaultOutputPrefix when it is empty
+func outputPrefix(prefix string) string {
+	if prefix == "" {
+		return DefaultOutputPrefix
+	}
+	return prefix
+}
+
 // JSON reporter for machine-readable output
 type JSON struct {
 	outputPath string
+	prefix     string // Generated filename prefix, empty for DefaultOutputPrefix
+
+	timestamp time.Time // Overrides the result timestamp in generated filenames when set
+	suffix    string    // Appended to the filename before the extension
+	appendTo  bool      // Accumulate results in a JSON array instead of overwriting
 }
 
 // NewJSON creates a new JSON reporter
@@ -20,6 +39,27 @@ func NewJSON(outputPath string) *JSON {
 	return &JSON{outputPath: outputPath}
 }
 
+// SetPrefix replaces DefaultOutputPrefix in generated filenames, e.g. with
+// "staging" for staging_<timestamp>.json. Explicit file paths are unaffected.
+func (j *JSON) SetPrefix(prefix string) {
+	j.prefix = prefix
+}
+
+// SetFilename names the file <prefix>_<timestamp><suffix>.json, or inserts
+// suffix before the extension of an explicit file path, so that the files of
+// one --repeat invocation share a timestamp
+func (j *JSON) SetFilename(timestamp time.Time, suffix string) {
+	j.timestamp = timestamp
+	j.suffix = suffix
+}
+
+// SetAppend makes Report add the result to a JSON array in an explicit output
+// file instead of overwriting it. An existing single-result file becomes the
+// first element of the array. Directory output is unaffected.
+func (j *JSON) SetAppend(enabled bool) {
+	j.appendTo = enabled
+}
+
 // Report writes the benchmark results to a JSON file
 // If outputPath is a directory, generates a timestamped filename
 // If outputPath is a file, uses it directly
@@ -38,9 +78,21 @@ func (j *JSON) Report(result *internal.BenchmarkResult) (string, error) {
 
 	if isDir || !strings.HasSuffix(strings.ToLower(j.outputPath), ".json") {
 		// Treat as directory, generate timestamped filename
-		timestamp := result.Timestamp.Format("2006-01-02_150405")
-		filename := fmt.Sprintf("benchmark_%s.json", timestamp)
+		ts := result.Timestamp
+		if !j.timestamp.IsZero() {
+			ts = j.timestamp
+		}
+		filename := fmt.Sprintf("%s_%s%s.json", outputPrefix(j.prefix), ts.Format("2006-01-02_150405"), j.suffix)
 		outputFile = filepath.Join(j.outputPath, filename)
+	} else {
+		if j.suffix != "" {
+			outputFile = strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + j.suffix + filepath.Ext(outputFile)
+		}
+		if j.appendTo {
+			if data, err = appendResult(outputFile, data); err != nil {
+				return "", err
+			}
+		}
 	}
 
 	// Create parent directories if they don't exist
@@ -57,3 +109,37 @@ func (j *JSON) Report(result *internal.BenchmarkResult) (string, error) {
 
 	return outputFile, nil
 }
+
+// appendResult returns the results already in path, as a JSON array, with the
+// marshaled result added. A missing file starts a new array.
+func appendResult(path string, result []byte) ([]byte, error) {
+	var results []json.RawMessage
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	case !json.Valid(existing):
+		return nil, fmt.Errorf("append to %s: existing file is not valid JSON", path)
+	default:
+		trimmed := bytes.TrimSpace(existing)
+		switch trimmed[0] {
+		case '[':
+			if err := json.Unmarshal(trimmed, &results); err != nil {
+				return nil, fmt.Errorf("append to %s: %w", path, err)
+			}
+		case '{':
+			results = append(results, json.RawMessage(trimmed))
+		default:
+			return nil, fmt.Errorf("append to %s: existing file must hold a result object or array", path)
+		}
+	}
+
+	results = append(results, json.RawMessage(result))
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal results: %w", err)
+	}
+	return data, nil
+}
@@ -337,6 +337,132 @@ func TestJSON_Report_DirectoryMode(t *testing.T) {
 	}
 }
 
+func TestJSON_Report_SetFilename(t *testing.T) {
+	tmpDir := t.TempDir()
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Date(2026, 1, 3, 14, 31, 10, 0, time.UTC),
+		Target:    "https://example.com",
+		Overall:   "pass",
+	}
+	start := time.Date(2026, 1, 3, 14, 30, 45, 0, time.UTC)
+
+	// Directory mode uses the given timestamp rather than the result's
+	j := NewJSON(tmpDir)
+	j.SetFilename(start, "_run2")
+	writtenPath, err := j.Report(result)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if expected := "benchmark_2026-01-03_143045_run2.json"; filepath.Base(writtenPath) != expected {
+		t.Errorf("expected filename '%s', got '%s'", expected, filepath.Base(writtenPath))
+	}
+
+	// Explicit file paths get the suffix before the extension
+	j = NewJSON(filepath.Join(tmpDir, "results.json"))
+	j.SetFilename(start, "_agg")
+	writtenPath, err = j.Report(result)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if expected := "results_agg.json"; filepath.Base(writtenPath) != expected {
+		t.Errorf("expected filename '%s', got '%s'", expected, filepath.Base(writtenPath))
+	}
+}
+
+func TestJSON_Report_SetPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Date(2026, 1, 3, 14, 30, 45, 0, time.UTC),
+		Target:    "https://staging.example.com",
+		Overall:   "pass",
+	}
+
+	j := NewJSON(tmpDir)
+	j.SetPrefix("staging")
+	writtenPath, err := j.Report(result)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if expected := "staging_2026-01-03_143045.json"; filepath.Base(writtenPath) != expected {
+		t.Errorf("expected filename '%s', got '%s'", expected, filepath.Base(writtenPath))
+	}
+
+	// Explicit file paths keep their name
+	j = NewJSON(filepath.Join(tmpDir, "results.json"))
+	j.SetPrefix("staging")
+	if writtenPath, err = j.Report(result); err != nil || filepath.Base(writtenPath) != "results.json" {
+		t.Errorf("expected results.json, got %s, %v", writtenPath, err)
+	}
+}
+
+func TestJSON_Report_Append(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	j := NewJSON(path)
+	j.SetAppend(true)
+
+	for i := 0; i < 2; i++ {
+		result := &internal.BenchmarkResult{
+			Timestamp: time.Date(2026, 1, 3+i, 14, 30, 45, 0, time.UTC),
+			Target:    "https://example.com",
+			Overall:   "pass",
+		}
+		if _, err := j.Report(result); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	var results []internal.BenchmarkResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		t.Fatalf("expected a JSON array, got: %v", err)
+	}
+	if len(results) != 2 || results[1].Timestamp.Day() != 4 {
+		t.Errorf("expected 2 results in run order, got %+v", results)
+	}
+}
+
+func TestJSON_Report_AppendToSingleResult(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	first := &internal.BenchmarkResult{Target: "https://example.com", Version: "1.0.0", Overall: "pass"}
+	if _, err := NewJSON(path).Report(first); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	j := NewJSON(path)
+	j.SetAppend(true)
+	if _, err := j.Report(&internal.BenchmarkResult{Target: "https://example.com", Version: "1.0.1", Overall: "pass"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	var results []internal.BenchmarkResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		t.Fatalf("expected a JSON array, got: %v", err)
+	}
+	if len(results) != 2 || results[0].Version != "1.0.0" || results[1].Version != "1.0.1" {
+		t.Errorf("expected existing result converted to the first element, got %+v", results)
+	}
+}
+
+func TestJSON_Report_AppendInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	j := NewJSON(path)
+	j.SetAppend(true)
+	if _, err := j.Report(&internal.BenchmarkResult{Overall: "pass"}); err == nil {
+		t.Fatal("expected error for invalid existing file")
+	}
+
+	// The invalid file is left untouched
+	data, _ := os.ReadFile(path)
+	if string(data) != "{not json" {
+		t.Errorf("expected existing file unchanged, got %q", data)
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsAt(s, substr, 0))
 }
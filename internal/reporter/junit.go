@@ -0,0 +1,258 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// junitClassPrefix starts the classname of every JUnit test case, followed by
+// the suite name
+const junitClassPrefix = "actalog-bench."
+
+// JUnit reporter writing JUnit XML, which Jenkins, GitHub Actions, GitLab CI,
+// and CircleCI display as test results. Each benchmark phase is a test suite
+// and each metric a test case; a metric that crossed its alert threshold is a
+// failure, and a phase that could not run is an error.
+type JUnit struct {
+	outputPath string
+	prefix     string // Generated filename prefix, empty for DefaultOutputPrefix
+}
+
+// NewJUnit creates a new JUnit reporter
+func NewJUnit(outputPath string) *JUnit {
+	return &JUnit{outputPath: outputPath}
+}
+
+// SetPrefix replaces DefaultOutputPrefix in generated filenames, e.g. with
+// "staging" for staging_<timestamp>.xml. Explicit file paths are unaffected.
+func (j *JUnit) SetPrefix(prefix string) {
+	j.prefix = prefix
+}
+
+// junitTestSuites is the root element of a JUnit report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite is one benchmark phase
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is one metric of a phase. Time is the metric itself, in
+// seconds, for latency metrics, so CI trend graphs plot it.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"` // The measured value and unit
+}
+
+// junitProblem is the failure or error of a test case
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+// Report writes the benchmark results to a JUnit XML file
+// If outputPath is a directory, generates a timestamped filename
+// If outputPath is a file, uses it directly
+func (j *JUnit) Report(result *internal.BenchmarkResult) (string, error) {
+	outputFile := j.outputPath
+	info, err := os.Stat(j.outputPath)
+	isDir := (err == nil && info.IsDir()) || strings.HasSuffix(j.outputPath, "/")
+	if isDir || !strings.HasSuffix(strings.ToLower(j.outputPath), ".xml") {
+		filename := fmt.Sprintf("%s_%s.xml", outputPrefix(j.prefix), result.Timestamp.Format("2006-01-02_150405"))
+		outputFile = filepath.Join(j.outputPath, filename)
+	}
+
+	report := junitTestSuites{Name: "actalog-bench", Suites: junitSuites(result)}
+	for _, suite := range report.Suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+	}
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal results: %w", err)
+	}
+
+	dir := filepath.Dir(outputFile)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("create directory: %w", err)
+		}
+	}
+
+	data = append([]byte(xml.Header), append(data, '\n')...)
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+
+	return outputFile, nil
+}
+
+// junitSuites maps the phases of result to test suites: connectivity (DNS,
+// TCP, and TLS times), health (status and response time), endpoints (one case
+// per endpoint), and load (p95, p99, RPS, and error rate). Breaches of
+// metrics without a case of their own, such as server-side operations, form
+// a thresholds suite so that CI still shows them.
+func junitSuites(result *internal.BenchmarkResult) []junitTestSuite {
+	breaches := make(map[string]internal.ThresholdBreach)
+	for _, b := range result.ThresholdBreaches {
+		breaches[b.Metric] = b
+	}
+	reported := make(map[string]bool)
+
+	// metric returns a case for a measured value, failed if the value breached
+	// the threshold of thresholdMetric
+	metric := func(suite, name, thresholdMetric string, value float64, unit string) junitTestCase {
+		c := junitTestCase{Name: name, Classname: junitClassPrefix + suite, Time: "0", SystemOut: fmt.Sprintf("%.2f %s", value, unit)}
+		if unit == "ms" {
+			c.Time = junitSeconds(value)
+		}
+		if b, ok := breaches[thresholdMetric]; ok && thresholdMetric != "" {
+			c.Failure = &junitProblem{Message: breachDetail(b), Type: b.Severity}
+			reported[thresholdMetric] = true
+		}
+		return c
+	}
+	errored := func(suite, name, message string) junitTestCase {
+		return junitTestCase{Name: name, Classname: junitClassPrefix + suite, Time: "0", Error: &junitProblem{Message: message, Type: "error"}}
+	}
+
+	var suites []junitTestSuite
+	add := func(name string, seconds float64, cases ...junitTestCase) {
+		suite := junitTestSuite{
+			Name:      name,
+			Tests:     len(cases),
+			Time:      fmt.Sprintf("%.3f", seconds),
+			Timestamp: result.Timestamp.Format("2006-01-02T15:04:05"),
+			Cases:     cases,
+		}
+		for _, c := range cases {
+			if c.Failure != nil {
+				suite.Failures++
+			}
+			if c.Error != nil {
+				suite.Errors++
+			}
+		}
+		suites = append(suites, suite)
+	}
+
+	// A run that stopped early, e.g. when authentication failed
+	if result.Error != "" {
+		add("benchmark", 0, errored("benchmark", "run", result.Error))
+	}
+
+	if conn := result.Connectivity; conn != nil {
+		if !conn.Connected || conn.Error != "" {
+			message := conn.Error
+			if message == "" {
+				message = "not connected"
+			}
+			add("connectivity", 0, errored("connectivity", "connect", message))
+		} else {
+			cases := []junitTestCase{
+				metric("connectivity", "dns_ms", "", conn.DNSMs, "ms"),
+				metric("connectivity", "tcp_ms", "", conn.TCPMs, "ms"),
+			}
+			// Plain HTTP has no TLS handshake to report
+			if conn.TLSMs > 0 {
+				cases = append(cases, metric("connectivity", "tls_ms", "", conn.TLSMs, "ms"))
+			}
+			add("connectivity", conn.TotalMs/1000, cases...)
+		}
+	}
+
+	if h := result.Health; h != nil {
+		if h.Error != "" {
+			add("health", 0, errored("health", "status", h.Error))
+		} else {
+			status := junitTestCase{Name: "status", Classname: junitClassPrefix + "health", Time: "0", SystemOut: h.Status}
+			if h.Status != "healthy" {
+				status.Failure = &junitProblem{
+					Message: fmt.Sprintf("Health status %s (HTTP %d)", h.Status, h.HTTPStatus),
+					Type:    "status",
+				}
+			}
+			add("health", h.ResponseMs/1000, status,
+				metric("health", "response_ms", metricHealthResponse, h.ResponseMs, "ms"))
+		}
+	}
+
+	if len(result.Endpoints) > 0 {
+		var cases []junitTestCase
+		var seconds float64
+		for _, ep := range result.Endpoints {
+			label := endpointLabel(ep)
+			c := metric("endpoints", label, metricHTTPSDowngradePrefix+label, ep.ResponseMs, "ms")
+			if !ep.Success && c.Failure == nil {
+				message := ep.Error
+				if message == "" {
+					message = fmt.Sprintf("HTTP %d", ep.Status)
+				}
+				c.Failure = &junitProblem{Message: message, Type: "request"}
+			}
+			cases = append(cases, c)
+			seconds += ep.ResponseMs / 1000
+		}
+		add("endpoints", seconds, cases...)
+	}
+
+	if lt := result.LoadTest; lt != nil {
+		cases := []junitTestCase{
+			metric("load", "latency_p95_ms", metricLatencyP95, lt.LatencyP95Ms, "ms"),
+			metric("load", "latency_p99_ms", metricLatencyP99, lt.LatencyP99Ms, "ms"),
+			metric("load", "rps", metricRPS, lt.RPS, "req/s"),
+		}
+		if lt.TotalRequests > 0 {
+			cases = append(cases, metric("load", "error_rate_pct", metricErrorRate, float64(lt.Failed)/float64(lt.TotalRequests)*100, "%"))
+		} else {
+			cases = append(cases, errored("load", "error_rate_pct", "no requests completed"))
+		}
+		add("load", lt.DurationSec, cases...)
+	}
+
+	var other []junitTestCase
+	for _, b := range result.ThresholdBreaches {
+		if !reported[b.Metric] {
+			other = append(other, junitTestCase{
+				Name:      b.Metric,
+				Classname: junitClassPrefix + "thresholds",
+				Time:      "0",
+				Failure:   &junitProblem{Message: breachDetail(b), Type: b.Severity},
+				SystemOut: fmt.Sprintf("%.2f", b.Actual),
+			})
+		}
+	}
+	if len(other) > 0 {
+		add("thresholds", 0, other...)
+	}
+
+	return suites
+}
+
+// junitSeconds formats a duration in milliseconds as JUnit seconds
+func junitSeconds(ms float64) string {
+	return fmt.Sprintf("%.3f", ms/1000)
+}
@@ -0,0 +1,155 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestJUnit_Report(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC),
+		Connectivity: &internal.ConnectivityResult{
+			Connected: true,
+			DNSMs:     10.5,
+			TCPMs:     25.3,
+			TLSMs:     45.2,
+			TotalMs:   81,
+		},
+		Health: &internal.HealthResult{Status: "healthy", ResponseMs: 15.5, HTTPStatus: 200},
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/workouts", ResponseMs: 42, Status: 200, Success: true},
+			{Path: "/api/workouts", Method: "POST", ResponseMs: 60, Status: 500},
+		},
+		LoadTest: &internal.LoadTestResult{
+			DurationSec:   30,
+			TotalRequests: 200,
+			Successful:    200,
+			RPS:           20,
+			LatencyP95Ms:  620,
+			LatencyP99Ms:  700,
+		},
+		ThresholdBreaches: []internal.ThresholdBreach{
+			{Metric: metricLatencyP95, Actual: 620, Threshold: 500, Severity: internal.SeverityWarning},
+			{Metric: metricDatabasePrefix + "list_workouts", Actual: 80, Threshold: 50, Severity: internal.SeverityWarning},
+		},
+	}
+
+	j := NewJUnit(tmpDir)
+	j.SetPrefix("staging")
+	writtenPath, err := j.Report(result)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if want := filepath.Join(tmpDir, "staging_2026-01-03_120000.xml"); writtenPath != want {
+		t.Errorf("expected %s, got %s", want, writtenPath)
+	}
+
+	data, err := os.ReadFile(writtenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("expected valid XML, got: %v", err)
+	}
+
+	// One endpoint failed, and two thresholds were breached
+	if report.Tests != 12 || report.Failures != 3 || report.Errors != 0 {
+		t.Errorf("expected 12 tests with 3 failures, got %d tests, %d failures, %d errors", report.Tests, report.Failures, report.Errors)
+	}
+
+	suites := make(map[string]junitTestSuite)
+	for _, s := range report.Suites {
+		suites[s.Name] = s
+	}
+	tests := []struct {
+		suite    string
+		cases    int
+		failures int
+	}{
+		{"connectivity", 3, 0},
+		{"health", 2, 0},
+		{"endpoints", 2, 1},
+		{"load", 4, 1},
+		{"thresholds", 1, 1},
+	}
+	for _, tt := range tests {
+		s, ok := suites[tt.suite]
+		if !ok {
+			t.Errorf("expected a %s suite", tt.suite)
+			continue
+		}
+		if len(s.Cases) != tt.cases || s.Failures != tt.failures {
+			t.Errorf("%s: expected %d cases with %d failures, got %d with %d", tt.suite, tt.cases, tt.failures, len(s.Cases), s.Failures)
+		}
+	}
+
+	p95 := suites["load"].Cases[0]
+	if p95.Name != "latency_p95_ms" || p95.Failure == nil || p95.Failure.Message != "p95 latency 620.00 ms exceeds threshold 500 ms" {
+		t.Errorf("expected a failed p95 case describing the breach, got %+v", p95)
+	}
+	if p95.Time != "0.620" {
+		t.Errorf("expected the latency as the case time, got %s", p95.Time)
+	}
+	if c := suites["endpoints"].Cases[1]; c.Name != "POST /api/workouts" || c.Failure == nil || c.Failure.Message != "HTTP 500" {
+		t.Errorf("expected the failed POST to fail with its status, got %+v", c)
+	}
+}
+
+func TestJUnit_Report_Errors(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "nested", "junit.xml")
+
+	result := &internal.BenchmarkResult{
+		Timestamp:    time.Now(),
+		Connectivity: &internal.ConnectivityResult{Error: "dial tcp: connection refused"},
+		Health:       &internal.HealthResult{Status: "unhealthy", HTTPStatus: 503},
+	}
+
+	writtenPath, err := NewJUnit(outputPath).Report(result)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if writtenPath != outputPath {
+		t.Errorf("expected %s, got %s", outputPath, writtenPath)
+	}
+
+	data, err := os.ReadFile(writtenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("expected valid XML, got: %v", err)
+	}
+
+	conn := report.Suites[0]
+	if conn.Name != "connectivity" || conn.Errors != 1 || conn.Cases[0].Error.Message != "dial tcp: connection refused" {
+		t.Errorf("expected the connection error, got %+v", conn)
+	}
+	health := report.Suites[1]
+	if health.Failures != 1 || health.Cases[0].Failure.Message != "Health status unhealthy (HTTP 503)" {
+		t.Errorf("expected a failed health status, got %+v", health)
+	}
+}
+
+func TestJUnitSuites_PlainHTTP(t *testing.T) {
+	result := &internal.BenchmarkResult{
+		Connectivity: &internal.ConnectivityResult{Connected: true, DNSMs: 1, TCPMs: 2, TLSMs: internal.NotApplicableMs, TotalMs: 3},
+	}
+	suites := junitSuites(result)
+	if len(suites) != 1 || len(suites[0].Cases) != 2 {
+		t.Fatalf("expected a connectivity suite with dns_ms and tcp_ms only, got %+v", suites)
+	}
+	for _, c := range suites[0].Cases {
+		if c.Name == "tls_ms" {
+			t.Errorf("expected no tls_ms case for a plain HTTP target, got %+v", c)
+		}
+	}
+}
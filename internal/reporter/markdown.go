@@ -4,12 +4,34 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/johnzastrow/actalog-benchmark/internal"
 )
 
+// lowPathMTU is the path MTU below which a link likely carries tunnel overhead.
+// It sits under the 1500-byte Ethernet MTU to allow for TCP option bytes, which
+// make MSS-based estimates read slightly low.
+const lowPathMTU = 1480
+
+// superlinearTolerance is how much faster than the record count an operation's
+// duration may grow in a records sweep before it is flagged, allowing for noise
+const superlinearTolerance = 1.5
+
+// Memory leak detection limits for --leak-detect window RPS
+const (
+	leakMaxSlopeFraction = -0.05 // Per-window RPS change, as a fraction of the mean RPS
+	leakMinRSquare       = 0.8   // The decline must be steady, not noise
+)
+
+// Cache lifetime limits for the frontend caching strategy summary
+const (
+	longCacheMaxAge  = 30 * 24 * 60 * 60 // Seconds; fingerprinted assets should be cached at least this long
+	shortCacheMaxAge = 60 * 60           // Seconds; below this an asset is revalidated on nearly every visit
+)
+
 // Markdown reporter for markdown formatted output
 type Markdown struct {
 	outputDir string
@@ -28,9 +50,28 @@ func NewMarkdown(outputDir string, config *internal.Config) *Markdown {
 func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 	// Generate filename with timestamp
 	timestamp := result.Timestamp.Format("2006-01-02_150405")
-	filename := fmt.Sprintf("benchmark_%s.md", timestamp)
+	filename := fmt.Sprintf("%s_%s.md", outputPrefix(m.config.OutputPrefix), timestamp)
 	filepath := filepath.Join(m.outputDir, filename)
 
+	content := m.render(result)
+
+	// Create parent directories if they don't exist
+	if m.outputDir != "" && m.outputDir != "." {
+		if err := os.MkdirAll(m.outputDir, 0755); err != nil {
+			return "", fmt.Errorf("create directory: %w", err)
+		}
+	}
+
+	// Write to file
+	if err := os.WriteFile(filepath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("write markdown file: %w", err)
+	}
+
+	return filepath, nil
+}
+
+// render returns the text of the markdown report for result
+func (m *Markdown) render(result *internal.BenchmarkResult) string {
 	var sb strings.Builder
 
 	// Header
@@ -48,6 +89,9 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 
 	// Executive Summary
 	sb.WriteString("## Executive Summary\n\n")
+	if result.AgentCount > 0 {
+		sb.WriteString(fmt.Sprintf("These results merge **%d benchmark agents**. Load test counts and throughput are summed across agents; latency percentiles are request-weighted averages of each agent's percentiles and are approximate.\n\n", result.AgentCount))
+	}
 	if result.Error != "" {
 		sb.WriteString(fmt.Sprintf("The benchmark **failed** with error: %s\n\n", result.Error))
 	} else if result.Overall == "pass" {
@@ -57,6 +101,16 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		sb.WriteString(fmt.Sprintf("The benchmark completed with status: **%s**. ", strings.ToUpper(result.Overall)))
 		sb.WriteString("Some checks may require attention.\n\n")
 	}
+	if result.LoadTest != nil && result.LoadTest.EarlyAbort {
+		sb.WriteString(fmt.Sprintf("❌ **The load test was aborted early:** %s.\n\n", result.LoadTest.AbortReason))
+	}
+	if result.GracefulShutdown {
+		sb.WriteString(fmt.Sprintf("⚠️ **The run was stopped before it finished:** %s.\n\n", result.ShutdownReason))
+	}
+	if result.SimulatedLatencyMs > 0 || result.SimulatedLossRate > 0 {
+		sb.WriteString(fmt.Sprintf("⚠️ **Network conditions were simulated:** every request was held for %.0fms or more and %.1f%% were dropped, so these results do not reflect the real network.\n\n",
+			result.SimulatedLatencyMs, result.SimulatedLossRate*100))
+	}
 
 	// Test Parameters
 	sb.WriteString("## Test Parameters\n\n")
@@ -67,7 +121,22 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 	if result.Version != "" {
 		sb.WriteString(fmt.Sprintf("| Target Version | %s |\n", result.Version))
 	}
-	sb.WriteString(fmt.Sprintf("| Authenticated | %t |\n", m.config.User != ""))
+	if result.UserAgent != "" {
+		sb.WriteString(fmt.Sprintf("| User-Agent | `%s` |\n", result.UserAgent))
+	}
+	if result.TraceID != "" {
+		sb.WriteString(fmt.Sprintf("| Trace ID | `%s` |\n", result.TraceID))
+	}
+	if result.WaitedForHealthySec > 0 {
+		sb.WriteString(fmt.Sprintf("| Waited for Healthy | %.1fs |\n", result.WaitedForHealthySec))
+	}
+	if result.SampleCount > 1 {
+		sb.WriteString(fmt.Sprintf("| Averaged Runs | %d |\n", result.SampleCount))
+	}
+	if phases := formatPhaseDurations(result.PhaseDurations); phases != "" {
+		sb.WriteString(fmt.Sprintf("| Phase Durations | %s |\n", phases))
+	}
+	sb.WriteString(fmt.Sprintf("| Authenticated | %t |\n", m.config.User != "" || m.config.Token != ""))
 	if m.config.User != "" {
 		sb.WriteString(fmt.Sprintf("| User | %s |\n", m.config.User))
 	}
@@ -95,14 +164,64 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		} else {
 			sb.WriteString("| Metric | Time (ms) | Description |\n")
 			sb.WriteString("|--------|----------:|-------------|\n")
-			sb.WriteString(fmt.Sprintf("| DNS Resolution | %.2f | Time to resolve the hostname to an IP address |\n", result.Connectivity.DNSMs))
-			sb.WriteString(fmt.Sprintf("| TCP Connect | %.2f | Time to establish a TCP connection to the server |\n", result.Connectivity.TCPMs))
+			if result.Connectivity.UnixSocket != "" {
+				sb.WriteString(fmt.Sprintf("| Socket Connect | %.2f | Time to connect to the Unix domain socket `%s` (no DNS or TCP) |\n", result.Connectivity.TCPMs, result.Connectivity.UnixSocket))
+			} else {
+				sb.WriteString(fmt.Sprintf("| DNS Resolution | %.2f | Time to resolve the hostname to an IP address |\n", result.Connectivity.DNSMs))
+				sb.WriteString(fmt.Sprintf("| TCP Connect | %.2f | Time to establish a TCP connection to the server |\n", result.Connectivity.TCPMs))
+			}
+			if result.Connectivity.IPv4Ms > 0 {
+				sb.WriteString(fmt.Sprintf("| IPv4 Probe | %.2f | TCP connect time to the server's IPv4 address |\n", result.Connectivity.IPv4Ms))
+			}
+			if result.Connectivity.IPv6Ms > 0 {
+				sb.WriteString(fmt.Sprintf("| IPv6 Probe | %.2f | TCP connect time to the server's IPv6 address |\n", result.Connectivity.IPv6Ms))
+			}
 			if result.Connectivity.TLSMs > 0 {
 				sb.WriteString(fmt.Sprintf("| TLS Handshake | %.2f | Time to complete the TLS/SSL handshake for HTTPS |\n", result.Connectivity.TLSMs))
 			}
+			if result.Connectivity.HTTP3Supported {
+				sb.WriteString(fmt.Sprintf("| HTTP/3 First Byte | %.2f | Time to the first byte of a request over QUIC, handshake included (not part of the total) |\n", result.Connectivity.HTTP3Ms))
+			}
 			sb.WriteString(fmt.Sprintf("| **Total** | **%.2f** | Total time to establish a secure connection |\n", result.Connectivity.TotalMs))
 			sb.WriteString("\n")
 
+			if result.Connectivity.TLSResumed {
+				sb.WriteString(fmt.Sprintf("**TLS Session Resumption:** A second connection resumed the session in %.2f ms, skipping the certificate exchange and key agreement of the full handshake above. ",
+					result.Connectivity.TLSResumedMs))
+				sb.WriteString("Returning visitors pay this shorter time.\n\n")
+			}
+
+			if fraction := result.Connectivity.KeepAliveReuseFraction; fraction != nil {
+				reuse := *fraction * 100
+				sb.WriteString(fmt.Sprintf("**Keep-Alive Reuse:** %.0f%% of sequential requests reused an existing connection. ", reuse))
+				if reuse >= 90 {
+					sb.WriteString("Connection reuse is working, so later requests skip the TCP and TLS setup cost.\n\n")
+				} else {
+					sb.WriteString("Connections are being closed between requests, so each one pays the full TCP and TLS setup cost. Check the server or proxy keep-alive settings.\n\n")
+				}
+			}
+
+			if result.Connectivity.HopCount > 0 {
+				sb.WriteString(fmt.Sprintf("**Network Hops:** %d hops to the server.", result.Connectivity.HopCount))
+				if n := len(result.Connectivity.TraceRouteMs); n > 0 {
+					sb.WriteString(fmt.Sprintf(" The last hop answered in %.2f ms.", result.Connectivity.TraceRouteMs[n-1]))
+				}
+				sb.WriteString(" Many hops add latency to every request; a nearby benchmark host gives a truer picture of server performance.\n\n")
+			}
+
+			if result.Connectivity.HTTP3Error != "" {
+				sb.WriteString(fmt.Sprintf("**HTTP/3:** Not supported. The server did not complete a request over QUIC (%s), so clients use HTTP/2 or HTTP/1.1 over TCP.\n\n", result.Connectivity.HTTP3Error))
+			}
+
+			if mtu := result.Connectivity.PathMTU; mtu > 0 {
+				sb.WriteString(fmt.Sprintf("**Path MTU:** about %d bytes. ", mtu))
+				if mtu < lowPathMTU {
+					sb.WriteString("This is below the standard Ethernet MTU of 1500 bytes, as is common over VPN tunnels. Large responses need more packets and may be fragmented or dropped, which can explain slow transfers.\n\n")
+				} else {
+					sb.WriteString("Large responses travel in full-size packets.\n\n")
+				}
+			}
+
 			// Interpretation
 			sb.WriteString("### Interpretation\n\n")
 			if result.Connectivity.TotalMs < 100 {
@@ -132,6 +251,15 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		sb.WriteString(fmt.Sprintf("| Status | %s |\n", status))
 		sb.WriteString(fmt.Sprintf("| Response Time | %.2f ms |\n", result.Health.ResponseMs))
 		sb.WriteString(fmt.Sprintf("| HTTP Status | %d |\n", result.Health.HTTPStatus))
+		if result.Health.Uptime != "" {
+			sb.WriteString(fmt.Sprintf("| Uptime | %s |\n", result.Health.Uptime))
+		}
+		if result.Health.ActiveConnections > 0 {
+			sb.WriteString(fmt.Sprintf("| Active Connections | %d |\n", result.Health.ActiveConnections))
+		}
+		if result.Health.MemoryMB > 0 {
+			sb.WriteString(fmt.Sprintf("| Memory | %.1f MB |\n", result.Health.MemoryMB))
+		}
 		if result.Health.Error != "" {
 			sb.WriteString(fmt.Sprintf("| Error | %s |\n", result.Health.Error))
 		}
@@ -154,14 +282,40 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		}
 	}
 
+	// gRPC Health Check
+	if health := result.GRPCHealth; health != nil {
+		sb.WriteString("## gRPC Health Check\n\n")
+		sb.WriteString(fmt.Sprintf("The gRPC Health Checking Protocol (`grpc.health.v1.Health/Check`) was called at `%s`. ", m.config.GRPCAddr))
+		sb.WriteString("The response time includes opening the connection.\n\n")
+
+		sb.WriteString("| Metric | Value |\n")
+		sb.WriteString("|--------|-------|\n")
+		status := "✅ " + health.Status
+		if health.Status != "healthy" {
+			status = "❌ " + health.Status
+		}
+		sb.WriteString(fmt.Sprintf("| Status | %s |\n", status))
+		sb.WriteString(fmt.Sprintf("| Response Time | %.2f ms |\n", health.ResponseMs))
+		if health.Error != "" {
+			sb.WriteString(fmt.Sprintf("| Error | %s |\n", health.Error))
+		}
+		sb.WriteString("\n")
+	}
+
 	// API Endpoints
 	if len(result.Endpoints) > 0 {
 		sb.WriteString("## API Endpoint Performance\n\n")
 		sb.WriteString("Each API endpoint was tested to measure response time and verify successful responses. ")
 		sb.WriteString("Response times under 100ms are generally considered excellent for API endpoints.\n\n")
+		switch {
+		case result.EndpointOrderSeed != 0:
+			sb.WriteString(fmt.Sprintf("Endpoints were requested in **%s** order (`--seed %d` repeats it) and are listed in the default order.\n\n", result.EndpointOrder, result.EndpointOrderSeed))
+		case result.EndpointOrder != "":
+			sb.WriteString(fmt.Sprintf("Endpoints were requested in **%s** order and are listed in the default order.\n\n", result.EndpointOrder))
+		}
 
-		sb.WriteString("| Endpoint | Response (ms) | Status | Result |\n")
-		sb.WriteString("|----------|-------------:|-------:|--------|\n")
+		sb.WriteString("| Endpoint | Response (ms) | TTFB (ms) | Status | Result |\n")
+		sb.WriteString("|----------|-------------:|----------:|-------:|--------|\n")
 		var totalTime float64
 		var successCount, failCount int
 		for _, ep := range result.Endpoints {
@@ -173,12 +327,34 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 				successCount++
 			}
 			totalTime += ep.ResponseMs
-			sb.WriteString(fmt.Sprintf("| `%s` | %.2f | %d | %s |\n", ep.Path, ep.ResponseMs, ep.Status, status))
+			ttfb := "-"
+			if ep.TTFBMs > 0 {
+				ttfb = fmt.Sprintf("%.2f", ep.TTFBMs)
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | %.2f | %s | %d | %s |\n", endpointLabel(ep), ep.ResponseMs, ttfb, ep.Status, status))
 		}
 		avgTime := totalTime / float64(len(result.Endpoints))
-		sb.WriteString(fmt.Sprintf("| **Average** | **%.2f** | | |\n", avgTime))
+		sb.WriteString(fmt.Sprintf("| **Average** | **%.2f** | | | |\n", avgTime))
 		sb.WriteString("\n")
 
+		writeColdStartTable(&sb, result.Endpoints)
+		writeSampleTable(&sb, result.Endpoints)
+		writeDeprecationWarnings(&sb, result.Endpoints)
+
+		var curlCommands []string
+		for _, ep := range result.Endpoints {
+			if !ep.Success && ep.CurlCommand != "" {
+				curlCommands = append(curlCommands, ep.CurlCommand)
+			}
+		}
+		if len(curlCommands) > 0 {
+			sb.WriteString("<details>\n<summary>Reproduce failed endpoints with curl</summary>\n\n")
+			sb.WriteString("Replace `<TOKEN>` with a valid JWT for authenticated endpoints.\n\n")
+			sb.WriteString("```bash\n")
+			sb.WriteString(strings.Join(curlCommands, "\n"))
+			sb.WriteString("\n```\n\n</details>\n\n")
+		}
+
 		// Interpretation
 		sb.WriteString("### Interpretation\n\n")
 		sb.WriteString(fmt.Sprintf("- **%d of %d** endpoints returned successful responses\n", successCount, len(result.Endpoints)))
@@ -203,28 +379,31 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		sb.WriteString("Frontend assets (HTML, JavaScript, CSS) directly impact the initial page load experience. ")
 		sb.WriteString("Smaller assets and faster load times improve user experience, especially on mobile devices.\n\n")
 
-		sb.WriteString("| Asset | Size (KB) | Time (ms) | Result |\n")
-		sb.WriteString("|-------|----------:|----------:|--------|\n")
+		sb.WriteString("| Asset | Size (KB) | Compression | Time (ms) | Result |\n")
+		sb.WriteString("|-------|----------:|------------:|----------:|--------|\n")
 		if result.Frontend.IndexHTML != nil {
 			status := "✅"
 			if !result.Frontend.IndexHTML.Success {
 				status = "❌"
 			}
-			sb.WriteString(fmt.Sprintf("| `index.html` | %.2f | %.2f | %s |\n",
-				result.Frontend.IndexHTML.SizeKB, result.Frontend.IndexHTML.ResponseMs, status))
+			sb.WriteString(fmt.Sprintf("| `index.html` | %.2f | %s | %.2f | %s |\n",
+				result.Frontend.IndexHTML.SizeKB, formatCompression(*result.Frontend.IndexHTML), result.Frontend.IndexHTML.ResponseMs, status))
 		}
 		for _, asset := range result.Frontend.Assets {
 			status := "✅"
 			if !asset.Success {
 				status = "❌"
 			}
-			sb.WriteString(fmt.Sprintf("| `%s` | %.2f | %.2f | %s |\n",
-				asset.Path, asset.SizeKB, asset.ResponseMs, status))
+			sb.WriteString(fmt.Sprintf("| `%s` | %.2f | %s | %.2f | %s |\n",
+				asset.Path, asset.SizeKB, formatCompression(asset), asset.ResponseMs, status))
 		}
-		sb.WriteString(fmt.Sprintf("| **Total** | **%.2f** | **%.2f** | |\n",
+		sb.WriteString(fmt.Sprintf("| **Total** | **%.2f** | | **%.2f** | |\n",
 			result.Frontend.TotalSizeKB, result.Frontend.TotalTimeMs))
 		sb.WriteString("\n")
 
+		writeSRITable(&sb, result.Frontend.Assets)
+		writeCachingTable(&sb, result.Frontend.Assets)
+
 		// Interpretation
 		sb.WriteString("### Interpretation\n\n")
 		sb.WriteString(fmt.Sprintf("- **Total bundle size:** %.2f KB\n", result.Frontend.TotalSizeKB))
@@ -241,15 +420,69 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		}
 	}
 
+	// Server-Sent Events
+	if sse := result.SSE; sse != nil {
+		sb.WriteString("## Server-Sent Events\n\n")
+		sb.WriteString(fmt.Sprintf("The event stream at `%s` was subscribed to and read for up to %d events. ", sse.Path, m.config.SSEEvents))
+		sb.WriteString("Time to first event is measured from the request, so it includes connecting and any server-side delay before the first event.\n\n")
+
+		sb.WriteString("| Metric | Value |\n")
+		sb.WriteString("|--------|-------|\n")
+		status := "✅ connected"
+		if !sse.Connected {
+			status = "❌ not connected"
+		}
+		sb.WriteString(fmt.Sprintf("| Status | %s |\n", status))
+		sb.WriteString(fmt.Sprintf("| Events Received | %d |\n", sse.EventsReceived))
+		if sse.EventsReceived > 0 {
+			sb.WriteString(fmt.Sprintf("| Time to First Event | %.2f ms |\n", sse.TimeToFirstEventMs))
+		}
+		if sse.EventsReceived > 1 {
+			sb.WriteString(fmt.Sprintf("| Avg Event Interval | %.2f ms |\n", sse.AvgIntervalMs))
+		}
+		if sse.Error != "" {
+			sb.WriteString(fmt.Sprintf("| Error | %s |\n", sse.Error))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Load Test
 	if result.LoadTest != nil {
-		sb.WriteString("## Load Test Results\n\n")
-		sb.WriteString("The load test simulates multiple concurrent users accessing the application simultaneously. ")
-		sb.WriteString("This helps identify performance bottlenecks and capacity limits.\n\n")
+		if result.LoadTest.StressedEndpoint != "" {
+			sb.WriteString(fmt.Sprintf("## Load Test Results: `%s`\n\n", result.LoadTest.StressedEndpoint))
+			sb.WriteString("This stress test sent every request to a single endpoint to measure its behavior under sustained concurrent load. ")
+			sb.WriteString("Results reflect that endpoint alone, not the application as a whole.\n\n")
+		} else {
+			sb.WriteString("## Load Test Results\n\n")
+			sb.WriteString("The load test simulates multiple concurrent users accessing the application simultaneously. ")
+			sb.WriteString("This helps identify performance bottlenecks and capacity limits.\n\n")
+		}
+
+		if result.LoadTest.EarlyAbort {
+			sb.WriteString(fmt.Sprintf("> ❌ **Load test aborted early** after %.0f seconds: %s. ", result.LoadTest.DurationSec, result.LoadTest.AbortReason))
+			sb.WriteString("The results below cover only the time before the abort.\n\n")
+		}
 
 		sb.WriteString("### Configuration\n\n")
 		sb.WriteString(fmt.Sprintf("- **Concurrent Workers:** %d\n", result.LoadTest.Concurrent))
-		sb.WriteString(fmt.Sprintf("- **Duration:** %.0f seconds\n\n", result.LoadTest.DurationSec))
+		sb.WriteString(fmt.Sprintf("- **Duration:** %.0f seconds\n", result.LoadTest.DurationSec))
+		if result.LoadTest.WarmupSec > 0 {
+			sb.WriteString(fmt.Sprintf("- **Warmup:** %.0f seconds before the measured run, not counted in the results\n", result.LoadTest.WarmupSec))
+		}
+		if lt := result.LoadTest; lt.ThinkTimeMs > 0 {
+			thinkTime := fmt.Sprintf("%.0fms", lt.ThinkTimeMs)
+			if lt.ThinkTimeJitterPct > 0 {
+				thinkTime += fmt.Sprintf(" plus up to %.0f%% at random", lt.ThinkTimeJitterPct)
+			}
+			sb.WriteString(fmt.Sprintf("- **Think Time:** %s after each response, so each worker paces like one user rather than sending back to back\n", thinkTime))
+		}
+		if result.LoadTest.StressedEndpoint != "" {
+			sb.WriteString(fmt.Sprintf("- **Stressed Endpoint:** `%s`\n", result.LoadTest.StressedEndpoint))
+		}
+		if result.LoadTest.EndpointStrategy != "" {
+			sb.WriteString(fmt.Sprintf("- **Endpoint Strategy:** %s\n", result.LoadTest.EndpointStrategy))
+		}
+		sb.WriteString("\n")
 
 		sb.WriteString("### Throughput\n\n")
 		sb.WriteString("| Metric | Value |\n")
@@ -260,7 +493,25 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		failRate := float64(result.LoadTest.Failed) / float64(result.LoadTest.TotalRequests) * 100
 		sb.WriteString(fmt.Sprintf("| Failed | %d (%.1f%%) |\n", result.LoadTest.Failed, failRate))
 		sb.WriteString(fmt.Sprintf("| **Requests/Second** | **%.2f** |\n", result.LoadTest.RPS))
+		if result.LoadTest.TargetRPS > 0 {
+			sb.WriteString(fmt.Sprintf("| Target RPS | %.2f |\n", result.LoadTest.TargetRPS))
+		}
+		if result.LoadTest.ArrivalRate > 0 {
+			sb.WriteString(fmt.Sprintf("| Arrival Rate | %.2f req/s |\n", result.LoadTest.ArrivalRate))
+			sb.WriteString(fmt.Sprintf("| Service Rate | %.2f req/s |\n", result.LoadTest.ServiceRate))
+			sb.WriteString(fmt.Sprintf("| Max Queue Depth | %d |\n", result.LoadTest.MaxQueueDepth))
+		}
+		if result.LoadTest.NewConnections > 0 {
+			sb.WriteString(fmt.Sprintf("| New Connections | %d |\n", result.LoadTest.NewConnections))
+		}
+		if result.LoadTest.DNSCacheHits > 0 {
+			sb.WriteString(fmt.Sprintf("| DNS Cache Hits | %d |\n", result.LoadTest.DNSCacheHits))
+		}
 		sb.WriteString("\n")
+		if lt := result.LoadTest; lt.ArrivalRate > 0 && lt.ArrivalRate >= lt.ServiceRate {
+			sb.WriteString(fmt.Sprintf("> ⚠️ **Workers overloaded** - requests arrived at %.2f req/s but the %d workers could serve only %.2f req/s, so the queue grew and latency includes time spent waiting in it. Add workers with `--concurrent` to measure the server alone.\n\n",
+				lt.ArrivalRate, lt.Concurrent, lt.ServiceRate))
+		}
 
 		sb.WriteString("### Latency Distribution\n\n")
 		sb.WriteString("Latency percentiles show how response times are distributed across all requests. ")
@@ -269,13 +520,32 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		sb.WriteString("| Percentile | Latency (ms) | Description |\n")
 		sb.WriteString("|------------|-------------:|-------------|\n")
 		sb.WriteString(fmt.Sprintf("| Min | %.2f | Fastest response |\n", result.LoadTest.MinLatencyMs))
-		sb.WriteString(fmt.Sprintf("| p50 (Median) | %.2f | Half of requests faster than this |\n", result.LoadTest.LatencyP50Ms))
-		sb.WriteString(fmt.Sprintf("| p95 | %.2f | 95%% of requests faster than this |\n", result.LoadTest.LatencyP95Ms))
-		sb.WriteString(fmt.Sprintf("| p99 | %.2f | 99%% of requests faster than this |\n", result.LoadTest.LatencyP99Ms))
+		for _, p := range loadTestPercentiles(result.LoadTest) {
+			switch {
+			case p.Rank == 50:
+				sb.WriteString(fmt.Sprintf("| p50 (Median) | %.2f | Half of requests faster than this |\n", p.Ms))
+			case p.Rank == 95 && result.LoadTest.LatencyP95MsCI95 > 0:
+				sb.WriteString(fmt.Sprintf("| p95 | %.2f ± %.2f | 95%% of requests faster than this (95%% confidence interval over %d runs) |\n",
+					p.Ms, result.LoadTest.LatencyP95MsCI95, result.SampleCount))
+			default:
+				sb.WriteString(fmt.Sprintf("| %s | %.2f | %s%% of requests faster than this |\n", p.Label, p.Ms, strings.TrimPrefix(p.Label, "p")))
+			}
+		}
 		sb.WriteString(fmt.Sprintf("| Max | %.2f | Slowest response |\n", result.LoadTest.MaxLatencyMs))
 		sb.WriteString(fmt.Sprintf("| Average | %.2f | Mean response time |\n", result.LoadTest.AvgLatencyMs))
+		sb.WriteString(fmt.Sprintf("| Std Deviation | %.2f | Typical distance of a response time from the average |\n", result.LoadTest.StdDevLatencyMs))
+		sb.WriteString(fmt.Sprintf("| IQR | %.2f | Spread of the middle half of requests (p75 - p25) |\n", result.LoadTest.IQRLatencyMs))
+		if lt := result.LoadTest; lt.LatencyP50Ms > 0 && lt.IQRLatencyMs > lt.LatencyP50Ms {
+			sb.WriteString("\n⚠️ **Wide latency spread** - the interquartile range exceeds the median, which often means requests fall into two groups, such as cache hits and misses or fast and slow endpoints. Worth investigating.\n")
+		}
 		sb.WriteString("\n")
 
+		writeSLABreaches(&sb, result.LoadTest)
+		writeErrorBreakdown(&sb, result.LoadTest)
+		writeEndpointDistribution(&sb, result.LoadTest)
+		writeLoadTestWindows(&sb, result.LoadTest.PerWindowStats)
+		writeStepResults(&sb, result.LoadTest.StepResults)
+
 		// Interpretation
 		sb.WriteString("### Interpretation\n\n")
 		sb.WriteString(fmt.Sprintf("At **%d concurrent users**, the server achieved **%.2f requests per second** ", result.LoadTest.Concurrent, result.LoadTest.RPS))
@@ -300,9 +570,91 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		} else {
 			sb.WriteString("❌ **High latency** - 95th percentile exceeds 500ms, consider scaling resources.\n")
 		}
+
+		if result.LoadTest.PoolSaturation == internal.PoolSaturated {
+			sb.WriteString(fmt.Sprintf("⚠️ **Connection pool saturated** - %d of %d requests opened a new connection instead of reusing an idle one, ",
+				result.LoadTest.NewConnections, result.LoadTest.TotalRequests))
+			sb.WriteString("so part of the measured latency is connection setup. ")
+			sb.WriteString(fmt.Sprintf("Raise `--max-idle-conns-per-host` to at least %d (the number of concurrent workers) and rerun.\n", result.LoadTest.Concurrent))
+		}
+		if result.LoadTest.ErrorsClustered && len(result.LoadTest.ErrorTimestamps) > 0 {
+			// The middle 80% of the sorted failure times
+			times := result.LoadTest.ErrorTimestamps
+			from, to := times[len(times)/10], times[len(times)*9/10]
+			sb.WriteString(fmt.Sprintf("⚠️ **Errors clustered** - most failures came between %.1fs and %.1fs of the %.0f second run rather than throughout it, ",
+				from/1000, to/1000, result.LoadTest.DurationSec))
+			sb.WriteString("which points to a brief outage or restart rather than sustained overload.\n")
+		}
 		sb.WriteString("\n")
 	}
 
+	// Capacity Estimation
+	if result.LoadTest != nil {
+		writeCapacitySection(&sb, result.LoadTest)
+	}
+
+	// WebSocket Load Test
+	if ws := result.WebSocketLoadTest; ws != nil {
+		sb.WriteString("## WebSocket Load Test\n\n")
+		sb.WriteString(fmt.Sprintf("Each of **%d workers** opened a WebSocket connection to `%s` and repeatedly sent a ping message, ", ws.Concurrent, ws.Path))
+		sb.WriteString("measuring the round-trip time until the server's reply arrived. This reflects the latency of real-time updates.\n\n")
+
+		if ws.Error != "" {
+			sb.WriteString(fmt.Sprintf("❌ **WebSocket Error:** %s\n\n", ws.Error))
+		} else {
+			sb.WriteString("| Metric | Value |\n")
+			sb.WriteString("|--------|------:|\n")
+			sb.WriteString(fmt.Sprintf("| Connections | %d of %d |\n", ws.Connections, ws.Concurrent))
+			sb.WriteString(fmt.Sprintf("| Round Trips | %d |\n", ws.Successful))
+			sb.WriteString(fmt.Sprintf("| Failed | %d |\n", ws.Failed))
+			sb.WriteString(fmt.Sprintf("| **Messages/Second** | **%.2f** |\n", ws.MessagesPerSec))
+			sb.WriteString(fmt.Sprintf("| Min RTT | %.2f ms |\n", ws.MinLatencyMs))
+			sb.WriteString(fmt.Sprintf("| p50 RTT | %.2f ms |\n", ws.LatencyP50Ms))
+			sb.WriteString(fmt.Sprintf("| p95 RTT | %.2f ms |\n", ws.LatencyP95Ms))
+			sb.WriteString(fmt.Sprintf("| p99 RTT | %.2f ms |\n", ws.LatencyP99Ms))
+			sb.WriteString(fmt.Sprintf("| Max RTT | %.2f ms |\n", ws.MaxLatencyMs))
+			sb.WriteString("\n")
+
+			if ws.Failed > 0 || ws.Connections < ws.Concurrent {
+				sb.WriteString("⚠️ **Dropped connections** - Some workers failed to connect or lost their connection during the test.\n\n")
+			} else if ws.LatencyP95Ms < 100 {
+				sb.WriteString("✅ **Responsive real-time channel** - 95th percentile round trip under 100ms.\n\n")
+			} else {
+				sb.WriteString("⚠️ **Slow real-time channel** - 95th percentile round trip exceeds 100ms; live updates may feel delayed.\n\n")
+			}
+		}
+	}
+
+	// Concurrency Profile
+	if len(result.ConcurrencyProfile) > 0 {
+		sb.WriteString("## Concurrency Profile\n\n")
+		sb.WriteString("The load test was repeated at increasing concurrency levels to find where the server delivers the most throughput for the least latency. ")
+		sb.WriteString("Each level is scored by its requests per second divided by its p95 latency, so a higher score means more work done without users waiting longer.\n\n")
+
+		sb.WriteString("| Concurrent | RPS | p95 (ms) | Error Rate | RPS / p95 |\n")
+		sb.WriteString("|-----------:|----:|---------:|-----------:|----------:|\n")
+		for _, p := range result.ConcurrencyProfile {
+			ratio := "-"
+			if p.LatencyP95Ms > 0 {
+				ratio = fmt.Sprintf("%.2f", p.RPS/p.LatencyP95Ms)
+			}
+			label := fmt.Sprintf("%d", p.Concurrent)
+			if p.Concurrent == result.RecommendedConcurrency {
+				label = fmt.Sprintf("**%d** ⭐", p.Concurrent)
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %.2f | %.2f | %.2f%% | %s |\n", label, p.RPS, p.LatencyP95Ms, p.ErrorRatePct, ratio))
+		}
+		sb.WriteString("\n")
+
+		sb.WriteString("### Recommendation\n\n")
+		if result.RecommendedConcurrency > 0 {
+			sb.WriteString(fmt.Sprintf("✅ **Use %d concurrent workers.** This level had the highest RPS-to-p95-latency ratio with an error rate of at most 1%%. ", result.RecommendedConcurrency))
+			sb.WriteString("Beyond this point, added concurrency raises latency faster than it raises throughput, which usually means the server is saturated.\n\n")
+		} else {
+			sb.WriteString("❌ **No recommendation** - Every concurrency level exceeded a 1% error rate. Check server logs before increasing load.\n\n")
+		}
+	}
+
 	// Server-Side Benchmark API
 	if result.BenchmarkAPI != nil && result.BenchmarkAPI.Response != nil {
 		sb.WriteString("## Server-Side Benchmark\n\n")
@@ -311,6 +663,9 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		sb.WriteString("independent of network latency.\n\n")
 
 		resp := result.BenchmarkAPI.Response
+		if result.BenchmarkAPI.ConcurrentMode {
+			sb.WriteString("Run in **concurrent mode** (`--benchmark-concurrent`), so the server also timed operations running in parallel.\n\n")
+		}
 
 		// System Information
 		if resp.SystemInfo != nil {
@@ -416,6 +771,9 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 		}
 	}
 
+	// Records Sensitivity
+	writeRecordsSensitivity(&sb, result.BenchmarkAPISweep)
+
 	// Overall Result
 	sb.WriteString("## Conclusion\n\n")
 	if result.Error != "" {
@@ -431,17 +789,482 @@ func (m *Markdown) Report(result *internal.BenchmarkResult) (string, error) {
 	sb.WriteString(fmt.Sprintf("*Report generated by actalog-bench v0.1.0 at %s*\n",
 		time.Now().Format("2006-01-02 15:04:05 MST")))
 
-	// Create parent directories if they don't exist
-	if m.outputDir != "" && m.outputDir != "." {
-		if err := os.MkdirAll(m.outputDir, 0755); err != nil {
-			return "", fmt.Errorf("create directory: %w", err)
+	return sb.String()
+}
+
+// formatPhaseDurations lists the phases that ran, in run order, with their
+// wall-clock times (e.g. "connectivity 12 ms, load test 10.0 s")
+func formatPhaseDurations(durations map[string]float64) string {
+	var parts []string
+	for _, phase := range internal.Phases {
+		ms, ok := durations[phase]
+		if !ok {
+			continue
+		}
+		label := strings.ReplaceAll(phase, "_", " ")
+		if ms >= 1000 {
+			parts = append(parts, fmt.Sprintf("%s %.1f s", label, ms/1000))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %.0f ms", label, ms))
 		}
 	}
+	return strings.Join(parts, ", ")
+}
 
-	// Write to file
-	if err := os.WriteFile(filepath, []byte(sb.String()), 0644); err != nil {
-		return "", fmt.Errorf("write markdown file: %w", err)
+// writeColdStartTable compares warm and cold-connection response times for
+// endpoints measured with --cold-start
+func writeColdStartTable(sb *strings.Builder, endpoints []internal.EndpointResult) {
+	var cold []internal.EndpointResult
+	for _, ep := range endpoints {
+		if ep.ColdStartMs > 0 {
+			cold = append(cold, ep)
+		}
+	}
+	if len(cold) == 0 {
+		return
 	}
 
-	return filepath, nil
+	sb.WriteString("### Cold Start\n\n")
+	sb.WriteString("Each endpoint was requested again over a fresh TCP connection with keep-alive disabled. ")
+	sb.WriteString("The difference from the warm response time is the cost of connection setup that keep-alive avoids.\n\n")
+	sb.WriteString("| Endpoint | Warm (ms) | Cold (ms) | Keep-Alive Benefit (ms) |\n")
+	sb.WriteString("|----------|----------:|----------:|------------------------:|\n")
+	for _, ep := range cold {
+		sb.WriteString(fmt.Sprintf("| `%s` | %.2f | %.2f | %.2f |\n", endpointLabel(ep), ep.ResponseMs, ep.ColdStartMs, ep.ColdStartMs-ep.ResponseMs))
+	}
+	sb.WriteString("\n")
+}
+
+// writeSampleTable shows response time statistics for endpoints requested
+// repeatedly with --endpoint-samples
+func writeSampleTable(sb *strings.Builder, endpoints []internal.EndpointResult) {
+	var sampled []internal.EndpointResult
+	percentiles := false
+	for _, ep := range endpoints {
+		if ep.SampleCount > 1 {
+			sampled = append(sampled, ep)
+			percentiles = percentiles || ep.SampleP95Ms > 0
+		}
+	}
+	if len(sampled) == 0 {
+		return
+	}
+
+	sb.WriteString("### Endpoint Samples\n\n")
+	sb.WriteString("Each GET endpoint was requested repeatedly to smooth out one-off variation. ")
+	sb.WriteString("Percentiles are only reported for endpoints with at least 20 successful samples.\n\n")
+	if percentiles {
+		sb.WriteString("| Endpoint | Samples | Mean (ms) | Min (ms) | Max (ms) | p50 (ms) | p95 (ms) | p99 (ms) |\n")
+		sb.WriteString("|----------|--------:|----------:|---------:|---------:|---------:|---------:|---------:|\n")
+	} else {
+		sb.WriteString("| Endpoint | Samples | Mean (ms) | Min (ms) | Max (ms) |\n")
+		sb.WriteString("|----------|--------:|----------:|---------:|---------:|\n")
+	}
+	for _, ep := range sampled {
+		row := fmt.Sprintf("| `%s` | %d | %.2f | %.2f | %.2f |", endpointLabel(ep), ep.SampleCount, ep.SampleMeanMs, ep.SampleMinMs, ep.SampleMaxMs)
+		if percentiles {
+			if ep.SampleP95Ms > 0 {
+				row += fmt.Sprintf(" %.2f | %.2f | %.2f |", ep.SampleP50Ms, ep.SampleP95Ms, ep.SampleP99Ms)
+			} else {
+				row += " - | - | - |"
+			}
+		}
+		sb.WriteString(row + "\n")
+	}
+	sb.WriteString("\n")
+}
+
+// writeDeprecationWarnings lists endpoints whose responses announced deprecation
+func writeDeprecationWarnings(sb *strings.Builder, endpoints []internal.EndpointResult) {
+	var deprecated []internal.EndpointResult
+	for _, ep := range endpoints {
+		if ep.Deprecated {
+			deprecated = append(deprecated, ep)
+		}
+	}
+	if len(deprecated) == 0 {
+		return
+	}
+
+	sb.WriteString("### Deprecation Warnings\n\n")
+	sb.WriteString("These endpoints returned a `Deprecation` or `Sunset` header. Clients should move off them before the sunset date, after which they may stop responding.\n\n")
+	for _, ep := range deprecated {
+		if ep.SunsetDate != "" {
+			sb.WriteString(fmt.Sprintf("- ⚠️ `%s` - sunset %s\n", endpointLabel(ep), ep.SunsetDate))
+		} else {
+			sb.WriteString(fmt.Sprintf("- ⚠️ `%s` - no sunset date announced\n", endpointLabel(ep)))
+		}
+	}
+	sb.WriteString("\n")
+}
+
+// writeLoadTestWindows shows per-window load test throughput recorded with
+// --leak-detect, with a callout when RPS falls steadily across the run
+func writeLoadTestWindows(sb *strings.Builder, windows []internal.WindowStats) {
+	if len(windows) == 0 {
+		return
+	}
+
+	sb.WriteString("### Throughput Over Time\n\n")
+	sb.WriteString("The load test was split into equal time windows. A server that leaks memory or other resources ")
+	sb.WriteString("typically serves fewer requests per second in each successive window.\n\n")
+
+	rps := make([]float64, len(windows))
+	for i, w := range windows {
+		rps[i] = w.RPS
+	}
+	if leak, slope := possibleLeak(rps); leak {
+		sb.WriteString(fmt.Sprintf("> ⚠️ **Possible memory leak detected** - RPS fell by %.1f%% of the mean per window in a steady decline. ",
+			-slope*100))
+		sb.WriteString("Check server memory and connection counts over a longer run.\n\n")
+	}
+
+	sb.WriteString("| Window | Start (s) | Requests | RPS | p95 (ms) |\n")
+	sb.WriteString("|-------:|----------:|---------:|----:|---------:|\n")
+	for _, w := range windows {
+		sb.WriteString(fmt.Sprintf("| %d | %.1f | %d | %.2f | %.2f |\n", w.Window, w.StartSec, w.Requests, w.RPS, w.LatencyP95Ms))
+	}
+	sb.WriteString("\n")
+}
+
+// writeStepResults writes the throughput and latency of each --step-load step
+// against the concurrency reached in it
+func writeStepResults(sb *strings.Builder, steps []internal.StepResult) {
+	if len(steps) == 0 {
+		return
+	}
+
+	sb.WriteString("### Concurrency vs Latency\n\n")
+	sb.WriteString("The load test added workers step by step (`--step-load`). A p95 latency or error rate that climbs while RPS levels off marks the concurrency at which the server starts to degrade.\n\n")
+	sb.WriteString("| Step | Concurrency | Requests | RPS | p95 (ms) | Error Rate |\n")
+	sb.WriteString("|-----:|------------:|---------:|----:|---------:|-----------:|\n")
+	for _, s := range steps {
+		sb.WriteString(fmt.Sprintf("| %d | %d | %d | %.2f | %.2f | %.2f%% |\n", s.Step, s.Concurrency, s.Requests, s.RPS, s.LatencyP95Ms, s.ErrorRatePct))
+	}
+	sb.WriteString("\n")
+}
+
+// writeSLABreaches writes how many load test requests were slower than each
+// --sla-targets target, fastest target first
+func writeSLABreaches(sb *strings.Builder, lt *internal.LoadTestResult) {
+	if len(lt.SLABreachCount) == 0 {
+		return
+	}
+
+	targets := make([]string, 0, len(lt.SLABreachCount))
+	for target := range lt.SLABreachCount {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		a, _ := time.ParseDuration(targets[i])
+		b, _ := time.ParseDuration(targets[j])
+		return a < b
+	})
+
+	sb.WriteString("### SLA Targets\n\n")
+	sb.WriteString("Requests slower than each latency target given with `--sla-targets`.\n\n")
+	sb.WriteString("| Target | Breaches | Breach Rate |\n")
+	sb.WriteString("|--------|---------:|------------:|\n")
+	for _, target := range targets {
+		count := lt.SLABreachCount[target]
+		rate := 0.0
+		if lt.TotalRequests > 0 {
+			rate = float64(count) / float64(lt.TotalRequests) * 100
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.2f%% |\n", target, count, rate))
+	}
+	sb.WriteString("\n")
+}
+
+// writeErrorBreakdown writes the failed load test requests by error type, in
+// the order of internal.ErrorTypes
+func writeErrorBreakdown(sb *strings.Builder, lt *internal.LoadTestResult) {
+	if lt.Failed == 0 || len(lt.ErrorBreakdown) == 0 {
+		return
+	}
+
+	sb.WriteString("### Error Type Breakdown\n\n")
+	sb.WriteString("| Error Type | Count | Share of Failures |\n")
+	sb.WriteString("|------------|------:|------------------:|\n")
+	for _, errorType := range internal.ErrorTypes {
+		count, ok := lt.ErrorBreakdown[errorType]
+		if !ok {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% |\n", errorType, count, float64(count)/float64(lt.Failed)*100))
+	}
+	sb.WriteString("\n")
+}
+
+// writeEndpointDistribution writes how many load test requests went to each
+// path of the endpoint rotation, busiest first
+func writeEndpointDistribution(sb *strings.Builder, lt *internal.LoadTestResult) {
+	if len(lt.EndpointDistribution) == 0 {
+		return
+	}
+
+	paths := make([]string, 0, len(lt.EndpointDistribution))
+	total := 0
+	for path, count := range lt.EndpointDistribution {
+		paths = append(paths, path)
+		total += count
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		ci, cj := lt.EndpointDistribution[paths[i]], lt.EndpointDistribution[paths[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return paths[i] < paths[j]
+	})
+
+	sb.WriteString("### Endpoint Distribution\n\n")
+	sb.WriteString("| Endpoint | Requests | Share |\n")
+	sb.WriteString("|----------|---------:|------:|\n")
+	for _, path := range paths {
+		count := lt.EndpointDistribution[path]
+		sb.WriteString(fmt.Sprintf("| `%s` | %d | %.1f%% |\n", path, count, float64(count)/float64(total)*100))
+	}
+	sb.WriteString("\n")
+}
+
+// possibleLeak reports whether window RPS values decline steadily enough to
+// suggest a leak, along with the per-window slope as a fraction of the mean
+func possibleLeak(rps []float64) (bool, float64) {
+	mean := meanOf(rps)
+	if mean <= 0 {
+		return false, 0
+	}
+	slope, r2 := computeTrend(rps)
+	relative := slope / mean
+	return relative < leakMaxSlopeFraction && r2 > leakMinRSquare, relative
+}
+
+// writeSRITable reports Subresource Integrity coverage of the frontend assets
+// and whether each protected asset matched its integrity hash
+func writeSRITable(sb *strings.Builder, assets []internal.AssetResult) {
+	if len(assets) == 0 {
+		return
+	}
+	protected := 0
+	for _, a := range assets {
+		if a.SRIProtected {
+			protected++
+		}
+	}
+
+	sb.WriteString("### Subresource Integrity\n\n")
+	sb.WriteString("An `integrity` attribute lets the browser refuse a script or stylesheet that was altered after publishing, ")
+	sb.WriteString("for example by a compromised CDN. ")
+	sb.WriteString(fmt.Sprintf("**%d of %d** assets are SRI protected.\n\n", protected, len(assets)))
+	if protected == 0 {
+		return
+	}
+
+	sb.WriteString("| Asset | Integrity |\n")
+	sb.WriteString("|-------|-----------|\n")
+	for _, a := range assets {
+		if !a.SRIProtected {
+			continue
+		}
+		status := "⚠️ Unsupported algorithm"
+		if a.SRIValid != nil && *a.SRIValid {
+			status = "✅ Hash matches"
+		} else if a.SRIValid != nil {
+			status = "❌ Hash mismatch - browsers will block this asset"
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %s |\n", a.Path, status))
+	}
+	sb.WriteString("\n")
+}
+
+// formatCompression describes how much smaller an asset was on the wire, e.g.
+// "12.40 KB (31%)", or "-" when it was sent uncompressed
+func formatCompression(a internal.AssetResult) string {
+	if a.CompressionRatio <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f KB (%.0f%%)", a.WireTransferKB, a.CompressionRatio*100)
+}
+
+// writeCachingTable summarizes each asset's caching strategy: a content-hashed
+// file name with a long max-age is optimal, while an unhashed name with a short
+// max-age means browsers keep fetching or revalidating it
+func writeCachingTable(sb *strings.Builder, assets []internal.AssetResult) {
+	if len(assets) == 0 {
+		return
+	}
+	optimal := 0
+	for _, a := range assets {
+		if cachingStrategy(a) == cacheOptimal {
+			optimal++
+		}
+	}
+
+	sb.WriteString("### Caching Strategy\n\n")
+	sb.WriteString("Assets with a content hash in their file name (e.g. `app.abc123.js`) change name whenever they change, ")
+	sb.WriteString("so they can be cached for a long time, ideally with `Cache-Control: max-age=31536000, immutable`. ")
+	sb.WriteString(fmt.Sprintf("**%d of %d** assets use an optimal caching strategy.\n\n", optimal, len(assets)))
+
+	sb.WriteString("| Asset | Fingerprinted | max-age | Strategy |\n")
+	sb.WriteString("|-------|:-------------:|--------:|----------|\n")
+	for _, a := range assets {
+		fingerprinted := "No"
+		if a.Fingerprinted {
+			fingerprinted = "Yes"
+		}
+		maxAge := "-"
+		if a.CacheControlMaxAge > 0 {
+			maxAge = formatCacheAge(a.CacheControlMaxAge)
+		}
+		if a.ImmutableCache {
+			maxAge += " (immutable)"
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", a.Path, fingerprinted, maxAge, cachingStrategy(a)))
+	}
+	sb.WriteString("\n")
+}
+
+// Caching strategy verdicts for writeCachingTable
+const (
+	cacheOptimal       = "✅ Optimal"
+	cacheTooShort      = "⚠️ Fingerprinted but cached briefly - raise max-age"
+	cacheStaleRisk     = "⚠️ Long-lived without a content hash - users may get stale files after a deploy"
+	cacheMisconfigured = "❌ Misconfigured - no content hash and a short max-age, so it is refetched on nearly every visit"
+	cacheRevalidated   = "Not fingerprinted - revalidated periodically"
+)
+
+// cachingStrategy classifies an asset's file naming and Cache-Control lifetime
+func cachingStrategy(a internal.AssetResult) string {
+	long := a.CacheControlMaxAge >= longCacheMaxAge || a.ImmutableCache
+	switch {
+	case a.Fingerprinted && long:
+		return cacheOptimal
+	case a.Fingerprinted:
+		return cacheTooShort
+	case long:
+		return cacheStaleRisk
+	case a.CacheControlMaxAge < shortCacheMaxAge:
+		return cacheMisconfigured
+	default:
+		return cacheRevalidated
+	}
+}
+
+// formatCacheAge formats a max-age in seconds using the largest whole unit
+func formatCacheAge(seconds int) string {
+	switch {
+	case seconds%(24*60*60) == 0:
+		return fmt.Sprintf("%dd", seconds/(24*60*60))
+	case seconds%(60*60) == 0:
+		return fmt.Sprintf("%dh", seconds/(60*60))
+	case seconds%60 == 0:
+		return fmt.Sprintf("%dm", seconds/60)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+// writeRecordsSensitivity shows how each server-side operation's duration
+// changes across the --benchmark-records-sweep record counts
+func writeRecordsSensitivity(sb *strings.Builder, sweep []internal.BenchmarkAPISweepPoint) {
+	if len(sweep) == 0 {
+		return
+	}
+
+	sb.WriteString("## Records Sensitivity\n\n")
+	sb.WriteString("The server-side benchmark was run at each record count below. Operations whose duration grows faster than ")
+	sb.WriteString("the record count will dominate as the data set grows, and are the first candidates for indexing or pagination.\n\n")
+
+	// Collect every operation reported at any record count, by category
+	var names []string
+	seen := make(map[string]bool)
+	durations := make([]map[string]float64, len(sweep))
+	for i, p := range sweep {
+		durations[i] = make(map[string]float64)
+		if p.Response == nil {
+			continue
+		}
+		for _, group := range []struct {
+			category string
+			ops      map[string]*internal.OperationResult
+		}{
+			{"Database", p.Response.Database},
+			{"Serialization", p.Response.Serialization},
+			{"Business Logic", p.Response.BusinessLogic},
+		} {
+			for name, op := range group.ops {
+				if op == nil || !op.Success {
+					continue
+				}
+				key := group.category + ": " + name
+				durations[i][key] = op.DurationMs
+				if !seen[key] {
+					seen[key] = true
+					names = append(names, key)
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+
+	header := "| Operation |"
+	divider := "|-----------|"
+	for _, p := range sweep {
+		header += fmt.Sprintf(" %d records (ms) |", p.RecordCount)
+		divider += "------:|"
+	}
+	sb.WriteString(header + " Growth |\n")
+	sb.WriteString(divider + "-------:|\n")
+
+	first, last := sweep[0], sweep[len(sweep)-1]
+	recordGrowth := float64(last.RecordCount) / float64(first.RecordCount)
+	var superlinear []string
+	for _, name := range names {
+		row := fmt.Sprintf("| %s |", name)
+		for i := range sweep {
+			if ms, ok := durations[i][name]; ok {
+				row += fmt.Sprintf(" %.2f |", ms)
+			} else {
+				row += " - |"
+			}
+		}
+
+		growth := "-"
+		from, okFrom := durations[0][name]
+		to, okTo := durations[len(sweep)-1][name]
+		if okFrom && okTo && from > 0 && len(sweep) > 1 {
+			factor := to / from
+			growth = fmt.Sprintf("×%.1f", factor)
+			if factor > recordGrowth*superlinearTolerance {
+				growth += " ⚠️"
+				superlinear = append(superlinear, name)
+			}
+		}
+		sb.WriteString(row + fmt.Sprintf(" %s |\n", growth))
+	}
+
+	total := "| **Total** |"
+	for _, p := range sweep {
+		if p.Response != nil {
+			total += fmt.Sprintf(" **%.2f** |", p.Response.TotalDurationMs)
+		} else {
+			total += " ❌ |"
+		}
+	}
+	sb.WriteString(total + " |\n\n")
+
+	for _, p := range sweep {
+		if p.Error != "" {
+			sb.WriteString(fmt.Sprintf("❌ **%d records failed:** %s\n\n", p.RecordCount, strings.TrimSpace(p.Error)))
+		}
+	}
+
+	if len(sweep) > 1 {
+		sb.WriteString(fmt.Sprintf("Growth compares the largest run with the smallest, over ×%.1f the records. ", recordGrowth))
+		if len(superlinear) > 0 {
+			sb.WriteString(fmt.Sprintf("⚠️ **%d of %d operations grew faster than the record count**, suggesting worse than linear scaling.\n\n", len(superlinear), len(names)))
+		} else {
+			sb.WriteString("✅ No operation grew faster than the record count.\n\n")
+		}
+	}
 }
@@ -260,6 +260,159 @@ func TestMarkdown_Report_ConnectivityInterpretations(t *testing.T) {
 	}
 }
 
+func TestMarkdown_Report_EndpointOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp:         time.Now(),
+		Target:            "https://example.com",
+		Overall:           "pass",
+		Endpoints:         []internal.EndpointResult{{Path: "/health", ResponseMs: 5, Status: 200, Success: true}},
+		EndpointOrder:     "random",
+		EndpointOrderSeed: 42,
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath)
+	if !strings.Contains(string(content), "Endpoints were requested in **random** order (`--seed 42` repeats it)") {
+		t.Error("expected the endpoint order and seed to be noted")
+	}
+}
+
+func TestMarkdown_Report_GRPCHealth(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second, GRPCHealth: true, GRPCAddr: "actalog:50051"}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp:  time.Now(),
+		Target:     "https://example.com",
+		Overall:    "fail",
+		Health:     &internal.HealthResult{Status: "healthy", ResponseMs: 12, HTTPStatus: 200},
+		GRPCHealth: &internal.HealthResult{Status: "unhealthy", ResponseMs: 3.5, Error: "gRPC health status NOT_SERVING"},
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath)
+	content := string(data)
+	for _, want := range []string{
+		"## Health Check",
+		"## gRPC Health Check",
+		"called at `actalog:50051`",
+		"| Status | ❌ unhealthy |",
+		"| Error | gRPC health status NOT_SERVING |",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in content", want)
+		}
+	}
+}
+
+func TestMarkdown_Report_TraceID(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second, TraceHeader: "traceparent"}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		TraceID:   "6960f8e8a1b2c3d4e5f60718293a4b5c",
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath)
+	if want := "| Trace ID | `6960f8e8a1b2c3d4e5f60718293a4b5c` |"; !strings.Contains(string(data), want) {
+		t.Errorf("expected %q in the Test Parameters table", want)
+	}
+}
+
+func TestMarkdown_Report_SSE(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second, SSEPath: "/api/events", SSEEvents: 5}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "degraded",
+		SSE: &internal.SSEResult{
+			Path:               "/api/events",
+			Connected:          true,
+			TimeToFirstEventMs: 42.5,
+			AvgIntervalMs:      1000.25,
+			EventsReceived:     3,
+			Error:              "received 3 of 5 events: unexpected EOF",
+		},
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath)
+	content := string(data)
+	for _, want := range []string{
+		"## Server-Sent Events",
+		"`/api/events` was subscribed to and read for up to 5 events",
+		"| Status | ✅ connected |",
+		"| Events Received | 3 |",
+		"| Time to First Event | 42.50 ms |",
+		"| Avg Event Interval | 1000.25 ms |",
+		"| Error | received 3 of 5 events: unexpected EOF |",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in content", want)
+		}
+	}
+}
+
+func TestMarkdown_Report_UnixSocket(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "http://actalog.internal", Timeout: 30 * time.Second, UnixSocket: "/var/run/actalog/actalog.sock"}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "http://actalog.internal",
+		Overall:   "pass",
+		Connectivity: &internal.ConnectivityResult{
+			TCPMs:      0.08,
+			TotalMs:    0.08,
+			Connected:  true,
+			UnixSocket: "/var/run/actalog/actalog.sock",
+		},
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath)
+	if !strings.Contains(string(content), "| Socket Connect | 0.08 | Time to connect to the Unix domain socket `/var/run/actalog/actalog.sock`") {
+		t.Error("expected a socket connect row")
+	}
+	if strings.Contains(string(content), "| DNS Resolution |") {
+		t.Error("expected no DNS row for a Unix socket")
+	}
+}
+
 func TestMarkdown_Report_ConnectivityError(t *testing.T) {
 	tmpDir := t.TempDir()
 	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
@@ -403,167 +556,730 @@ func TestMarkdown_Report_EndpointFailures(t *testing.T) {
 	}
 }
 
-func TestMarkdown_Report_FrontendInterpretations(t *testing.T) {
-	tests := []struct {
-		name           string
-		totalSizeKB    float64
-		expectedPhrase string
-	}{
-		{"excellent", 300.0, "Excellent bundle size"},
-		{"good", 750.0, "Good bundle size"},
-		{"large", 1500.0, "Large bundle size"},
-		{"very_large", 2500.0, "Very large bundle size"},
+func TestMarkdown_Report_SubresourceIntegrity(t *testing.T) {
+	valid, invalid := true, false
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		Frontend: &internal.FrontendResult{
+			IndexHTML: &internal.AssetResult{Path: "/", Success: true},
+			Assets: []internal.AssetResult{
+				{Path: "/app.js", Success: true, SRIProtected: true, SRIValid: &valid},
+				{Path: "/style.css", Success: true, SRIProtected: true, SRIValid: &invalid},
+				{Path: "/vendor.js", Success: true},
+			},
+		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tmpDir := t.TempDir()
-			config := &internal.Config{URL: "https://example.com", Frontend: true, Timeout: 30 * time.Second}
-			m := NewMarkdown(tmpDir, config)
-
-			result := &internal.BenchmarkResult{
-				Timestamp: time.Now(),
-				Target:    "https://example.com",
-				Overall:   "pass",
-				Frontend: &internal.FrontendResult{
-					IndexHTML: &internal.AssetResult{
-						Path:       "/",
-						SizeKB:     10.0,
-						ResponseMs: 50.0,
-						Status:     200,
-						Success:    true,
-					},
-					TotalSizeKB: tt.totalSizeKB,
-					TotalTimeMs: 200.0,
-				},
-			}
-
-			filepath, err := m.Report(result)
-			if err != nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			data, _ := os.ReadFile(filepath)
-			if !strings.Contains(string(data), tt.expectedPhrase) {
-				t.Errorf("expected '%s' in content", tt.expectedPhrase)
-			}
-		})
+	content := renderMarkdown(t, config, result)
+	for _, want := range []string{
+		"### Subresource Integrity",
+		"**2 of 3** assets are SRI protected",
+		"| `/app.js` | ✅ Hash matches |",
+		"| `/style.css` | ❌ Hash mismatch",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+	if strings.Contains(content, "`/vendor.js` | ✅") {
+		t.Error("expected unprotected asset to be left out of the integrity table")
 	}
 }
 
-func TestMarkdown_Report_LoadTestInterpretations(t *testing.T) {
-	tests := []struct {
-		name           string
-		successful     int
-		total          int
-		p95Ms          float64
-		expectedPhrase string
-	}{
-		{"excellent_reliability", 1000, 1000, 50.0, "Excellent reliability"},
-		{"good_reliability", 995, 1000, 150.0, "Good reliability"},
-		{"moderate_reliability", 960, 1000, 350.0, "Moderate reliability"},
-		{"poor_reliability", 900, 1000, 600.0, "Poor reliability"},
+func TestMarkdown_Report_EarlyAbort(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "degraded",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:    10,
+			DurationSec:   8,
+			TotalRequests: 100,
+			Successful:    100,
+			EarlyAbort:    true,
+			AbortReason:   "p95 latency 9800ms exceeded threshold 500ms after 8s",
+		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tmpDir := t.TempDir()
-			config := &internal.Config{URL: "https://example.com", Concurrent: 10, Duration: 30 * time.Second, Timeout: 30 * time.Second}
-			m := NewMarkdown(tmpDir, config)
-
-			result := &internal.BenchmarkResult{
-				Timestamp: time.Now(),
-				Target:    "https://example.com",
-				Overall:   "pass",
-				LoadTest: &internal.LoadTestResult{
-					Concurrent:    10,
-					DurationSec:   30,
-					TotalRequests: tt.total,
-					Successful:    tt.successful,
-					Failed:        tt.total - tt.successful,
-					RPS:           33.3,
-					LatencyP50Ms:  tt.p95Ms * 0.5,
-					LatencyP95Ms:  tt.p95Ms,
-					LatencyP99Ms:  tt.p95Ms * 1.5,
-					MinLatencyMs:  5.0,
-					MaxLatencyMs:  tt.p95Ms * 2,
-					AvgLatencyMs:  tt.p95Ms * 0.6,
-				},
-			}
-
-			filepath, err := m.Report(result)
-			if err != nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			data, _ := os.ReadFile(filepath)
-			if !strings.Contains(string(data), tt.expectedPhrase) {
-				t.Errorf("expected '%s' in content", tt.expectedPhrase)
-			}
-		})
+	content := renderMarkdown(t, config, result)
+	for _, want := range []string{
+		"❌ **The load test was aborted early:** p95 latency 9800ms exceeded threshold 500ms after 8s.",
+		"> ❌ **Load test aborted early** after 8 seconds",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
 	}
 }
 
-func TestMarkdown_Report_LatencyInterpretations(t *testing.T) {
-	tests := []struct {
-		name           string
-		p95Ms          float64
-		expectedPhrase string
-	}{
-		{"excellent", 50.0, "Excellent latency"},
-		{"good", 150.0, "Good latency"},
-		{"elevated", 350.0, "Elevated latency"},
-		{"high", 600.0, "High latency"},
+func TestMarkdown_Report_GracefulShutdown(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp:        time.Now(),
+		Target:           "https://example.com",
+		Overall:          "pass",
+		GracefulShutdown: true,
+		ShutdownReason:   "interrupted by SIGINT or SIGTERM",
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tmpDir := t.TempDir()
-			config := &internal.Config{URL: "https://example.com", Concurrent: 10, Duration: 30 * time.Second, Timeout: 30 * time.Second}
-			m := NewMarkdown(tmpDir, config)
+	content := renderMarkdown(t, config, result)
+	if want := "⚠️ **The run was stopped before it finished:** interrupted by SIGINT or SIGTERM."; !strings.Contains(content, want) {
+		t.Errorf("expected report to contain %q", want)
+	}
+}
 
-			result := &internal.BenchmarkResult{
-				Timestamp: time.Now(),
-				Target:    "https://example.com",
-				Overall:   "pass",
-				LoadTest: &internal.LoadTestResult{
-					Concurrent:    10,
-					DurationSec:   30,
-					TotalRequests: 1000,
-					Successful:    1000, // 100% success to focus on latency test
-					Failed:        0,
-					RPS:           33.3,
-					LatencyP50Ms:  tt.p95Ms * 0.5,
-					LatencyP95Ms:  tt.p95Ms,
-					LatencyP99Ms:  tt.p95Ms * 1.5,
-					MinLatencyMs:  5.0,
-					MaxLatencyMs:  tt.p95Ms * 2,
-					AvgLatencyMs:  tt.p95Ms * 0.6,
-				},
-			}
+func TestMarkdown_Report_SimulatedNetwork(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp:          time.Now(),
+		Target:             "https://example.com",
+		Overall:            "pass",
+		SimulatedLatencyMs: 200,
+		SimulatedLossRate:  0.05,
+	}
 
-			filepath, err := m.Report(result)
-			if err != nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
+	content := renderMarkdown(t, config, result)
+	if want := "held for 200ms or more and 5.0% were dropped"; !strings.Contains(content, want) {
+		t.Errorf("expected report to contain %q", want)
+	}
 
-			data, _ := os.ReadFile(filepath)
-			if !strings.Contains(string(data), tt.expectedPhrase) {
-				t.Errorf("expected '%s' in content", tt.expectedPhrase)
-			}
-		})
+	result.SimulatedLatencyMs, result.SimulatedLossRate = 0, 0
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "Network conditions were simulated") {
+		t.Error("expected no simulated network warning for a real run")
 	}
 }
 
-func TestMarkdown_Report_DegradedStatus(t *testing.T) {
-	tmpDir := t.TempDir()
+func TestMarkdown_Report_PoolSaturation(t *testing.T) {
 	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
-	m := NewMarkdown(tmpDir, config)
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "healthy",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:     200,
+			DurationSec:    10,
+			TotalRequests:  1000,
+			Successful:     1000,
+			NewConnections: 400,
+			PoolSaturation: internal.PoolSaturated,
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	for _, want := range []string{
+		"| New Connections | 400 |",
+		"⚠️ **Connection pool saturated** - 400 of 1000 requests opened a new connection",
+		"Raise `--max-idle-conns-per-host` to at least 200",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+
+	result.LoadTest.PoolSaturation = ""
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "Connection pool saturated") {
+		t.Error("expected no saturation warning for an unsaturated pool")
+	}
+}
+
+func TestMarkdown_Report_SLABreaches(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "healthy",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:     10,
+			DurationSec:    10,
+			TotalRequests:  1000,
+			Successful:     1000,
+			SLABreachCount: map[string]int{"1s": 5, "200ms": 50},
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	fast := strings.Index(content, "| 200ms | 50 | 5.00% |")
+	slow := strings.Index(content, "| 1s | 5 | 0.50% |")
+	if !strings.Contains(content, "### SLA Targets") || fast < 0 || slow < fast {
+		t.Errorf("expected SLA breaches listed fastest target first, got:\n%s", content)
+	}
+
+	result.LoadTest.SLABreachCount = nil
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "### SLA Targets") {
+		t.Error("expected no SLA section without targets")
+	}
+}
+
+func TestMarkdown_Report_ErrorBreakdown(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "degraded",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:     10,
+			DurationSec:    10,
+			TotalRequests:  1000,
+			Successful:     960,
+			Failed:         40,
+			ErrorBreakdown: map[string]int{internal.ErrorTypeHTTP5xx: 30, internal.ErrorTypeTimeout: 10},
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	timeouts := strings.Index(content, "| timeout | 10 | 25.0% |")
+	http5xx := strings.Index(content, "| http_5xx | 30 | 75.0% |")
+	if !strings.Contains(content, "### Error Type Breakdown") || timeouts < 0 || http5xx < timeouts {
+		t.Errorf("expected error types listed in order, got:\n%s", content)
+	}
+
+	result.LoadTest.Failed, result.LoadTest.ErrorBreakdown = 0, nil
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "### Error Type Breakdown") {
+		t.Error("expected no error breakdown without failures")
+	}
+}
+
+func TestMarkdown_Report_EndpointDistribution(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:           10,
+			DurationSec:          10,
+			TotalRequests:        500,
+			Successful:           500,
+			EndpointStrategy:     "weighted",
+			EndpointDistribution: map[string]int{"/health": 100, "/api/workouts": 300, "/api/movements": 100},
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	workouts := strings.Index(content, "| `/api/workouts` | 300 | 60.0% |")
+	movements := strings.Index(content, "| `/api/movements` | 100 | 20.0% |")
+	health := strings.Index(content, "| `/health` | 100 | 20.0% |")
+	if !strings.Contains(content, "### Endpoint Distribution") || workouts < 0 || movements < workouts || health < movements {
+		t.Errorf("expected endpoints listed busiest first, got:\n%s", content)
+	}
+}
+
+func TestMarkdown_Report_Percentiles(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "healthy",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:    10,
+			DurationSec:   10,
+			TotalRequests: 1000,
+			Successful:    1000,
+			LatencyP50Ms:  10,
+			LatencyP95Ms:  20,
+			LatencyP99Ms:  30,
+			Percentiles:   map[string]float64{"p99.9": 45, "p50": 10, "p90": 18},
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	p50 := strings.Index(content, "| p50 (Median) | 10.00 |")
+	p90 := strings.Index(content, "| p90 | 18.00 | 90% of requests faster than this |")
+	p999 := strings.Index(content, "| p99.9 | 45.00 | 99.9% of requests faster than this |")
+	if p50 < 0 || p90 < p50 || p999 < p90 {
+		t.Errorf("expected the configured percentiles in ascending order, got:\n%s", content)
+	}
+	if strings.Contains(content, "| p95 |") {
+		t.Error("expected no p95 row when it was not configured")
+	}
+
+	// Results saved before --percentiles show the fixed set
+	result.LoadTest.Percentiles = nil
+	if content := renderMarkdown(t, config, result); !strings.Contains(content, "| p95 | 20.00 |") {
+		t.Error("expected the legacy p95 row")
+	}
+}
+
+func TestMarkdown_Report_LatencySpread(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "healthy",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:      10,
+			DurationSec:     10,
+			TotalRequests:   1000,
+			Successful:      1000,
+			LatencyP50Ms:    20,
+			StdDevLatencyMs: 12.5,
+			IQRLatencyMs:    8,
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "| Std Deviation | 12.50 |") || !strings.Contains(content, "| IQR | 8.00 |") {
+		t.Error("expected standard deviation and IQR rows")
+	}
+	if strings.Contains(content, "Wide latency spread") {
+		t.Error("expected no spread warning when the IQR is below the median")
+	}
+
+	result.LoadTest.IQRLatencyMs = 35
+	if content := renderMarkdown(t, config, result); !strings.Contains(content, "Wide latency spread") {
+		t.Error("expected a spread warning when the IQR exceeds the median")
+	}
+}
+
+func TestMarkdown_Report_ErrorsClustered(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	times := make([]float64, 20)
+	for i := range times {
+		times[i] = 4000 + float64(i)*50 // 4.0s to 4.95s
+	}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "degraded",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:      10,
+			DurationSec:     30,
+			TotalRequests:   1000,
+			Successful:      980,
+			Failed:          20,
+			ErrorTimestamps: times,
+			ErrorsClustered: true,
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	if want := "⚠️ **Errors clustered** - most failures came between 4.1s and 4.9s of the 30 second run"; !strings.Contains(content, want) {
+		t.Errorf("expected report to contain %q", want)
+	}
+
+	result.LoadTest.ErrorsClustered = false
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "Errors clustered") {
+		t.Error("expected no clustering warning for evenly spread errors")
+	}
+}
+
+func TestMarkdown_Report_Compression(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		Frontend: &internal.FrontendResult{
+			IndexHTML: &internal.AssetResult{Path: "/", Success: true, SizeKB: 2, WireTransferKB: 2},
+			Assets: []internal.AssetResult{
+				{Path: "/app.js", Success: true, SizeKB: 40, WireTransferKB: 12.4, CompressionRatio: 0.31, ResponseMs: 5},
+			},
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	for _, want := range []string{
+		"| Asset | Size (KB) | Compression | Time (ms) | Result |",
+		"| `index.html` | 2.00 | - |",
+		"| `/app.js` | 40.00 | 12.40 KB (31%) | 5.00 | ✅ |",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdown_Report_CachingStrategy(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		Frontend: &internal.FrontendResult{
+			IndexHTML: &internal.AssetResult{Path: "/", Success: true},
+			Assets: []internal.AssetResult{
+				{Path: "/app.abc123.js", Success: true, Fingerprinted: true, CacheControlMaxAge: 31536000, ImmutableCache: true},
+				{Path: "/vendor.def456.js", Success: true, Fingerprinted: true, CacheControlMaxAge: 600},
+				{Path: "/style.css", Success: true},
+				{Path: "/theme.css", Success: true, CacheControlMaxAge: 7 * 24 * 60 * 60},
+				{Path: "/legacy.js", Success: true, CacheControlMaxAge: 31536000},
+			},
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	for _, want := range []string{
+		"### Caching Strategy",
+		"**1 of 5** assets use an optimal caching strategy",
+		"| `/app.abc123.js` | Yes | 365d (immutable) | ✅ Optimal |",
+		"| `/vendor.def456.js` | Yes | 10m | ⚠️ Fingerprinted but cached briefly",
+		"| `/style.css` | No | - | ❌ Misconfigured",
+		"| `/theme.css` | No | 7d | Not fingerprinted - revalidated periodically |",
+		"| `/legacy.js` | No | 365d | ⚠️ Long-lived without a content hash",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+}
+
+func TestMarkdown_Report_FrontendInterpretations(t *testing.T) {
+	tests := []struct {
+		name           string
+		totalSizeKB    float64
+		expectedPhrase string
+	}{
+		{"excellent", 300.0, "Excellent bundle size"},
+		{"good", 750.0, "Good bundle size"},
+		{"large", 1500.0, "Large bundle size"},
+		{"very_large", 2500.0, "Very large bundle size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			config := &internal.Config{URL: "https://example.com", Frontend: true, Timeout: 30 * time.Second}
+			m := NewMarkdown(tmpDir, config)
+
+			result := &internal.BenchmarkResult{
+				Timestamp: time.Now(),
+				Target:    "https://example.com",
+				Overall:   "pass",
+				Frontend: &internal.FrontendResult{
+					IndexHTML: &internal.AssetResult{
+						Path:       "/",
+						SizeKB:     10.0,
+						ResponseMs: 50.0,
+						Status:     200,
+						Success:    true,
+					},
+					TotalSizeKB: tt.totalSizeKB,
+					TotalTimeMs: 200.0,
+				},
+			}
+
+			filepath, err := m.Report(result)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			data, _ := os.ReadFile(filepath)
+			if !strings.Contains(string(data), tt.expectedPhrase) {
+				t.Errorf("expected '%s' in content", tt.expectedPhrase)
+			}
+		})
+	}
+}
+
+func TestMarkdown_Report_LoadTestInterpretations(t *testing.T) {
+	tests := []struct {
+		name           string
+		successful     int
+		total          int
+		p95Ms          float64
+		expectedPhrase string
+	}{
+		{"excellent_reliability", 1000, 1000, 50.0, "Excellent reliability"},
+		{"good_reliability", 995, 1000, 150.0, "Good reliability"},
+		{"moderate_reliability", 960, 1000, 350.0, "Moderate reliability"},
+		{"poor_reliability", 900, 1000, 600.0, "Poor reliability"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			config := &internal.Config{URL: "https://example.com", Concurrent: 10, Duration: 30 * time.Second, Timeout: 30 * time.Second}
+			m := NewMarkdown(tmpDir, config)
+
+			result := &internal.BenchmarkResult{
+				Timestamp: time.Now(),
+				Target:    "https://example.com",
+				Overall:   "pass",
+				LoadTest: &internal.LoadTestResult{
+					Concurrent:    10,
+					DurationSec:   30,
+					TotalRequests: tt.total,
+					Successful:    tt.successful,
+					Failed:        tt.total - tt.successful,
+					RPS:           33.3,
+					LatencyP50Ms:  tt.p95Ms * 0.5,
+					LatencyP95Ms:  tt.p95Ms,
+					LatencyP99Ms:  tt.p95Ms * 1.5,
+					MinLatencyMs:  5.0,
+					MaxLatencyMs:  tt.p95Ms * 2,
+					AvgLatencyMs:  tt.p95Ms * 0.6,
+				},
+			}
+
+			filepath, err := m.Report(result)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			data, _ := os.ReadFile(filepath)
+			if !strings.Contains(string(data), tt.expectedPhrase) {
+				t.Errorf("expected '%s' in content", tt.expectedPhrase)
+			}
+		})
+	}
+}
+
+func TestMarkdown_Report_LatencyInterpretations(t *testing.T) {
+	tests := []struct {
+		name           string
+		p95Ms          float64
+		expectedPhrase string
+	}{
+		{"excellent", 50.0, "Excellent latency"},
+		{"good", 150.0, "Good latency"},
+		{"elevated", 350.0, "Elevated latency"},
+		{"high", 600.0, "High latency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			config := &internal.Config{URL: "https://example.com", Concurrent: 10, Duration: 30 * time.Second, Timeout: 30 * time.Second}
+			m := NewMarkdown(tmpDir, config)
+
+			result := &internal.BenchmarkResult{
+				Timestamp: time.Now(),
+				Target:    "https://example.com",
+				Overall:   "pass",
+				LoadTest: &internal.LoadTestResult{
+					Concurrent:    10,
+					DurationSec:   30,
+					TotalRequests: 1000,
+					Successful:    1000, // 100% success to focus on latency test
+					Failed:        0,
+					RPS:           33.3,
+					LatencyP50Ms:  tt.p95Ms * 0.5,
+					LatencyP95Ms:  tt.p95Ms,
+					LatencyP99Ms:  tt.p95Ms * 1.5,
+					MinLatencyMs:  5.0,
+					MaxLatencyMs:  tt.p95Ms * 2,
+					AvgLatencyMs:  tt.p95Ms * 0.6,
+				},
+			}
+
+			filepath, err := m.Report(result)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			data, _ := os.ReadFile(filepath)
+			if !strings.Contains(string(data), tt.expectedPhrase) {
+				t.Errorf("expected '%s' in content", tt.expectedPhrase)
+			}
+		})
+	}
+}
+
+func TestMarkdown_Report_DegradedStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "degraded",
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath)
+	content := string(data)
+
+	if !strings.Contains(content, "DEGRADED") {
+		t.Error("expected DEGRADED status in conclusion")
+	}
+	if !strings.Contains(content, "Some checks require attention") {
+		t.Error("expected attention message")
+	}
+}
+
+func TestMarkdown_Report_CreatesDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+	// Use a nested path that doesn't exist
+	nestedDir := tmpDir + "/nested/dir/path"
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	m := NewMarkdown(nestedDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// Verify file was created
+	if _, err := os.Stat(filepath); os.IsNotExist(err) {
+		t.Error("expected output file to exist")
+	}
+}
+
+func TestMarkdown_Report_NoTLS(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "http://example.com", Timeout: 30 * time.Second}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "http://example.com",
+		Overall:   "pass",
+		Connectivity: &internal.ConnectivityResult{
+			DNSMs:     10.0,
+			TCPMs:     25.0,
+			TLSMs:     0, // No TLS for HTTP
+			TotalMs:   35.0,
+			Connected: true,
+		},
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath)
+	content := string(data)
+
+	// Should NOT have TLS row since TLSMs is 0
+	if strings.Contains(content, "TLS Handshake") {
+		t.Error("expected no TLS row for HTTP connection")
+	}
+}
+
+func TestMarkdown_Report_WithHealthError(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "fail",
+		Health: &internal.HealthResult{
+			Status:     "unhealthy",
+			ResponseMs: 100.0,
+			HTTPStatus: 503,
+			Error:      "database connection failed",
+		},
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath)
+	content := string(data)
+
+	if !strings.Contains(content, "database connection failed") {
+		t.Error("expected health error in content")
+	}
+}
+
+func TestMarkdown_Report_HealthMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		Health: &internal.HealthResult{
+			Status:            "healthy",
+			ResponseMs:        12.0,
+			HTTPStatus:        200,
+			Uptime:            "72h15m",
+			ActiveConnections: 8,
+			MemoryMB:          256.5,
+		},
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath)
+	content := string(data)
+
+	for _, want := range []string{"| Uptime | 72h15m |", "| Active Connections | 8 |", "| Memory | 256.5 MB |"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in content", want)
+		}
+	}
+
+	// Metadata rows are omitted when the health endpoint does not report them
+	result.Health = &internal.HealthResult{Status: "healthy", ResponseMs: 12.0, HTTPStatus: 200}
+	filepath, err = m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ = os.ReadFile(filepath)
+	if strings.Contains(string(data), "| Uptime |") {
+		t.Error("expected no uptime row without metadata")
+	}
+}
+
+func TestMarkdown_Report_PhaseDurations(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	m := NewMarkdown(tmpDir, config)
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		PhaseDurations: map[string]float64{
+			internal.PhaseLoadTest:     10012.4,
+			internal.PhaseConnectivity: 42.7,
+			internal.PhaseHealth:       8.2,
+		},
+	}
+
+	filepath, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath)
+	content := string(data)
+
+	expected := "| Phase Durations | connectivity 43 ms, health 8 ms, load test 10.0 s |"
+	if !strings.Contains(content, expected) {
+		t.Errorf("expected %q in content", expected)
+	}
+}
+
+func TestMarkdown_Report_ColdStart(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	m := NewMarkdown(tmpDir, config)
 
 	result := &internal.BenchmarkResult{
 		Timestamp: time.Now(),
 		Target:    "https://example.com",
-		Overall:   "degraded",
+		Overall:   "pass",
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/version", ResponseMs: 10, Status: 200, Success: true, ColdStartMs: 35.5},
+			{Path: "/api/workouts", ResponseMs: 20, Status: 200, Success: true},
+		},
 	}
 
 	filepath, err := m.Report(result)
@@ -574,53 +1290,50 @@ func TestMarkdown_Report_DegradedStatus(t *testing.T) {
 	data, _ := os.ReadFile(filepath)
 	content := string(data)
 
-	if !strings.Contains(content, "DEGRADED") {
-		t.Error("expected DEGRADED status in conclusion")
+	if !strings.Contains(content, "| `/api/version` | 10.00 | 35.50 | 25.50 |") {
+		t.Error("expected cold start row for /api/version")
 	}
-	if !strings.Contains(content, "Some checks require attention") {
-		t.Error("expected attention message")
+	if strings.Count(content, "`/api/workouts`") != 1 {
+		t.Error("expected no cold start row for endpoint without a cold measurement")
 	}
 }
 
-func TestMarkdown_Report_CreatesDirectory(t *testing.T) {
-	tmpDir := t.TempDir()
-	// Use a nested path that doesn't exist
-	nestedDir := tmpDir + "/nested/dir/path"
+func TestMarkdown_Report_EndpointTTFB(t *testing.T) {
 	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
-	m := NewMarkdown(nestedDir, config)
-
 	result := &internal.BenchmarkResult{
 		Timestamp: time.Now(),
 		Target:    "https://example.com",
 		Overall:   "pass",
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/workouts", ResponseMs: 40, TTFBMs: 12.5, Status: 200, Success: true},
+			{Path: "/api/version", ResponseMs: 10, Status: 200, Success: true},
+		},
 	}
 
-	filepath, err := m.Report(result)
-	if err != nil {
-		t.Fatalf("expected no error, got: %v", err)
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "| `/api/workouts` | 40.00 | 12.50 | 200 | ✅ |") {
+		t.Error("expected a TTFB column for /api/workouts")
 	}
-
-	// Verify file was created
-	if _, err := os.Stat(filepath); os.IsNotExist(err) {
-		t.Error("expected output file to exist")
+	if !strings.Contains(content, "| `/api/version` | 10.00 | - | 200 | ✅ |") {
+		t.Error("expected - for an endpoint without a TTFB")
 	}
 }
 
-func TestMarkdown_Report_NoTLS(t *testing.T) {
+func TestMarkdown_Report_EndpointSamples(t *testing.T) {
 	tmpDir := t.TempDir()
-	config := &internal.Config{URL: "http://example.com", Timeout: 30 * time.Second}
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
 	m := NewMarkdown(tmpDir, config)
 
 	result := &internal.BenchmarkResult{
 		Timestamp: time.Now(),
-		Target:    "http://example.com",
+		Target:    "https://example.com",
 		Overall:   "pass",
-		Connectivity: &internal.ConnectivityResult{
-			DNSMs:     10.0,
-			TCPMs:     25.0,
-			TLSMs:     0, // No TLS for HTTP
-			TotalMs:   35.0,
-			Connected: true,
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/version", ResponseMs: 10, Status: 200, Success: true,
+				SampleCount: 20, SampleMeanMs: 12, SampleMinMs: 8, SampleMaxMs: 30,
+				SampleP50Ms: 11, SampleP95Ms: 25, SampleP99Ms: 29},
+			{Path: "/health", ResponseMs: 5, Status: 200, Success: true,
+				SampleCount: 19, SampleMeanMs: 6, SampleMinMs: 4, SampleMaxMs: 9},
 		},
 	}
 
@@ -632,39 +1345,46 @@ func TestMarkdown_Report_NoTLS(t *testing.T) {
 	data, _ := os.ReadFile(filepath)
 	content := string(data)
 
-	// Should NOT have TLS row since TLSMs is 0
-	if strings.Contains(content, "TLS Handshake") {
-		t.Error("expected no TLS row for HTTP connection")
+	expected := []string{
+		"### Endpoint Samples",
+		"| `/api/version` | 20 | 12.00 | 8.00 | 30.00 | 11.00 | 25.00 | 29.00 |",
+		"| `/health` | 19 | 6.00 | 4.00 | 9.00 | - | - | - |",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
 	}
 }
 
-func TestMarkdown_Report_WithHealthError(t *testing.T) {
-	tmpDir := t.TempDir()
+func TestMarkdown_Report_DeprecationWarnings(t *testing.T) {
 	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
-	m := NewMarkdown(tmpDir, config)
-
 	result := &internal.BenchmarkResult{
 		Timestamp: time.Now(),
 		Target:    "https://example.com",
-		Overall:   "fail",
-		Health: &internal.HealthResult{
-			Status:     "unhealthy",
-			ResponseMs: 100.0,
-			HTTPStatus: 503,
-			Error:      "database connection failed",
+		Overall:   "pass",
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/version", ResponseMs: 10, Status: 200, Success: true},
+			{Path: "/api/legacy", ResponseMs: 20, Status: 200, Success: true, Deprecated: true, SunsetDate: "2026-12-31"},
+			{Path: "/api/old", Method: "POST", ResponseMs: 20, Status: 200, Success: true, Deprecated: true},
 		},
 	}
 
-	filepath, err := m.Report(result)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	content := renderMarkdown(t, config, result)
+	expected := []string{
+		"### Deprecation Warnings",
+		"- ⚠️ `/api/legacy` - sunset 2026-12-31",
+		"- ⚠️ `POST /api/old` - no sunset date announced",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
 	}
 
-	data, _ := os.ReadFile(filepath)
-	content := string(data)
-
-	if !strings.Contains(content, "database connection failed") {
-		t.Error("expected health error in content")
+	result.Endpoints = result.Endpoints[:1]
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "Deprecation Warnings") {
+		t.Error("expected no deprecation section without deprecated endpoints")
 	}
 }
 
@@ -763,3 +1483,423 @@ func TestMarkdown_FilenameTimestamp(t *testing.T) {
 		t.Errorf("expected filename to contain '%s', got '%s'", expectedFilename, filepath)
 	}
 }
+
+// renderMarkdown writes the report to a temp dir and returns its content
+func renderMarkdown(t *testing.T, config *internal.Config, result *internal.BenchmarkResult) string {
+	t.Helper()
+
+	m := NewMarkdown(t.TempDir(), config)
+	path, err := m.Report(result)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	return string(data)
+}
+
+func TestMarkdown_Report_EndpointStrategy(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:       5,
+			DurationSec:      10,
+			TotalRequests:    100,
+			Successful:       100,
+			EndpointStrategy: "weighted",
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "**Endpoint Strategy:** weighted") {
+		t.Error("expected endpoint strategy in load test configuration")
+	}
+
+	result.LoadTest.EndpointStrategy = ""
+	content = renderMarkdown(t, config, result)
+	if strings.Contains(content, "Endpoint Strategy") {
+		t.Error("expected no endpoint strategy line when unset")
+	}
+}
+
+func TestMarkdown_Report_StepLoad(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:    2,
+			DurationSec:   10,
+			TotalRequests: 300,
+			Successful:    299,
+			Failed:        1,
+			StepResults: []internal.StepResult{
+				{Step: 1, Concurrency: 1, Requests: 100, RPS: 20, LatencyP95Ms: 12},
+				{Step: 2, Concurrency: 2, Requests: 200, RPS: 40, LatencyP95Ms: 18, ErrorRatePct: 0.5},
+			},
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	for _, want := range []string{"### Concurrency vs Latency", "| 2 | 2 | 200 | 40.00 | 18.00 | 0.50% |"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+
+	result.LoadTest.StepResults = nil
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "Concurrency vs Latency") {
+		t.Error("expected no step table without --step-load")
+	}
+}
+
+func TestMarkdown_Report_LeakDetection(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	windows := func(rps ...float64) []internal.WindowStats {
+		stats := make([]internal.WindowStats, len(rps))
+		for i, r := range rps {
+			stats[i] = internal.WindowStats{Window: i + 1, StartSec: float64(i), Requests: int(r), RPS: r, LatencyP95Ms: 20}
+		}
+		return stats
+	}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:     5,
+			DurationSec:    10,
+			TotalRequests:  100,
+			Successful:     100,
+			PerWindowStats: windows(100, 92, 85, 77, 70, 61, 55, 46, 40, 31),
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	for _, want := range []string{"### Throughput Over Time", "| 1 | 0.0 | 100 | 100.00 | 20.00 |", "⚠️ **Possible memory leak detected**"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+
+	// A noisy series with no steady decline is not a leak
+	result.LoadTest.PerWindowStats = windows(100, 60, 110, 70, 105, 65, 100, 60, 95, 70)
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "Possible memory leak") {
+		t.Error("expected no leak callout for noisy throughput")
+	}
+
+	result.LoadTest.PerWindowStats = nil
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "Throughput Over Time") {
+		t.Error("expected no window table without --leak-detect")
+	}
+}
+
+func TestMarkdown_Report_StressedEndpoint(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		LoadTest: &internal.LoadTestResult{
+			Concurrent:       5,
+			DurationSec:      10,
+			TotalRequests:    100,
+			Successful:       100,
+			StressedEndpoint: "/api/workouts",
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "## Load Test Results: `/api/workouts`") {
+		t.Error("expected load test heading labelled with the stressed endpoint")
+	}
+	if !strings.Contains(content, "**Stressed Endpoint:** `/api/workouts`") {
+		t.Error("expected stressed endpoint in load test configuration")
+	}
+}
+
+func TestMarkdown_Report_FailedEndpointCurl(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	curl := "curl -i -H 'User-Agent: actalog-bench/1.0' 'https://example.com/api/fail'"
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "degraded",
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/ok", ResponseMs: 10, Status: 200, Success: true},
+			{Path: "/api/fail", ResponseMs: 12, Status: 500, Success: false, CurlCommand: curl},
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "<details>") {
+		t.Error("expected collapsed details block for failed endpoints")
+	}
+	if !strings.Contains(content, curl) {
+		t.Error("expected curl command in report")
+	}
+
+	result.Endpoints = result.Endpoints[:1]
+	content = renderMarkdown(t, config, result)
+	if strings.Contains(content, "<details>") {
+		t.Error("expected no details block when all endpoints succeed")
+	}
+}
+
+func TestMarkdown_Report_KeepAlive(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second, ProbeKeepAlive: true}
+	full, none := 1.0, 0.0
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		Connectivity: &internal.ConnectivityResult{
+			DNSMs:                  1,
+			TCPMs:                  2,
+			TotalMs:                3,
+			Connected:              true,
+			KeepAliveReuseFraction: &full,
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "**Keep-Alive Reuse:** 100%") {
+		t.Error("expected keep-alive reuse line")
+	}
+
+	// A probed run with no reuse is still reported
+	result.Connectivity.KeepAliveReuseFraction = &none
+	content = renderMarkdown(t, config, result)
+	if !strings.Contains(content, "**Keep-Alive Reuse:** 0%") || !strings.Contains(content, "keep-alive settings") {
+		t.Error("expected keep-alive warning when no connection was reused")
+	}
+
+	// A probe that failed or was not run records nothing
+	result.Connectivity.KeepAliveReuseFraction = nil
+	content = renderMarkdown(t, config, result)
+	if strings.Contains(content, "Keep-Alive Reuse") {
+		t.Error("expected no keep-alive line without a probe result")
+	}
+}
+
+func TestMarkdown_Report_PathMTU(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second, ProbeMTU: true}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		Connectivity: &internal.ConnectivityResult{
+			DNSMs:     1,
+			TCPMs:     2,
+			TotalMs:   3,
+			Connected: true,
+			PathMTU:   1420,
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "**Path MTU:** about 1420 bytes") || !strings.Contains(content, "VPN tunnels") {
+		t.Error("expected path MTU line with a low MTU warning")
+	}
+
+	// An MSS-based estimate with TCP timestamps reads a little under 1500
+	result.Connectivity.PathMTU = 1488
+	content = renderMarkdown(t, config, result)
+	if strings.Contains(content, "VPN tunnels") {
+		t.Error("expected no low MTU warning for a standard Ethernet path")
+	}
+}
+
+func TestMarkdown_Report_HTTP3(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second, ProbeHTTP3: true}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		Connectivity: &internal.ConnectivityResult{
+			DNSMs:          1,
+			TCPMs:          2,
+			TotalMs:        3,
+			Connected:      true,
+			HTTP3Ms:        42.5,
+			HTTP3Supported: true,
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "| HTTP/3 First Byte | 42.50 |") {
+		t.Error("expected HTTP/3 row in the connectivity table")
+	}
+
+	result.Connectivity.HTTP3Ms = 0
+	result.Connectivity.HTTP3Supported = false
+	result.Connectivity.HTTP3Error = "HTTP/3 request: timeout: no recent network activity"
+	content = renderMarkdown(t, config, result)
+	if strings.Contains(content, "HTTP/3 First Byte") || !strings.Contains(content, "**HTTP/3:** Not supported") ||
+		!strings.Contains(content, "no recent network activity") {
+		t.Error("expected an unsupported note with its reason and no HTTP/3 row")
+	}
+
+	// A probe that never ran is not reported as unsupported
+	result.Connectivity.HTTP3Error = ""
+	if content = renderMarkdown(t, config, result); strings.Contains(content, "**HTTP/3:**") {
+		t.Error("expected no HTTP/3 note without a probe result")
+	}
+}
+
+func TestMarkdown_Report_TLSResumed(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		Connectivity: &internal.ConnectivityResult{
+			DNSMs:              1,
+			TCPMs:              2,
+			TLSMs:              3,
+			TotalMs:            6,
+			Connected:          true,
+			TLSFullHandshakeMs: 3,
+			TLSResumed:         true,
+			TLSResumedMs:       1.25,
+		},
+	}
+
+	if content := renderMarkdown(t, config, result); !strings.Contains(content, "**TLS Session Resumption:** A second connection resumed the session in 1.25 ms") {
+		t.Error("expected a note about TLS session resumption")
+	}
+
+	result.Connectivity.TLSResumed = false
+	result.Connectivity.TLSResumedMs = 0
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "TLS Session Resumption") {
+		t.Error("expected no resumption note for a full handshake")
+	}
+}
+
+func TestMarkdown_Report_RecordsSensitivity(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	response := func(records int, query, encode float64) *internal.BenchmarkAPIResponse {
+		return &internal.BenchmarkAPIResponse{
+			RecordCount:     records,
+			TotalDurationMs: query + encode,
+			Database:        map[string]*internal.OperationResult{"query_workouts": {Success: true, DurationMs: query}},
+			Serialization:   map[string]*internal.OperationResult{"encode_json": {Success: true, DurationMs: encode}},
+		}
+	}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		BenchmarkAPISweep: []internal.BenchmarkAPISweepPoint{
+			{RecordCount: 1000, Response: response(1000, 10, 2)},
+			{RecordCount: 5000, Response: response(5000, 250, 10)},
+			{RecordCount: 10000, Error: "write timeout"},
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	for _, want := range []string{
+		"## Records Sensitivity",
+		"| Operation | 1000 records (ms) | 5000 records (ms) | 10000 records (ms) | Growth |",
+		"| Database: query_workouts | 10.00 | 250.00 | - | - |",
+		"| **Total** | **12.00** | **260.00** | ❌ | |",
+		"❌ **10000 records failed:** write timeout",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+
+	result.BenchmarkAPISweep = result.BenchmarkAPISweep[:2]
+	content = renderMarkdown(t, config, result)
+	for _, want := range []string{
+		"| Database: query_workouts | 10.00 | 250.00 | ×25.0 ⚠️ |",
+		"| Serialization: encode_json | 2.00 | 10.00 | ×5.0 |",
+		"**1 of 2 operations grew faster than the record count**",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+
+	result.BenchmarkAPISweep = nil
+	if content := renderMarkdown(t, config, result); strings.Contains(content, "Records Sensitivity") {
+		t.Error("expected no records sensitivity section without a sweep")
+	}
+}
+
+func TestMarkdown_Report_ConcurrencyProfile(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		ConcurrencyProfile: []internal.ConcurrencyDataPoint{
+			{Concurrent: 1, RPS: 50, LatencyP95Ms: 20},
+			{Concurrent: 5, RPS: 200, LatencyP95Ms: 40},
+			{Concurrent: 10, RPS: 250, LatencyP95Ms: 80, ErrorRatePct: 2.5},
+		},
+		RecommendedConcurrency: 5,
+	}
+
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "## Concurrency Profile") {
+		t.Error("expected Concurrency Profile section")
+	}
+	if !strings.Contains(content, "| **5** ⭐ | 200.00 | 40.00 | 0.00% | 5.00 |") {
+		t.Error("expected recommended row to be highlighted")
+	}
+	if !strings.Contains(content, "| 10 | 250.00 | 80.00 | 2.50% | 3.12 |") {
+		t.Error("expected regular profile row")
+	}
+	if !strings.Contains(content, "**Use 5 concurrent workers.**") {
+		t.Error("expected concurrency recommendation")
+	}
+
+	result.RecommendedConcurrency = 0
+	content = renderMarkdown(t, config, result)
+	if !strings.Contains(content, "**No recommendation**") {
+		t.Error("expected no-recommendation message")
+	}
+}
+
+func TestMarkdown_Report_WebSocketLoadTest(t *testing.T) {
+	config := &internal.Config{URL: "https://example.com", Timeout: 30 * time.Second}
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Now(),
+		Target:    "https://example.com",
+		Overall:   "pass",
+		WebSocketLoadTest: &internal.WebSocketLoadTestResult{
+			Path:           "/ws",
+			Concurrent:     2,
+			Connections:    2,
+			Successful:     500,
+			MessagesPerSec: 250,
+			LatencyP50Ms:   3,
+			LatencyP95Ms:   8,
+		},
+	}
+
+	content := renderMarkdown(t, config, result)
+	if !strings.Contains(content, "## WebSocket Load Test") {
+		t.Error("expected WebSocket Load Test section")
+	}
+	if !strings.Contains(content, "| **Messages/Second** | **250.00** |") {
+		t.Error("expected messages per second row")
+	}
+	if !strings.Contains(content, "Responsive real-time channel") {
+		t.Error("expected responsive interpretation")
+	}
+
+	result.WebSocketLoadTest = &internal.WebSocketLoadTestResult{Path: "/ws", Concurrent: 2, Error: "websocket dial: bad status"}
+	content = renderMarkdown(t, config, result)
+	if !strings.Contains(content, "**WebSocket Error:** websocket dial: bad status") {
+		t.Error("expected WebSocket error message")
+	}
+}
@@ -0,0 +1,134 @@
+package reporter
+
+import (
+	"math"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// overallRank orders run outcomes from best to worst
+var overallRank = map[string]int{"pass": 0, "degraded": 1, "fail": 2}
+
+// MergeResults combines results from multiple benchmark agents into one result.
+// Load test counters and RPS are summed across agents. Latency percentiles are
+// combined as an average weighted by each agent's request count, which
+// approximates the percentile of the combined distribution. The standard
+// deviation is pooled, so it includes the spread between the agents' means.
+// Other sections are taken from the earliest result, and Overall is the worst
+// outcome of any agent.
+func MergeResults(results []*internal.BenchmarkResult) *internal.BenchmarkResult {
+	if len(results) == 0 {
+		return nil
+	}
+
+	first := results[0]
+	for _, r := range results[1:] {
+		if r.Timestamp.Before(first.Timestamp) {
+			first = r
+		}
+	}
+
+	merged := *first
+	merged.AgentCount = len(results)
+	merged.LoadTest = mergeLoadTests(results)
+
+	for _, r := range results {
+		if overallRank[r.Overall] > overallRank[merged.Overall] {
+			merged.Overall = r.Overall
+		}
+		if merged.Error == "" && r.Error != "" {
+			merged.Error = r.Error
+		}
+	}
+
+	return &merged
+}
+
+// mergeLoadTests combines the load test results of all agents that ran one
+func mergeLoadTests(results []*internal.BenchmarkResult) *internal.LoadTestResult {
+	var merged *internal.LoadTestResult
+	var p50, p95, p99, avg, squares, iqr, weight float64
+	percentiles, percentileWeights := make(map[string]float64), make(map[string]float64)
+
+	for _, r := range results {
+		lt := r.LoadTest
+		if lt == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &internal.LoadTestResult{
+				MinLatencyMs:     lt.MinLatencyMs,
+				EndpointStrategy: lt.EndpointStrategy,
+			}
+		}
+
+		merged.Concurrent += lt.Concurrent
+		merged.DurationSec = math.Max(merged.DurationSec, lt.DurationSec)
+		merged.TotalRequests += lt.TotalRequests
+		merged.Successful += lt.Successful
+		merged.Failed += lt.Failed
+		merged.TotalBytesReceived += lt.TotalBytesReceived
+		merged.DNSCacheHits += lt.DNSCacheHits
+		merged.RPS += lt.RPS
+		merged.TargetRPS += lt.TargetRPS
+		merged.ArrivalRate += lt.ArrivalRate
+		merged.ServiceRate += lt.ServiceRate
+		merged.MaxQueueDepth = max(merged.MaxQueueDepth, lt.MaxQueueDepth)
+		merged.MinLatencyMs = math.Min(merged.MinLatencyMs, lt.MinLatencyMs)
+		merged.MaxLatencyMs = math.Max(merged.MaxLatencyMs, lt.MaxLatencyMs)
+		if merged.EndpointStrategy != lt.EndpointStrategy {
+			merged.EndpointStrategy = "mixed"
+		}
+		for target, count := range lt.SLABreachCount {
+			if merged.SLABreachCount == nil {
+				merged.SLABreachCount = make(map[string]int)
+			}
+			merged.SLABreachCount[target] += count
+		}
+		for errorType, count := range lt.ErrorBreakdown {
+			if merged.ErrorBreakdown == nil {
+				merged.ErrorBreakdown = make(map[string]int)
+			}
+			merged.ErrorBreakdown[errorType] += count
+		}
+		for path, count := range lt.EndpointDistribution {
+			if merged.EndpointDistribution == nil {
+				merged.EndpointDistribution = make(map[string]int)
+			}
+			merged.EndpointDistribution[path] += count
+		}
+
+		w := float64(lt.TotalRequests)
+		p50 += lt.LatencyP50Ms * w
+		p95 += lt.LatencyP95Ms * w
+		p99 += lt.LatencyP99Ms * w
+		avg += lt.AvgLatencyMs * w
+		// Each agent's second moment about zero, so the spread between agent means counts too
+		squares += (lt.StdDevLatencyMs*lt.StdDevLatencyMs + lt.AvgLatencyMs*lt.AvgLatencyMs) * w
+		iqr += lt.IQRLatencyMs * w
+		weight += w
+		for label, ms := range lt.Percentiles {
+			percentiles[label] += ms * w
+			percentileWeights[label] += w
+		}
+	}
+
+	if merged != nil && weight > 0 {
+		merged.LatencyP50Ms = p50 / weight
+		merged.LatencyP95Ms = p95 / weight
+		merged.LatencyP99Ms = p99 / weight
+		merged.AvgLatencyMs = avg / weight
+		// The pooled standard deviation, sqrt(Σ wᵢ(σᵢ² + (μᵢ − μ)²) / Σ wᵢ); rounding can leave the variance slightly negative
+		merged.StdDevLatencyMs = math.Sqrt(math.Max(squares/weight-merged.AvgLatencyMs*merged.AvgLatencyMs, 0))
+		merged.IQRLatencyMs = iqr / weight
+		for label, sum := range percentiles {
+			if percentileWeights[label] > 0 {
+				if merged.Percentiles == nil {
+					merged.Percentiles = make(map[string]float64, len(percentiles))
+				}
+				merged.Percentiles[label] = sum / percentileWeights[label]
+			}
+		}
+	}
+	return merged
+}
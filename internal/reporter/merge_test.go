@@ -0,0 +1,121 @@
+package reporter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestMergeResults(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{
+			Timestamp: time.Date(2026, 1, 1, 10, 0, 5, 0, time.UTC),
+			Target:    "https://example.com",
+			Overall:   "pass",
+			LoadTest: &internal.LoadTestResult{
+				Concurrent: 5, DurationSec: 10, TotalRequests: 100, Successful: 100,
+				TotalBytesReceived: 1000, RPS: 10,
+				LatencyP50Ms: 10, LatencyP95Ms: 20, LatencyP99Ms: 30, AvgLatencyMs: 12,
+				MinLatencyMs: 2, MaxLatencyMs: 40,
+				SLABreachCount: map[string]int{"200ms": 3},
+			},
+		},
+		{
+			Timestamp: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			Target:    "https://example.com",
+			Version:   "1.2.3",
+			Overall:   "degraded",
+			LoadTest: &internal.LoadTestResult{
+				Concurrent: 5, DurationSec: 12, TotalRequests: 300, Successful: 290, Failed: 10,
+				TotalBytesReceived: 3000, RPS: 25,
+				LatencyP50Ms: 20, LatencyP95Ms: 40, LatencyP99Ms: 70, AvgLatencyMs: 24,
+				MinLatencyMs: 1, MaxLatencyMs: 90,
+				SLABreachCount: map[string]int{"200ms": 7},
+			},
+		},
+		{
+			Timestamp: time.Date(2026, 1, 1, 10, 0, 3, 0, time.UTC),
+			Overall:   "pass",
+		},
+	}
+
+	merged := MergeResults(results)
+
+	if merged.AgentCount != 3 {
+		t.Errorf("expected agent count 3, got %d", merged.AgentCount)
+	}
+	if merged.Version != "1.2.3" {
+		t.Errorf("expected non-load fields from the earliest result, got version %q", merged.Version)
+	}
+	if merged.Overall != "degraded" {
+		t.Errorf("expected worst overall status 'degraded', got %s", merged.Overall)
+	}
+
+	lt := merged.LoadTest
+	if lt == nil {
+		t.Fatal("expected merged load test")
+	}
+	if lt.TotalRequests != 400 || lt.Successful != 390 || lt.Failed != 10 || lt.TotalBytesReceived != 4000 {
+		t.Errorf("unexpected summed counters: %+v", lt)
+	}
+	if lt.RPS != 35 || lt.Concurrent != 10 || lt.DurationSec != 12 {
+		t.Errorf("expected RPS 35, concurrent 10, duration 12; got %.1f, %d, %.1f", lt.RPS, lt.Concurrent, lt.DurationSec)
+	}
+	if lt.MinLatencyMs != 1 || lt.MaxLatencyMs != 90 {
+		t.Errorf("expected min 1 and max 90, got %.1f and %.1f", lt.MinLatencyMs, lt.MaxLatencyMs)
+	}
+	if lt.SLABreachCount["200ms"] != 10 {
+		t.Errorf("expected 10 summed SLA breaches, got %v", lt.SLABreachCount)
+	}
+	// Weighted by request count: (20*100 + 40*300) / 400
+	if math.Abs(lt.LatencyP95Ms-35) > 1e-9 {
+		t.Errorf("expected weighted p95 35, got %.4f", lt.LatencyP95Ms)
+	}
+	if math.Abs(lt.LatencyP50Ms-17.5) > 1e-9 || math.Abs(lt.LatencyP99Ms-60) > 1e-9 {
+		t.Errorf("expected weighted p50 17.5 and p99 60, got %.4f and %.4f", lt.LatencyP50Ms, lt.LatencyP99Ms)
+	}
+
+	// Inputs must not be modified
+	if results[1].LoadTest.TotalRequests != 300 {
+		t.Error("expected input results to be left unchanged")
+	}
+}
+
+func TestMergeResults_PooledStdDev(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{Overall: "pass", LoadTest: &internal.LoadTestResult{TotalRequests: 100, AvgLatencyMs: 10, StdDevLatencyMs: 3}},
+		{Overall: "pass", LoadTest: &internal.LoadTestResult{TotalRequests: 300, AvgLatencyMs: 30, StdDevLatencyMs: 4}},
+	}
+
+	lt := MergeResults(results).LoadTest
+
+	// Mean (10*100 + 30*300) / 400 = 25; variance (100*(9+225) + 300*(16+25)) / 400 = 89.25
+	if math.Abs(lt.AvgLatencyMs-25) > 1e-9 {
+		t.Errorf("expected weighted average 25, got %.4f", lt.AvgLatencyMs)
+	}
+	if want := math.Sqrt(89.25); math.Abs(lt.StdDevLatencyMs-want) > 1e-9 {
+		t.Errorf("expected pooled stddev %.4f, got %.4f", want, lt.StdDevLatencyMs)
+	}
+}
+
+func TestMergeResults_NoLoadTests(t *testing.T) {
+	merged := MergeResults([]*internal.BenchmarkResult{
+		{Overall: "pass"},
+		{Overall: "fail", Error: "authentication failed"},
+	})
+
+	if merged.LoadTest != nil {
+		t.Error("expected no merged load test")
+	}
+	if merged.Overall != "fail" || merged.Error != "authentication failed" {
+		t.Errorf("expected failure to propagate, got %s / %q", merged.Overall, merged.Error)
+	}
+}
+
+func TestMergeResults_Empty(t *testing.T) {
+	if MergeResults(nil) != nil {
+		t.Error("expected nil for no results")
+	}
+}
@@ -0,0 +1,118 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+	"github.com/johnzastrow/actalog-benchmark/internal/exporter"
+)
+
+// prometheusShutdownTimeout bounds how long Close waits for the HTTP server to stop
+const prometheusShutdownTimeout = 5 * time.Second
+
+// PrometheusServer serves the latest benchmark result on /metrics in the
+// Prometheus text exposition format, so Prometheus can scrape it and
+// Alertmanager alert on it. Gauges describe the most recent run, as pushed to
+// a Pushgateway by exporter.PushPrometheus; actalog_bench_requests_total
+// counts the requests of every run since the server started.
+type PrometheusServer struct {
+	mu       sync.Mutex // Serializes Update
+	success  int        // Requests that succeeded, over all runs
+	failure  int        // Requests that failed, over all runs
+	exposure atomic.Pointer[string]
+
+	httpServer *http.Server
+	listener   net.Listener
+}
+
+// NewPrometheusServer creates a server exposing no results yet. Call Start to
+// serve it and Close to stop it.
+func NewPrometheusServer() *PrometheusServer {
+	s := &PrometheusServer{}
+	exposure := s.requestsTotal()
+	s.exposure.Store(&exposure)
+	return s
+}
+
+// Start listens on addr (e.g. ":9090") and serves /metrics in the background
+func (s *PrometheusServer) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprint(w, *s.exposure.Load())
+	})
+	s.listener = listener
+	s.httpServer = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Warning: Prometheus metrics server stopped: %v\n", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, or "" before Start
+func (s *PrometheusServer) Addr() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Update replaces the exposed gauges with those of result and adds its
+// endpoint and load test requests to actalog_bench_requests_total. Scrapes
+// see either the previous run or this one, never a mix.
+func (s *PrometheusServer) Update(result *internal.BenchmarkResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ep := range result.Endpoints {
+		if ep.Success {
+			s.success++
+		} else {
+			s.failure++
+		}
+	}
+	if lt := result.LoadTest; lt != nil {
+		s.success += lt.Successful
+		s.failure += lt.Failed
+	}
+
+	exposure := exporter.FormatPrometheus(result) + s.requestsTotal()
+	s.exposure.Store(&exposure)
+}
+
+// requestsTotal renders the actalog_bench_requests_total counter
+func (s *PrometheusServer) requestsTotal() string {
+	var sb strings.Builder
+	sb.WriteString("# HELP actalog_bench_requests_total Requests sent by the benchmark runs, by outcome\n")
+	sb.WriteString("# TYPE actalog_bench_requests_total counter\n")
+	fmt.Fprintf(&sb, "actalog_bench_requests_total{status=\"success\"} %d\n", s.success)
+	fmt.Fprintf(&sb, "actalog_bench_requests_total{status=\"failure\"} %d\n", s.failure)
+	return sb.String()
+}
+
+// Close stops the HTTP server
+func (s *PrometheusServer) Close() error {
+	if s.httpServer == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), prometheusShutdownTimeout)
+	defer cancel()
+	return s.httpServer.Shutdown(ctx)
+}
@@ -0,0 +1,64 @@
+package reporter
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestPrometheusServer_Update(t *testing.T) {
+	s := NewPrometheusServer()
+	if err := s.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer s.Close()
+
+	scrape := func() string {
+		t.Helper()
+		resp, err := http.Get("http://" + s.Addr() + "/metrics")
+		if err != nil {
+			t.Fatalf("expected a scrape, got: %v", err)
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+			t.Errorf("expected the Prometheus text format, got %q", ct)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	if body := scrape(); !strings.Contains(body, `actalog_bench_requests_total{status="success"} 0`) {
+		t.Errorf("expected a zero counter before the first run, got:\n%s", body)
+	}
+
+	result := &internal.BenchmarkResult{
+		Timestamp: time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC),
+		Health:    &internal.HealthResult{Status: "healthy", ResponseMs: 15.5, HTTPStatus: 200},
+		Endpoints: []internal.EndpointResult{
+			{Path: "/api/workouts", ResponseMs: 42, Status: 200, Success: true},
+			{Path: "/api/movements", ResponseMs: 60, Status: 500},
+		},
+		LoadTest: &internal.LoadTestResult{TotalRequests: 100, Successful: 98, Failed: 2},
+	}
+	s.Update(result)
+	s.Update(result)
+
+	body := scrape()
+	for _, want := range []string{
+		"actalog_bench_health_response_ms",
+		`actalog_bench_endpoint_response_ms{path="/api/workouts"`,
+		`actalog_bench_requests_total{status="success"} 198`,
+		`actalog_bench_requests_total{status="failure"} 6`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in the exposition, got:\n%s", want, body)
+		}
+	}
+}
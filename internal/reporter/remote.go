@@ -0,0 +1,182 @@
+package reporter
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// remoteFetchTimeout bounds each manifest or result download
+const remoteFetchTimeout = 60 * time.Second
+
+// hrefPattern finds link targets in an HTML directory listing
+var hrefPattern = regexp.MustCompile(`(?i)<a\s[^>]*href=["']([^"'#?]+\.json(?:\?[^"'#]*)?)["']`)
+
+// SetRemoteToken sets a bearer token sent when fetching remote results. It is
+// only sent to the manifest's host, so pre-signed URLs on other hosts (S3, GCS)
+// keep working and never see the token.
+func (c *Comparison) SetRemoteToken(token string) {
+	c.remoteToken = token
+}
+
+// FetchRemote downloads the benchmark results listed at manifestURL into a
+// temporary directory and returns their local paths in listing order. The
+// manifest is either a text file with one JSON URL per line (blank lines and
+// # comments are skipped) or an HTML directory listing, whose links to .json
+// files are used. Relative URLs resolve against the manifest URL. Call Cleanup
+// to remove the downloaded files.
+func (c *Comparison) FetchRemote(manifestURL string) ([]string, error) {
+	base, err := url.Parse(manifestURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse manifest URL: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("manifest URL must be http or https, got %q", manifestURL)
+	}
+
+	httpClient := &http.Client{Timeout: remoteFetchTimeout}
+	manifest, contentType, err := c.fetchRemote(httpClient, base, base)
+	if err != nil {
+		return nil, fmt.Errorf("fetch manifest: %w", err)
+	}
+
+	urls, err := parseManifest(base, manifest, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no JSON result URLs found in %s", manifestURL)
+	}
+
+	dir, err := os.MkdirTemp("", "actalog-compare-*")
+	if err != nil {
+		return nil, fmt.Errorf("create download directory: %w", err)
+	}
+	c.remoteDirs = append(c.remoteDirs, dir)
+
+	paths := make([]string, 0, len(urls))
+	for i, u := range urls {
+		data, _, err := c.fetchRemote(httpClient, base, u)
+		if err != nil {
+			return nil, fmt.Errorf("download %s: %w", remoteLabel(u), err)
+		}
+		// The index prefix keeps results with the same file name apart
+		local := filepath.Join(dir, fmt.Sprintf("%03d_%s", i+1, remoteFileName(u)))
+		if err := os.WriteFile(local, data, 0644); err != nil {
+			return nil, fmt.Errorf("save %s: %w", remoteLabel(u), err)
+		}
+		paths = append(paths, local)
+	}
+
+	if c.since > 0 {
+		return c.filterSince(paths, manifestURL)
+	}
+	return paths, nil
+}
+
+// Cleanup removes the files downloaded by FetchRemote
+func (c *Comparison) Cleanup() error {
+	var firstErr error
+	for _, dir := range c.remoteDirs {
+		if err := os.RemoveAll(dir); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	c.remoteDirs = nil
+	return firstErr
+}
+
+// fetchRemote GETs target and returns its body and Content-Type, adding the
+// bearer token only when target is on the manifest's host
+func (c *Comparison) fetchRemote(httpClient *http.Client, manifest, target *url.URL) ([]byte, string, error) {
+	req, err := http.NewRequest(http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("create request: %w", err)
+	}
+	if c.remoteToken != "" && target.Host == manifest.Host {
+		req.Header.Set("Authorization", "Bearer "+c.remoteToken)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// The url.Error would repeat the URL, pre-signed query and all
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, "", fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("read body: %w", err)
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// parseManifest returns the result URLs listed in a manifest, resolved
+// against base. HTML content is treated as a directory listing.
+func parseManifest(base *url.URL, manifest []byte, contentType string) ([]*url.URL, error) {
+	var refs []string
+	if strings.Contains(contentType, "text/html") || bytes.Contains(bytes.ToLower(manifest), []byte("<a ")) {
+		for _, m := range hrefPattern.FindAllSubmatch(manifest, -1) {
+			refs = append(refs, html.UnescapeString(string(m[1])))
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(manifest))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			refs = append(refs, line)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("read manifest: %w", err)
+		}
+	}
+
+	urls := make([]*url.URL, 0, len(refs))
+	for _, ref := range refs {
+		u, err := base.Parse(ref)
+		if err != nil {
+			return nil, fmt.Errorf("manifest entry %q: %w", ref, err)
+		}
+		urls = append(urls, u)
+	}
+	return urls, nil
+}
+
+// remoteFileName returns the last path element of u, ending in .json
+func remoteFileName(u *url.URL) string {
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		name = "result"
+	}
+	if !strings.HasSuffix(strings.ToLower(name), ".json") {
+		name += ".json"
+	}
+	return name
+}
+
+// remoteLabel returns u without credentials or query string, so errors do not
+// echo pre-signed URL signatures
+func remoteLabel(u *url.URL) string {
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+}
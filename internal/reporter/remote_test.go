@@ -0,0 +1,156 @@
+package reporter
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFetchRemote_Manifest(t *testing.T) {
+	var authHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/manifest.txt":
+			fmt.Fprintf(w, "# nightly runs\n\nruns/a.json\n%s/runs/b.json?X-Amz-Signature=abc\n", "http://"+r.Host)
+		case "/runs/a.json":
+			w.Write([]byte(`{"target": "a"}`))
+		case "/runs/b.json":
+			w.Write([]byte(`{"target": "b"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	comp := NewComparison(t.TempDir())
+	comp.SetRemoteToken("token123")
+	paths, err := comp.FetchRemote(server.URL + "/manifest.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 paths, got %d", len(paths))
+	}
+	if filepath.Base(paths[0]) != "001_a.json" || filepath.Base(paths[1]) != "002_b.json" {
+		t.Errorf("unexpected file names %v", paths)
+	}
+	data, err := os.ReadFile(paths[1])
+	if err != nil || string(data) != `{"target": "b"}` {
+		t.Errorf("expected downloaded content, got %q (%v)", data, err)
+	}
+	for _, h := range authHeaders {
+		if h != "Bearer token123" {
+			t.Errorf("expected bearer token on manifest host requests, got %q", h)
+		}
+	}
+
+	if err := comp.Cleanup(); err != nil {
+		t.Fatalf("cleanup: %v", err)
+	}
+	if _, err := os.Stat(paths[0]); !os.IsNotExist(err) {
+		t.Error("expected Cleanup to remove downloaded files")
+	}
+}
+
+func TestFetchRemote_DirectoryListing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/results/":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<pre><a href="../">../</a>
+<a href="benchmark_1.json">benchmark_1.json</a>
+<a href="notes.txt">notes.txt</a>
+<a href="/results/benchmark_2.json">benchmark_2.json</a></pre>`))
+		case "/results/benchmark_1.json", "/results/benchmark_2.json":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	comp := NewComparison(t.TempDir())
+	defer comp.Cleanup()
+	paths, err := comp.FetchRemote(server.URL + "/results/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("expected only the 2 JSON links, got %v", paths)
+	}
+}
+
+func TestFetchRemote_TokenNotSentToOtherHosts(t *testing.T) {
+	var resultAuth string
+	results := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resultAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{}`))
+	}))
+	defer results.Close()
+
+	manifest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, results.URL+"/run.json")
+	}))
+	defer manifest.Close()
+
+	comp := NewComparison(t.TempDir())
+	comp.SetRemoteToken("token123")
+	defer comp.Cleanup()
+	if _, err := comp.FetchRemote(manifest.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resultAuth != "" {
+		t.Errorf("expected no Authorization header on another host, got %q", resultAuth)
+	}
+}
+
+func TestFetchRemote_Errors(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/empty.txt":
+			w.Write([]byte("# nothing yet\n"))
+		case "/missing.txt":
+			w.Write([]byte("gone.json?X-Amz-Signature=secret\n"))
+		case "/unreachable.txt":
+			w.Write([]byte(closed.URL + "/run.json?X-Amz-Signature=secret\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{"unsupported scheme", "file:///tmp/manifest.txt", "must be http or https"},
+		{"manifest not found", server.URL + "/nope.txt", "fetch manifest: server returned status 404"},
+		{"empty manifest", server.URL + "/empty.txt", "no JSON result URLs"},
+		{"result not found", server.URL + "/missing.txt", "download " + server.URL + "/gone.json: server returned status 404"},
+		{"result unreachable", server.URL + "/unreachable.txt", "download " + closed.URL + "/run.json: execute request:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comp := NewComparison(t.TempDir())
+			defer comp.Cleanup()
+			_, err := comp.FetchRemote(tt.url)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if strings.Contains(err.Error(), "secret") {
+				t.Errorf("expected pre-signed query to be left out of the error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package reporter
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// schemaField records a result field and the schema version that introduced it
+type schemaField struct {
+	since   int
+	field   string
+	section string // Comparison section that reads the field, empty if none
+}
+
+// schemaFields lists result fields added after the unversioned 0.7.0 format.
+// Add an entry here with the new version whenever internal.SchemaVersion is bumped.
+var schemaFields = []schemaField{
+	{1, "schema_version", ""},
+	{1, "connectivity.ipv4_ms, connectivity.ipv6_ms", "Connectivity Comparison (IPv4/IPv6 rows and IPv6 support changes)"},
+	{1, "connectivity.keep_alive_reuse_fraction", ""},
+	{1, "connectivity.hop_count, connectivity.traceroute_ms", ""},
+	{1, "connectivity.path_mtu", ""},
+	{1, "connectivity.http3_ms, connectivity.http3_supported, connectivity.http3_error", "Connectivity Comparison (HTTP/3 row)"},
+	{1, "connectivity.dns_resolver", "Connectivity Comparison (DNS resolver changes)"},
+	{1, "endpoints[].method, endpoints[].request_id, endpoints[].curl_command, endpoints[].cold_start_ms, endpoints[].response_truncated", ""},
+	{1, "endpoints[].deprecated, endpoints[].sunset_date", "API Endpoint Performance Comparison (newly deprecated endpoints)"},
+	{1, "load_test.endpoint_strategy, load_test.total_bytes_received, load_test.stressed_endpoint", ""},
+	{1, "health.uptime, health.active_connections, health.memory_mb", "Health Check Comparison (Active Connections row)"},
+	{1, "waited_for_healthy_sec", ""},
+	{1, "phase_durations_ms", ""},
+	{1, "concurrency_profile, recommended_concurrency", ""},
+	{1, "ws_load_test", ""},
+	{1, "agent_count", ""},
+	{1, "sample_count, load_test.latency_p95_ci95", ""},
+	{1, "endpoints[].sample_count, endpoints[].sample_mean_ms, endpoints[].sample_min_ms, endpoints[].sample_max_ms, endpoints[].sample_p50_ms, endpoints[].sample_p95_ms, endpoints[].sample_p99_ms", ""},
+	{1, "load_test.per_window_stats", ""},
+	{1, "load_test.rps_timeline", ""},
+	{1, "frontend.assets[].sri_protected, frontend.assets[].sri_valid", ""},
+	{1, "connectivity.tls_resumed, connectivity.tls_resumed_ms, connectivity.tls_full_handshake_ms", ""},
+	{1, "benchmark_api_sweep", ""},
+	{1, "user_agent", ""},
+	{1, "threshold_breaches", ""},
+	{1, "connectivity.bound_to", "Connectivity Comparison (local address changes)"},
+	{1, "frontend.assets[].fingerprinted, frontend.assets[].cache_control_max_age, frontend.assets[].immutable_cache", ""},
+	{1, "load_test.early_abort, load_test.abort_reason", ""},
+	{1, "connectivity.dns_method, connectivity.doh_server", "Connectivity Comparison (DNS resolver changes)"},
+	{1, "frontend.assets[].wire_transfer_kb, frontend.assets[].compression_ratio", ""},
+	{1, "benchmark_api.concurrent_mode", ""},
+	{1, "benchmark_api.benchmark_timeout_sec", ""},
+	{1, "connectivity.unix_socket", ""},
+	{1, "endpoint_order, endpoint_order_seed", ""},
+	{1, "grpc_health", ""},
+	{1, "sse", ""},
+	{1, "endpoints[].https_downgrade", "Threshold Alerts (HTTPS downgrades)"},
+	{1, "trace_id", ""},
+	{1, "multi_url_mode", "Run Overview (URL column)"},
+	{1, "load_test.new_connections, load_test.pool_saturation", ""},
+	{1, "graceful_shutdown, shutdown_reason", ""},
+	{1, "frontend.asset_filenames", "Frontend Assets Comparison (Asset Inventory Changes)"},
+	{1, "anonymized", ""},
+	{1, "load_test.sla_breach_count", ""},
+	{1, "load_test.error_timestamps_ms, load_test.errors_clustered", ""},
+	{1, "load_test.percentiles", "Load Test Comparison (a row per recorded percentile)"},
+	{1, "simulated_latency_ms, simulated_loss_rate", ""},
+	{1, "load_test.dns_cache_hits", ""},
+	{1, "load_test.stddev_latency_ms, load_test.iqr_latency_ms", "Load Test Comparison (Latency Std Dev and IQR rows)"},
+	{1, "load_test.worker_stats", ""},
+	{1, "load_test.error_breakdown", "Load Test Comparison (Errors rows)"},
+	{1, "load_test.latency_histogram", ""},
+	{1, "load_test.target_rps", ""},
+	{1, "load_test.warmup_sec", "Load Test Comparison (Warmup row and changes)"},
+	{1, "load_test.step_results", "Chart-Ready Data (Step Load CSV)"},
+	{1, "load_test.arrival_rate, load_test.service_rate, load_test.max_queue_depth", ""},
+	{1, "endpoints[].ttfb_ms, endpoints[].total_ms", "API Endpoint Performance Comparison (Time to First Byte table)"},
+	{1, "endpoints[].custom_endpoint", ""},
+	{1, "load_test.endpoint_distribution", ""},
+	{1, "load_test.think_time_ms, load_test.think_time_jitter_pct", "Load Test Comparison (Think Time row)"},
+}
+
+// checkSchemaVersion returns a warning describing what may be missing from a
+// result written with a different schema version, or "" when it matches
+func checkSchemaVersion(path string, r *internal.BenchmarkResult) string {
+	name := filepath.Base(path)
+	if r.SchemaVersion == internal.SchemaVersion {
+		return ""
+	}
+
+	if r.SchemaVersion > internal.SchemaVersion {
+		return fmt.Sprintf("%s uses schema version %d, newer than this tool's version %d. "+
+			"Fields added in newer versions are ignored; upgrade actalog-bench to compare them.",
+			name, r.SchemaVersion, internal.SchemaVersion)
+	}
+
+	var sb strings.Builder
+	if r.SchemaVersion == 0 {
+		sb.WriteString(fmt.Sprintf("%s has no schema version (written before versioning was added). ", name))
+	} else {
+		sb.WriteString(fmt.Sprintf("%s uses schema version %d, older than this tool's version %d. ", name, r.SchemaVersion, internal.SchemaVersion))
+	}
+	sb.WriteString("These fields may be missing:")
+
+	var incomplete []string
+	for _, f := range schemaFields {
+		if f.since <= r.SchemaVersion {
+			continue
+		}
+		sb.WriteString("\n  - " + f.field)
+		if f.section != "" {
+			incomplete = append(incomplete, f.section)
+		}
+	}
+	if len(incomplete) > 0 {
+		sb.WriteString("\n  Incomplete comparison sections: " + strings.Join(incomplete, "; "))
+	}
+
+	return sb.String()
+}
+
+// writeSchemaWarnings writes the schema compatibility section when any file mismatched
+func writeSchemaWarnings(sb *strings.Builder, warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	sb.WriteString("## ⚠️ Schema Compatibility\n\n")
+	sb.WriteString(fmt.Sprintf("Some result files were written by a different version of actalog-bench (current schema version: %d). ", internal.SchemaVersion))
+	sb.WriteString("Metrics those files do not contain are shown as `-` in the tables below.\n\n")
+	for _, w := range warnings {
+		lines := strings.Split(w, "\n")
+		sb.WriteString("- " + lines[0] + "\n")
+		for _, line := range lines[1:] {
+			sb.WriteString("  " + strings.TrimSpace(line) + "\n")
+		}
+	}
+	sb.WriteString("\n")
+}
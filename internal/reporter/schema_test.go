@@ -0,0 +1,60 @@
+package reporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestCheckSchemaVersion(t *testing.T) {
+	current := &internal.BenchmarkResult{SchemaVersion: internal.SchemaVersion}
+	if w := checkSchemaVersion("/tmp/current.json", current); w != "" {
+		t.Errorf("expected no warning for current schema, got %q", w)
+	}
+
+	w := checkSchemaVersion("/tmp/old.json", &internal.BenchmarkResult{})
+	if !strings.HasPrefix(w, "old.json has no schema version") {
+		t.Errorf("expected unversioned warning naming the file, got %q", w)
+	}
+	if !strings.Contains(w, "connectivity.ipv4_ms") {
+		t.Error("expected missing fields to be listed")
+	}
+	if !strings.Contains(w, "Incomplete comparison sections: Connectivity Comparison") {
+		t.Error("expected incomplete comparison sections to be listed")
+	}
+
+	w = checkSchemaVersion("/tmp/new.json", &internal.BenchmarkResult{SchemaVersion: internal.SchemaVersion + 1})
+	if !strings.Contains(w, "newer than this tool's version") {
+		t.Errorf("expected newer-version warning, got %q", w)
+	}
+}
+
+func TestComparison_SchemaVersionCheck(t *testing.T) {
+	results := []*internal.BenchmarkResult{
+		{Timestamp: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), Overall: "pass"},
+		{Timestamp: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), Overall: "pass", SchemaVersion: internal.SchemaVersion},
+	}
+
+	c := NewComparison(t.TempDir())
+	c.SetSchemaVersionCheck(true)
+	content := renderComparison(t, c, results)
+
+	if len(c.SchemaWarnings()) != 1 {
+		t.Fatalf("expected 1 schema warning, got %d", len(c.SchemaWarnings()))
+	}
+	if !strings.Contains(content, "## ⚠️ Schema Compatibility") {
+		t.Error("expected Schema Compatibility section")
+	}
+	if !strings.Contains(content, "- benchmark_0.json has no schema version") {
+		t.Error("expected warning for the unversioned file")
+	}
+
+	// Disabled by default
+	c = NewComparison(t.TempDir())
+	content = renderComparison(t, c, results)
+	if len(c.SchemaWarnings()) != 0 || strings.Contains(content, "Schema Compatibility") {
+		t.Error("expected no schema warnings when the check is disabled")
+	}
+}
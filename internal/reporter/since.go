@@ -0,0 +1,141 @@
+package reporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// SetSince limits the comparison to files holding a result from within the
+// last since, as recorded in each result's timestamp. Zero compares all files.
+func (c *Comparison) SetSince(since time.Duration) {
+	c.since = since
+}
+
+// filterSince keeps the paths whose newest result is no older than c.since.
+// source names the directory or manifest in errors.
+func (c *Comparison) filterSince(paths []string, source string) ([]string, error) {
+	cutoff := time.Now().Add(-c.since)
+
+	var kept []string
+	for _, path := range paths {
+		ts, err := peekTimestamp(path)
+		if err != nil {
+			return nil, fmt.Errorf("read timestamp of %s: %w", path, err)
+		}
+		if !ts.Before(cutoff) {
+			kept = append(kept, path)
+		}
+	}
+
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("no benchmark files from the last %s in %s", c.since, source)
+	}
+	return kept, nil
+}
+
+// peekTimestamp returns the timestamp of the result in a JSON file without
+// decoding the rest of it. For a single result the file is read only up to
+// its timestamp field; for an array written with --json-append it is the
+// newest timestamp of any element.
+func peekTimestamp(path string) (time.Time, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(f))
+	tok, err := dec.Token()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse: %w", err)
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		ts, found, err := objectTimestamp(dec)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !found {
+			return time.Time{}, errors.New("no timestamp field")
+		}
+		return ts, nil
+
+	case json.Delim('['):
+		var newest time.Time
+		for dec.More() {
+			if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+				return time.Time{}, errors.New("array element is not an object")
+			}
+			ts, found, err := objectTimestamp(dec)
+			if err != nil {
+				return time.Time{}, err
+			}
+			// The rest of the element still has to be consumed to reach the next one
+			if found {
+				if err := skipObject(dec); err != nil {
+					return time.Time{}, err
+				}
+				if ts.After(newest) {
+					newest = ts
+				}
+			}
+		}
+		if newest.IsZero() {
+			return time.Time{}, errors.New("no timestamp field")
+		}
+		return newest, nil
+
+	default:
+		return time.Time{}, errors.New("not a JSON object or array")
+	}
+}
+
+// objectTimestamp reads the fields of an object whose opening brace was
+// already consumed until it finds "timestamp". When found, the decoder is left
+// just after that value; otherwise the whole object, closing brace included,
+// has been consumed.
+func objectTimestamp(dec *json.Decoder) (time.Time, bool, error) {
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("parse: %w", err)
+		}
+		if key == "timestamp" {
+			var ts time.Time
+			if err := dec.Decode(&ts); err != nil {
+				return time.Time{}, false, fmt.Errorf("parse timestamp: %w", err)
+			}
+			return ts, true, nil
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return time.Time{}, false, fmt.Errorf("parse: %w", err)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return time.Time{}, false, fmt.Errorf("parse: %w", err)
+	}
+	return time.Time{}, false, nil
+}
+
+// skipObject consumes the remaining fields and closing brace of an object
+func skipObject(dec *json.Decoder) error {
+	for dec.More() {
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("parse: %w", err)
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return fmt.Errorf("parse: %w", err)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+	return nil
+}
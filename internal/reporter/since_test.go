@@ -0,0 +1,87 @@
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeSinceFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestPeekTimestamp(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{"single result", `{"timestamp": "2026-01-09T14:30:00Z", "target": "x", "overall": "pass"}`, "2026-01-09T14:30:00Z", false},
+		{"timestamp after other fields", `{"target": "x", "load_test": {"timestamp": "1999-01-01T00:00:00Z"}, "timestamp": "2026-01-09T14:30:00Z"}`, "2026-01-09T14:30:00Z", false},
+		{"appended array uses newest", `[{"timestamp": "2026-01-08T10:00:00Z", "endpoints": [{"path": "/"}]}, {"timestamp": "2026-01-09T14:30:00Z"}, {"timestamp": "2026-01-07T10:00:00Z"}]`, "2026-01-09T14:30:00Z", false},
+		{"missing timestamp", `{"target": "x"}`, "", true},
+		{"not an object", `"hello"`, "", true},
+		{"invalid JSON", `{"timestamp": `, "", true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeSinceFile(t, dir, fmt.Sprintf("file%d.json", i), tt.content)
+			got, err := peekTimestamp(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want, _ := time.Parse(time.RFC3339, tt.want); !got.Equal(want) {
+				t.Errorf("expected %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestScanDirectory_Since(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().UTC()
+	result := func(age time.Duration) string {
+		return fmt.Sprintf(`{"timestamp": %q, "target": "x", "overall": "pass"}`, now.Add(-age).Format(time.RFC3339))
+	}
+	writeSinceFile(t, dir, "benchmark_1.json", result(30*24*time.Hour))
+	writeSinceFile(t, dir, "benchmark_2.json", result(2*24*time.Hour))
+	writeSinceFile(t, dir, "benchmark_3.json", result(time.Hour))
+
+	c := NewComparison(dir)
+	c.SetSince(7 * 24 * time.Hour)
+	files, err := c.ScanDirectory(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 || filepath.Base(files[0]) != "benchmark_2.json" || filepath.Base(files[1]) != "benchmark_3.json" {
+		t.Errorf("expected the two recent files, got %v", files)
+	}
+
+	// Without a limit every file is compared
+	c.SetSince(0)
+	if files, _ := c.ScanDirectory(dir); len(files) != 3 {
+		t.Errorf("expected all 3 files without --compare-since, got %v", files)
+	}
+
+	c.SetSince(30 * time.Minute)
+	if _, err := c.ScanDirectory(dir); err == nil || !strings.Contains(err.Error(), "no benchmark files from the last 30m0s") {
+		t.Errorf("expected an error when every file is too old, got %v", err)
+	}
+}
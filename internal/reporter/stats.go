@@ -0,0 +1,129 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// Trend annotation limits
+const (
+	trendMinRuns    = 3   // Two points always fit a line exactly, so need at least three
+	trendMinRSquare = 0.7 // Weaker fits are reported as noise rather than a trend
+)
+
+// computeTrend fits values (one per run, oldest first) to a line by ordinary
+// least squares and returns the slope per run and the coefficient of
+// determination. Fewer than two values or a flat series return 0, 0.
+func computeTrend(values []float64) (slope float64, rSquared float64) {
+	n := float64(len(values))
+	if len(values) < 2 {
+		return 0, 0
+	}
+
+	var sumX, sumY float64
+	for i, y := range values {
+		sumX += float64(i)
+		sumY += y
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy, syy float64
+	for i, y := range values {
+		dx, dy := float64(i)-meanX, y-meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if syy == 0 {
+		return 0, 0
+	}
+
+	slope = sxy / sxx
+	rSquared = sxy * sxy / (sxx * syy)
+	return slope, rSquared
+}
+
+// trendMetric is a metric whose values across runs get a trend annotation
+type trendMetric struct {
+	label string
+	unit  string
+	get   func(r *internal.BenchmarkResult) (float64, bool)
+}
+
+// writeTrends writes a one-line annotation for each metric with a clear linear
+// trend across the runs that reported it
+func writeTrends(sb *strings.Builder, results []*internal.BenchmarkResult, metrics []trendMetric) {
+	var lines []string
+	for _, m := range metrics {
+		slope, r2, ok := metricTrend(results, m.get)
+		if !ok {
+			continue
+		}
+		direction := "📈 Trending up"
+		if slope < 0 {
+			direction = "📉 Trending down"
+		}
+		lines = append(lines, fmt.Sprintf("- **%s:** %s: %+.2f %s/run (R²=%.2f)", m.label, direction, slope, m.unit, r2))
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+	sb.WriteString(strings.Join(lines, "\n") + "\n\n")
+}
+
+// metricTrend fits the values get reports across results to a line. ok is
+// false unless enough runs reported the metric and the fit is clear enough to
+// call a trend.
+func metricTrend(results []*internal.BenchmarkResult, get func(r *internal.BenchmarkResult) (float64, bool)) (slope, rSquared float64, ok bool) {
+	var values []float64
+	for _, r := range results {
+		if v, found := get(r); found {
+			values = append(values, v)
+		}
+	}
+	if len(values) < trendMinRuns {
+		return 0, 0, false
+	}
+
+	slope, rSquared = computeTrend(values)
+	if rSquared <= trendMinRSquare {
+		return 0, 0, false
+	}
+	return slope, rSquared, true
+}
+
+// latencyPercentile is one load test latency percentile
+type latencyPercentile struct {
+	Label string  // e.g. "p99.9"
+	Rank  float64 // e.g. 99.9
+	Ms    float64
+}
+
+// loadTestPercentiles returns the latency percentiles of lt in ascending
+// order: those recorded in Percentiles, or the fixed p50, p95, and p99 of
+// results saved before --percentiles existed
+func loadTestPercentiles(lt *internal.LoadTestResult) []latencyPercentile {
+	if len(lt.Percentiles) == 0 {
+		return []latencyPercentile{
+			{"p50", 50, lt.LatencyP50Ms},
+			{"p95", 95, lt.LatencyP95Ms},
+			{"p99", 99, lt.LatencyP99Ms},
+		}
+	}
+
+	percentiles := make([]latencyPercentile, 0, len(lt.Percentiles))
+	for label, ms := range lt.Percentiles {
+		rank, err := strconv.ParseFloat(strings.TrimPrefix(label, "p"), 64)
+		if err != nil {
+			continue
+		}
+		percentiles = append(percentiles, latencyPercentile{label, rank, ms})
+	}
+	sort.Slice(percentiles, func(i, j int) bool { return percentiles[i].Rank < percentiles[j].Rank })
+	return percentiles
+}
@@ -0,0 +1,73 @@
+package reporter
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestComputeTrend(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []float64
+		wantSlope float64
+		wantR2    float64
+	}{
+		{"perfect increase", []float64{10, 12, 14, 16}, 2, 1},
+		{"perfect decrease", []float64{30, 20, 10}, -10, 1},
+		{"flat", []float64{5, 5, 5}, 0, 0},
+		{"single value", []float64{5}, 0, 0},
+		{"empty", nil, 0, 0},
+		{"oscillating", []float64{10, 20, 10, 20}, 2, 0.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slope, r2 := computeTrend(tt.values)
+			if math.Abs(slope-tt.wantSlope) > 1e-9 {
+				t.Errorf("slope: expected %.4f, got %.4f", tt.wantSlope, slope)
+			}
+			if math.Abs(r2-tt.wantR2) > 1e-9 {
+				t.Errorf("R²: expected %.4f, got %.4f", tt.wantR2, r2)
+			}
+		})
+	}
+}
+
+func TestWriteTrends(t *testing.T) {
+	var results []*internal.BenchmarkResult
+	for _, ms := range []float64{10, 12.3, 14.6, 16.9} {
+		results = append(results, &internal.BenchmarkResult{Health: &internal.HealthResult{ResponseMs: ms}})
+	}
+	response := func(r *internal.BenchmarkResult) (float64, bool) {
+		if r.Health == nil {
+			return 0, false
+		}
+		return r.Health.ResponseMs, true
+	}
+
+	var sb strings.Builder
+	writeTrends(&sb, results, []trendMetric{{"Health Response", "ms", response}})
+	if !strings.Contains(sb.String(), "📈 Trending up: +2.30 ms/run (R²=1.00)") {
+		t.Errorf("expected upward trend annotation, got %q", sb.String())
+	}
+
+	// Oscillating values fit poorly and are not annotated
+	for i, ms := range []float64{10, 30, 10, 30} {
+		results[i].Health.ResponseMs = ms
+	}
+	sb.Reset()
+	writeTrends(&sb, results, []trendMetric{{"Health Response", "ms", response}})
+	if sb.Len() != 0 {
+		t.Errorf("expected no annotation for noisy values, got %q", sb.String())
+	}
+
+	// Two runs always fit exactly and are not annotated
+	sb.Reset()
+	writeTrends(&sb, results[:2], []trendMetric{{"Health Response", "ms", response}})
+	if sb.Len() != 0 {
+		t.Errorf("expected no annotation for two runs, got %q", sb.String())
+	}
+}
@@ -0,0 +1,168 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// LoadThresholdConfig reads a JSON (.json) or YAML (.yaml, .yml) threshold
+// file, choosing the format from the file extension. Fields missing from the
+// file keep their DefaultThresholds value.
+func LoadThresholdConfig(path string) (*ThresholdConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read threshold file: %w", err)
+	}
+
+	t := DefaultThresholds()
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(t); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		// An empty file decodes to io.EOF and leaves every value at its default
+		if err := dec.Decode(t); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported threshold file extension %q (use .json, .yaml, or .yml)", ext)
+	}
+
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid thresholds in %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Validate reports every negative threshold, one error per field
+func (t *ThresholdConfig) Validate() error {
+	fields := []struct {
+		name  string
+		value float64
+	}{
+		{"latency_p95_max_ms", t.LatencyP95MaxMs},
+		{"latency_p99_max_ms", t.LatencyP99MaxMs},
+		{"error_rate_max_pct", t.ErrorRateMaxPct},
+		{"rps_minimum", t.RPSMinimum},
+		{"health_response_max", t.HealthResponseMax},
+		{"db_operation_max_ms", t.DBOperationMaxMs},
+		{"serialization_max_ms", t.SerializationMaxMs},
+	}
+
+	var errs []error
+	for _, f := range fields {
+		if f.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %g", f.name, f.value))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// criticalBreachFactor makes a breach critical once the metric is this many
+// times worse than its threshold: double a maximum, or half a minimum
+const criticalBreachFactor = 2
+
+// Metric names used in threshold breaches. Server-side operations are named
+// with the database or serialization prefix followed by the operation name,
+// and HTTPS downgrades with their prefix followed by the endpoint.
+const (
+	metricHealthResponse = "health_response_ms"
+	metricLatencyP95     = "latency_p95_ms"
+	metricLatencyP99     = "latency_p99_ms"
+	metricErrorRate      = "error_rate_pct"
+	metricRPS            = "rps"
+
+	metricDatabasePrefix       = "database."
+	metricSerializationPrefix  = "serialization."
+	metricHTTPSDowngradePrefix = "https_downgrade."
+)
+
+// RunLabel names the run at index i (zero-based) the way threshold alerts
+// refer to it
+func RunLabel(i int, r *internal.BenchmarkResult) string {
+	return fmt.Sprintf("Run %d (%s)", i+1, r.Timestamp.Format("2006-01-02 15:04"))
+}
+
+// Breaches returns every metric in r that crosses a threshold, each tagged
+// with runLabel. Maxima are breached when exceeded and RPSMinimum when
+// undercut; a zero DBOperationMaxMs or SerializationMaxMs disables that check.
+// An endpoint redirected from HTTPS to plain HTTP is always a critical breach.
+func (t *ThresholdConfig) Breaches(r *internal.BenchmarkResult, runLabel string) []internal.ThresholdBreach {
+	var breaches []internal.ThresholdBreach
+	above := func(metric string, actual, max float64) {
+		if actual > max {
+			severity := internal.SeverityWarning
+			if actual >= max*criticalBreachFactor {
+				severity = internal.SeverityCritical
+			}
+			breaches = append(breaches, internal.ThresholdBreach{
+				Metric: metric, Actual: actual, Threshold: max, Severity: severity, RunLabel: runLabel,
+			})
+		}
+	}
+
+	for _, ep := range r.Endpoints {
+		if ep.HTTPSDowngrade {
+			breaches = append(breaches, internal.ThresholdBreach{
+				Metric: metricHTTPSDowngradePrefix + endpointLabel(ep), Actual: 1, Threshold: 0,
+				Severity: internal.SeverityCritical, RunLabel: runLabel,
+			})
+		}
+	}
+
+	if r.Health != nil {
+		above(metricHealthResponse, r.Health.ResponseMs, t.HealthResponseMax)
+	}
+
+	if lt := r.LoadTest; lt != nil {
+		above(metricLatencyP95, lt.LatencyP95Ms, t.LatencyP95MaxMs)
+		above(metricLatencyP99, lt.LatencyP99Ms, t.LatencyP99MaxMs)
+		if lt.TotalRequests > 0 {
+			above(metricErrorRate, float64(lt.Failed)/float64(lt.TotalRequests)*100, t.ErrorRateMaxPct)
+		}
+		if lt.RPS < t.RPSMinimum {
+			severity := internal.SeverityWarning
+			if lt.RPS*criticalBreachFactor <= t.RPSMinimum {
+				severity = internal.SeverityCritical
+			}
+			breaches = append(breaches, internal.ThresholdBreach{
+				Metric: metricRPS, Actual: lt.RPS, Threshold: t.RPSMinimum, Severity: severity, RunLabel: runLabel,
+			})
+		}
+	}
+
+	if r.BenchmarkAPI != nil && r.BenchmarkAPI.Response != nil {
+		run := []*internal.BenchmarkResult{r}
+		if t.DBOperationMaxMs > 0 {
+			for _, name := range collectDBOperationNames(run) {
+				if d, ok := getDBOperationDuration(r, name); ok {
+					above(metricDatabasePrefix+name, d, t.DBOperationMaxMs)
+				}
+			}
+		}
+		if t.SerializationMaxMs > 0 {
+			for _, name := range collectSerializationOpNames(run) {
+				if d, ok := getSerializationOpDuration(r, name); ok {
+					above(metricSerializationPrefix+name, d, t.SerializationMaxMs)
+				}
+			}
+		}
+	}
+
+	return breaches
+}
@@ -0,0 +1,149 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func writeThresholdFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write threshold file: %v", err)
+	}
+	return path
+}
+
+func TestLoadThresholdConfig_JSON(t *testing.T) {
+	path := writeThresholdFile(t, "thresholds.json", `{"latency_p95_max_ms": 200, "rps_minimum": 50}`)
+
+	th, err := LoadThresholdConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if th.LatencyP95MaxMs != 200 {
+		t.Errorf("expected LatencyP95MaxMs 200, got %f", th.LatencyP95MaxMs)
+	}
+	if th.RPSMinimum != 50 {
+		t.Errorf("expected RPSMinimum 50, got %f", th.RPSMinimum)
+	}
+	// Fields missing from the file keep their defaults
+	if th.LatencyP99MaxMs != DefaultThresholds().LatencyP99MaxMs {
+		t.Errorf("expected default LatencyP99MaxMs, got %f", th.LatencyP99MaxMs)
+	}
+}
+
+func TestLoadThresholdConfig_YAML(t *testing.T) {
+	path := writeThresholdFile(t, "thresholds.yml", `
+error_rate_max_pct: 0.5
+health_response_max: 250
+db_operation_max_ms: 0
+`)
+
+	th, err := LoadThresholdConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if th.ErrorRateMaxPct != 0.5 {
+		t.Errorf("expected ErrorRateMaxPct 0.5, got %f", th.ErrorRateMaxPct)
+	}
+	if th.HealthResponseMax != 250 {
+		t.Errorf("expected HealthResponseMax 250, got %f", th.HealthResponseMax)
+	}
+	if th.DBOperationMaxMs != 0 {
+		t.Errorf("expected DBOperationMaxMs 0, got %f", th.DBOperationMaxMs)
+	}
+}
+
+func TestLoadThresholdConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr []string
+	}{
+		{"unsupported extension", "thresholds.toml", "", []string{"unsupported threshold file extension"}},
+		{"unknown JSON field", "thresholds.json", `{"latency_p95": 200}`, []string{"latency_p95"}},
+		{"unknown YAML field", "thresholds.yaml", "latency_p95: 200\n", []string{"latency_p95"}},
+		{
+			"negative values", "thresholds.json", `{"latency_p95_max_ms": -1, "rps_minimum": -5}`,
+			[]string{"latency_p95_max_ms must not be negative, got -1", "rps_minimum must not be negative, got -5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadThresholdConfig(writeThresholdFile(t, tt.file, tt.content))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error containing %q, got %q", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadThresholdConfig_MissingFile(t *testing.T) {
+	if _, err := LoadThresholdConfig(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestThresholdConfig_Breaches(t *testing.T) {
+	r := &internal.BenchmarkResult{
+		Timestamp: time.Date(2026, 1, 9, 14, 30, 0, 0, time.UTC),
+		Health:    &internal.HealthResult{ResponseMs: 90}, // Within 100
+		Endpoints: []internal.EndpointResult{
+			{Path: "/health", Success: true},
+			{Path: "/api/workouts", HTTPSDowngrade: true}, // Always critical
+		},
+		LoadTest: &internal.LoadTestResult{
+			LatencyP95Ms:  600,  // Warning: over 500
+			LatencyP99Ms:  2500, // Critical: over twice 1000
+			TotalRequests: 100,
+			Failed:        1, // 1% does not exceed 1%
+			RPS:           5, // Critical: half of 10
+		},
+		BenchmarkAPI: &internal.BenchmarkAPIResult{
+			Response: &internal.BenchmarkAPIResponse{
+				Database: map[string]*internal.OperationResult{
+					"bulk_insert": {DurationMs: 60}, // Warning: over 50
+				},
+			},
+		},
+	}
+
+	breaches := DefaultThresholds().Breaches(r, RunLabel(2, r))
+	want := []internal.ThresholdBreach{
+		{Metric: "https_downgrade./api/workouts", Actual: 1, Threshold: 0, Severity: internal.SeverityCritical},
+		{Metric: "latency_p95_ms", Actual: 600, Threshold: 500, Severity: internal.SeverityWarning},
+		{Metric: "latency_p99_ms", Actual: 2500, Threshold: 1000, Severity: internal.SeverityCritical},
+		{Metric: "rps", Actual: 5, Threshold: 10, Severity: internal.SeverityCritical},
+		{Metric: "database.bulk_insert", Actual: 60, Threshold: 50, Severity: internal.SeverityWarning},
+	}
+	if len(breaches) != len(want) {
+		t.Fatalf("expected %d breaches, got %d: %+v", len(want), len(breaches), breaches)
+	}
+	for i, b := range breaches {
+		want[i].RunLabel = "Run 3 (2026-01-09 14:30)"
+		if b != want[i] {
+			t.Errorf("breach %d: expected %+v, got %+v", i, want[i], b)
+		}
+	}
+
+	if breaches := DefaultThresholds().Breaches(&internal.BenchmarkResult{}, "empty"); len(breaches) != 0 {
+		t.Errorf("expected no breaches without measurements, got %+v", breaches)
+	}
+
+	if got := formatBreach(breaches[0]); got != "🔴 **Run 3 (2026-01-09 14:30)**: HTTPS downgrade: `/api/workouts` redirected to plain HTTP" {
+		t.Errorf("unexpected HTTPS downgrade alert: %s", got)
+	}
+}
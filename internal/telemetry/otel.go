@@ -0,0 +1,270 @@
+// Package telemetry emits OpenTelemetry traces of benchmark runs, so a run can
+// be found in Jaeger, Zipkin, or any OTLP backend by its trace ID and lined up
+// with the server's own traces of the same requests.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+// Span names of a benchmark run. SpanBenchmark is the root of each run and
+// the others its children, one SpanEndpoint per endpoint request.
+const (
+	SpanBenchmark    = "actalog.benchmark"
+	SpanConnectivity = "actalog.connectivity"
+	SpanHealth       = "actalog.health"
+	SpanEndpoint     = "actalog.endpoint"
+	SpanFrontend     = "actalog.frontend"
+	SpanLoadTest     = "actalog.loadtest"
+)
+
+// instrumentationName names the tracer and the service in exported spans
+const instrumentationName = "actalog-bench"
+
+// tracesPath is where OTLP HTTP receivers accept traces
+const tracesPath = "/v1/traces"
+
+// exportTimeout bounds each export request to the collector
+const exportTimeout = 10 * time.Second
+
+// peerNameKey is the context key of the target hostname set by WithTarget
+type peerNameKey struct{}
+
+// Setup sends the spans started from now on to the OTLP HTTP receiver at
+// endpoint (e.g. http://localhost:4318). Root spans take traceID, the run's
+// 32-hex-digit W3C trace ID, so the spans share the trace ID its requests
+// send in --trace-header. The returned shutdown function exports any spans
+// still queued and reports the first export that failed.
+func Setup(endpoint, traceID, version string) (shutdown func(context.Context) error, err error) {
+	exportURL, err := tracesURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	id, err := trace.TraceIDFromHex(traceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trace ID %q: %w", traceID, err)
+	}
+	// Export errors surface when the run ends rather than in the middle of its output
+	var mu sync.Mutex
+	var exportErr error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if exportErr == nil {
+			exportErr = err
+		}
+	}))
+
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpointURL(exportURL),
+		otlptracehttp.WithTimeout(exportTimeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", instrumentationName),
+			attribute.String("service.version", version),
+		)),
+		sdktrace.WithIDGenerator(runIDGenerator{traceID: id}),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		flushErr := provider.ForceFlush(ctx)
+		if err := provider.Shutdown(ctx); flushErr == nil {
+			flushErr = err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if flushErr == nil {
+			flushErr = exportErr
+		}
+		if flushErr != nil {
+			return fmt.Errorf("export spans: %w", flushErr)
+		}
+		return nil
+	}, nil
+}
+
+// tracesURL returns the traces URL of an OTLP HTTP receiver, adding
+// /v1/traces unless endpoint already ends with it
+func tracesURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid OTLP endpoint %q: expected an http or https URL such as http://localhost:4318", endpoint)
+	}
+	if !strings.HasSuffix(u.Path, tracesPath) {
+		u.Path = strings.TrimSuffix(u.Path, "/") + tracesPath
+	}
+	return u.String(), nil
+}
+
+// runIDGenerator gives every root span the run's trace ID and every span a
+// random span ID
+type runIDGenerator struct {
+	traceID trace.TraceID
+}
+
+func (g runIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	return g.traceID, g.NewSpanID(ctx, g.traceID)
+}
+
+func (g runIDGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	var id trace.SpanID
+	for !id.IsValid() {
+		if _, err := rand.Read(id[:]); err != nil {
+			id[7] = 1 // An all-zero span ID is invalid
+		}
+	}
+	return id
+}
+
+// WithTarget returns a copy of ctx whose spans record the hostname of the
+// target URL as net.peer.name, so each run of --url-list names its own host
+func WithTarget(ctx context.Context, target string) context.Context {
+	u, err := url.Parse(target)
+	if err != nil || u.Hostname() == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, peerNameKey{}, u.Hostname())
+}
+
+// StartSpan starts a span as a child of the span in ctx, if any. Until Setup
+// is called the span is a no-op, so callers need not check whether tracing
+// is enabled.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if peerName, ok := ctx.Value(peerNameKey{}).(string); ok {
+		attrs = append(attrs, attribute.String("net.peer.name", peerName))
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// latency records a measured time in milliseconds
+func latency(ms float64) attribute.KeyValue {
+	return attribute.Float64("actalog.latency_ms", ms)
+}
+
+// end marks span failed with message, when there is one, and ends it
+func end(span trace.Span, message string) {
+	if message != "" {
+		span.SetStatus(codes.Error, message)
+	}
+	span.End()
+}
+
+// EndBenchmark ends the root span of a run with its overall status
+func EndBenchmark(span trace.Span, r *internal.BenchmarkResult) {
+	span.SetAttributes(attribute.String("actalog.overall", r.Overall))
+	message := r.Error
+	if message == "" && r.Overall == "fail" {
+		message = "benchmark failed"
+	}
+	end(span, message)
+}
+
+// EndConnectivity ends a connectivity span with the connection timings
+func EndConnectivity(span trace.Span, r *internal.ConnectivityResult) {
+	span.SetAttributes(
+		latency(r.TotalMs),
+		attribute.Float64("actalog.dns_ms", r.DNSMs),
+		attribute.Float64("actalog.tcp_ms", r.TCPMs),
+	)
+	// Plain HTTP records 0 or NotApplicableMs, which is no handshake time
+	if r.TLSMs > 0 {
+		span.SetAttributes(attribute.Float64("actalog.tls_ms", r.TLSMs))
+	}
+	message := r.Error
+	if message == "" && !r.Connected {
+		message = "not connected"
+	}
+	end(span, message)
+}
+
+// EndHealth ends a health check span with the server's status
+func EndHealth(span trace.Span, r *internal.HealthResult) {
+	span.SetAttributes(
+		latency(r.ResponseMs),
+		attribute.String("actalog.health.status", r.Status),
+	)
+	if r.HTTPStatus != 0 {
+		span.SetAttributes(attribute.Int("http.status_code", r.HTTPStatus))
+	}
+	message := r.Error
+	if message == "" && r.Status != "healthy" {
+		message = "health status " + r.Status
+	}
+	end(span, message)
+}
+
+// EndpointAttributes returns the request attributes of an endpoint span
+func EndpointAttributes(method, path string) []attribute.KeyValue {
+	if method == "" {
+		method = "GET"
+	}
+	return []attribute.KeyValue{
+		attribute.String("http.method", method),
+		attribute.String("http.target", path),
+	}
+}
+
+// EndEndpoint ends an endpoint span with the response received, of
+// contentLength bytes
+func EndEndpoint(span trace.Span, r internal.EndpointResult, contentLength int64) {
+	span.SetAttributes(latency(r.ResponseMs))
+	if r.Status != 0 {
+		span.SetAttributes(
+			attribute.Int("http.status_code", r.Status),
+			attribute.Int64("http.response_content_length", contentLength),
+		)
+	}
+	message := r.Error
+	if message == "" && !r.Success {
+		message = fmt.Sprintf("HTTP %d", r.Status)
+	}
+	end(span, message)
+}
+
+// EndFrontend ends a frontend span with the size and load time of the assets
+func EndFrontend(span trace.Span, r *internal.FrontendResult) {
+	span.SetAttributes(
+		latency(r.TotalTimeMs),
+		attribute.Float64("actalog.frontend.total_size_kb", r.TotalSizeKB),
+		attribute.Int("actalog.frontend.assets", len(r.Assets)),
+	)
+	end(span, "")
+}
+
+// EndLoadTest ends a load test span with its throughput, latency, and errors
+func EndLoadTest(span trace.Span, r *internal.LoadTestResult) {
+	span.SetAttributes(
+		latency(r.AvgLatencyMs),
+		attribute.Int("actalog.loadtest.concurrent", r.Concurrent),
+		attribute.Int("actalog.loadtest.total_requests", r.TotalRequests),
+		attribute.Int("actalog.loadtest.failed", r.Failed),
+		attribute.Float64("actalog.loadtest.rps", r.RPS),
+		attribute.Float64("actalog.loadtest.p95_ms", r.LatencyP95Ms),
+		attribute.Float64("actalog.loadtest.p99_ms", r.LatencyP99Ms),
+	)
+	end(span, r.AbortReason)
+}
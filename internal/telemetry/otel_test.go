@@ -0,0 +1,198 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/johnzastrow/actalog-benchmark/internal"
+)
+
+func TestSetup_ExportsSpans(t *testing.T) {
+	var mu sync.Mutex
+	var received []*coltracepb.ExportTraceServiceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			t.Errorf("expected protobuf posted to /v1/traces, got %s with %q", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		req := &coltracepb.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			t.Errorf("expected an OTLP protobuf body, got: %v", err)
+		}
+		mu.Lock()
+		received = append(received, req)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer server.Close()
+
+	const traceID = "6960d4b8a3b1c2d3e4f5a6b7c8d9e0f1"
+	shutdown, err := Setup(server.URL, traceID, "1.2.3")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	ctx, root := StartSpan(WithTarget(context.Background(), "https://actalog.example.com:8443"), SpanBenchmark)
+	_, span := StartSpan(ctx, SpanEndpoint, EndpointAttributes("", "/api/workouts")...)
+	EndEndpoint(span, internal.EndpointResult{Path: "/api/workouts", ResponseMs: 42, Status: 500}, 128)
+	EndBenchmark(root, &internal.BenchmarkResult{Overall: "degraded"})
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("expected the spans to be sent, got: %v", err)
+	}
+
+	spans := make(map[string]*tracepb.Span)
+	mu.Lock()
+	for _, req := range received {
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+	}
+	mu.Unlock()
+
+	rootSpan, endpointSpan := spans[SpanBenchmark], spans[SpanEndpoint]
+	if rootSpan == nil || endpointSpan == nil {
+		t.Fatalf("expected the root and endpoint spans, got %v", spans)
+	}
+	if hex.EncodeToString(rootSpan.TraceId) != traceID || hex.EncodeToString(endpointSpan.TraceId) != traceID {
+		t.Errorf("expected both spans in trace %s, got %x and %x", traceID, rootSpan.TraceId, endpointSpan.TraceId)
+	}
+	if len(rootSpan.ParentSpanId) != 0 || hex.EncodeToString(endpointSpan.ParentSpanId) != hex.EncodeToString(rootSpan.SpanId) {
+		t.Errorf("expected the endpoint span under the root, got parents %x and %x", rootSpan.ParentSpanId, endpointSpan.ParentSpanId)
+	}
+	if s := endpointSpan.Status; s.GetCode() != tracepb.Status_STATUS_CODE_ERROR || s.GetMessage() != "HTTP 500" {
+		t.Errorf("expected a failed endpoint span, got %v", s)
+	}
+
+	attrs := make(map[string]*commonpb.AnyValue)
+	for _, kv := range endpointSpan.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if v := attrs["http.target"].GetStringValue(); v != "/api/workouts" {
+		t.Errorf("expected http.target /api/workouts, got %q", v)
+	}
+	if v := attrs["net.peer.name"].GetStringValue(); v != "actalog.example.com" {
+		t.Errorf("expected net.peer.name actalog.example.com, got %q", v)
+	}
+	if v := attrs["http.status_code"].GetIntValue(); v != 500 {
+		t.Errorf("expected http.status_code 500, got %d", v)
+	}
+	if v := attrs["http.response_content_length"].GetIntValue(); v != 128 {
+		t.Errorf("expected http.response_content_length 128, got %d", v)
+	}
+	if v := attrs["actalog.latency_ms"].GetDoubleValue(); v != 42 {
+		t.Errorf("expected actalog.latency_ms 42, got %f", v)
+	}
+}
+
+func TestSetup_Invalid(t *testing.T) {
+	if _, err := Setup("localhost:4318", "6960d4b8a3b1c2d3e4f5a6b7c8d9e0f1", "dev"); err == nil {
+		t.Error("expected an error for an endpoint without a scheme")
+	}
+	if _, err := Setup("http://localhost:4318", "not-a-trace-id", "dev"); err == nil {
+		t.Error("expected an error for an invalid trace ID")
+	}
+}
+
+func TestTracesURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"http://localhost:4318", "http://localhost:4318/v1/traces"},
+		{"http://localhost:4318/", "http://localhost:4318/v1/traces"},
+		{"https://otel.example.com/v1/traces", "https://otel.example.com/v1/traces"},
+		{"https://gateway.example.com/otlp", "https://gateway.example.com/otlp/v1/traces"},
+	}
+
+	for _, tt := range tests {
+		got, err := tracesURL(tt.endpoint)
+		if err != nil {
+			t.Errorf("%s: expected no error, got: %v", tt.endpoint, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.endpoint, tt.want, got)
+		}
+	}
+}
+
+// recordSpans sends the spans started during the test to a recorder
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+// spanAttributes maps the attributes of a recorded span by key
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestWithTarget(t *testing.T) {
+	recorder := recordSpans(t)
+
+	// Each run of --url-list names its own host
+	for _, target := range []string{"https://one.example.com", "http://two.example.com:8080"} {
+		_, span := StartSpan(WithTarget(context.Background(), target), SpanBenchmark)
+		span.End()
+	}
+	_, span := StartSpan(context.Background(), SpanBenchmark)
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	for i, want := range []string{"one.example.com", "two.example.com"} {
+		if got := spanAttributes(spans[i])["net.peer.name"].AsString(); got != want {
+			t.Errorf("span %d: expected net.peer.name %s, got %q", i, want, got)
+		}
+	}
+	if _, ok := spanAttributes(spans[2])["net.peer.name"]; ok {
+		t.Error("expected no net.peer.name without a target")
+	}
+}
+
+func TestEndConnectivity_TLS(t *testing.T) {
+	recorder := recordSpans(t)
+
+	for _, tls := range []float64{45.2, 0, internal.NotApplicableMs} {
+		_, span := StartSpan(context.Background(), SpanConnectivity)
+		EndConnectivity(span, &internal.ConnectivityResult{Connected: true, DNSMs: 1, TCPMs: 2, TLSMs: tls, TotalMs: 3})
+	}
+
+	spans := recorder.Ended()
+	if got, ok := spanAttributes(spans[0])["actalog.tls_ms"]; !ok || got.AsFloat64() != 45.2 {
+		t.Errorf("expected actalog.tls_ms 45.2, got %v", got.AsFloat64())
+	}
+	for _, span := range spans[1:] {
+		if got, ok := spanAttributes(span)["actalog.tls_ms"]; ok {
+			t.Errorf("expected no actalog.tls_ms without a TLS handshake, got %v", got.AsFloat64())
+		}
+	}
+}
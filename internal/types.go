@@ -2,6 +2,41 @@ package internal
 
 import "time"
 
+// SchemaVersion is the version of the BenchmarkResult JSON format written by this tool.
+// Bump it, and record the new fields in the comparison reporter, when fields are added or renamed.
+const SchemaVersion = 1
+
+// Benchmark phase names, used as keys of BenchmarkResult.PhaseDurations
+const (
+	PhaseConnectivity = "connectivity"
+	PhaseHealth       = "health"
+	PhaseEndpoints    = "endpoints"
+	PhaseFrontend     = "frontend"
+	PhaseBenchmarkAPI = "benchmark_api"
+	PhaseLoadTest     = "load_test"
+)
+
+// PoolSaturated is the LoadTestResult.PoolSaturation of a load test that
+// dialed new connections for too many of its requests, a sign that the idle
+// connection pool is smaller than the concurrency
+const PoolSaturated = "saturated"
+
+// Error types counted in LoadTestResult.ErrorBreakdown
+const (
+	ErrorTypeTimeout           = "timeout"
+	ErrorTypeConnectionRefused = "connection_refused"
+	ErrorTypeConnectionReset   = "connection_reset"
+	ErrorTypeHTTP4xx           = "http_4xx"
+	ErrorTypeHTTP5xx           = "http_5xx"
+	ErrorTypeOther             = "other"
+)
+
+// ErrorTypes lists the load test error types in the order they are reported
+var ErrorTypes = []string{ErrorTypeTimeout, ErrorTypeConnectionRefused, ErrorTypeConnectionReset, ErrorTypeHTTP4xx, ErrorTypeHTTP5xx, ErrorTypeOther}
+
+// Phases lists the benchmark phase names in the order they run
+var Phases = []string{PhaseConnectivity, PhaseHealth, PhaseEndpoints, PhaseFrontend, PhaseBenchmarkAPI, PhaseLoadTest}
+
 // BenchmarkResult holds all benchmark results
 type BenchmarkResult struct {
 	Timestamp    time.Time           `json:"timestamp"`
@@ -15,16 +50,124 @@ type BenchmarkResult struct {
 	BenchmarkAPI *BenchmarkAPIResult `json:"benchmark_api,omitempty"`
 	Overall      string              `json:"overall"`
 	Error        string              `json:"error,omitempty"`
+
+	WaitedForHealthySec float64 `json:"waited_for_healthy_sec,omitempty"`
+
+	PhaseDurations map[string]float64 `json:"phase_durations_ms,omitempty"` // Wall-clock time per phase that ran, keyed by phase name
+
+	ConcurrencyProfile     []ConcurrencyDataPoint `json:"concurrency_profile,omitempty"`
+	RecommendedConcurrency int                    `json:"recommended_concurrency,omitempty"` // Profile step with the best RPS/p95 ratio
+
+	BenchmarkAPISweep []BenchmarkAPISweepPoint `json:"benchmark_api_sweep,omitempty"` // Server-side benchmark at each --benchmark-records-sweep count
+
+	WebSocketLoadTest *WebSocketLoadTestResult `json:"ws_load_test,omitempty"`
+
+	AgentCount int `json:"agent_count,omitempty"` // Number of agent results combined with --merge
+
+	SampleCount int `json:"sample_count,omitempty"` // Number of runs averaged with --repeat
+
+	MultiURLMode bool `json:"multi_url_mode,omitempty"` // One of several URLs benchmarked in turn with --url-list
+
+	UserAgent string `json:"user_agent,omitempty"` // User-Agent header sent with every request
+
+	TraceID string `json:"trace_id,omitempty"` // W3C trace ID sent in --trace-header and of the --otel-endpoint spans, for finding the run's traces
+
+	ThresholdBreaches []ThresholdBreach `json:"threshold_breaches,omitempty"` // Metrics that crossed an alert threshold
+
+	SchemaVersion int `json:"schema_version,omitempty"` // JSON format version; 0 for files from before versioning
+
+	EndpointOrder     string `json:"endpoint_order,omitempty"`      // Execution order from --endpoint-order, omitted for the default
+	EndpointOrderSeed int64  `json:"endpoint_order_seed,omitempty"` // Shuffle seed of a random endpoint order, to repeat it with --seed
+
+	GRPCHealth *HealthResult `json:"grpc_health,omitempty"` // gRPC health service check, with --grpc-health
+
+	SSE *SSEResult `json:"sse,omitempty"` // Server-sent events latency, with --sse-path
+
+	GracefulShutdown bool   `json:"graceful_shutdown,omitempty"` // Cut short by SIGINT or SIGTERM; later phases did not run
+	ShutdownReason   string `json:"shutdown_reason,omitempty"`   // Why the run stopped early
+
+	Anonymized bool `json:"anonymized,omitempty"` // Target, IP addresses, and endpoint paths were replaced by --anonymize
+
+	SimulatedLatencyMs float64 `json:"simulated_latency_ms,omitempty"` // Delay added before every request by --simulate-latency
+	SimulatedLossRate  float64 `json:"simulated_loss_rate,omitempty"`  // Fraction of requests dropped by --simulate-loss-rate
+}
+
+// Threshold breach severities
+const (
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// ThresholdBreach is one metric that crossed its alert threshold
+type ThresholdBreach struct {
+	Metric    string  `json:"metric"` // e.g. latency_p95_ms, or database.<operation> for server-side operations
+	Actual    float64 `json:"actual"`
+	Threshold float64 `json:"threshold"`
+	Severity  string  `json:"severity"` // SeverityWarning or SeverityCritical
+	RunLabel  string  `json:"run_label,omitempty"`
+}
+
+// ConcurrencyDataPoint holds load test results for one --concurrency-profile step
+type ConcurrencyDataPoint struct {
+	Concurrent   int     `json:"concurrent"`
+	RPS          float64 `json:"rps"`
+	LatencyP95Ms float64 `json:"latency_p95_ms"`
+	ErrorRatePct float64 `json:"error_rate_pct"`
+}
+
+// BenchmarkAPISweepPoint holds the server-side benchmark result for one
+// --benchmark-records-sweep record count
+type BenchmarkAPISweepPoint struct {
+	RecordCount     int                   `json:"record_count"`
+	TotalDurationMs float64               `json:"total_duration_ms"` // Client-observed request time
+	Response        *BenchmarkAPIResponse `json:"response,omitempty"`
+	Error           string                `json:"error,omitempty"`
 }
 
+// NotApplicableMs marks a timing that does not apply to the target, such as
+// TLSMs for --http-only runs, as opposed to zero for a value that was not measured
+const NotApplicableMs = -1
+
+// DNS resolution methods recorded in ConnectivityResult.DNSMethod
+const (
+	DNSMethodSystem = "system" // The system resolver, or the DNS server from --dns-resolver
+	DNSMethodDoH    = "doh"    // DNS-over-HTTPS from --doh-url
+)
+
 // ConnectivityResult holds connection timing metrics
 type ConnectivityResult struct {
 	DNSMs     float64 `json:"dns_ms"`
 	TCPMs     float64 `json:"tcp_ms"`
-	TLSMs     float64 `json:"tls_ms,omitempty"`
+	TLSMs     float64 `json:"tls_ms,omitempty"` // NotApplicableMs with --http-only
 	TotalMs   float64 `json:"total_ms"`
 	Connected bool    `json:"connected"`
 	Error     string  `json:"error,omitempty"`
+
+	TLSResumed         bool    `json:"tls_resumed,omitempty"`           // A second connection resumed the measured handshake's TLS session
+	TLSResumedMs       float64 `json:"tls_resumed_ms,omitempty"`        // Handshake time of that resumed session
+	TLSFullHandshakeMs float64 `json:"tls_full_handshake_ms,omitempty"` // TLSMs, always a full handshake
+
+	IPv4Ms float64 `json:"ipv4_ms,omitempty"` // TCP connect time to the first IPv4 address
+	IPv6Ms float64 `json:"ipv6_ms,omitempty"` // TCP connect time to the first IPv6 address
+
+	KeepAliveReuseFraction *float64 `json:"keep_alive_reuse_fraction,omitempty"` // Share of probe requests on a reused connection; nil unless --probe-keepalive succeeded
+
+	HopCount     int       `json:"hop_count,omitempty"`     // Network hops to the server, from --traceroute
+	TraceRouteMs []float64 `json:"traceroute_ms,omitempty"` // Round trip to each hop; 0 for hops that did not reply
+
+	PathMTU int `json:"path_mtu,omitempty"` // Estimated path MTU in bytes, from --probe-mtu
+
+	HTTP3Ms        float64 `json:"http3_ms,omitempty"`        // Time to first byte of a request over HTTP/3, from --probe-http3
+	HTTP3Supported bool    `json:"http3_supported,omitempty"` // The server completed the --probe-http3 request over QUIC
+	HTTP3Error     string  `json:"http3_error,omitempty"`     // Why the --probe-http3 request failed; empty when it succeeded or was not sent
+
+	DNSResolver string `json:"dns_resolver,omitempty"` // DNS server from --dns-resolver, empty for the system resolver
+	DNSMethod   string `json:"dns_method,omitempty"`   // DNSMethodSystem or DNSMethodDoH
+	DOHServer   string `json:"doh_server,omitempty"`   // DNS-over-HTTPS endpoint from --doh-url
+
+	BoundTo string `json:"bound_to,omitempty"` // Local IP address from --bind-addr, empty for the system's choice
+
+	UnixSocket string `json:"unix_socket,omitempty"` // Socket path from --unix-socket; TCPMs is then the socket connect time
 }
 
 // HealthResult holds health check results
@@ -33,6 +176,11 @@ type HealthResult struct {
 	ResponseMs float64 `json:"response_ms"`
 	HTTPStatus int     `json:"http_status"`
 	Error      string  `json:"error,omitempty"`
+
+	// Server resource metadata, when the health endpoint reports it
+	Uptime            string  `json:"uptime,omitempty"`
+	ActiveConnections int     `json:"active_connections,omitempty"`
+	MemoryMB          float64 `json:"memory_mb,omitempty"`
 }
 
 // EndpointResult holds results for a single endpoint test
@@ -42,22 +190,167 @@ type EndpointResult struct {
 	Status     int     `json:"status"`
 	Success    bool    `json:"success"`
 	Error      string  `json:"error,omitempty"`
+
+	CurlCommand string `json:"curl_command,omitempty"` // Reproduction command, set for failed endpoints
+	Method      string `json:"method,omitempty"`       // HTTP method, omitted for plain GET checks
+	RequestID   string `json:"request_id,omitempty"`   // Value sent in --request-id-header
+
+	CustomEndpoint bool `json:"custom_endpoint,omitempty"` // Listed in --endpoints-file rather than built in
+
+	ColdStartMs float64 `json:"cold_start_ms,omitempty"` // Response time over a fresh connection, with --cold-start
+
+	TTFBMs  float64 `json:"ttfb_ms,omitempty"`  // Time to the first response byte
+	TotalMs float64 `json:"total_ms,omitempty"` // Time until the body was read; TotalMs less TTFBMs is the body download
+
+	ResponseTruncated bool `json:"response_truncated,omitempty"` // Body exceeded --max-response-size and was not fully read
+
+	HTTPSDowngrade bool `json:"https_downgrade,omitempty"` // A redirect led from HTTPS to plain HTTP and was not followed
+
+	Deprecated bool   `json:"deprecated,omitempty"`  // Response carried a Deprecation or Sunset header
+	SunsetDate string `json:"sunset_date,omitempty"` // Sunset header date (YYYY-MM-DD), or its raw value if unparseable
+
+	// Response time statistics over repeated requests, with --endpoint-samples
+	SampleCount  int     `json:"sample_count,omitempty"`
+	SampleMeanMs float64 `json:"sample_mean_ms,omitempty"`
+	SampleMinMs  float64 `json:"sample_min_ms,omitempty"`
+	SampleMaxMs  float64 `json:"sample_max_ms,omitempty"`
+	SampleP50Ms  float64 `json:"sample_p50_ms,omitempty"` // Percentiles need at least 20 samples
+	SampleP95Ms  float64 `json:"sample_p95_ms,omitempty"`
+	SampleP99Ms  float64 `json:"sample_p99_ms,omitempty"`
 }
 
 // LoadTestResult holds concurrent load test results
 type LoadTestResult struct {
-	Concurrent    int     `json:"concurrent"`
+	Concurrent    int     `json:"concurrent"` // Workers running at the peak; below --concurrent when a step load ramp outlasted the test
 	DurationSec   float64 `json:"duration_sec"`
 	TotalRequests int     `json:"total_requests"`
 	Successful    int     `json:"successful"`
 	Failed        int     `json:"failed"`
 	RPS           float64 `json:"rps"`
+	TargetRPS     float64 `json:"target_rps,omitempty"` // Request rate set by --max-rps or --target-rps; RPS is the rate achieved
+	WarmupSec     float64 `json:"warmup_sec,omitempty"` // Unmeasured warmup run before DurationSec, set by --warmup
 	LatencyP50Ms  float64 `json:"latency_p50_ms"`
 	LatencyP95Ms  float64 `json:"latency_p95_ms"`
 	LatencyP99Ms  float64 `json:"latency_p99_ms"`
 	MinLatencyMs  float64 `json:"min_latency_ms"`
 	MaxLatencyMs  float64 `json:"max_latency_ms"`
 	AvgLatencyMs  float64 `json:"avg_latency_ms"`
+
+	StdDevLatencyMs float64 `json:"stddev_latency_ms,omitempty"` // Standard deviation of the latencies
+	IQRLatencyMs    float64 `json:"iqr_latency_ms,omitempty"`    // Interquartile range, p75 minus p25
+
+	EndpointStrategy string `json:"endpoint_strategy,omitempty"`
+
+	TotalBytesReceived int64 `json:"total_bytes_received,omitempty"`
+
+	StressedEndpoint string `json:"stressed_endpoint,omitempty"` // Single path targeted with --stress-endpoint
+
+	LatencyP95MsCI95 float64 `json:"latency_p95_ci95,omitempty"` // 95% confidence half-width of LatencyP95Ms over --repeat runs
+
+	PerWindowStats []WindowStats `json:"per_window_stats,omitempty"` // Equal time slices of the run, set by --leak-detect
+
+	RPSTimeline []float64 `json:"rps_timeline,omitempty"` // Requests completed in each second of the run, set by --html
+
+	StepResults []StepResult `json:"step_results,omitempty"` // Each --step-load step, with the concurrency reached in it
+
+	// Open-loop load from --target-rps
+	ArrivalRate   float64 `json:"arrival_rate,omitempty"`    // Requests that arrived per second
+	ServiceRate   float64 `json:"service_rate,omitempty"`    // Requests per second the workers could serve, from their mean time per request
+	MaxQueueDepth int     `json:"max_queue_depth,omitempty"` // Most arrivals waiting for a free worker at once
+
+	// Pause between each worker's requests, from --think-time
+	ThinkTimeMs        float64 `json:"think_time_ms,omitempty"`
+	ThinkTimeJitterPct float64 `json:"think_time_jitter_pct,omitempty"` // Random extra pause, up to this percentage of ThinkTimeMs
+
+	EarlyAbort  bool   `json:"early_abort,omitempty"`  // Stopped before its full duration by --abort-on-threshold
+	AbortReason string `json:"abort_reason,omitempty"` // The threshold that was crossed
+
+	NewConnections int    `json:"new_connections,omitempty"` // Requests that dialed a connection instead of reusing a pooled one
+	PoolSaturation string `json:"pool_saturation,omitempty"` // PoolSaturated when NewConnections, less one per worker, exceeded 10% of TotalRequests
+
+	SLABreachCount map[string]int `json:"sla_breach_count,omitempty"` // Requests slower than each --sla-targets target, keyed by the target, e.g. "200ms"
+
+	ErrorTimestamps []float64 `json:"error_timestamps_ms,omitempty"` // Milliseconds from the start at which requests failed, sorted; a sample of at most 1000
+	ErrorsClustered bool      `json:"errors_clustered,omitempty"`    // The failures were bunched in time, as in a brief outage, rather than spread evenly
+
+	Percentiles map[string]float64 `json:"percentiles,omitempty"` // Latency of each --percentiles percentile in ms, keyed like "p50" or "p99.9"
+
+	DNSCacheHits int `json:"dns_cache_hits,omitempty"` // New connections whose target address came from the run's DNS cache
+
+	WorkerStats []WorkerResult `json:"worker_stats,omitempty"` // Each worker's share of the requests, to spot uneven load
+
+	ErrorBreakdown map[string]int `json:"error_breakdown,omitempty"` // Failed requests by ErrorTypes entry; only types that occurred are present
+
+	EndpointDistribution map[string]int `json:"endpoint_distribution,omitempty"` // Requests sent to each path of an endpoint rotation
+
+	LatencyHistogram []HistogramBucket `json:"latency_histogram,omitempty"` // Equal-width latency buckets from MinLatencyMs to MaxLatencyMs
+}
+
+// WindowStats holds the throughput and latency of one time slice of a load test
+type WindowStats struct {
+	Window       int     `json:"window"` // 1-based position in the run
+	StartSec     float64 `json:"start_sec"`
+	Requests     int     `json:"requests"`
+	RPS          float64 `json:"rps"`
+	LatencyP95Ms float64 `json:"latency_p95_ms"`
+}
+
+// StepResult holds the throughput and latency of one step of a step load test
+type StepResult struct {
+	Step         int     `json:"step"`        // 1-based position in the run
+	Concurrency  int     `json:"concurrency"` // Workers running during the step
+	Requests     int     `json:"requests"`
+	RPS          float64 `json:"rps"`
+	LatencyP95Ms float64 `json:"latency_p95_ms"`
+	ErrorRatePct float64 `json:"error_rate_pct"`
+}
+
+// HistogramBucket counts the load test requests whose latency fell between
+// LowerMs and UpperMs. Each bucket includes its lower bound; the last also
+// includes its upper bound.
+type HistogramBucket struct {
+	LowerMs float64 `json:"lower_ms"`
+	UpperMs float64 `json:"upper_ms"`
+	Count   int     `json:"count"`
+}
+
+// WorkerResult holds the requests and latency of one load test worker
+type WorkerResult struct {
+	WorkerID      int     `json:"worker_id"` // 1-based
+	TotalRequests int     `json:"total_requests"`
+	Successful    int     `json:"successful"`
+	Failed        int     `json:"failed"`
+	AvgLatencyMs  float64 `json:"avg_latency_ms"`
+	P95LatencyMs  float64 `json:"p95_latency_ms"`
+}
+
+// SSEResult holds server-sent events latency results
+type SSEResult struct {
+	Path               string  `json:"path"`
+	Connected          bool    `json:"connected"`
+	TimeToFirstEventMs float64 `json:"time_to_first_event_ms"` // From sending the request to the first complete event
+	AvgIntervalMs      float64 `json:"avg_interval_ms"`        // Mean gap between consecutive events
+	EventsReceived     int     `json:"events_received"`
+	Error              string  `json:"error,omitempty"`
+}
+
+// WebSocketLoadTestResult holds WebSocket ping round-trip results
+type WebSocketLoadTestResult struct {
+	Path           string  `json:"path"`
+	Concurrent     int     `json:"concurrent"`
+	DurationSec    float64 `json:"duration_sec"`
+	Connections    int     `json:"connections"` // Workers that connected successfully
+	TotalMessages  int     `json:"total_messages"`
+	Successful     int     `json:"successful"`
+	Failed         int     `json:"failed"`
+	MessagesPerSec float64 `json:"messages_per_sec"`
+	LatencyP50Ms   float64 `json:"latency_p50_ms"`
+	LatencyP95Ms   float64 `json:"latency_p95_ms"`
+	LatencyP99Ms   float64 `json:"latency_p99_ms"`
+	MinLatencyMs   float64 `json:"min_latency_ms"`
+	MaxLatencyMs   float64 `json:"max_latency_ms"`
+	AvgLatencyMs   float64 `json:"avg_latency_ms"`
+	Error          string  `json:"error,omitempty"`
 }
 
 // FrontendResult holds frontend asset benchmark results
@@ -66,6 +359,8 @@ type FrontendResult struct {
 	TotalSizeKB  float64        `json:"total_size_kb"`
 	TotalTimeMs  float64        `json:"total_time_ms"`
 	Assets       []AssetResult  `json:"assets,omitempty"`
+
+	AssetFilenames []string `json:"asset_filenames,omitempty"` // Sorted paths of the discovered assets; a change means a new build was deployed
 }
 
 // AssetResult holds results for a single frontend asset
@@ -77,6 +372,16 @@ type AssetResult struct {
 	Success    bool    `json:"success"`
 	Type       string  `json:"type,omitempty"`
 	Error      string  `json:"error,omitempty"`
+
+	SRIProtected bool  `json:"sri_protected,omitempty"` // The referencing tag has an integrity attribute
+	SRIValid     *bool `json:"sri_valid,omitempty"`     // Body matches the integrity hash; nil without SRI or with only unsupported algorithms
+
+	WireTransferKB   float64 `json:"wire_transfer_kb,omitempty"`  // Bytes transferred, before decompression
+	CompressionRatio float64 `json:"compression_ratio,omitempty"` // WireTransferKB / SizeKB for compressed responses
+
+	Fingerprinted      bool `json:"fingerprinted,omitempty"`         // File name contains a content hash segment, e.g. app.abc123.js
+	CacheControlMaxAge int  `json:"cache_control_max_age,omitempty"` // Cache-Control max-age in seconds, 0 when absent
+	ImmutableCache     bool `json:"immutable_cache,omitempty"`       // Cache-Control includes immutable
 }
 
 // Config holds benchmark configuration
@@ -84,16 +389,136 @@ type Config struct {
 	URL              string
 	User             string
 	Pass             string
+	Token            string // Pre-computed JWT from --token-file, used instead of logging in
 	Full             bool
 	Frontend         bool
 	JSONOutput       string
+	JSONAppend       bool   // Accumulate results in a JSON array in JSONOutput
+	MaxOutputFiles   int    // Most <OutputPrefix>_*.json reports kept in the output directory, 0 for no limit
+	AutoCompare      bool   // Write a comparison report of the JSON output directory after each JSON report
+	AutoCompareLimit int    // Most recent JSON reports covered by AutoCompare
+	OutputPrefix     string // Start of generated report filenames, as in <OutputPrefix>_<timestamp>.json; empty for "benchmark"
 	MarkdownOutput   string
+	CSVOutput        string // Directory or .csv file for the one-row-per-metric CSV report
+	HTMLOutput       string // Directory for the self-contained HTML report
+	JUnitOutput      string // Directory or .xml file for the JUnit XML report
 	Concurrent       int
 	Duration         time.Duration
 	Timeout          time.Duration
+	MaxResponseSize  int64 // Response body bytes read per request, 0 for no limit
 	Verbose          bool
 	CommandLine      string // The exact command that was run
 	BenchmarkRecords int    // Number of records for server-side benchmark API
+	EndpointStrategy string // Load test endpoint rotation: round-robin, random, weighted
+	PushgatewayURL   string // Prometheus Pushgateway base URL
+	PushgatewayJob   string // Job label for pushed metrics
+	IPFamily         string // Preferred IP family: "", "ipv4", or "ipv6"
+	Silent           bool   // Suppress all stdout/stderr output
+	AssertOverall    string // Expected overall status (pass, degraded, fail, or any) that exits 0; others exit 2
+	Anonymize        bool   // Redact the target, IP addresses, and endpoint paths from every report
+	RequestIDHeader  string // Header carrying a per-request UUID, empty to disable
+	TraceHeader      string // Header carrying a W3C traceparent value, empty to disable
+	TraceID          string // Trace ID sent in TraceHeader and shared by OTelEndpoint spans, set when the client is created
+	OTelEndpoint     string // OTLP HTTP receiver for OpenTelemetry traces of the run, empty to disable
+	UserAgent        string // User-Agent header sent with every request
+	AuditLog         string // JSON Lines file that every HTTP request is appended to
+	ProbeKeepAlive   bool   // Measure connection reuse during the connectivity phase
+	GoBench          bool   // Print go test -bench text instead of the console report
+	CI               bool   // Print a KEY=value status line after all other output, even when silent
+
+	LoadEndpoints     []WeightedEndpoint // Entries from --endpoints-file
+	LoadTestEndpoints []WeightedEndpoint // Entries from --load-endpoints, used only by the load test
+
+	ElasticsearchURL      string // Elasticsearch base URL, empty to disable indexing
+	ElasticsearchIndex    string // Index that receives result documents
+	ElasticsearchUsername string // Basic auth username for Elasticsearch
+	ElasticsearchPassword string // Basic auth password for Elasticsearch
+
+	BenchmarkRecordsSweep []int // Record counts for the server-side benchmark sweep, empty to disable
+	BenchmarkConcurrent   bool  // Include concurrent operations in the server-side benchmark
+
+	BenchmarkTimeout time.Duration // Request timeout for /api/benchmark, 0 to use Timeout
+
+	ConcurrencyProfile bool          // Run the load test at each of ConcurrencySteps
+	ConcurrencySteps   []int         // Concurrency levels for the profile
+	StepDuration       time.Duration // Load test duration per profile step
+
+	WSLoadTest bool   // Run the WebSocket ping load test
+	WSPath     string // WebSocket endpoint path
+	WSServer   string // Listen address for live load test metrics, empty to disable
+
+	SSEPath   string // Server-sent events endpoint to measure, empty to disable
+	SSEEvents int    // Events to read from SSEPath
+
+	PrometheusPort int // Port serving each run's metrics on /metrics, 0 to disable
+
+	LoadTestProgress func(*LoadTestResult)  // Receives partial and final load test results, nil to disable
+	RunComplete      func(*BenchmarkResult) // Receives the result of each run of the suite, nil to disable
+	DNSCacheHits     func() int             // Counts lookups answered by the --dns-cache cache, nil without it
+
+	Traceroute bool // Count network hops to the server
+	ProbeMTU   bool // Estimate the path MTU to the server
+	ProbeHTTP3 bool // Time a request over HTTP/3 during the connectivity phase
+	HTTPOnly   bool // Skip TLS timing for plain HTTP targets
+	ColdStart  bool // Also time each endpoint over a fresh TCP connection
+
+	GRPCHealth bool   // Also check the gRPC health service at GRPCAddr
+	GRPCAddr   string // host:port of the gRPC health service
+
+	DNSResolver string // DNS server used instead of the system resolver
+	DoHURL      string // DNS-over-HTTPS endpoint the connectivity probe resolves the target with
+	DNSCache    bool   // Resolve the target once per load test duration instead of for every new connection
+	BindAddr    string // Local IP address every connection is made from, empty for the system's choice
+	UnixSocket  string // Unix domain socket every connection is made to, empty for TCP
+
+	AllowHTTPSDowngrade bool // Follow redirects from HTTPS to plain HTTP instead of failing the request
+
+	EndpointSamples int // Requests per GET endpoint; more than 1 records sample statistics
+
+	EndpointOrder string           // Endpoint benchmark execution order, e.g. alphabetical or random
+	Seed          int64            // Shuffle seed for the random endpoint order
+	Baseline      *BenchmarkResult // Result from --baseline-json, nil without one
+
+	StressEndpoint string // Run the load test against only this path
+	LeakDetect     bool   // Record per-window load test stats to spot a declining RPS
+
+	AbortOnThreshold bool // Stop the load test once p95 latency or error rate crosses its alert threshold
+
+	SLATargets  []time.Duration // Load test latency targets whose breaches are counted
+	Percentiles []float64       // Load test latency percentiles to record, ascending
+
+	HistogramBuckets int // Equal-width buckets in the load test latency histogram
+
+	MaxRPS float64 // Load test request rate cap across all workers; 0 for no cap
+
+	WarmupDuration time.Duration // Unmeasured load before the load test, to fill connection pools and caches
+
+	StepLoad     bool          // Ramp the load test up one worker per StepInterval
+	StepInterval time.Duration // Time between added workers with StepLoad
+	StepStart    int           // Workers running at the start with StepLoad
+
+	TargetRPS float64 // Open-loop load test arrival rate; 0 for the usual closed loop
+
+	ThinkTime          time.Duration // Pause after each load test response before the worker's next request
+	ThinkTimeJitterPct float64       // Random extra pause, up to this percentage of ThinkTime
+
+	MaxIdleConnsPerHost int // Idle connections kept for reuse per host
+
+	SimulateLatency  time.Duration // Delay added before every HTTP request
+	SimulateLossRate float64       // Fraction of HTTP requests failed without being sent
+
+	Repeat int // Number of times to run the benchmark suite; results are averaged when > 1
+}
+
+// WeightedEndpoint is an endpoints file entry: a target path with its relative
+// load test frequency and optional request details
+type WeightedEndpoint struct {
+	Path           string            `json:"path" yaml:"path"`
+	Weight         int               `json:"weight" yaml:"weight"`
+	Method         string            `json:"method,omitempty" yaml:"method"`                   // GET (default), POST, PUT, or DELETE
+	Body           string            `json:"body,omitempty" yaml:"body"`                       // Request payload for methods other than GET
+	Headers        map[string]string `json:"headers,omitempty" yaml:"headers"`                 // Sent with the endpoint benchmark request, not in the load test
+	ExpectedStatus int               `json:"expected_status,omitempty" yaml:"expected_status"` // Success status; any 2xx when zero
 }
 
 // BenchmarkAPIResult holds results from calling /api/benchmark
@@ -103,6 +528,9 @@ type BenchmarkAPIResult struct {
 	TotalDurationMs float64               `json:"total_duration_ms"`
 	Response        *BenchmarkAPIResponse `json:"response,omitempty"`
 	Error           string                `json:"error,omitempty"`
+	ConcurrentMode  bool                  `json:"concurrent_mode,omitempty"` // Requested with concurrent=true by --benchmark-concurrent
+
+	BenchmarkTimeoutSec float64 `json:"benchmark_timeout_sec,omitempty"` // Request timeout for this call, from --benchmark-timeout or --timeout
 }
 
 // BenchmarkAPIResponse mirrors the ActaLog benchmark endpoint response
@@ -0,0 +1,201 @@
+package wsserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// clientBuffer is how many messages a client may fall behind before further
+// messages to it are dropped; each message is a full snapshot, so a slow
+// dashboard only misses intermediate updates
+const clientBuffer = 16
+
+// shutdownTimeout bounds how long Close waits for the HTTP server to stop
+const shutdownTimeout = 5 * time.Second
+
+// MetricsServer streams JSON metrics snapshots to WebSocket clients. A single
+// hub goroutine owns the client set: handlers subscribe and unsubscribe
+// through channels, and Broadcast hands it each new snapshot. Clients that
+// connect mid-run receive the latest snapshot immediately.
+type MetricsServer struct {
+	register   chan *subscriber
+	unregister chan *subscriber
+	broadcast  chan []byte
+	done       chan struct{}
+	hubDone    chan struct{}
+	closeOnce  sync.Once
+
+	httpServer *http.Server
+	listener   net.Listener
+}
+
+// subscriber is one connected WebSocket client
+type subscriber struct {
+	send chan []byte
+}
+
+// NewMetricsServer creates a server and starts its hub. Call Close to stop it.
+func NewMetricsServer() *MetricsServer {
+	s := &MetricsServer{
+		register:   make(chan *subscriber),
+		unregister: make(chan *subscriber),
+		broadcast:  make(chan []byte),
+		done:       make(chan struct{}),
+		hubDone:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Start listens on addr (e.g. ":8081") and serves WebSocket clients on every
+// path in the background
+func (s *MetricsServer) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+	s.listener = listener
+	s.httpServer = &http.Server{Handler: s.Handler()}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.Close()
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, or "" before Start
+func (s *MetricsServer) Addr() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Handler returns the WebSocket handler, for mounting on an existing server
+func (s *MetricsServer) Handler() http.Handler {
+	// The dashboard may be served from any origin, so skip the Origin check
+	return websocket.Server{Handler: s.serveClient}
+}
+
+// Broadcast sends v, encoded as JSON, to every connected client and keeps it
+// as the snapshot for clients that connect later
+func (s *MetricsServer) Broadcast(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+	select {
+	case s.broadcast <- data:
+		return nil
+	case <-s.done:
+		return errors.New("metrics server is closed")
+	}
+}
+
+// Close disconnects every client and stops the server
+func (s *MetricsServer) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		<-s.hubDone
+		if s.httpServer != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			err = s.httpServer.Shutdown(ctx)
+		}
+	})
+	return err
+}
+
+// run is the hub loop; it is the only goroutine touching the client set
+func (s *MetricsServer) run() {
+	defer close(s.hubDone)
+
+	clients := make(map[*subscriber]struct{})
+	var snapshot []byte
+
+	for {
+		select {
+		case sub := <-s.register:
+			clients[sub] = struct{}{}
+			if snapshot != nil {
+				sub.send <- snapshot // The buffer is empty, so this cannot block
+			}
+		case sub := <-s.unregister:
+			if _, ok := clients[sub]; ok {
+				delete(clients, sub)
+				close(sub.send)
+			}
+		case msg := <-s.broadcast:
+			snapshot = msg
+			for sub := range clients {
+				select {
+				case sub.send <- msg:
+				default:
+					// Drop the update rather than stall every client on a slow one
+				}
+			}
+		case <-s.done:
+			for sub := range clients {
+				close(sub.send)
+			}
+			return
+		}
+	}
+}
+
+// serveClient subscribes ws to the hub and writes snapshots until the client
+// disconnects or the server closes
+func (s *MetricsServer) serveClient(ws *websocket.Conn) {
+	defer ws.Close()
+
+	sub := &subscriber{send: make(chan []byte, clientBuffer)}
+	select {
+	case s.register <- sub:
+	case <-s.done:
+		return
+	}
+
+	// Clients only listen, so a read returning means they went away
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		var discard string
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+	}()
+
+	for {
+		select {
+		case msg, ok := <-sub.send:
+			if !ok {
+				return
+			}
+			if err := websocket.Message.Send(ws, string(msg)); err != nil {
+				s.unsubscribe(sub)
+				return
+			}
+		case <-gone:
+			s.unsubscribe(sub)
+			return
+		}
+	}
+}
+
+// unsubscribe removes sub from the hub unless the hub already stopped
+func (s *MetricsServer) unsubscribe(sub *subscriber) {
+	select {
+	case s.unregister <- sub:
+	case <-s.done:
+	}
+}
@@ -0,0 +1,133 @@
+package wsserver
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+func dial(t *testing.T, serverURL string) *websocket.Conn {
+	t.Helper()
+	wsURL := "ws" + strings.TrimPrefix(serverURL, "http")
+	ws, err := websocket.Dial(wsURL, "", serverURL)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	ws.SetDeadline(time.Now().Add(5 * time.Second))
+	return ws
+}
+
+func receive(t *testing.T, ws *websocket.Conn) map[string]int {
+	t.Helper()
+	var msg string
+	if err := websocket.Message.Receive(ws, &msg); err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	var v map[string]int
+	if err := json.Unmarshal([]byte(msg), &v); err != nil {
+		t.Fatalf("decode %q: %v", msg, err)
+	}
+	return v
+}
+
+// broadcastAndExpect broadcasts v and checks that ws, already subscribed,
+// receives it
+func broadcastAndExpect(t *testing.T, s *MetricsServer, ws *websocket.Conn, v map[string]int) {
+	t.Helper()
+	if err := s.Broadcast(v); err != nil {
+		t.Fatalf("broadcast: %v", err)
+	}
+	if got := receive(t, ws); got["tick"] != v["tick"] {
+		t.Fatalf("expected tick %d, got %d", v["tick"], got["tick"])
+	}
+}
+
+func TestMetricsServer_Broadcast(t *testing.T) {
+	s := NewMetricsServer()
+	defer s.Close()
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	// Broadcast before any client connects: the first client gets it on connect
+	if err := s.Broadcast(map[string]int{"tick": 1}); err != nil {
+		t.Fatalf("broadcast: %v", err)
+	}
+
+	first := dial(t, server.URL)
+	defer first.Close()
+	if got := receive(t, first); got["tick"] != 1 {
+		t.Fatalf("expected snapshot tick 1 on connect, got %v", got)
+	}
+
+	second := dial(t, server.URL)
+	defer second.Close()
+	if got := receive(t, second); got["tick"] != 1 {
+		t.Fatalf("expected snapshot tick 1 for late client, got %v", got)
+	}
+
+	broadcastAndExpect(t, s, first, map[string]int{"tick": 2})
+	if got := receive(t, second); got["tick"] != 2 {
+		t.Errorf("expected every client to receive tick 2, got %v", got)
+	}
+}
+
+func TestMetricsServer_ClientDisconnect(t *testing.T) {
+	s := NewMetricsServer()
+	defer s.Close()
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	s.Broadcast(map[string]int{"tick": 1})
+	gone := dial(t, server.URL)
+	receive(t, gone)
+	gone.Close()
+
+	// Broadcasting keeps working for the remaining client
+	stay := dial(t, server.URL)
+	defer stay.Close()
+	receive(t, stay)
+	for tick := 2; tick <= 4; tick++ {
+		broadcastAndExpect(t, s, stay, map[string]int{"tick": tick})
+	}
+}
+
+func TestMetricsServer_Close(t *testing.T) {
+	s := NewMetricsServer()
+	if err := s.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if s.Addr() == "" {
+		t.Fatal("expected a listen address after Start")
+	}
+
+	ws := dial(t, "http://"+s.Addr())
+	defer ws.Close()
+	s.Broadcast(map[string]int{"tick": 1})
+	receive(t, ws)
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	var msg string
+	if err := websocket.Message.Receive(ws, &msg); err == nil {
+		t.Errorf("expected the connection to close, got message %q", msg)
+	}
+	if err := s.Broadcast(map[string]int{"tick": 2}); err == nil {
+		t.Error("expected an error broadcasting after Close")
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("expected a second Close to succeed, got %v", err)
+	}
+}
+
+func TestMetricsServer_StartError(t *testing.T) {
+	s := NewMetricsServer()
+	defer s.Close()
+	if err := s.Start("127.0.0.1:-1"); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}